@@ -0,0 +1,7 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+// Enables PKCS#12 bundles and encrypted keys in the TLS settings of the components.
+import _ "go.opentelemetry.io/collector/config/configtls/xconfigtls"
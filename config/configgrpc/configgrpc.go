@@ -16,6 +16,7 @@ import (
 	"github.com/mostynb/go-grpc-compression/nonclobbering/zstd"
 	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"go.opentelemetry.io/otel"
+	"go.uber.org/zap"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/balancer"
 	"google.golang.org/grpc/codes"
@@ -31,6 +32,7 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configauth"
 	"go.opentelemetry.io/collector/config/configcompression"
+	"go.opentelemetry.io/collector/config/configheaders"
 	"go.opentelemetry.io/collector/config/configmiddleware"
 	"go.opentelemetry.io/collector/config/confignet"
 	"go.opentelemetry.io/collector/config/configopaque"
@@ -96,6 +98,10 @@ type ClientConfig struct {
 	// The headers associated with gRPC requests.
 	Headers map[string]configopaque.String `mapstructure:"headers,omitempty"`
 
+	// HeadersFromContext lists headers whose values are copied from the client.Info
+	// metadata of the request context. They take precedence over Headers.
+	HeadersFromContext []configheaders.HeaderFromContext `mapstructure:"headers_from_context,omitempty"`
+
 	// Sets the balancer in grpclb_policy to discover the servers. Default is pick_first.
 	// https://github.com/grpc/grpc-go/blob/master/examples/features/load_balancing/README.md
 	BalancerName string `mapstructure:"balancer_name"`
@@ -223,6 +229,12 @@ func (gcs *ClientConfig) Validate() error {
 		}
 	}
 
+	for _, hfc := range gcs.HeadersFromContext {
+		if err := hfc.Validate(); err != nil {
+			return fmt.Errorf("headers_from_context: %w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -291,6 +303,23 @@ func (gcs *ClientConfig) addHeadersIfAbsent(ctx context.Context) context.Context
 	return metadata.AppendToOutgoingContext(ctx, kv...)
 }
 
+// addHeadersFromContext sets the headers configured in HeadersFromContext, replacing
+// any value already present in the outgoing metadata.
+func (gcs *ClientConfig) addHeadersFromContext(ctx context.Context) context.Context {
+	md, ok := metadata.FromOutgoingContext(ctx)
+	if ok {
+		md = md.Copy()
+	} else {
+		md = metadata.MD{}
+	}
+	for _, hfc := range gcs.HeadersFromContext {
+		if vals := hfc.Values(ctx); len(vals) > 0 {
+			md.Set(hfc.HeaderName(), vals...)
+		}
+	}
+	return metadata.NewOutgoingContext(ctx, md)
+}
+
 func (gcs *ClientConfig) getGrpcDialOptions(
 	ctx context.Context,
 	host component.Host,
@@ -380,6 +409,17 @@ func (gcs *ClientConfig) getGrpcDialOptions(
 		)
 	}
 
+	if len(gcs.HeadersFromContext) > 0 {
+		opts = append(opts,
+			grpc.WithChainUnaryInterceptor(func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+				return invoker(gcs.addHeadersFromContext(ctx), method, req, reply, cc, opts...)
+			}),
+			grpc.WithChainStreamInterceptor(func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+				return streamer(gcs.addHeadersFromContext(ctx), desc, cc, method, opts...)
+			}),
+		)
+	}
+
 	// Apply middleware options. Note: OpenTelemetry could be registered as an extension.
 	for _, middleware := range gcs.Middlewares {
 		middlewareOptions, err := middleware.GetGRPCClientOptions(ctx, host.GetExtensions())
@@ -452,6 +492,10 @@ func (gss *ServerConfig) getGrpcServerOptions(
 	var opts []grpc.ServerOption
 
 	if gss.TLSSetting != nil {
+		if gss.TLSSetting.Ephemeral != nil {
+			settings.Logger.Warn("Using an ephemeral self-signed TLS certificate. This mode is meant for development and testing only, DO NOT use it in production.",
+				zap.String("endpoint", gss.NetAddr.Endpoint), zap.String("ca_file", gss.TLSSetting.Ephemeral.CAFile))
+		}
 		tlsCfg, err := gss.TLSSetting.LoadTLSConfig(context.Background())
 		if err != nil {
 			return nil, err
@@ -26,6 +26,7 @@ import (
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/configauth"
 	"go.opentelemetry.io/collector/config/configcompression"
+	"go.opentelemetry.io/collector/config/configheaders"
 	"go.opentelemetry.io/collector/config/confignet"
 	"go.opentelemetry.io/collector/config/configopaque"
 	"go.opentelemetry.io/collector/config/configtls"
@@ -293,6 +294,56 @@ func TestHeaders(t *testing.T) {
 	assert.Equal(t, []string{"testvalue"}, md.Get("testheader"))
 }
 
+func TestHeadersFromContext(t *testing.T) {
+	traceServer := &grpcTraceServer{}
+	server, addr := traceServer.startTestServer(t, ServerConfig{
+		NetAddr: confignet.AddrConfig{
+			Endpoint:  "localhost:0",
+			Transport: confignet.TransportTypeTCP,
+		},
+	})
+	defer server.Stop()
+
+	gcs := ClientConfig{
+		Endpoint: addr,
+		TLSSetting: configtls.ClientConfig{
+			Insecure: true,
+		},
+		Headers: map[string]configopaque.String{
+			"x-scope-orgid": "static",
+		},
+		HeadersFromContext: []configheaders.HeaderFromContext{
+			{MetadataKey: "X-Tenant", Header: "x-scope-orgid"},
+			{MetadataKey: "x-region", Default: "unknown"},
+			{MetadataKey: "x-missing"},
+		},
+	}
+	require.NoError(t, gcs.Validate())
+	grpcClientConn, errClient := gcs.ToClientConn(context.Background(), componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, errClient)
+	defer func() { assert.NoError(t, grpcClientConn.Close()) }()
+
+	ctx := client.NewContext(context.Background(), client.Info{
+		Metadata: client.NewMetadata(map[string][]string{"x-tenant": {"acme"}}),
+	})
+	ctx, cancelFunc := context.WithTimeout(ctx, 2*time.Second)
+	defer cancelFunc()
+	_, errResp := ptraceotlp.NewGRPCClient(grpcClientConn).Export(ctx, ptraceotlp.NewExportRequest(), grpc.WaitForReady(true))
+	require.NoError(t, errResp)
+
+	md, ok := metadata.FromIncomingContext(traceServer.recordedContext)
+	require.True(t, ok)
+	assert.Equal(t, []string{"acme"}, md.Get("x-scope-orgid"))
+	assert.Equal(t, []string{"unknown"}, md.Get("x-region"))
+	assert.Empty(t, md.Get("x-missing"))
+}
+
+func TestHeadersFromContextValidate(t *testing.T) {
+	gcs := NewDefaultClientConfig()
+	gcs.HeadersFromContext = []configheaders.HeaderFromContext{{Header: "x-scope-orgid"}}
+	assert.EqualError(t, gcs.Validate(), "headers_from_context: metadata_key must not be empty")
+}
+
 func TestDefaultGrpcServerSettings(t *testing.T) {
 	gss := &ServerConfig{
 		NetAddr: confignet.AddrConfig{
@@ -0,0 +1,57 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package configheaders // import "go.opentelemetry.io/collector/config/configheaders"
+
+import (
+	"context"
+	"errors"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/config/configopaque"
+)
+
+// HeaderFromContext defines an outgoing header whose value is read from the
+// client.Info metadata of the request context.
+type HeaderFromContext struct {
+	// MetadataKey is the client.Info metadata key to read. The lookup is case-insensitive.
+	MetadataKey string `mapstructure:"metadata_key"`
+
+	// Header is the name of the outgoing header. If empty, MetadataKey is used.
+	Header string `mapstructure:"header,omitempty"`
+
+	// Default is the value sent when the metadata key is not present in the context.
+	// If empty, no header is sent in that case.
+	Default configopaque.String `mapstructure:"default,omitempty"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// Validate checks that the metadata key is set.
+func (hfc HeaderFromContext) Validate() error {
+	if hfc.MetadataKey == "" {
+		return errors.New("metadata_key must not be empty")
+	}
+	return nil
+}
+
+// HeaderName returns the name of the outgoing header.
+func (hfc HeaderFromContext) HeaderName() string {
+	if hfc.Header != "" {
+		return hfc.Header
+	}
+	return hfc.MetadataKey
+}
+
+// Values returns the header values for the given request context, or nil if no
+// header must be sent.
+func (hfc HeaderFromContext) Values(ctx context.Context) []string {
+	if vals := client.FromContext(ctx).Metadata.Get(hfc.MetadataKey); len(vals) > 0 {
+		return vals
+	}
+	if hfc.Default != "" {
+		return []string{string(hfc.Default)}
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package configheaders
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/collector/client"
+)
+
+func TestHeaderFromContext(t *testing.T) {
+	ctx := client.NewContext(context.Background(), client.Info{
+		Metadata: client.NewMetadata(map[string][]string{"X-Tenant": {"acme", "corp"}}),
+	})
+
+	hfc := HeaderFromContext{MetadataKey: "x-tenant"}
+	assert.NoError(t, hfc.Validate())
+	assert.Equal(t, "x-tenant", hfc.HeaderName())
+	assert.Equal(t, []string{"acme", "corp"}, hfc.Values(ctx))
+	assert.Nil(t, hfc.Values(context.Background()))
+
+	hfc = HeaderFromContext{MetadataKey: "x-tenant", Header: "X-Scope-OrgID", Default: "anonymous"}
+	assert.Equal(t, "X-Scope-OrgID", hfc.HeaderName())
+	assert.Equal(t, []string{"acme", "corp"}, hfc.Values(ctx))
+	assert.Equal(t, []string{"anonymous"}, hfc.Values(context.Background()))
+
+	assert.EqualError(t, HeaderFromContext{Header: "X-Scope-OrgID"}.Validate(), "metadata_key must not be empty")
+}
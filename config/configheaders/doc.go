@@ -0,0 +1,6 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package configheaders implements the configuration settings shared by the HTTP
+// and gRPC clients to set outgoing headers from the request context.
+package configheaders // import "go.opentelemetry.io/collector/config/configheaders"
@@ -26,6 +26,7 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configauth"
 	"go.opentelemetry.io/collector/config/configcompression"
+	"go.opentelemetry.io/collector/config/configheaders"
 	"go.opentelemetry.io/collector/config/confighttp/internal"
 	"go.opentelemetry.io/collector/config/configmiddleware"
 	"go.opentelemetry.io/collector/config/configopaque"
@@ -68,6 +69,10 @@ type ClientConfig struct {
 	// Header values are opaque since they may be sensitive.
 	Headers map[string]configopaque.String `mapstructure:"headers,omitempty"`
 
+	// HeadersFromContext lists headers whose values are copied from the client.Info
+	// metadata of the request context. They take precedence over Headers.
+	HeadersFromContext []configheaders.HeaderFromContext `mapstructure:"headers_from_context,omitempty"`
+
 	// Auth configuration for outgoing HTTP calls.
 	Auth *configauth.Authentication `mapstructure:"auth,omitempty"`
 
@@ -147,6 +152,11 @@ func (hcs *ClientConfig) Validate() error {
 			return err
 		}
 	}
+	for _, hfc := range hcs.HeadersFromContext {
+		if err := hfc.Validate(); err != nil {
+			return fmt.Errorf("headers_from_context: %w", err)
+		}
+	}
 	return nil
 }
 
@@ -237,6 +247,14 @@ func (hcs *ClientConfig) ToClient(ctx context.Context, host component.Host, sett
 		}
 	}
 
+	// Headers from the context are set after the static headers so that they take precedence.
+	if len(hcs.HeadersFromContext) > 0 {
+		clientTransport = &contextHeaderRoundTripper{
+			transport: clientTransport,
+			headers:   hcs.HeadersFromContext,
+		}
+	}
+
 	if len(hcs.Headers) > 0 {
 		clientTransport = &headerRoundTripper{
 			transport: clientTransport,
@@ -304,6 +322,30 @@ func (interceptor *headerRoundTripper) RoundTrip(req *http.Request) (*http.Respo
 	return interceptor.transport.RoundTrip(req)
 }
 
+// Custom RoundTripper that adds headers from the client.Info metadata of the request context.
+type contextHeaderRoundTripper struct {
+	transport http.RoundTripper
+	headers   []configheaders.HeaderFromContext
+}
+
+// RoundTrip is a custom RoundTripper that adds headers from the request context to the request.
+func (interceptor *contextHeaderRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	for _, hfc := range interceptor.headers {
+		vals := hfc.Values(req.Context())
+		if len(vals) == 0 {
+			continue
+		}
+		name := hfc.HeaderName()
+		req.Header.Del(name)
+		for _, v := range vals {
+			req.Header.Add(name, v)
+		}
+	}
+
+	// Send the request to next transport.
+	return interceptor.transport.RoundTrip(req)
+}
+
 // ServerConfig defines settings for creating an HTTP server.
 type ServerConfig struct {
 	// Endpoint configures the listening address for the server.
@@ -445,6 +487,11 @@ func (hss *ServerConfig) ToServer(ctx context.Context, host component.Host, sett
 	serverOpts := &toServerOptions{}
 	serverOpts.Apply(opts...)
 
+	if hss.TLSSetting != nil && hss.TLSSetting.Ephemeral != nil {
+		settings.Logger.Warn("Using an ephemeral self-signed TLS certificate. This mode is meant for development and testing only, DO NOT use it in production.",
+			zap.String("endpoint", hss.Endpoint), zap.String("ca_file", hss.TLSSetting.Ephemeral.CAFile))
+	}
+
 	if hss.MaxRequestBodySize <= 0 {
 		hss.MaxRequestBodySize = defaultMaxRequestBodySize
 	}
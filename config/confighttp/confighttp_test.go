@@ -22,12 +22,14 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
 
 	"go.opentelemetry.io/collector/client"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/configauth"
 	"go.opentelemetry.io/collector/config/configcompression"
+	"go.opentelemetry.io/collector/config/configheaders"
 	"go.opentelemetry.io/collector/config/configopaque"
 	"go.opentelemetry.io/collector/config/configtls"
 	"go.opentelemetry.io/collector/extension"
@@ -541,6 +543,41 @@ func TestHTTPServerSettingsError(t *testing.T) {
 	}
 }
 
+func TestHTTPServerEphemeralTLS(t *testing.T) {
+	core, logs := observer.New(zap.WarnLevel)
+	settings := componenttest.NewNopTelemetrySettings()
+	settings.Logger = zap.New(core)
+
+	caPath := filepath.Join(t.TempDir(), "ca.crt")
+	hss := ServerConfig{
+		Endpoint:   "localhost:0",
+		TLSSetting: &configtls.ServerConfig{Ephemeral: &configtls.EphemeralConfig{CAFile: caPath}},
+	}
+	ln, err := hss.ToListener(context.Background())
+	require.NoError(t, err)
+	srv, err := hss.ToServer(context.Background(), componenttest.NewNopHost(), settings, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	require.NoError(t, err)
+	require.Equal(t, 1, logs.FilterMessageSnippet("ephemeral self-signed TLS certificate").Len())
+
+	go func() {
+		_ = srv.Serve(ln)
+	}()
+	defer func() { require.NoError(t, srv.Close()) }()
+
+	hcs := ClientConfig{
+		Endpoint:   "https://" + ln.Addr().String(),
+		TLSSetting: configtls.ClientConfig{Config: configtls.Config{CAFile: caPath}, ServerName: "localhost"},
+	}
+	hc, err := hcs.ToClient(context.Background(), componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	resp, err := hc.Get(hcs.Endpoint)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	require.NoError(t, resp.Body.Close())
+}
+
 func TestHttpReception(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -1052,6 +1089,51 @@ func TestHttpClientHostHeader(t *testing.T) {
 	})
 }
 
+func TestHTTPClientHeadersFromContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, []string{"acme"}, r.Header.Values("X-Scope-OrgID"))
+		assert.Equal(t, []string{"eu", "us"}, r.Header.Values("X-Region"))
+		assert.Equal(t, "unknown", r.Header.Get("X-Team"))
+		assert.Empty(t, r.Header.Get("X-Missing"))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	setting := ClientConfig{
+		Endpoint: server.URL,
+		Headers: map[string]configopaque.String{
+			"X-Scope-OrgID": "static",
+		},
+		HeadersFromContext: []configheaders.HeaderFromContext{
+			{MetadataKey: "x-tenant", Header: "X-Scope-OrgID"},
+			{MetadataKey: "X-Region"},
+			{MetadataKey: "x-team", Default: "unknown"},
+			{MetadataKey: "x-missing"},
+		},
+	}
+	require.NoError(t, setting.Validate())
+	hc, err := setting.ToClient(context.Background(), componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+
+	ctx := client.NewContext(context.Background(), client.Info{
+		Metadata: client.NewMetadata(map[string][]string{
+			"x-tenant": {"acme"},
+			"x-region": {"eu", "us"},
+		}),
+	})
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, setting.Endpoint, nil)
+	require.NoError(t, err)
+	resp, err := hc.Do(req)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+}
+
+func TestHTTPClientHeadersFromContextValidate(t *testing.T) {
+	setting := NewDefaultClientConfig()
+	setting.HeadersFromContext = []configheaders.HeaderFromContext{{Header: "X-Scope-OrgID"}}
+	assert.EqualError(t, setting.Validate(), "headers_from_context: metadata_key must not be empty")
+}
+
 func TestHttpTransportOptions(t *testing.T) {
 	settings := componenttest.NewNopTelemetrySettings()
 	// Disable OTel instrumentation so the *http.Transport object is directly accessible
@@ -30,6 +30,7 @@ require (
 	go.opentelemetry.io/collector/component v1.30.0 // indirect
 	go.opentelemetry.io/collector/config/configauth v0.124.0 // indirect
 	go.opentelemetry.io/collector/config/configcompression v1.30.0 // indirect
+	go.opentelemetry.io/collector/config/configheaders v0.0.0-00010101000000-000000000000 // indirect
 	go.opentelemetry.io/collector/config/configmiddleware v0.0.0-00010101000000-000000000000 // indirect
 	go.opentelemetry.io/collector/config/configopaque v1.30.0 // indirect
 	go.opentelemetry.io/collector/config/configtls v1.30.0 // indirect
@@ -38,6 +39,7 @@ require (
 	go.opentelemetry.io/collector/featuregate v1.30.0 // indirect
 	go.opentelemetry.io/collector/internal/telemetry v0.124.0 // indirect
 	go.opentelemetry.io/collector/pdata v1.30.0 // indirect
+	go.opentelemetry.io/collector/pipeline v0.124.0 // indirect
 	go.opentelemetry.io/contrib/bridges/otelzap v0.10.0 // indirect
 	go.opentelemetry.io/otel v1.35.0 // indirect
 	go.opentelemetry.io/otel/log v0.11.0 // indirect
@@ -72,6 +74,8 @@ replace go.opentelemetry.io/collector/extension/extensionauth => ../../../extens
 
 replace go.opentelemetry.io/collector/config/configopaque => ../../configopaque
 
+replace go.opentelemetry.io/collector/config/configheaders => ../../configheaders
+
 replace go.opentelemetry.io/collector/component => ../../../component
 
 replace go.opentelemetry.io/collector/extension => ../../../extension
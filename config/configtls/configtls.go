@@ -15,6 +15,7 @@ import (
 	"time"
 
 	"go.opentelemetry.io/collector/config/configopaque"
+	"go.opentelemetry.io/collector/config/configtls/internal"
 )
 
 // We should avoid that users unknowingly use a vulnerable TLS version.
@@ -54,6 +55,14 @@ type Config struct {
 	// In memory PEM encoded TLS key to use for TLS required connections. (optional)
 	KeyPem configopaque.String `mapstructure:"key_pem,omitempty"`
 
+	// Path to a PKCS#12 (.p12/.pfx) bundle containing the TLS cert, its key and optionally
+	// the intermediate certificates. Cannot be combined with the cert and key settings. (optional)
+	PKCS12File string `mapstructure:"pkcs12_file,omitempty"`
+
+	// Password used to decrypt the PKCS#12 bundle, or the TLS key if it is an encrypted
+	// PKCS#8 PEM ("ENCRYPTED PRIVATE KEY"). (optional)
+	KeyPassword configopaque.String `mapstructure:"key_password,omitempty"`
+
 	// MinVersion sets the minimum TLS version that is acceptable.
 	// If not set, TLS 1.2 will be used. (optional)
 	MinVersion string `mapstructure:"min_version,omitempty"`
@@ -130,6 +139,11 @@ type ServerConfig struct {
 	// Reload the ClientCAs file when it is modified
 	// (optional, default false)
 	ReloadClientCAFile bool `mapstructure:"client_ca_file_reload,omitempty"`
+
+	// Ephemeral enables a development mode where a CA and server certificate are generated
+	// in memory at startup. Cannot be combined with a configured certificate and key.
+	// Not meant for production use. (optional)
+	Ephemeral *EphemeralConfig `mapstructure:"ephemeral,omitempty"`
 	// prevent unkeyed literal initialization
 	_ struct{}
 }
@@ -169,7 +183,7 @@ func (r *certReloader) GetCertificate() (*tls.Certificate, error) {
 	// If a reload is in progress this will block and we will skip reloading in the current
 	// call once we can continue
 	r.lock.RLock()
-	if r.tls.ReloadInterval != 0 && r.nextReload.Before(now) && (r.tls.hasCertFile() || r.tls.hasKeyFile()) {
+	if r.tls.ReloadInterval != 0 && r.nextReload.Before(now) && (r.tls.hasCertFile() || r.tls.hasKeyFile() || r.tls.hasPKCS12File()) {
 		// Need to release the read lock, otherwise we deadlock
 		r.lock.RUnlock()
 		r.lock.Lock()
@@ -191,6 +205,14 @@ func (c Config) Validate() error {
 		return errors.New("provide either a CA file or the PEM-encoded string, but not both")
 	}
 
+	if c.hasPKCS12File() && (c.hasCert() || c.hasKey()) {
+		return errors.New("provide either a PKCS#12 file or a certificate and key, but not both")
+	}
+
+	if (c.hasPKCS12File() || c.KeyPassword != "") && internal.GetKeyPairDecoders() == nil {
+		return errKeyPairDecodersNotRegistered
+	}
+
 	minTLS, err := convertVersion(c.MinVersion, defaultMinTLSVersion)
 	if err != nil {
 		return fmt.Errorf("invalid TLS min_version: %w", err)
@@ -218,7 +240,7 @@ func (c Config) loadTLSConfig() (*tls.Config, error) {
 
 	var getCertificate func(*tls.ClientHelloInfo) (*tls.Certificate, error)
 	var getClientCertificate func(*tls.CertificateRequestInfo) (*tls.Certificate, error)
-	if c.hasCert() || c.hasKey() {
+	if c.hasCert() || c.hasKey() || c.hasPKCS12File() {
 		var certReloader *certReloader
 		certReloader, err = c.newCertReloader()
 		if err != nil {
@@ -333,6 +355,10 @@ func (c Config) loadCertPem(certPem []byte) (*x509.CertPool, error) {
 
 func (c Config) loadCertificate() (tls.Certificate, error) {
 	switch {
+	case c.hasPKCS12File() && (c.hasCert() || c.hasKey()):
+		return tls.Certificate{}, errors.New("for auth via TLS, provide either a PKCS#12 file or a certificate and key, but not both")
+	case c.hasPKCS12File():
+		return c.loadPKCS12()
 	case c.hasCert() != c.hasKey():
 		return tls.Certificate{}, errors.New("for auth via TLS, provide both certificate and key, or neither")
 	case !c.hasCert() && !c.hasKey():
@@ -363,6 +389,11 @@ func (c Config) loadCertificate() (tls.Certificate, error) {
 		keyPem = []byte(c.KeyPem)
 	}
 
+	keyPem, err = decryptKeyPem(keyPem, []byte(c.KeyPassword))
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("failed to decrypt TLS key: %w", err)
+	}
+
 	certificate, err := tls.X509KeyPair(certPem, keyPem)
 	if err != nil {
 		return tls.Certificate{}, fmt.Errorf("failed to load TLS cert and key PEMs: %w", err)
@@ -409,10 +440,20 @@ func (c ClientConfig) LoadTLSConfig(_ context.Context) (*tls.Config, error) {
 
 // LoadTLSConfig loads the TLS configuration.
 func (c ServerConfig) LoadTLSConfig(_ context.Context) (*tls.Config, error) {
+	if err := c.Validate(); err != nil {
+		return nil, fmt.Errorf("failed to load TLS config: %w", err)
+	}
 	tlsCfg, err := c.loadTLSConfig()
 	if err != nil {
 		return nil, fmt.Errorf("failed to load TLS config: %w", err)
 	}
+	if c.Ephemeral != nil {
+		cert, genErr := c.Ephemeral.generate()
+		if genErr != nil {
+			return nil, fmt.Errorf("failed to generate ephemeral certificate: %w", genErr)
+		}
+		tlsCfg.GetCertificate = func(*tls.ClientHelloInfo) (*tls.Certificate, error) { return &cert, nil }
+	}
 	if c.ClientCAFile != "" {
 		reloader, err := newClientCAsReloader(c.ClientCAFile, &c)
 		if err != nil {
@@ -431,6 +472,14 @@ func (c ServerConfig) LoadTLSConfig(_ context.Context) (*tls.Config, error) {
 	return tlsCfg, nil
 }
 
+// Validate checks the server specific settings. The common settings are validated by Config.Validate.
+func (c ServerConfig) Validate() error {
+	if c.Ephemeral != nil && (c.hasCert() || c.hasKey() || c.hasPKCS12File()) {
+		return errors.New("ephemeral certificates cannot be combined with a certificate and key")
+	}
+	return nil
+}
+
 func (c ServerConfig) loadClientCAFile() (*x509.CertPool, error) {
 	return c.loadCert(c.ClientCAFile)
 }
@@ -448,6 +497,8 @@ func (c Config) hasCertPem() bool  { return len(c.CertPem) != 0 }
 func (c Config) hasKeyFile() bool { return c.KeyFile != "" }
 func (c Config) hasKeyPem() bool  { return len(c.KeyPem) != 0 }
 
+func (c Config) hasPKCS12File() bool { return c.PKCS12File != "" }
+
 func convertVersion(v string, defaultVersion uint16) (uint16, error) {
 	// Use a default that is explicitly defined
 	if v == "" {
@@ -0,0 +1,174 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package configtls // import "go.opentelemetry.io/collector/config/configtls"
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+const defaultEphemeralValidity = 24 * time.Hour
+
+var defaultEphemeralHosts = []string{"localhost", "127.0.0.1", "::1"}
+
+// EphemeralConfig configures a throwaway CA and server certificate that are generated
+// in memory when the TLS configuration is loaded.
+//
+// This mode is meant for local development and integration tests only. The generated
+// keys are never persisted and change on every start, and clients must trust the
+// generated CA. The CA is generated once per process and CAFile, so that servers
+// sharing the same CAFile can all be verified with it.
+type EphemeralConfig struct {
+	// CAFile is the path the generated CA certificate is written to, in PEM format,
+	// so that clients can trust it. If empty, the CA is not written. (optional)
+	CAFile string `mapstructure:"ca_file,omitempty"`
+
+	// Hosts lists the DNS names and IP addresses the server certificate is valid for.
+	// If empty, "localhost", "127.0.0.1" and "::1" are used. (optional)
+	Hosts []string `mapstructure:"hosts,omitempty"`
+
+	// Validity is how long the generated certificates are valid for.
+	// If not set, 24 hours is used. (optional)
+	Validity time.Duration `mapstructure:"validity,omitempty"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// ephemeralCA is a CA generated by the process, see loadEphemeralCA.
+type ephemeralCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+var (
+	ephemeralCAsMu sync.Mutex
+	// ephemeralCAs holds the CAs generated by the process, by CA file path.
+	ephemeralCAs = map[string]*ephemeralCA{}
+)
+
+// loadEphemeralCA returns the CA generated by the process for caFile. A new CA is generated,
+// and written to caFile if not empty, when none exists yet or when it has expired.
+func loadEphemeralCA(caFile string, notBefore time.Time, notAfter time.Time) (*ephemeralCA, error) {
+	ephemeralCAsMu.Lock()
+	defer ephemeralCAsMu.Unlock()
+
+	if ca, ok := ephemeralCAs[caFile]; ok && time.Now().Before(ca.cert.NotAfter) {
+		return ca, nil
+	}
+
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	caTemplate := &x509.Certificate{
+		SerialNumber:          newSerialNumber(),
+		Subject:               pkix.Name{Organization: []string{"OpenTelemetry Collector"}, CommonName: "Ephemeral development CA"},
+		NotBefore:             notBefore,
+		NotAfter:              notAfter,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		MaxPathLenZero:        true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	if err != nil {
+		return nil, err
+	}
+	caCert, err := x509.ParseCertificate(caDER)
+	if err != nil {
+		return nil, err
+	}
+
+	if caFile != "" {
+		caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caDER})
+		// #nosec G306 -- the CA certificate is public and meant to be read by clients.
+		if err = os.WriteFile(filepath.Clean(caFile), caPEM, 0o644); err != nil {
+			return nil, fmt.Errorf("failed to write ephemeral CA to %s: %w", caFile, err)
+		}
+	}
+
+	ca := &ephemeralCA{cert: caCert, key: caKey}
+	ephemeralCAs[caFile] = ca
+	return ca, nil
+}
+
+// generate creates a server certificate signed by the CA of the process for CAFile.
+// The returned certificate includes the CA in its chain.
+func (e EphemeralConfig) generate() (tls.Certificate, error) {
+	validity := e.Validity
+	if validity <= 0 {
+		validity = defaultEphemeralValidity
+	}
+	hosts := e.Hosts
+	if len(hosts) == 0 {
+		hosts = defaultEphemeralHosts
+	}
+	notBefore := time.Now().Add(-time.Minute)
+	notAfter := notBefore.Add(validity)
+
+	ca, err := loadEphemeralCA(e.CAFile, notBefore, notAfter)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	// The certificate cannot outlive the CA, which may have been generated with another validity.
+	if notAfter.After(ca.cert.NotAfter) {
+		notAfter = ca.cert.NotAfter
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	template := &x509.Certificate{
+		SerialNumber: newSerialNumber(),
+		Subject:      pkix.Name{Organization: []string{"OpenTelemetry Collector"}, CommonName: hosts[0]},
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	for _, h := range hosts {
+		if ip := net.ParseIP(h); ip != nil {
+			template.IPAddresses = append(template.IPAddresses, ip)
+		} else {
+			template.DNSNames = append(template.DNSNames, h)
+		}
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	leaf, err := x509.ParseCertificate(der)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	return tls.Certificate{
+		Certificate: [][]byte{der, ca.cert.Raw},
+		PrivateKey:  key,
+		Leaf:        leaf,
+	}, nil
+}
+
+func newSerialNumber() *big.Int {
+	// Errors from crypto/rand are fatal in recent Go versions, a zero serial is only used if reading fails.
+	serial, _ := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if serial == nil {
+		return big.NewInt(0)
+	}
+	return serial
+}
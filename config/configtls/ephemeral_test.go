@@ -0,0 +1,106 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package configtls
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEphemeralServerConfig(t *testing.T) {
+	caPath := filepath.Join(t.TempDir(), "ca.crt")
+	serverCfg := ServerConfig{
+		Ephemeral: &EphemeralConfig{CAFile: caPath},
+	}
+	serverTLS, err := serverCfg.LoadTLSConfig(context.Background())
+	require.NoError(t, err)
+
+	ln, err := tls.Listen("tcp", "localhost:0", serverTLS)
+	require.NoError(t, err)
+	defer ln.Close()
+	go func() {
+		conn, acceptErr := ln.Accept()
+		if acceptErr != nil {
+			return
+		}
+		_ = conn.(*tls.Conn).Handshake()
+		_ = conn.Close()
+	}()
+
+	clientCfg := ClientConfig{
+		Config:     Config{CAFile: caPath},
+		ServerName: "localhost",
+	}
+	clientTLS, err := clientCfg.LoadTLSConfig(context.Background())
+	require.NoError(t, err)
+	conn, err := tls.Dial("tcp", ln.Addr().String(), clientTLS)
+	require.NoError(t, err)
+	require.NoError(t, conn.Close())
+}
+
+func TestEphemeralServerConfigSharedCAFile(t *testing.T) {
+	caPath := filepath.Join(t.TempDir(), "ca.crt")
+	var certs []*tls.Certificate
+	for i := 0; i < 2; i++ {
+		serverTLS, err := ServerConfig{Ephemeral: &EphemeralConfig{CAFile: caPath}}.LoadTLSConfig(context.Background())
+		require.NoError(t, err)
+		cert, err := serverTLS.GetCertificate(&tls.ClientHelloInfo{})
+		require.NoError(t, err)
+		certs = append(certs, cert)
+	}
+
+	// The CA written by the first server is still valid for it after the second one is loaded.
+	caPem, err := os.ReadFile(caPath)
+	require.NoError(t, err)
+	roots := x509.NewCertPool()
+	require.True(t, roots.AppendCertsFromPEM(caPem))
+	for _, cert := range certs {
+		_, err = cert.Leaf.Verify(x509.VerifyOptions{Roots: roots, DNSName: "localhost"})
+		require.NoError(t, err)
+	}
+	assert.NotEqual(t, certs[0].Certificate[0], certs[1].Certificate[0])
+	assert.Equal(t, certs[0].Certificate[1], certs[1].Certificate[1])
+}
+
+func TestEphemeralGenerate(t *testing.T) {
+	cert, err := EphemeralConfig{
+		Hosts:    []string{"collector.local", "10.0.0.1"},
+		Validity: time.Hour,
+	}.generate()
+	require.NoError(t, err)
+	require.Len(t, cert.Certificate, 2)
+	assert.Equal(t, []string{"collector.local"}, cert.Leaf.DNSNames)
+	require.Len(t, cert.Leaf.IPAddresses, 1)
+	assert.True(t, cert.Leaf.IPAddresses[0].Equal(net.ParseIP("10.0.0.1")))
+	assert.WithinDuration(t, time.Now().Add(time.Hour), cert.Leaf.NotAfter, 2*time.Minute)
+}
+
+func TestEphemeralValidate(t *testing.T) {
+	serverCfg := ServerConfig{
+		Config:    Config{CertFile: filepath.Join("testdata", "server-1.crt"), KeyFile: filepath.Join("testdata", "server-1.key")},
+		Ephemeral: &EphemeralConfig{},
+	}
+	require.EqualError(t, serverCfg.Validate(), "ephemeral certificates cannot be combined with a certificate and key")
+	_, err := serverCfg.LoadTLSConfig(context.Background())
+	require.Error(t, err)
+}
+
+func TestEphemeralCAFileError(t *testing.T) {
+	serverCfg := ServerConfig{
+		Ephemeral: &EphemeralConfig{CAFile: filepath.Join(t.TempDir(), "missing", "ca.crt")},
+	}
+	_, err := serverCfg.LoadTLSConfig(context.Background())
+	require.ErrorContains(t, err, "failed to write ephemeral CA")
+	_, statErr := os.Stat(serverCfg.Ephemeral.CAFile)
+	assert.True(t, os.IsNotExist(statErr))
+}
@@ -0,0 +1,32 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal // import "go.opentelemetry.io/collector/config/configtls/internal"
+
+import (
+	"crypto"
+	"crypto/x509"
+)
+
+// KeyPairDecoders decodes the key pair formats that need third-party dependencies.
+// They are registered by the xconfigtls module, so that the stable configtls module
+// does not depend on them.
+type KeyPairDecoders struct {
+	// DecodePKCS12 decodes a PKCS#12 bundle into its private key, leaf certificate and
+	// intermediate certificates.
+	DecodePKCS12 func(data []byte, password string) (crypto.PrivateKey, *x509.Certificate, []*x509.Certificate, error)
+	// DecryptPKCS8 decrypts an encrypted PKCS#8 private key.
+	DecryptPKCS8 func(der []byte, password []byte) (crypto.PrivateKey, error)
+}
+
+var decoders *KeyPairDecoders
+
+// RegisterKeyPairDecoders registers the decoders. It must be called from an init function.
+func RegisterKeyPairDecoders(d KeyPairDecoders) {
+	decoders = &d
+}
+
+// GetKeyPairDecoders returns the registered decoders, or nil if none is registered.
+func GetKeyPairDecoders() *KeyPairDecoders {
+	return decoders
+}
@@ -0,0 +1,93 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package configtls // import "go.opentelemetry.io/collector/config/configtls"
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"go.opentelemetry.io/collector/config/configtls/internal"
+)
+
+const encryptedPrivateKeyBlockType = "ENCRYPTED PRIVATE KEY"
+
+var errKeyPairDecodersNotRegistered = errors.New("PKCS#12 bundles and encrypted PKCS#8 keys require the go.opentelemetry.io/collector/config/configtls/xconfigtls module to be imported")
+
+// loadPKCS12 loads the certificate, its private key and the optional intermediate
+// certificates from the configured PKCS#12 bundle.
+func (c Config) loadPKCS12() (tls.Certificate, error) {
+	decoders := internal.GetKeyPairDecoders()
+	if decoders == nil {
+		return tls.Certificate{}, errKeyPairDecodersNotRegistered
+	}
+
+	data, err := os.ReadFile(filepath.Clean(c.PKCS12File))
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	key, leaf, chain, err := decoders.DecodePKCS12(data, string(c.KeyPassword))
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("failed to decode PKCS#12 file %s: %w", c.PKCS12File, err)
+	}
+
+	certificate := tls.Certificate{
+		Certificate: make([][]byte, 0, len(chain)+1),
+		PrivateKey:  key,
+		Leaf:        leaf,
+	}
+	certificate.Certificate = append(certificate.Certificate, leaf.Raw)
+	for _, cert := range chain {
+		certificate.Certificate = append(certificate.Certificate, cert.Raw)
+	}
+	return certificate, nil
+}
+
+// decryptKeyPem returns keyPem with every encrypted PKCS#8 block replaced by its
+// unencrypted PKCS#8 form. Unencrypted blocks are returned unchanged.
+func decryptKeyPem(keyPem []byte, password []byte) ([]byte, error) {
+	var out []byte
+	rest := keyPem
+	encrypted := false
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != encryptedPrivateKeyBlockType {
+			out = append(out, pem.EncodeToMemory(block)...)
+			continue
+		}
+
+		encrypted = true
+		decoders := internal.GetKeyPairDecoders()
+		if decoders == nil {
+			return nil, errKeyPairDecodersNotRegistered
+		}
+		if len(password) == 0 {
+			return nil, errors.New("key is encrypted but no key_password is set")
+		}
+		key, err := decoders.DecryptPKCS8(block.Bytes, password)
+		if err != nil {
+			return nil, err
+		}
+		der, err := x509.MarshalPKCS8PrivateKey(key)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})...)
+	}
+
+	if !encrypted {
+		// Leave the input untouched so that tls.X509KeyPair reports the usual errors.
+		return keyPem, nil
+	}
+	return out, nil
+}
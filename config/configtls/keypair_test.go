@@ -0,0 +1,47 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package configtls
+
+import (
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/config/configopaque"
+)
+
+func TestLoadPKCS12WithoutDecoders(t *testing.T) {
+	p12Path := filepath.Join(t.TempDir(), "server.p12")
+	require.NoError(t, os.WriteFile(p12Path, []byte("not a bundle"), 0o600))
+
+	cfg := Config{PKCS12File: p12Path, KeyPassword: "s3cr3t"}
+	require.ErrorIs(t, cfg.Validate(), errKeyPairDecodersNotRegistered)
+	_, err := cfg.loadCertificate()
+	require.ErrorIs(t, err, errKeyPairDecodersNotRegistered)
+
+	cfg = Config{PKCS12File: p12Path, CertFile: filepath.Join("testdata", "server-1.crt")}
+	require.EqualError(t, cfg.Validate(), "provide either a PKCS#12 file or a certificate and key, but not both")
+	_, err = cfg.loadCertificate()
+	require.Error(t, err)
+}
+
+func TestLoadEncryptedKeyPemWithoutDecoders(t *testing.T) {
+	certPem, err := os.ReadFile(filepath.Join("testdata", "server-1.crt"))
+	require.NoError(t, err)
+	keyPem := pem.EncodeToMemory(&pem.Block{Type: encryptedPrivateKeyBlockType, Bytes: []byte("encrypted")})
+
+	cfg := Config{CertPem: configopaque.String(certPem), KeyPem: configopaque.String(keyPem), KeyPassword: "s3cr3t"}
+	require.ErrorIs(t, cfg.Validate(), errKeyPairDecodersNotRegistered)
+	_, err = cfg.loadCertificate()
+	assert.ErrorIs(t, err, errKeyPairDecodersNotRegistered)
+
+	// Unencrypted keys don't need the decoders.
+	cfg = Config{CertFile: filepath.Join("testdata", "server-1.crt"), KeyFile: filepath.Join("testdata", "server-1.key")}
+	_, err = cfg.loadCertificate()
+	assert.NoError(t, err)
+}
@@ -0,0 +1,38 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package xconfigtls enables the support of PKCS#12 bundles (`pkcs12_file`) and of
+// encrypted PKCS#8 keys (`key_password`) in configtls.
+//
+// The support is enabled by importing this package for its side effects:
+//
+//	import _ "go.opentelemetry.io/collector/config/configtls/xconfigtls"
+//
+// It is located in a separate, experimental module because the decoders depend on
+// third-party libraries that the stable configtls module does not depend on.
+package xconfigtls // import "go.opentelemetry.io/collector/config/configtls/xconfigtls"
+
+import (
+	"crypto"
+	"crypto/x509"
+
+	"github.com/youmark/pkcs8"
+	"software.sslmate.com/src/go-pkcs12"
+
+	"go.opentelemetry.io/collector/config/configtls/internal"
+)
+
+func init() {
+	internal.RegisterKeyPairDecoders(internal.KeyPairDecoders{
+		DecodePKCS12: decodePKCS12,
+		DecryptPKCS8: decryptPKCS8,
+	})
+}
+
+func decodePKCS12(data []byte, password string) (crypto.PrivateKey, *x509.Certificate, []*x509.Certificate, error) {
+	return pkcs12.DecodeChain(data, password)
+}
+
+func decryptPKCS8(der []byte, password []byte) (crypto.PrivateKey, error) {
+	return pkcs8.ParsePKCS8PrivateKey(der, password)
+}
@@ -0,0 +1,148 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package xconfigtls
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/youmark/pkcs8"
+	"software.sslmate.com/src/go-pkcs12"
+
+	"go.opentelemetry.io/collector/config/configopaque"
+	"go.opentelemetry.io/collector/config/configtls"
+)
+
+const testKeyPassword = "s3cr3t"
+
+func loadTestKeyPair(t *testing.T, name string) tls.Certificate {
+	cert, err := tls.LoadX509KeyPair(filepath.Join("..", "testdata", name+".crt"), filepath.Join("..", "testdata", name+".key"))
+	require.NoError(t, err)
+	cert.Leaf, err = x509.ParseCertificate(cert.Certificate[0])
+	require.NoError(t, err)
+	return cert
+}
+
+func writePKCS12(t *testing.T, path string, name string) tls.Certificate {
+	cert := loadTestKeyPair(t, name)
+	ca, err := os.ReadFile(filepath.Join("..", "testdata", "ca-1.crt"))
+	require.NoError(t, err)
+	block, _ := pem.Decode(ca)
+	caCert, err := x509.ParseCertificate(block.Bytes)
+	require.NoError(t, err)
+
+	data, err := pkcs12.Modern.Encode(cert.PrivateKey, cert.Leaf, []*x509.Certificate{caCert}, testKeyPassword)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+	return cert
+}
+
+func encryptedKeyPem(t *testing.T, name string) []byte {
+	cert := loadTestKeyPair(t, name)
+	der, err := pkcs8.MarshalPrivateKey(cert.PrivateKey, []byte(testKeyPassword), nil)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "ENCRYPTED PRIVATE KEY", Bytes: der})
+}
+
+func getCertificate(t *testing.T, cfg configtls.Config) (*tls.Certificate, error) {
+	tlsCfg, err := configtls.ServerConfig{Config: cfg}.LoadTLSConfig(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	return tlsCfg.GetCertificate(&tls.ClientHelloInfo{})
+}
+
+func TestLoadPKCS12(t *testing.T) {
+	p12Path := filepath.Join(t.TempDir(), "server.p12")
+	expected := writePKCS12(t, p12Path, "server-1")
+
+	cfg := configtls.Config{PKCS12File: p12Path, KeyPassword: testKeyPassword}
+	require.NoError(t, cfg.Validate())
+	cert, err := getCertificate(t, cfg)
+	require.NoError(t, err)
+	require.Len(t, cert.Certificate, 2)
+	assert.Equal(t, expected.Certificate[0], cert.Certificate[0])
+	assert.Equal(t, expected.PrivateKey, cert.PrivateKey)
+}
+
+func TestLoadPKCS12Errors(t *testing.T) {
+	p12Path := filepath.Join(t.TempDir(), "server.p12")
+	writePKCS12(t, p12Path, "server-1")
+
+	_, err := getCertificate(t, configtls.Config{PKCS12File: p12Path, KeyPassword: "wrong"})
+	require.ErrorContains(t, err, "failed to decode PKCS#12 file")
+
+	_, err = getCertificate(t, configtls.Config{PKCS12File: filepath.Join(t.TempDir(), "missing.p12")})
+	require.Error(t, err)
+}
+
+func TestLoadEncryptedKeyPem(t *testing.T) {
+	certPem, err := os.ReadFile(filepath.Join("..", "testdata", "server-1.crt"))
+	require.NoError(t, err)
+	keyPem := encryptedKeyPem(t, "server-1")
+	keyPath := filepath.Join(t.TempDir(), "server.key")
+	require.NoError(t, os.WriteFile(keyPath, keyPem, 0o600))
+
+	tests := []struct {
+		name    string
+		cfg     configtls.Config
+		wantErr string
+	}{
+		{
+			name: "key_pem",
+			cfg:  configtls.Config{CertPem: configopaque.String(certPem), KeyPem: configopaque.String(keyPem), KeyPassword: testKeyPassword},
+		},
+		{
+			name: "key_file",
+			cfg:  configtls.Config{CertPem: configopaque.String(certPem), KeyFile: keyPath, KeyPassword: testKeyPassword},
+		},
+		{
+			name:    "missing_password",
+			cfg:     configtls.Config{CertPem: configopaque.String(certPem), KeyFile: keyPath},
+			wantErr: "failed to decrypt TLS key: key is encrypted but no key_password is set",
+		},
+		{
+			name:    "wrong_password",
+			cfg:     configtls.Config{CertPem: configopaque.String(certPem), KeyFile: keyPath, KeyPassword: "wrong"},
+			wantErr: "failed to decrypt TLS key",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cert, err := getCertificate(t, tt.cfg)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, loadTestKeyPair(t, "server-1").PrivateKey, cert.PrivateKey)
+		})
+	}
+}
+
+func TestPKCS12Reload(t *testing.T) {
+	p12Path := filepath.Join(t.TempDir(), "server.p12")
+	first := writePKCS12(t, p12Path, "server-1")
+
+	cfg := configtls.Config{PKCS12File: p12Path, KeyPassword: testKeyPassword, ReloadInterval: 10 * time.Millisecond}
+	tlsCfg, err := configtls.ServerConfig{Config: cfg}.LoadTLSConfig(context.Background())
+	require.NoError(t, err)
+	cert, err := tlsCfg.GetCertificate(&tls.ClientHelloInfo{})
+	require.NoError(t, err)
+	assert.Equal(t, first.Certificate[0], cert.Certificate[0])
+
+	second := writePKCS12(t, p12Path, "server-2")
+	assert.Eventually(t, func() bool {
+		cert, err = tlsCfg.GetCertificate(&tls.ClientHelloInfo{})
+		return err == nil && assert.ObjectsAreEqual(second.Certificate[0], cert.Certificate[0])
+	}, 5*time.Second, 10*time.Millisecond)
+}
@@ -43,3 +43,12 @@ func WithRetry(config configretry.BackOffConfig) Option {
 func WithCapabilities(capabilities consumer.Capabilities) Option {
 	return internal.WithCapabilities(capabilities)
 }
+
+// WithReleaseOnSuccess, if enabled, returns the memory of the data exported successfully to pools, so that the data received
+// afterward reuses it, see ptrace.Traces.Release. It must only be used by the exporters which do not use the data
+// once they exported it. The data shared with other consumers, or which fails to be exported, is not released.
+// Since releasing the data modifies it, the exporter is declared as mutating the data.
+// Experimental: This API is at the early stage of development and may change without backward compatibility.
+func WithReleaseOnSuccess(enabled bool) Option {
+	return internal.WithReleaseOnSuccess(enabled)
+}
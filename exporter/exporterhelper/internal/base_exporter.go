@@ -49,6 +49,7 @@ type BaseExporter struct {
 	queueBatchSettings QueueBatchSettings[request.Request]
 	queueCfg           queuebatch.Config
 	batcherCfg         BatcherConfig
+	releaseOnSuccess   bool
 }
 
 func NewBaseExporter(set exporter.Settings, signal pipeline.Signal, pusher sender.SendFunc[request.Request], options ...Option) (*BaseExporter, error) {
@@ -83,6 +84,12 @@ func NewBaseExporter(set exporter.Settings, signal pipeline.Signal, pusher sende
 		return nil, err
 	}
 
+	if be.releaseOnSuccess {
+		be.firstSender = newReleaseSender(be.firstSender)
+		// Releasing the data modifies it, so the data shared with other consumers must be copied.
+		be.ConsumerOptions = append(be.ConsumerOptions, consumer.WithCapabilities(consumer.Capabilities{MutatesData: true}))
+	}
+
 	if be.batcherCfg.Enabled || be.queueCfg.Batch != nil {
 		// Batcher mutates the data.
 		be.ConsumerOptions = append(be.ConsumerOptions, consumer.WithCapabilities(consumer.Capabilities{MutatesData: true}))
@@ -150,6 +157,15 @@ func (be *BaseExporter) Shutdown(ctx context.Context) error {
 	return multierr.Append(err, be.ShutdownFunc.Shutdown(ctx))
 }
 
+// DrainReport returns the number of requests left in a persistent sending queue and the number of items
+// dropped because the shutdown deadline was reached before the sending queue was drained.
+func (be *BaseExporter) DrainReport() (queuedRequests, droppedItems int64) {
+	if dr, ok := be.QueueSender.(interface{ DrainReport() (int64, int64) }); ok {
+		return dr.DrainReport()
+	}
+	return 0, 0
+}
+
 // WithStart overrides the default Start function for an exporter.
 // The default start function does nothing and always returns nil.
 func WithStart(start component.StartFunc) Option {
@@ -231,6 +247,15 @@ func WithCapabilities(capabilities consumer.Capabilities) Option {
 	}
 }
 
+// WithReleaseOnSuccess releases the data of the requests exported successfully, see WithReleaseOnSuccess
+// in exporterhelper.
+func WithReleaseOnSuccess(enabled bool) Option {
+	return func(o *BaseExporter) error {
+		o.releaseOnSuccess = enabled
+		return nil
+	}
+}
+
 // WithBatcher enables batching for an exporter based on custom request types.
 // For now, it can be used only with the New[Traces|Metrics|Logs|Profiles]Request exporter helpers and
 // WithRequestBatchFuncs provided.
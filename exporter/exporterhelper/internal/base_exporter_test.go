@@ -29,6 +29,25 @@ func TestBaseExporter(t *testing.T) {
 	require.NoError(t, be.Shutdown(context.Background()))
 }
 
+func TestBaseExporterDrainReport(t *testing.T) {
+	be, err := NewBaseExporter(exportertest.NewNopSettings(exportertest.NopType), pipeline.SignalMetrics, noopExport)
+	require.NoError(t, err)
+	queuedRequests, droppedItems := be.DrainReport()
+	assert.Zero(t, queuedRequests)
+	assert.Zero(t, droppedItems)
+
+	be, err = NewBaseExporter(exportertest.NewNopSettings(exportertest.NopType), pipeline.SignalMetrics, noopExport,
+		WithQueueBatchSettings(newFakeQueueBatch()),
+		WithQueue(NewDefaultQueueConfig()))
+	require.NoError(t, err)
+	require.NoError(t, be.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, be.Send(context.Background(), &requesttest.FakeRequest{Items: 2}))
+	require.NoError(t, be.Shutdown(context.Background()))
+	queuedRequests, droppedItems = be.DrainReport()
+	assert.Zero(t, queuedRequests)
+	assert.Zero(t, droppedItems)
+}
+
 func TestBaseExporterWithOptions(t *testing.T) {
 	want := errors.New("my error")
 	be, err := NewBaseExporter(
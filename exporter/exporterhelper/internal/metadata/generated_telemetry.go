@@ -33,6 +33,7 @@ type TelemetryBuilder struct {
 	ExporterEnqueueFailedSpans        metric.Int64Counter
 	ExporterQueueCapacity             metric.Int64ObservableGauge
 	ExporterQueueSize                 metric.Int64ObservableGauge
+	ExporterQueueTenantSize           metric.Int64ObservableGauge
 	ExporterSendFailedLogRecords      metric.Int64Counter
 	ExporterSendFailedMetricPoints    metric.Int64Counter
 	ExporterSendFailedSpans           metric.Int64Counter
@@ -82,6 +83,21 @@ func (builder *TelemetryBuilder) RegisterExporterQueueSizeCallback(cb metric.Int
 	return nil
 }
 
+// RegisterExporterQueueTenantSizeCallback sets callback for observable ExporterQueueTenantSize metric.
+func (builder *TelemetryBuilder) RegisterExporterQueueTenantSizeCallback(cb metric.Int64Callback) error {
+	reg, err := builder.meter.RegisterCallback(func(ctx context.Context, o metric.Observer) error {
+		cb(ctx, &observerInt64{inst: builder.ExporterQueueTenantSize, obs: o})
+		return nil
+	}, builder.ExporterQueueTenantSize)
+	if err != nil {
+		return err
+	}
+	builder.mu.Lock()
+	defer builder.mu.Unlock()
+	builder.registrations = append(builder.registrations, reg)
+	return nil
+}
+
 type observerInt64 struct {
 	embedded.Int64Observer
 	inst metric.Int64Observable
@@ -140,6 +156,12 @@ func NewTelemetryBuilder(settings component.TelemetrySettings, options ...Teleme
 		metric.WithUnit("{batches}"),
 	)
 	errs = errors.Join(errs, err)
+	builder.ExporterQueueTenantSize, err = builder.meter.Int64ObservableGauge(
+		"otelcol_exporter_queue_tenant_size",
+		metric.WithDescription("Current size of the retry queue used by each tenant, only reported when tenant isolation is configured [alpha]"),
+		metric.WithUnit("{batches}"),
+	)
+	errs = errors.Join(errs, err)
 	builder.ExporterSendFailedLogRecords, err = builder.meter.Int64Counter(
 		"otelcol_exporter_send_failed_log_records",
 		metric.WithDescription("Number of log records in failed attempts to send to destination. [alpha]"),
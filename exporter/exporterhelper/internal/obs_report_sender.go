@@ -17,6 +17,7 @@ import (
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/queuebatch"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/request"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/sender"
+	"go.opentelemetry.io/collector/internal/telemetry/componentattribute"
 	"go.opentelemetry.io/collector/pipeline"
 )
 
@@ -43,7 +44,7 @@ type obsReportSender[K request.Request] struct {
 	spanName        string
 	tracer          trace.Tracer
 	spanAttrs       trace.SpanStartEventOption
-	metricAttr      metric.MeasurementOption
+	metricAttr      *componentattribute.PipelineMeasurementOptions
 	itemsSentInst   metric.Int64Counter
 	itemsFailedInst metric.Int64Counter
 	next            sender.Sender[K]
@@ -62,7 +63,7 @@ func newObsReportSender[K request.Request](set exporter.Settings, signal pipelin
 		spanName:   ExporterKey + spanNameSep + idStr + spanNameSep + signal.String(),
 		tracer:     metadata.Tracer(set.TelemetrySettings),
 		spanAttrs:  trace.WithAttributes(expAttr, attribute.String(DataTypeKey, signal.String())),
-		metricAttr: metric.WithAttributeSet(attribute.NewSet(expAttr)),
+		metricAttr: componentattribute.NewPipelineMeasurementOptions(expAttr),
 		next:       next,
 	}
 
@@ -108,14 +109,16 @@ func (ors *obsReportSender[K]) startOp(ctx context.Context) context.Context {
 func (ors *obsReportSender[K]) endOp(ctx context.Context, numLogRecords int, err error) {
 	numSent, numFailedToSend := toNumItems(numLogRecords, err)
 
+	metricAttr := ors.metricAttr.ForContext(ctx)
 	// No metrics recorded for profiles.
 	if ors.itemsSentInst != nil {
-		ors.itemsSentInst.Add(ctx, numSent, ors.metricAttr)
+		ors.itemsSentInst.Add(ctx, numSent, metricAttr)
 	}
 	// No metrics recorded for profiles.
 	if ors.itemsFailedInst != nil {
-		ors.itemsFailedInst.Add(ctx, numFailedToSend, ors.metricAttr)
+		ors.itemsFailedInst.Add(ctx, numFailedToSend, metricAttr)
 	}
+	componentattribute.PipelineExported(ctx, err)
 
 	span := trace.SpanFromContext(ctx)
 	defer span.End()
@@ -22,6 +22,7 @@ import (
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/request"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/requesttest"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/sender"
+	"go.opentelemetry.io/collector/internal/telemetry/componentattribute"
 	"go.opentelemetry.io/collector/pipeline"
 )
 
@@ -235,6 +236,35 @@ func TestExportLogsOp(t *testing.T) {
 	}
 }
 
+func TestExportPipeline(t *testing.T) {
+	tt := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+
+	obsrep, err := newObsReportSender(
+		exporter.Settings{ID: exporterID, TelemetrySettings: tt.NewTelemetrySettings(), BuildInfo: component.NewDefaultBuildInfo()},
+		pipeline.SignalLogs,
+		sender.NewSender(func(context.Context, request.Request) error { return errFake }),
+	)
+	require.NoError(t, err)
+
+	var exported []error
+	ctx := componentattribute.ContextWithPipeline(context.Background(), pipeline.NewIDWithName(pipeline.SignalLogs, "1"),
+		func(_ context.Context, err error) { exported = append(exported, err) })
+	require.ErrorIs(t, obsrep.Send(ctx, &requesttest.FakeRequest{Items: 3}), errFake)
+	// The pipeline is told when the export completes.
+	assert.Equal(t, []error{errFake}, exported)
+
+	attrs := attribute.NewSet(
+		attribute.String("exporter", exporterID.String()),
+		attribute.String(componentattribute.PipelineIDKey, "logs/1"))
+	metadatatest.AssertEqualExporterSentLogRecords(t, tt,
+		[]metricdata.DataPoint[int64]{{Attributes: attrs, Value: 0}},
+		metricdatatest.IgnoreTimestamp(), metricdatatest.IgnoreExemplars())
+	metadatatest.AssertEqualExporterSendFailedLogRecords(t, tt,
+		[]metricdata.DataPoint[int64]{{Attributes: attrs, Value: 3}},
+		metricdatatest.IgnoreTimestamp(), metricdatatest.IgnoreExemplars())
+}
+
 type testParams struct {
 	items int
 	err   error
@@ -7,6 +7,9 @@ import (
 	"context"
 
 	"go.opentelemetry.io/otel/trace"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/internal/telemetry/componentattribute"
 )
 
 type traceContextKeyType int
@@ -31,10 +34,37 @@ func parentsFromContext(ctx context.Context) []trace.Link {
 	return LinksFromContext(ctx)
 }
 
-func contextWithMergedLinks(ctx1 context.Context, ctx2 context.Context) context.Context {
+// contextWithMergedLinks returns a context with the links of both contexts, and the pipeline they flow through.
+// If metadataKeys is not empty, the context also carries the values of these client metadata keys taken from ctx1,
+// callers must only merge contexts in the same partition, see newBatchPartitioner.
+func contextWithMergedLinks(ctx1 context.Context, ctx2 context.Context, metadataKeys []string) context.Context {
+	ctx := componentattribute.ContextWithMergedPipeline(context.Background(), ctx1, ctx2)
+	if len(metadataKeys) > 0 {
+		ctx = client.NewContext(ctx, clientInfoWithKeys(ctx1, metadataKeys))
+	}
 	return context.WithValue(
-		context.Background(),
+		ctx,
 		batchSpanLinksKey,
 		append(parentsFromContext(ctx1), parentsFromContext(ctx2)...),
 	)
 }
+
+// contextWithClientMetadata returns a copy of ctx whose client.Info only keeps the values of the given
+// metadata keys, like the context of a merged batch. It returns ctx if metadataKeys is empty.
+func contextWithClientMetadata(ctx context.Context, metadataKeys []string) context.Context {
+	if len(metadataKeys) == 0 {
+		return ctx
+	}
+	return client.NewContext(ctx, clientInfoWithKeys(ctx, metadataKeys))
+}
+
+func clientInfoWithKeys(ctx context.Context, metadataKeys []string) client.Info {
+	md := client.FromContext(ctx).Metadata
+	values := make(map[string][]string, len(metadataKeys))
+	for _, k := range metadataKeys {
+		if v := md.Get(k); len(v) > 0 {
+			values[k] = v
+		}
+	}
+	return client.Info{Metadata: client.NewMetadata(values)}
+}
@@ -10,7 +10,10 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/otel/trace"
 
+	"go.opentelemetry.io/collector/client"
 	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/internal/telemetry/componentattribute"
+	"go.opentelemetry.io/collector/pipeline"
 )
 
 func TestBatchContextLink(t *testing.T) {
@@ -28,8 +31,8 @@ func TestBatchContextLink(t *testing.T) {
 	ctx4, span4 := tracer.Start(ctx1, "span4")
 	defer span4.End()
 
-	batchContext := contextWithMergedLinks(ctx2, ctx3)
-	batchContext = contextWithMergedLinks(batchContext, ctx4)
+	batchContext := contextWithMergedLinks(ctx2, ctx3, nil)
+	batchContext = contextWithMergedLinks(batchContext, ctx4, nil)
 
 	actualLinks := LinksFromContext(batchContext)
 	require.Len(t, actualLinks, 3)
@@ -37,3 +40,37 @@ func TestBatchContextLink(t *testing.T) {
 	require.Equal(t, trace.SpanContextFromContext(ctx3), actualLinks[1].SpanContext)
 	require.Equal(t, trace.SpanContextFromContext(ctx4), actualLinks[2].SpanContext)
 }
+
+func TestBatchContextClientInfo(t *testing.T) {
+	info := client.Info{Metadata: client.NewMetadata(map[string][]string{"x-tenant": {"acme"}, "x-other": {"1"}})}
+	ctx1 := client.NewContext(context.Background(), info)
+	ctx2 := client.NewContext(context.Background(), info)
+
+	batchContext := contextWithMergedLinks(ctx1, ctx2, nil)
+	require.Empty(t, client.FromContext(batchContext).Metadata.Get("x-tenant"))
+
+	batchContext = contextWithMergedLinks(ctx1, ctx2, []string{"x-tenant"})
+	require.Equal(t, []string{"acme"}, client.FromContext(batchContext).Metadata.Get("x-tenant"))
+	require.Empty(t, client.FromContext(batchContext).Metadata.Get("x-other"))
+
+	// A batch with a single request keeps the same metadata as a merged batch.
+	require.Equal(t, ctx1, contextWithClientMetadata(ctx1, nil))
+	batchContext = contextWithClientMetadata(ctx1, []string{"x-tenant"})
+	require.Equal(t, []string{"acme"}, client.FromContext(batchContext).Metadata.Get("x-tenant"))
+	require.Empty(t, client.FromContext(batchContext).Metadata.Get("x-other"))
+}
+
+func TestBatchContextPipeline(t *testing.T) {
+	logs1 := pipeline.NewIDWithName(pipeline.SignalLogs, "1")
+	exported := 0
+	onExported := func(context.Context, error) { exported++ }
+	ctx1 := componentattribute.ContextWithPipeline(context.Background(), logs1, onExported)
+	ctx2 := componentattribute.ContextWithPipeline(context.Background(), logs1, onExported)
+
+	batchContext := contextWithMergedLinks(ctx1, ctx2, nil)
+	id, ok := componentattribute.PipelineFromContext(batchContext)
+	require.True(t, ok)
+	require.Equal(t, logs1, id)
+	componentattribute.PipelineExported(batchContext, nil)
+	require.Equal(t, 2, exported)
+}
@@ -7,6 +7,7 @@ import (
 	"context"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/request"
 )
 
 // Batcher is in charge of reading items from the queue and send them out asynchronously.
@@ -14,3 +15,10 @@ type Batcher[T any] interface {
 	component.Component
 	Consume(context.Context, T, Done)
 }
+
+// newBatcher returns a batcher that never merges requests from different pipelines, or with different
+// values for the configured client metadata keys.
+func newBatcher(bCfg BatchConfig, bSet batcherSettings[request.Request]) Batcher[request.Request] {
+	bSet.partitioner = newBatchPartitioner(bCfg.MetadataKeys)
+	return newMultiBatcher(bCfg, bSet)
+}
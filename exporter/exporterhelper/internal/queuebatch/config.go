@@ -50,6 +50,11 @@ type Config struct {
 	// TODO: This will be changed to Optional when available.
 	Batch *BatchConfig `mapstructure:"batch"`
 
+	// Tenants if not nil, splits the queue capacity between the tenants identified by a client metadata key,
+	// so that a single tenant cannot fill the whole queue.
+	// TODO: This will be changed to Optional when available.
+	Tenants *TenantConfig `mapstructure:"tenants"`
+
 	// TODO: Remove when deprecated "blocking" is removed.
 	hasBlocking bool
 }
@@ -97,6 +102,11 @@ func (cfg *Config) Validate() error {
 		return errors.New("`batch` supports only `items` or `bytes` sizer")
 	}
 
+	// Client metadata is not persisted, so tenants cannot be identified after a restart.
+	if cfg.StorageID != nil && cfg.Tenants != nil {
+		return errors.New("`tenants` is not supported with a persistent queue configured with `storage`")
+	}
+
 	return nil
 }
 
@@ -110,6 +120,11 @@ type BatchConfig struct {
 
 	// MaxSize defines the configuration for the maximum size of a batch.
 	MaxSize int64 `mapstructure:"max_size"`
+
+	// MetadataKeys is a list of client metadata keys. Requests are batched separately for every distinct
+	// combination of values for these keys, and the batch only keeps these values in its client.Info,
+	// e.g. for `headers_from_context` to forward them. If empty, the client metadata is ignored.
+	MetadataKeys []string `mapstructure:"metadata_keys,omitempty"`
 }
 
 func (cfg *BatchConfig) Validate() error {
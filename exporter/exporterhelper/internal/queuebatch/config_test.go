@@ -46,6 +46,13 @@ func TestConfig_Validate(t *testing.T) {
 	cfg.StorageID = &storageID
 	require.EqualError(t, cfg.Validate(), "persistent queue configured with `storage` only supports `requests` sizer")
 
+	cfg = newTestConfig()
+	cfg.Sizer = request.SizerTypeRequests
+	cfg.Batch = nil
+	cfg.StorageID = &storageID
+	cfg.Tenants = &TenantConfig{MetadataKey: "x-tenant"}
+	require.EqualError(t, cfg.Validate(), "`tenants` is not supported with a persistent queue configured with `storage`")
+
 	cfg = newTestConfig()
 	cfg.Sizer = request.SizerTypeRequests
 	require.EqualError(t, cfg.Validate(), "`batch` supports only `items` or `bytes` sizer")
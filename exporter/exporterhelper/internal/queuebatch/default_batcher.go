@@ -22,10 +22,11 @@ type batch struct {
 }
 
 type batcherSettings[T any] struct {
-	sizerType  request.SizerType
-	sizer      request.Sizer[T]
-	next       sender.SendFunc[T]
-	maxWorkers int
+	sizerType   request.SizerType
+	sizer       request.Sizer[T]
+	partitioner Partitioner[T]
+	next        sender.SendFunc[T]
+	maxWorkers  int
 }
 
 // defaultBatcher continuously batch incoming requests and flushes asynchronously if minimum size limit is met or on timeout.
@@ -43,14 +44,23 @@ type defaultBatcher struct {
 }
 
 func newDefaultBatcher(bCfg BatchConfig, bSet batcherSettings[request.Request]) *defaultBatcher {
+	return newDefaultBatcherWithWorkerPool(bCfg, bSet, newWorkerPool(bSet.maxWorkers))
+}
+
+// newWorkerPool returns a pool of maxWorkers tokens, or nil if the number of workers is not limited.
+func newWorkerPool(maxWorkers int) chan struct{} {
 	// TODO: Determine what is the right behavior for this in combination with async queue.
-	var workerPool chan struct{}
-	if bSet.maxWorkers != 0 {
-		workerPool = make(chan struct{}, bSet.maxWorkers)
-		for i := 0; i < bSet.maxWorkers; i++ {
-			workerPool <- struct{}{}
-		}
+	if maxWorkers == 0 {
+		return nil
 	}
+	workerPool := make(chan struct{}, maxWorkers)
+	for i := 0; i < maxWorkers; i++ {
+		workerPool <- struct{}{}
+	}
+	return workerPool
+}
+
+func newDefaultBatcherWithWorkerPool(bCfg BatchConfig, bSet batcherSettings[request.Request], workerPool chan struct{}) *defaultBatcher {
 	return &defaultBatcher{
 		cfg:         bCfg,
 		workerPool:  workerPool,
@@ -69,6 +79,8 @@ func (qb *defaultBatcher) resetTimer() {
 }
 
 func (qb *defaultBatcher) Consume(ctx context.Context, req request.Request, done Done) {
+	// Every batch keeps the same client metadata, whether it holds a single request or merged ones.
+	ctx = contextWithClientMetadata(ctx, qb.cfg.MetadataKeys)
 	qb.currentBatchMu.Lock()
 
 	if qb.currentBatch == nil {
@@ -127,7 +139,7 @@ func (qb *defaultBatcher) Consume(ctx context.Context, req request.Request, done
 	// Logic on how to deal with the current batch:
 	qb.currentBatch.req = reqList[0]
 	qb.currentBatch.done = append(qb.currentBatch.done, done)
-	qb.currentBatch.ctx = contextWithMergedLinks(qb.currentBatch.ctx, ctx)
+	qb.currentBatch.ctx = contextWithMergedLinks(qb.currentBatch.ctx, ctx, qb.cfg.MetadataKeys)
 
 	// Save the "currentBatch" if we need to flush it, because we want to execute flush without holding the lock, and
 	// cannot unlock and re-lock because we are not done processing all the responses.
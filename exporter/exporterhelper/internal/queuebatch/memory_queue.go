@@ -31,6 +31,7 @@ type memoryQueueSettings[T any] struct {
 	capacity        int64
 	waitForResult   bool
 	blockOnOverflow bool
+	tenants         *tenantLimiter
 }
 
 // memoryQueue is an in-memory implementation of a Queue.
@@ -47,6 +48,7 @@ type memoryQueue[T any] struct {
 	stopped         bool
 	waitForResult   bool
 	blockOnOverflow bool
+	tenants         *tenantLimiter
 }
 
 // newMemoryQueue creates a sized elements channel. Each element is assigned a size by the provided sizer.
@@ -58,6 +60,7 @@ func newMemoryQueue[T any](set memoryQueueSettings[T]) readableQueue[T] {
 		items:           &linkedQueue[T]{},
 		waitForResult:   set.waitForResult,
 		blockOnOverflow: set.blockOnOverflow,
+		tenants:         set.tenants,
 	}
 	sq.hasMoreElements = sync.NewCond(&sq.mu)
 	sq.hasMoreSpace = newCond(&sq.mu)
@@ -82,7 +85,15 @@ func (mq *memoryQueue[T]) Offer(ctx context.Context, el T) error {
 		return errSizeTooLarge
 	}
 
-	done, err := mq.add(ctx, el, elSize)
+	var tenant string
+	if mq.tenants != nil {
+		tenant = mq.tenants.tenant(ctx)
+		if elSize > mq.tenants.maxSize(tenant) {
+			return errSizeTooLarge
+		}
+	}
+
+	done, err := mq.add(ctx, el, elSize, tenant)
 	if err != nil {
 		return err
 	}
@@ -102,13 +113,17 @@ func (mq *memoryQueue[T]) Offer(ctx context.Context, el T) error {
 	return nil
 }
 
-func (mq *memoryQueue[T]) add(ctx context.Context, el T, elSize int64) (*blockingDone, error) {
+func (mq *memoryQueue[T]) add(ctx context.Context, el T, elSize int64, tenant string) (*blockingDone, error) {
 	mq.mu.Lock()
 	defer mq.mu.Unlock()
 
-	for mq.size+elSize > mq.cap {
+	for {
+		err := mq.hasSpace(elSize, tenant)
+		if err == nil {
+			break
+		}
 		if !mq.blockOnOverflow {
-			return nil, ErrQueueIsFull
+			return nil, err
 		}
 		// Wait for more space or before the ctx is Done.
 		if err := mq.hasMoreSpace.Wait(ctx); err != nil {
@@ -117,8 +132,11 @@ func (mq *memoryQueue[T]) add(ctx context.Context, el T, elSize int64) (*blockin
 	}
 
 	mq.size += elSize
+	if mq.tenants != nil {
+		mq.tenants.reserve(tenant, elSize)
+	}
 	done := blockingDonePool.Get().(*blockingDone)
-	done.reset(elSize, mq)
+	done.reset(elSize, tenant, mq)
 
 	if !mq.waitForResult {
 		// Prevent cancellation and deadline to propagate to the context stored in the queue.
@@ -132,6 +150,18 @@ func (mq *memoryQueue[T]) add(ctx context.Context, el T, elSize int64) (*blockin
 	return done, nil
 }
 
+// hasSpace returns nil if an element of the given size can be added for the tenant.
+// It requires the caller to hold mq.mu.
+func (mq *memoryQueue[T]) hasSpace(elSize int64, tenant string) error {
+	if mq.tenants != nil && !mq.tenants.fits(tenant, elSize) {
+		return errTenantQueueIsFull
+	}
+	if mq.size+elSize > mq.cap {
+		return ErrQueueIsFull
+	}
+	return nil
+}
+
 // Read removes the element from the queue and returns it.
 // The call blocks until there is an item available or the queue is stopped.
 // The function returns true when an item is consumed or false if the queue is stopped and emptied.
@@ -160,7 +190,13 @@ func (mq *memoryQueue[T]) onDone(bd *blockingDone, err error) {
 	mq.mu.Lock()
 	defer mq.mu.Unlock()
 	mq.size -= bd.elSize
-	mq.hasMoreSpace.Signal()
+	if mq.tenants != nil {
+		mq.tenants.release(bd.tenant, bd.elSize)
+		// Waiters may belong to different tenants, wake all of them so that the one with room proceeds.
+		mq.hasMoreSpace.Broadcast()
+	} else {
+		mq.hasMoreSpace.Signal()
+	}
 	if mq.waitForResult {
 		// In this case the done will be added back to the queue by the waiter.
 		bd.ch <- err
@@ -232,11 +268,13 @@ type blockingDone struct {
 		onDone(*blockingDone, error)
 	}
 	elSize int64
+	tenant string
 	ch     chan error
 }
 
-func (bd *blockingDone) reset(elSize int64, queue interface{ onDone(*blockingDone, error) }) {
+func (bd *blockingDone) reset(elSize int64, tenant string, queue interface{ onDone(*blockingDone, error) }) {
 	bd.elSize = elSize
+	bd.tenant = tenant
 	bd.queue = queue
 }
 
@@ -0,0 +1,88 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package queuebatch // import "go.opentelemetry.io/collector/exporter/exporterhelper/internal/queuebatch"
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"go.uber.org/multierr"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/request"
+	"go.opentelemetry.io/collector/internal/telemetry/componentattribute"
+)
+
+// multiBatcher batches the requests of every partition independently, with a defaultBatcher per partition
+// key. The batchers are created on the first request of their partition and share the same worker pool.
+type multiBatcher struct {
+	cfg        BatchConfig
+	set        batcherSettings[request.Request]
+	workerPool chan struct{}
+
+	mu       sync.Mutex
+	batchers map[string]*defaultBatcher
+}
+
+func newMultiBatcher(bCfg BatchConfig, bSet batcherSettings[request.Request]) *multiBatcher {
+	return &multiBatcher{
+		cfg:        bCfg,
+		set:        bSet,
+		workerPool: newWorkerPool(bSet.maxWorkers),
+		batchers:   make(map[string]*defaultBatcher),
+	}
+}
+
+func (mb *multiBatcher) Start(context.Context, component.Host) error {
+	return nil
+}
+
+func (mb *multiBatcher) Consume(ctx context.Context, req request.Request, done Done) {
+	mb.batcher(ctx, req).Consume(ctx, req, done)
+}
+
+// batcher returns the batcher of the partition of the request, it creates and starts it if needed.
+func (mb *multiBatcher) batcher(ctx context.Context, req request.Request) *defaultBatcher {
+	key := mb.set.partitioner.GetKey(ctx, req)
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+	b, ok := mb.batchers[key]
+	if !ok {
+		b = newDefaultBatcherWithWorkerPool(mb.cfg, mb.set, mb.workerPool)
+		// Starting a defaultBatcher never fails.
+		_ = b.Start(ctx, nil)
+		mb.batchers[key] = b
+	}
+	return b
+}
+
+func (mb *multiBatcher) Shutdown(ctx context.Context) error {
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+	var errs error
+	for _, b := range mb.batchers {
+		errs = multierr.Append(errs, b.Shutdown(ctx))
+	}
+	return errs
+}
+
+// newBatchPartitioner returns a partitioner keyed by the pipeline of the request and the values of the given
+// client metadata keys, so that the batch context keeps the same pipeline and metadata for every request.
+func newBatchPartitioner(metadataKeys []string) Partitioner[request.Request] {
+	return NewPartitioner(func(ctx context.Context, _ request.Request) string {
+		var b strings.Builder
+		if id, ok := componentattribute.PipelineFromContext(ctx); ok {
+			b.WriteString(id.String())
+		}
+		b.WriteByte(';')
+		md := client.FromContext(ctx).Metadata
+		for _, k := range metadataKeys {
+			fmt.Fprintf(&b, "%q;", md.Get(k))
+		}
+		return b.String()
+	})
+}
@@ -0,0 +1,183 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package queuebatch
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/request"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/requesttest"
+	"go.opentelemetry.io/collector/internal/telemetry/componentattribute"
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+func TestBatcher_MetadataKeys(t *testing.T) {
+	tests := []struct {
+		name         string
+		metadataKeys []string
+		expected     map[string]int
+	}{
+		{
+			name:     "ignored_by_default",
+			expected: map[string]int{"": 12, "b": 8},
+		},
+		{
+			name:         "grouped_by_keys",
+			metadataKeys: []string{"x-tenant"},
+			expected:     map[string]int{"a": 8, "b": 12},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := BatchConfig{
+				FlushTimeout: 0,
+				MinSize:      10,
+				MetadataKeys: tt.metadataKeys,
+			}
+
+			var mu sync.Mutex
+			tenants := map[string]int{}
+			ba := newBatcher(cfg, batcherSettings[request.Request]{
+				sizerType: request.SizerTypeItems,
+				sizer:     request.NewItemsSizer(),
+				next: func(ctx context.Context, req request.Request) error {
+					mu.Lock()
+					defer mu.Unlock()
+					md := client.FromContext(ctx).Metadata
+					tenants[strings.Join(md.Get("x-tenant"), ",")] += req.ItemsCount()
+					if len(tt.metadataKeys) > 0 {
+						// Only the configured keys are kept, whether the batch holds one request or merged ones.
+						assert.Empty(t, md.Get("x-other"))
+					}
+					return nil
+				},
+				maxWorkers: 1,
+			})
+			require.NoError(t, ba.Start(context.Background(), componenttest.NewNopHost()))
+
+			newCtx := func(tenant string) context.Context {
+				return client.NewContext(context.Background(), client.Info{
+					Metadata: client.NewMetadata(map[string][]string{"x-tenant": {tenant}, "x-other": {"1"}}),
+				})
+			}
+			done := newFakeDone()
+			// The requests of the tenants are interleaved, they are still batched per tenant.
+			ba.Consume(newCtx("a"), &requesttest.FakeRequest{Items: 4}, done)
+			ba.Consume(newCtx("b"), &requesttest.FakeRequest{Items: 4}, done)
+			ba.Consume(newCtx("a"), &requesttest.FakeRequest{Items: 4}, done)
+			ba.Consume(newCtx("b"), &requesttest.FakeRequest{Items: 8}, done)
+			require.NoError(t, ba.Shutdown(context.Background()))
+
+			assert.Equal(t, tt.expected, tenants)
+			assert.EqualValues(t, 4, done.success.Load())
+		})
+	}
+}
+
+func TestMultiBatcher_SharesWorkerPool(t *testing.T) {
+	cfg := BatchConfig{
+		FlushTimeout: 0,
+		MinSize:      0,
+		MetadataKeys: []string{"x-tenant"},
+	}
+	release := make(chan struct{})
+	var mu sync.Mutex
+	var running, maxRunning int
+	ba := newBatcher(cfg, batcherSettings[request.Request]{
+		sizerType: request.SizerTypeItems,
+		sizer:     request.NewItemsSizer(),
+		next: func(context.Context, request.Request) error {
+			mu.Lock()
+			running++
+			maxRunning = max(maxRunning, running)
+			mu.Unlock()
+			<-release
+			mu.Lock()
+			running--
+			mu.Unlock()
+			return nil
+		},
+		maxWorkers: 1,
+	})
+	require.NoError(t, ba.Start(context.Background(), componenttest.NewNopHost()))
+
+	done := newFakeDone()
+	consumed := make(chan struct{})
+	go func() {
+		defer close(consumed)
+		for _, tenant := range []string{"a", "b", "c"} {
+			ctx := client.NewContext(context.Background(), client.Info{
+				Metadata: client.NewMetadata(map[string][]string{"x-tenant": {tenant}}),
+			})
+			ba.Consume(ctx, &requesttest.FakeRequest{Items: 1}, done)
+		}
+	}()
+	for range 3 {
+		release <- struct{}{}
+	}
+	<-consumed
+	require.NoError(t, ba.Shutdown(context.Background()))
+	assert.Equal(t, 1, maxRunning)
+	assert.EqualValues(t, 3, done.success.Load())
+}
+
+func TestBatcher_Pipelines(t *testing.T) {
+	cfg := BatchConfig{
+		FlushTimeout: 0,
+		MinSize:      10,
+	}
+
+	var mu sync.Mutex
+	pipelines := map[string]int{}
+	ba := newBatcher(cfg, batcherSettings[request.Request]{
+		sizerType: request.SizerTypeItems,
+		sizer:     request.NewItemsSizer(),
+		next: func(ctx context.Context, req request.Request) error {
+			mu.Lock()
+			defer mu.Unlock()
+			id, _ := componentattribute.PipelineFromContext(ctx)
+			pipelines[id.String()] += req.ItemsCount()
+			componentattribute.PipelineExported(ctx, nil)
+			return nil
+		},
+		maxWorkers: 1,
+	})
+	require.NoError(t, ba.Start(context.Background(), componenttest.NewNopHost()))
+
+	var exported atomic.Int64
+	newCtx := func(id pipeline.ID) context.Context {
+		return componentattribute.ContextWithPipeline(context.Background(), id, func(context.Context, error) {
+			exported.Add(1)
+		})
+	}
+	logs1 := pipeline.NewIDWithName(pipeline.SignalLogs, "1")
+	logs2 := pipeline.NewIDWithName(pipeline.SignalLogs, "2")
+	done := newFakeDone()
+	// The requests of the pipelines are interleaved, they are still batched per pipeline without flushing.
+	ba.Consume(newCtx(logs1), &requesttest.FakeRequest{Items: 4}, done)
+	ba.Consume(newCtx(logs2), &requesttest.FakeRequest{Items: 4}, done)
+	ba.Consume(newCtx(logs1), &requesttest.FakeRequest{Items: 6}, done)
+	assert.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return pipelines["logs/1"] == 10 && len(pipelines) == 1
+	}, time.Second, time.Millisecond)
+	ba.Consume(newCtx(logs2), &requesttest.FakeRequest{Items: 2}, done)
+	ba.Consume(context.Background(), &requesttest.FakeRequest{Items: 3}, done)
+	require.NoError(t, ba.Shutdown(context.Background()))
+
+	assert.Equal(t, map[string]int{"logs/1": 10, "logs/2": 6, "": 3}, pipelines)
+	assert.EqualValues(t, 4, exported.Load())
+	assert.EqualValues(t, 5, done.success.Load())
+}
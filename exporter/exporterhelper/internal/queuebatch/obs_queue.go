@@ -12,6 +12,7 @@ import (
 
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/metadata"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/request"
+	"go.opentelemetry.io/collector/internal/telemetry/componentattribute"
 	"go.opentelemetry.io/collector/pipeline"
 )
 
@@ -21,18 +22,21 @@ const (
 
 	// DataTypeKey used to identify the data type in the queue size metric.
 	dataTypeKey = "data_type"
+
+	// tenantKey used to identify the tenant in the per-tenant queue size metric.
+	tenantKey = "tenant"
 )
 
 // obsQueue is a helper to add observability to a queue.
 type obsQueue[T request.Request] struct {
 	Queue[T]
 	tb                *metadata.TelemetryBuilder
-	metricAttr        metric.MeasurementOption
+	metricAttr        *componentattribute.PipelineMeasurementOptions
 	enqueueFailedInst metric.Int64Counter
 	tracer            trace.Tracer
 }
 
-func newObsQueue[T request.Request](set Settings[T], delegate Queue[T]) (Queue[T], error) {
+func newObsQueue[T request.Request](set Settings[T], delegate Queue[T], tenants *tenantLimiter) (Queue[T], error) {
 	tb, err := metadata.NewTelemetryBuilder(set.Telemetry)
 	if err != nil {
 		return nil, err
@@ -56,12 +60,25 @@ func newObsQueue[T request.Request](set Settings[T], delegate Queue[T]) (Queue[T
 		return nil, err
 	}
 
+	if tenants != nil {
+		exporterAttrs := []attribute.KeyValue{exporterAttr, attribute.String(dataTypeKey, set.Signal.String())}
+		err = tb.RegisterExporterQueueTenantSizeCallback(func(_ context.Context, o metric.Int64Observer) error {
+			tenants.observe(func(tenant string, size int64) {
+				o.Observe(size, metric.WithAttributeSet(attribute.NewSet(append(exporterAttrs, attribute.String(tenantKey, tenant))...)))
+			})
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	tracer := metadata.Tracer(set.Telemetry)
 
 	or := &obsQueue[T]{
 		Queue:      delegate,
 		tb:         tb,
-		metricAttr: metric.WithAttributeSet(attribute.NewSet(exporterAttr)),
+		metricAttr: componentattribute.NewPipelineMeasurementOptions(exporterAttr),
 		tracer:     tracer,
 	}
 
@@ -91,9 +108,13 @@ func (or *obsQueue[T]) Offer(ctx context.Context, req T) error {
 	err := or.Queue.Offer(ctx, req)
 	span.End()
 
-	// No metrics recorded for profiles, remove enqueueFailedInst check with nil when profiles metrics available.
-	if err != nil && or.enqueueFailedInst != nil {
-		or.enqueueFailedInst.Add(ctx, int64(numItems), or.metricAttr)
+	if err != nil {
+		// No metrics recorded for profiles, remove enqueueFailedInst check with nil when profiles metrics available.
+		if or.enqueueFailedInst != nil {
+			or.enqueueFailedInst.Add(ctx, int64(numItems), or.metricAttr.ForContext(ctx))
+		}
+		// The data is dropped, it is done for the pipeline.
+		componentattribute.PipelineExported(ctx, err)
 	}
 	return err
 }
@@ -55,7 +55,7 @@ func TestObsQueueLogsSizeCapacity(t *testing.T) {
 		Signal:    pipeline.SignalLogs,
 		ID:        exporterID,
 		Telemetry: tt.NewTelemetrySettings(),
-	}, newFakeQueue[request.Request](nil, 7, 9))
+	}, newFakeQueue[request.Request](nil, 7, 9), nil)
 	require.NoError(t, err)
 	require.NoError(t, te.Offer(context.Background(), &requesttest.FakeRequest{Items: 2}))
 	metadatatest.AssertEqualExporterQueueSize(t, tt,
@@ -86,7 +86,7 @@ func TestObsQueueLogsFailure(t *testing.T) {
 		Signal:    pipeline.SignalLogs,
 		ID:        exporterID,
 		Telemetry: tt.NewTelemetrySettings(),
-	}, newFakeQueue[request.Request](errors.New("my error"), 7, 9))
+	}, newFakeQueue[request.Request](errors.New("my error"), 7, 9), nil)
 	require.NoError(t, err)
 	require.Error(t, te.Offer(context.Background(), &requesttest.FakeRequest{Items: 2}))
 	metadatatest.AssertEqualExporterEnqueueFailedLogRecords(t, tt,
@@ -107,7 +107,7 @@ func TestObsQueueTracesSizeCapacity(t *testing.T) {
 		Signal:    pipeline.SignalTraces,
 		ID:        exporterID,
 		Telemetry: tt.NewTelemetrySettings(),
-	}, newFakeQueue[request.Request](nil, 17, 19))
+	}, newFakeQueue[request.Request](nil, 17, 19), nil)
 	require.NoError(t, err)
 	require.NoError(t, te.Offer(context.Background(), &requesttest.FakeRequest{Items: 12}))
 	metadatatest.AssertEqualExporterQueueSize(t, tt,
@@ -138,7 +138,7 @@ func TestObsQueueTracesFailure(t *testing.T) {
 		Signal:    pipeline.SignalTraces,
 		ID:        exporterID,
 		Telemetry: tt.NewTelemetrySettings(),
-	}, newFakeQueue[request.Request](errors.New("my error"), 0, 0))
+	}, newFakeQueue[request.Request](errors.New("my error"), 0, 0), nil)
 	require.NoError(t, err)
 	require.Error(t, te.Offer(context.Background(), &requesttest.FakeRequest{Items: 12}))
 	metadatatest.AssertEqualExporterEnqueueFailedSpans(t, tt,
@@ -159,7 +159,7 @@ func TestObsQueueMetrics(t *testing.T) {
 		Signal:    pipeline.SignalMetrics,
 		ID:        exporterID,
 		Telemetry: tt.NewTelemetrySettings(),
-	}, newFakeQueue[request.Request](nil, 27, 29))
+	}, newFakeQueue[request.Request](nil, 27, 29), nil)
 	require.NoError(t, err)
 	require.NoError(t, te.Offer(context.Background(), &requesttest.FakeRequest{Items: 22}))
 	metadatatest.AssertEqualExporterQueueSize(t, tt,
@@ -190,7 +190,7 @@ func TestObsQueueMetricsFailure(t *testing.T) {
 		Signal:    pipeline.SignalMetrics,
 		ID:        exporterID,
 		Telemetry: tt.NewTelemetrySettings(),
-	}, newFakeQueue[request.Request](errors.New("my error"), 0, 0))
+	}, newFakeQueue[request.Request](errors.New("my error"), 0, 0), nil)
 	require.NoError(t, err)
 	require.Error(t, te.Offer(context.Background(), &requesttest.FakeRequest{Items: 22}))
 	metadatatest.AssertEqualExporterEnqueueFailedMetricPoints(t, tt,
@@ -126,6 +126,15 @@ func (pq *persistentQueue[T]) Size() int64 {
 	return pq.queueSize
 }
 
+// pendingRequests returns the number of requests left in the storage, including the dispatched requests
+// that are not done. Once the queue is shut down and its consumers stopped, these are the requests sent after restart.
+func (pq *persistentQueue[T]) pendingRequests() int64 {
+	pq.mu.Lock()
+	defer pq.mu.Unlock()
+	//nolint:gosec
+	return int64(pq.writeIndex-pq.readIndex) + int64(len(pq.currentlyDispatchedItems))
+}
+
 func (pq *persistentQueue[T]) Capacity() int64 {
 	return pq.set.capacity
 }
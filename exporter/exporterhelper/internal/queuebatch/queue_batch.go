@@ -7,10 +7,15 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"go.uber.org/zap"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/request"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/sender"
+	"go.opentelemetry.io/collector/internal/reservation"
 	"go.opentelemetry.io/collector/pipeline"
 )
 
@@ -23,9 +28,22 @@ type Settings[T any] struct {
 	Sizers    map[request.SizerType]request.Sizer[T]
 }
 
+// errShutdownDeadline is passed to the done callback of the requests dropped at shutdown.
+var errShutdownDeadline = errors.New("shutdown deadline reached before the request was sent")
+
 type QueueBatch struct {
 	queue   Queue[request.Request]
 	batcher Batcher[request.Request]
+	logger  *zap.Logger
+
+	// dropping is set when the shutdown deadline is reached before the queue is drained.
+	dropping     atomic.Bool
+	droppedItems atomic.Int64
+	// pendingRequests returns the requests left in a persistent queue, it is nil for a memory queue.
+	pendingRequests func() int64
+	// holdsReservations is set for a memory queue: the requests it holds keep the memory reserved
+	// by the receivers until they are exported.
+	holdsReservations bool
 }
 
 func NewQueueBatch(
@@ -67,14 +85,14 @@ func newQueueBatch(
 		cfg.NumConsumers = 1
 		if oldBatcher {
 			// If user configures the old batcher we only can support "items" sizer.
-			b = newDefaultBatcher(*cfg.Batch, batcherSettings[request.Request]{
+			b = newBatcher(*cfg.Batch, batcherSettings[request.Request]{
 				sizerType:  request.SizerTypeItems,
 				sizer:      request.NewItemsSizer(),
 				next:       next,
 				maxWorkers: cfg.NumConsumers,
 			})
 		} else {
-			b = newDefaultBatcher(*cfg.Batch, batcherSettings[request.Request]{
+			b = newBatcher(*cfg.Batch, batcherSettings[request.Request]{
 				sizerType:  cfg.Sizer,
 				sizer:      sizer,
 				next:       next,
@@ -85,17 +103,24 @@ func newQueueBatch(
 		b = newDisabledBatcher[request.Request](next)
 	}
 
+	qs := &QueueBatch{batcher: b, logger: set.Telemetry.Logger}
 	var q Queue[request.Request]
+	var tl *tenantLimiter
 	// Configure memory queue or persistent based on the config.
 	if cfg.StorageID == nil {
+		qs.holdsReservations = true
+		if cfg.Tenants != nil {
+			tl = newTenantLimiter(*cfg.Tenants, cfg.QueueSize)
+		}
 		q = newAsyncQueue(newMemoryQueue[request.Request](memoryQueueSettings[request.Request]{
 			sizer:           sizer,
 			capacity:        cfg.QueueSize,
 			waitForResult:   cfg.WaitForResult,
 			blockOnOverflow: cfg.BlockOnOverflow,
-		}), cfg.NumConsumers, b.Consume)
+			tenants:         tl,
+		}), cfg.NumConsumers, qs.consume)
 	} else {
-		q = newAsyncQueue(newPersistentQueue[request.Request](persistentQueueSettings[request.Request]{
+		pq := newPersistentQueue[request.Request](persistentQueueSettings[request.Request]{
 			sizer:           sizer,
 			capacity:        cfg.QueueSize,
 			blockOnOverflow: cfg.BlockOnOverflow,
@@ -104,15 +129,17 @@ func newQueueBatch(
 			encoding:        set.Encoding,
 			id:              set.ID,
 			telemetry:       set.Telemetry,
-		}), cfg.NumConsumers, b.Consume)
+		})
+		qs.pendingRequests = pq.(*persistentQueue[request.Request]).pendingRequests
+		q = newAsyncQueue(pq, cfg.NumConsumers, qs.consume)
 	}
 
-	oq, err := newObsQueue(set, q)
+	oq, err := newObsQueue(set, q, tl)
 	if err != nil {
 		return nil, err
 	}
-
-	return &QueueBatch{queue: oq, batcher: b}, nil
+	qs.queue = oq
+	return qs, nil
 }
 
 // Start is invoked during service startup.
@@ -129,11 +156,85 @@ func (qs *QueueBatch) Start(ctx context.Context, host component.Host) error {
 // Shutdown is invoked during service shutdown.
 func (qs *QueueBatch) Shutdown(ctx context.Context) error {
 	// Stop the queue and batcher, this will drain the queue and will call the retry (which is stopped) that will only
-	// try once every request.
-	return errors.Join(qs.queue.Shutdown(ctx), qs.batcher.Shutdown(ctx))
+	// try once every request. Once ctx is done, the requests left in the queue are dropped instead of being sent.
+	queueErr := make(chan error, 1)
+	go func() {
+		queueErr <- qs.queue.Shutdown(context.WithoutCancel(ctx))
+	}()
+	var err error
+	select {
+	case err = <-queueErr:
+	case <-ctx.Done():
+		qs.dropping.Store(true)
+		err = <-queueErr
+	}
+	if dropped := qs.droppedItems.Load(); dropped > 0 {
+		qs.logger.Warn("Shutdown deadline reached before the sending queue was drained. Dropping data.",
+			zap.Int64("dropped_items", dropped))
+	}
+	return errors.Join(err, qs.batcher.Shutdown(ctx))
+}
+
+// DrainReport returns the number of requests left in a persistent queue, sent after restart, and the number
+// of items dropped because the shutdown deadline was reached before the queue was drained.
+func (qs *QueueBatch) DrainReport() (queuedRequests, droppedItems int64) {
+	if qs.pendingRequests != nil {
+		queuedRequests = qs.pendingRequests()
+	}
+	return queuedRequests, qs.droppedItems.Load()
+}
+
+// consume passes the requests read from the queue to the batcher, or drops them once the shutdown deadline is reached.
+func (qs *QueueBatch) consume(ctx context.Context, req request.Request, done Done) {
+	if held, ok := ctx.Value(heldReservationKey{}).(*heldReservation); ok {
+		done = releaseDone{Done: done, held: held}
+	}
+	if qs.dropping.Load() {
+		qs.droppedItems.Add(int64(req.ItemsCount()))
+		done.OnDone(errShutdownDeadline)
+		return
+	}
+	qs.batcher.Consume(ctx, req, done)
 }
 
 // Send implements the requestSender interface. It puts the request in the queue.
 func (qs *QueueBatch) Send(ctx context.Context, req request.Request) error {
-	return qs.queue.Offer(ctx, req)
+	if !qs.holdsReservations {
+		return qs.queue.Offer(ctx, req)
+	}
+	r := reservation.FromContext(ctx)
+	if r == nil {
+		return qs.queue.Offer(ctx, req)
+	}
+	held := &heldReservation{r: r}
+	r.Hold()
+	err := qs.queue.Offer(context.WithValue(ctx, heldReservationKey{}, held), req)
+	if err != nil {
+		// Either the request was not queued, or it was already exported when waiting for the result.
+		held.release()
+	}
+	return err
+}
+
+type heldReservationKey struct{}
+
+// heldReservation is the hold of the memory queue on the memory reserved by the receiver of a request.
+type heldReservation struct {
+	once sync.Once
+	r    *reservation.Reservation
+}
+
+func (h *heldReservation) release() {
+	h.once.Do(h.r.Release)
+}
+
+// releaseDone releases the memory reserved for a request once it is done.
+type releaseDone struct {
+	Done
+	held *heldReservation
+}
+
+func (rd releaseDone) OnDone(err error) {
+	rd.held.release()
+	rd.Done.OnDone(err)
 }
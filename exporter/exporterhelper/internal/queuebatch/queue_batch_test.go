@@ -8,6 +8,7 @@ import (
 	"errors"
 	"runtime"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -23,6 +24,7 @@ import (
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/sendertest"
 	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/storagetest"
 	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/internal/reservation"
 	"go.opentelemetry.io/collector/pipeline"
 )
 
@@ -227,6 +229,90 @@ func TestQueueBatchPersistentEnabled_NoDataLossOnShutdown(t *testing.T) {
 	require.NoError(t, qb.Shutdown(context.Background()))
 }
 
+func TestQueueBatchShutdownDeadline(t *testing.T) {
+	sink := requesttest.NewSink()
+	cfg := newTestConfig()
+	cfg.NumConsumers = 1
+	cfg.Batch = nil
+	qb, err := NewQueueBatch(newFakeRequestSettings(), cfg, sink.Export)
+	require.NoError(t, err)
+	require.NoError(t, qb.Start(context.Background(), componenttest.NewNopHost()))
+	// The first request keeps the only consumer busy until after the deadline.
+	require.NoError(t, qb.Send(context.Background(), &requesttest.FakeRequest{Items: 4, Delay: 100 * time.Millisecond}))
+	for i := 0; i < 3; i++ {
+		require.NoError(t, qb.Send(context.Background(), &requesttest.FakeRequest{Items: 2}))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	require.NoError(t, qb.Shutdown(ctx))
+	assert.Equal(t, 1, sink.RequestsCount())
+	assert.Equal(t, 4, sink.ItemsCount())
+	require.Zero(t, qb.queue.Size())
+
+	queuedRequests, droppedItems := qb.DrainReport()
+	assert.Zero(t, queuedRequests)
+	assert.Equal(t, int64(6), droppedItems)
+}
+
+func TestQueueBatchHoldsReservation(t *testing.T) {
+	sink := requesttest.NewSink()
+	cfg := newTestConfig()
+	cfg.Batch = nil
+	qb, err := NewQueueBatch(newFakeRequestSettings(), cfg, sink.Export)
+	require.NoError(t, err)
+	require.NoError(t, qb.Start(context.Background(), componenttest.NewNopHost()))
+
+	var released atomic.Bool
+	r := reservation.New(func() { released.Store(true) })
+	ctx := reservation.NewContext(context.Background(), r)
+	require.NoError(t, qb.Send(ctx, &requesttest.FakeRequest{Items: 2, Delay: 50 * time.Millisecond}))
+	// The receiver returned, the memory stays reserved until the request is exported.
+	r.Release()
+	assert.False(t, released.Load())
+	assert.Eventually(t, released.Load, time.Second, time.Millisecond)
+	assert.Equal(t, 2, sink.ItemsCount())
+	require.NoError(t, qb.Shutdown(context.Background()))
+}
+
+func TestQueueBatchPersistentDrainReport(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.NumConsumers = 1
+	cfg.Batch = nil
+	storageID := component.MustNewIDWithName("file_storage", "storage")
+	cfg.StorageID = &storageID
+
+	mockReq := &requesttest.FakeRequest{Items: 2}
+	qSet := newFakeRequestSettings()
+	qSet.Encoding = newFakeEncoding(mockReq)
+	sending := make(chan struct{}, 1)
+	done := make(chan struct{})
+	qb, err := NewQueueBatch(qSet, cfg, func(context.Context, request.Request) error {
+		sending <- struct{}{}
+		<-done
+		return experr.NewShutdownErr(errors.New("could not export data"))
+	})
+	require.NoError(t, err)
+
+	host := hosttest.NewHost(map[component.ID]component.Component{
+		storageID: storagetest.NewMockStorageExtension(nil),
+	})
+	require.NoError(t, qb.Start(context.Background(), host))
+	for i := 0; i < 3; i++ {
+		require.NoError(t, qb.Send(context.Background(), mockReq))
+	}
+	// Wait for the consumer to take the first request.
+	<-sending
+
+	close(done)
+	require.NoError(t, qb.Shutdown(context.Background()))
+
+	// The request being sent is kept in the queue, as well as the two not read.
+	queuedRequests, droppedItems := qb.DrainReport()
+	assert.Equal(t, int64(3), queuedRequests)
+	assert.Zero(t, droppedItems)
+}
+
 func TestQueueBatchNoStartShutdown(t *testing.T) {
 	qs, err := NewQueueBatch(newFakeRequestSettings(), newTestConfig(), sendertest.NewNopSenderFunc[request.Request]())
 	require.NoError(t, err)
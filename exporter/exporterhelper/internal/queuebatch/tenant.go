@@ -0,0 +1,169 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package queuebatch // import "go.opentelemetry.io/collector/exporter/exporterhelper/internal/queuebatch"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/collector/client"
+)
+
+// otherTenants is the tenant attribute value used for tenants exceeding the metrics cardinality cap.
+const otherTenants = "_other"
+
+const defaultMetricsMaxTenants = 100
+
+// errTenantQueueIsFull is returned when a tenant has used all its share of the queue.
+// It wraps ErrQueueIsFull so that callers handle both cases the same way.
+var errTenantQueueIsFull = fmt.Errorf("%w for tenant", ErrQueueIsFull)
+
+// TenantConfig defines how the queue capacity is split between tenants.
+type TenantConfig struct {
+	// MetadataKey is the client.Info metadata key identifying the tenant of a request.
+	// Requests without the key share the capacity of the empty tenant.
+	MetadataKey string `mapstructure:"metadata_key"`
+
+	// DefaultQuota is the maximum size, measured by the queue sizer, a tenant without an
+	// explicit quota can use. If 0, every tenant gets a fair share of `queue_size`
+	// divided by the number of tenants that currently have data in the queue.
+	DefaultQuota int64 `mapstructure:"default_quota"`
+
+	// Quotas overrides the maximum size for specific tenants.
+	Quotas map[string]int64 `mapstructure:"quotas"`
+
+	// MetricsMaxTenants limits the number of distinct tenants reported in the per-tenant
+	// queue size metric. Additional tenants are reported together as "_other".
+	MetricsMaxTenants int `mapstructure:"metrics_max_tenants"`
+}
+
+// Validate checks if the TenantConfig is valid.
+func (cfg *TenantConfig) Validate() error {
+	if cfg == nil {
+		return nil
+	}
+
+	if cfg.MetadataKey == "" {
+		return errors.New("`metadata_key` must not be empty")
+	}
+
+	if cfg.DefaultQuota < 0 {
+		return errors.New("`default_quota` must be non-negative")
+	}
+
+	for tenant, quota := range cfg.Quotas {
+		if quota <= 0 {
+			return fmt.Errorf("quota for tenant %q must be positive", tenant)
+		}
+	}
+
+	if cfg.MetricsMaxTenants < 0 {
+		return errors.New("`metrics_max_tenants` must be non-negative")
+	}
+
+	return nil
+}
+
+// tenantLimiter tracks the queue usage per tenant. The queue calls fits and reserve
+// under its own lock, mu only guards against the concurrent metrics callback.
+type tenantLimiter struct {
+	cfg      TenantConfig
+	capacity int64
+
+	mu    sync.Mutex
+	sizes map[string]int64
+}
+
+func newTenantLimiter(cfg TenantConfig, capacity int64) *tenantLimiter {
+	if cfg.MetricsMaxTenants == 0 {
+		cfg.MetricsMaxTenants = defaultMetricsMaxTenants
+	}
+	return &tenantLimiter{
+		cfg:      cfg,
+		capacity: capacity,
+		sizes:    make(map[string]int64),
+	}
+}
+
+// tenant returns the tenant of the request context.
+func (tl *tenantLimiter) tenant(ctx context.Context) string {
+	return strings.Join(client.FromContext(ctx).Metadata.Get(tl.cfg.MetadataKey), ",")
+}
+
+// active returns the number of tenants with data in the queue, including the given one.
+func (tl *tenantLimiter) active(tenant string) int64 {
+	active := int64(len(tl.sizes))
+	if _, ok := tl.sizes[tenant]; !ok {
+		active++
+	}
+	return active
+}
+
+// limit returns the maximum size the tenant can use.
+func (tl *tenantLimiter) limit(tenant string) int64 {
+	if quota, ok := tl.cfg.Quotas[tenant]; ok {
+		return quota
+	}
+	if tl.cfg.DefaultQuota > 0 {
+		return tl.cfg.DefaultQuota
+	}
+	return tl.capacity / tl.active(tenant)
+}
+
+// fits reports whether the tenant can add an element of the given size without exceeding its limit.
+// The queue capacity is checked by the queue itself.
+func (tl *tenantLimiter) fits(tenant string, size int64) bool {
+	tl.mu.Lock()
+	defer tl.mu.Unlock()
+	return tl.sizes[tenant]+size <= tl.limit(tenant)
+}
+
+// maxSize returns the largest element the tenant could ever add.
+func (tl *tenantLimiter) maxSize(tenant string) int64 {
+	if quota, ok := tl.cfg.Quotas[tenant]; ok {
+		return quota
+	}
+	if tl.cfg.DefaultQuota > 0 {
+		return tl.cfg.DefaultQuota
+	}
+	return tl.capacity
+}
+
+func (tl *tenantLimiter) reserve(tenant string, size int64) {
+	tl.mu.Lock()
+	defer tl.mu.Unlock()
+	tl.sizes[tenant] += size
+}
+
+func (tl *tenantLimiter) release(tenant string, size int64) {
+	tl.mu.Lock()
+	defer tl.mu.Unlock()
+	tl.sizes[tenant] -= size
+	if tl.sizes[tenant] <= 0 {
+		delete(tl.sizes, tenant)
+	}
+}
+
+// observe calls fn for every tenant with data in the queue. At most MetricsMaxTenants
+// tenants, in lexical order, are reported individually, the size of the remaining ones is aggregated.
+func (tl *tenantLimiter) observe(fn func(tenant string, size int64)) {
+	tl.mu.Lock()
+	defer tl.mu.Unlock()
+	var other int64
+	for i, tenant := range slices.Sorted(maps.Keys(tl.sizes)) {
+		if i < tl.cfg.MetricsMaxTenants {
+			fn(tenant, tl.sizes[tenant])
+			continue
+		}
+		other += tl.sizes[tenant]
+	}
+	if other > 0 {
+		fn(otherTenants, other)
+	}
+}
@@ -0,0 +1,199 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package queuebatch
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/metadatatest"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/request"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/requesttest"
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+func tenantContext(tenant string) context.Context {
+	return client.NewContext(context.Background(), client.Info{
+		Metadata: client.NewMetadata(map[string][]string{"X-Tenant": {tenant}}),
+	})
+}
+
+func TestTenantConfig_Validate(t *testing.T) {
+	var cfg *TenantConfig
+	require.NoError(t, cfg.Validate())
+
+	cfg = &TenantConfig{MetadataKey: "x-tenant", Quotas: map[string]int64{"a": 10}}
+	require.NoError(t, cfg.Validate())
+
+	cfg = &TenantConfig{}
+	require.EqualError(t, cfg.Validate(), "`metadata_key` must not be empty")
+
+	cfg = &TenantConfig{MetadataKey: "x-tenant", DefaultQuota: -1}
+	require.EqualError(t, cfg.Validate(), "`default_quota` must be non-negative")
+
+	cfg = &TenantConfig{MetadataKey: "x-tenant", Quotas: map[string]int64{"a": 0}}
+	require.EqualError(t, cfg.Validate(), "quota for tenant \"a\" must be positive")
+
+	cfg = &TenantConfig{MetadataKey: "x-tenant", MetricsMaxTenants: -1}
+	require.EqualError(t, cfg.Validate(), "`metrics_max_tenants` must be non-negative")
+}
+
+func TestMemoryQueueTenantsFairShare(t *testing.T) {
+	q := newMemoryQueue[int64](memoryQueueSettings[int64]{
+		sizer:    sizerInt64{},
+		capacity: 10,
+		tenants:  newTenantLimiter(TenantConfig{MetadataKey: "x-tenant"}, 10),
+	})
+
+	// A tenant alone in the queue can use all of it.
+	require.NoError(t, q.Offer(tenantContext("a"), 6))
+	// Tenant "b" gets half of the queue once it has data in it.
+	require.NoError(t, q.Offer(tenantContext("b"), 3))
+	require.ErrorIs(t, q.Offer(tenantContext("b"), 3), errTenantQueueIsFull)
+	// Tenant "a" is now over its share.
+	require.ErrorIs(t, q.Offer(tenantContext("a"), 1), errTenantQueueIsFull)
+	require.NoError(t, q.Offer(tenantContext("b"), 1))
+	assert.EqualValues(t, 10, q.Size())
+	// A tenant under its share is only refused because the queue is full.
+	err := q.Offer(tenantContext("c"), 1)
+	require.ErrorIs(t, err, ErrQueueIsFull)
+	require.NotErrorIs(t, err, errTenantQueueIsFull)
+
+	assert.True(t, consume(q, func(_ context.Context, el int64) error {
+		assert.EqualValues(t, 6, el)
+		return nil
+	}))
+	require.NoError(t, q.Offer(tenantContext("c"), 3))
+	// The queue has room left but tenant "c" would exceed its share.
+	require.ErrorIs(t, q.Offer(tenantContext("c"), 3), errTenantQueueIsFull)
+	require.NoError(t, q.Shutdown(context.Background()))
+}
+
+func TestMemoryQueueTenantsQuotas(t *testing.T) {
+	q := newMemoryQueue[int64](memoryQueueSettings[int64]{
+		sizer:    sizerInt64{},
+		capacity: 10,
+		tenants: newTenantLimiter(TenantConfig{
+			MetadataKey:  "x-tenant",
+			DefaultQuota: 3,
+			Quotas:       map[string]int64{"big": 6},
+		}, 10),
+	})
+
+	require.ErrorIs(t, q.Offer(tenantContext("small"), 4), errSizeTooLarge)
+	require.NoError(t, q.Offer(tenantContext("small"), 3))
+	require.ErrorIs(t, q.Offer(tenantContext("small"), 1), errTenantQueueIsFull)
+	// Requests without tenant share the default quota of the empty tenant.
+	require.NoError(t, q.Offer(context.Background(), 1))
+	require.NoError(t, q.Offer(tenantContext("big"), 4))
+	require.NoError(t, q.Offer(tenantContext("big"), 2))
+	require.ErrorIs(t, q.Offer(tenantContext("big"), 1), errTenantQueueIsFull)
+	assert.EqualValues(t, 10, q.Size())
+	require.ErrorIs(t, q.Offer(tenantContext("other"), 2), ErrQueueIsFull)
+	require.NoError(t, q.Shutdown(context.Background()))
+}
+
+func TestMemoryQueueTenantsQuotasAboveCapacity(t *testing.T) {
+	q := newMemoryQueue[int64](memoryQueueSettings[int64]{
+		sizer:    sizerInt64{},
+		capacity: 10,
+		tenants: newTenantLimiter(TenantConfig{
+			MetadataKey: "x-tenant",
+			Quotas:      map[string]int64{"a": 10, "b": 10},
+		}, 10),
+	})
+
+	// Tenant "a" alone can fill the queue up to its quota.
+	require.NoError(t, q.Offer(tenantContext("a"), 4))
+	require.NoError(t, q.Offer(tenantContext("a"), 6))
+	// Tenant "b" is under its quota, it is only refused because the queue is full.
+	err := q.Offer(tenantContext("b"), 3)
+	require.ErrorIs(t, err, ErrQueueIsFull)
+	require.NotErrorIs(t, err, errTenantQueueIsFull)
+
+	assert.True(t, consume(q, func(context.Context, int64) error { return nil }))
+	require.NoError(t, q.Offer(tenantContext("b"), 3))
+	require.NoError(t, q.Shutdown(context.Background()))
+}
+
+func TestMemoryQueueTenantsBlocking(t *testing.T) {
+	q := newMemoryQueue[int64](memoryQueueSettings[int64]{
+		sizer:           sizerInt64{},
+		capacity:        10,
+		blockOnOverflow: true,
+		tenants:         newTenantLimiter(TenantConfig{MetadataKey: "x-tenant", DefaultQuota: 3}, 10),
+	})
+
+	require.NoError(t, q.Offer(tenantContext("a"), 3))
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		// Blocked until the first element of tenant "a" is consumed.
+		assert.NoError(t, q.Offer(tenantContext("a"), 3))
+	}()
+	// Another tenant is not affected by the blocked one.
+	require.NoError(t, q.Offer(tenantContext("b"), 3))
+
+	assert.Eventually(t, func() bool {
+		return consume(q, func(context.Context, int64) error { return nil })
+	}, time.Second, 10*time.Millisecond)
+	wg.Wait()
+	assert.EqualValues(t, 6, q.Size())
+	require.NoError(t, q.Shutdown(context.Background()))
+}
+
+func TestObsQueueTenantSize(t *testing.T) {
+	tt := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+
+	tl := newTenantLimiter(TenantConfig{MetadataKey: "x-tenant", MetricsMaxTenants: 2}, 100)
+	tl.reserve("a", 1)
+	tl.reserve("b", 2)
+	tl.reserve("c", 3)
+	tl.reserve("d", 4)
+
+	_, err := newObsQueue[request.Request](Settings[request.Request]{
+		Signal:    pipeline.SignalLogs,
+		ID:        exporterID,
+		Telemetry: tt.NewTelemetrySettings(),
+	}, newFakeQueue[request.Request](nil, 10, 100), tl)
+	require.NoError(t, err)
+
+	dp := func(tenant string, value int64) metricdata.DataPoint[int64] {
+		return metricdata.DataPoint[int64]{
+			Attributes: attribute.NewSet(
+				attribute.String(exporterKey, exporterID.String()),
+				attribute.String(dataTypeKey, pipeline.SignalLogs.String()),
+				attribute.String(tenantKey, tenant)),
+			Value: value,
+		}
+	}
+	metadatatest.AssertEqualExporterQueueTenantSize(t, tt,
+		[]metricdata.DataPoint[int64]{dp("a", 1), dp("b", 2), dp(otherTenants, 7)},
+		metricdatatest.IgnoreTimestamp())
+}
+
+func TestQueueBatchTenants(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.Batch = nil
+	cfg.Tenants = &TenantConfig{MetadataKey: "x-tenant", DefaultQuota: 10}
+	sink := requesttest.NewSink()
+	qb, err := NewQueueBatch(newFakeRequestSettings(), cfg, sink.Export)
+	require.NoError(t, err)
+	require.NoError(t, qb.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, qb.Send(tenantContext("a"), &requesttest.FakeRequest{Items: 10}))
+	require.NoError(t, qb.Shutdown(context.Background()))
+	assert.Equal(t, 10, sink.ItemsCount())
+}
@@ -0,0 +1,34 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal // import "go.opentelemetry.io/collector/exporter/exporterhelper/internal"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/request"
+	"go.opentelemetry.io/collector/exporter/exporterhelper/internal/sender"
+)
+
+// releaseSender releases the data of the requests once they were exported successfully. The requests
+// failing to be exported are not released, as the caller may still use their data, e.g. to retry.
+type releaseSender struct {
+	component.StartFunc
+	component.ShutdownFunc
+	next sender.Sender[request.Request]
+}
+
+func newReleaseSender(next sender.Sender[request.Request]) sender.Sender[request.Request] {
+	return &releaseSender{next: next}
+}
+
+func (rs *releaseSender) Send(ctx context.Context, req request.Request) error {
+	err := rs.next.Send(ctx, req)
+	if err == nil {
+		if r, ok := req.(request.Releaser); ok {
+			r.Release()
+		}
+	}
+	return err
+}
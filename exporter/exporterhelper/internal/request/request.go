@@ -39,3 +39,11 @@ type ErrorHandler interface {
 	// Otherwise, it should return the original Request.
 	OnError(error) Request
 }
+
+// Releaser is an optional interface that can be implemented by Request to return the memory of its data to
+// pools once it was exported, see WithReleaseOnSuccess.
+type Releaser interface {
+	Request
+	// Release returns the memory of the data to pools. The Request must not be used afterward.
+	Release()
+}
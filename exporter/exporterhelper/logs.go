@@ -77,6 +77,10 @@ func (req *logsRequest) OnError(err error) Request {
 	return req
 }
 
+func (req *logsRequest) Release() {
+	req.ld.Release()
+}
+
 func (req *logsRequest) ItemsCount() int {
 	return req.ld.LogRecordCount()
 }
@@ -192,6 +192,31 @@ func TestLogs_WithPersistentQueue(t *testing.T) {
 	}, 500*time.Millisecond, 10*time.Millisecond)
 }
 
+func TestLogs_WithReleaseOnSuccess(t *testing.T) {
+	pushErr := errors.New("push error")
+	failing := false
+	e, err := NewLogs(context.Background(), exportertest.NewNopSettings(exportertest.NopType), &fakeLogsConfig,
+		func(context.Context, plog.Logs) error {
+			if failing {
+				return pushErr
+			}
+			return nil
+		}, WithReleaseOnSuccess(true))
+	require.NoError(t, err)
+	assert.True(t, e.Capabilities().MutatesData)
+	require.NoError(t, e.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, e.Shutdown(context.Background())) })
+
+	ld := testdata.GenerateLogs(2)
+	require.NoError(t, e.ConsumeLogs(context.Background(), ld))
+	assert.PanicsWithValue(t, "invalid access to released data", func() { ld.ResourceLogs().AppendEmpty() })
+
+	failing = true
+	ld = testdata.GenerateLogs(2)
+	require.ErrorIs(t, e.ConsumeLogs(context.Background(), ld), pushErr)
+	assert.NotPanics(t, func() { ld.ResourceLogs().AppendEmpty() })
+}
+
 func TestLogs_WithRecordMetrics(t *testing.T) {
 	tt := componenttest.NewTelemetry()
 	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
@@ -77,6 +77,10 @@ func (req *metricsRequest) OnError(err error) Request {
 	return req
 }
 
+func (req *metricsRequest) Release() {
+	req.md.Release()
+}
+
 func (req *metricsRequest) ItemsCount() int {
 	return req.md.DataPointCount()
 }
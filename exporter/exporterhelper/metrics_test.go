@@ -192,6 +192,31 @@ func TestMetrics_WithPersistentQueue(t *testing.T) {
 	}, 500*time.Millisecond, 10*time.Millisecond)
 }
 
+func TestMetrics_WithReleaseOnSuccess(t *testing.T) {
+	pushErr := errors.New("push error")
+	failing := false
+	e, err := NewMetrics(context.Background(), exportertest.NewNopSettings(exportertest.NopType), &fakeMetricsConfig,
+		func(context.Context, pmetric.Metrics) error {
+			if failing {
+				return pushErr
+			}
+			return nil
+		}, WithReleaseOnSuccess(true))
+	require.NoError(t, err)
+	assert.True(t, e.Capabilities().MutatesData)
+	require.NoError(t, e.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, e.Shutdown(context.Background())) })
+
+	md := testdata.GenerateMetrics(2)
+	require.NoError(t, e.ConsumeMetrics(context.Background(), md))
+	assert.PanicsWithValue(t, "invalid access to released data", func() { md.ResourceMetrics().AppendEmpty() })
+
+	failing = true
+	md = testdata.GenerateMetrics(2)
+	require.ErrorIs(t, e.ConsumeMetrics(context.Background(), md), pushErr)
+	assert.NotPanics(t, func() { md.ResourceMetrics().AppendEmpty() })
+}
+
 func TestMetrics_WithRecordMetrics(t *testing.T) {
 	tt := componenttest.NewTelemetry()
 	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
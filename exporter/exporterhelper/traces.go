@@ -77,6 +77,10 @@ func (req *tracesRequest) OnError(err error) Request {
 	return req
 }
 
+func (req *tracesRequest) Release() {
+	req.td.Release()
+}
+
 func (req *tracesRequest) ItemsCount() int {
 	return req.td.SpanCount()
 }
@@ -6,6 +6,8 @@ package exporterhelper
 import (
 	"context"
 	"errors"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -190,6 +192,71 @@ func TestTraces_WithPersistentQueue(t *testing.T) {
 	}, 500*time.Millisecond, 10*time.Millisecond)
 }
 
+func TestTraces_WithReleaseOnSuccess(t *testing.T) {
+	pushErr := errors.New("push error")
+	te, err := NewTraces(context.Background(), exportertest.NewNopSettings(exportertest.NopType), &fakeTracesConfig,
+		func(_ context.Context, td ptrace.Traces) error {
+			if td.SpanCount() == 1 {
+				return pushErr
+			}
+			return nil
+		}, WithCapabilities(consumer.Capabilities{MutatesData: false}), WithReleaseOnSuccess(true))
+	require.NoError(t, err)
+	// Releasing the data modifies it, even if the exporter does not.
+	assert.True(t, te.Capabilities().MutatesData)
+	require.NoError(t, te.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, te.Shutdown(context.Background())) })
+
+	td := testdata.GenerateTraces(2)
+	require.NoError(t, te.ConsumeTraces(context.Background(), td))
+	assert.PanicsWithValue(t, "invalid access to released data", func() { td.ResourceSpans().AppendEmpty() })
+
+	// The data failing to be exported is not released.
+	td = testdata.GenerateTraces(1)
+	require.ErrorIs(t, te.ConsumeTraces(context.Background(), td), pushErr)
+	assert.NotPanics(t, func() { td.ResourceSpans().AppendEmpty() })
+
+	// The data shared with other consumers is not released.
+	td = testdata.GenerateTraces(2)
+	td.MarkReadOnly()
+	require.NoError(t, te.ConsumeTraces(context.Background(), td))
+	assert.Equal(t, 2, td.SpanCount())
+}
+
+func TestTraces_WithReleaseOnSuccessAndQueue(t *testing.T) {
+	var exported atomic.Int64
+	qCfg := NewDefaultQueueConfig()
+	qCfg.NumConsumers = 4
+	te, err := NewTraces(context.Background(), exportertest.NewNopSettings(exportertest.NopType), &fakeTracesConfig,
+		func(_ context.Context, td ptrace.Traces) error {
+			span := td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0)
+			span.Attributes().PutStr("exported", "true")
+			exported.Add(int64(td.SpanCount()))
+			return nil
+		}, WithQueue(qCfg), WithReleaseOnSuccess(true))
+	require.NoError(t, err)
+	require.NoError(t, te.Start(context.Background(), componenttest.NewNopHost()))
+
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			unmarshaler := &ptrace.ProtoUnmarshaler{}
+			buf, err := (&ptrace.ProtoMarshaler{}).MarshalTraces(testdata.GenerateTraces(2))
+			assert.NoError(t, err)
+			for i := 0; i < 50; i++ {
+				td, err := unmarshaler.UnmarshalTraces(buf)
+				assert.NoError(t, err)
+				assert.NoError(t, te.ConsumeTraces(context.Background(), td))
+			}
+		}()
+	}
+	wg.Wait()
+	require.NoError(t, te.Shutdown(context.Background()))
+	assert.Equal(t, int64(4*50*2), exported.Load())
+}
+
 func TestTraces_WithRecordMetrics(t *testing.T) {
 	tt := componenttest.NewTelemetry()
 	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
@@ -25,6 +25,12 @@ type Config struct {
 	RetryConfig   configretry.BackOffConfig       `mapstructure:"retry_on_failure"`
 	ClientConfig  configgrpc.ClientConfig         `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
 
+	// ReleaseOnSuccess returns the memory of the data exported successfully to pools, for the data
+	// received afterward to reuse it. Disabled by default.
+	//
+	// Experimental: This configuration is at the early stage of development and may change without backward compatibility.
+	ReleaseOnSuccess bool `mapstructure:"release_on_success"`
+
 	// Experimental: This configuration is at the early stage of development and may change without backward compatibility
 	// until https://github.com/open-telemetry/opentelemetry-collector/issues/8122 is resolved
 	//
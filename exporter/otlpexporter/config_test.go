@@ -84,6 +84,7 @@ func TestUnmarshalConfig(t *testing.T) {
 				BalancerName:    "round_robin",
 				Auth:            &configauth.Authentication{AuthenticatorID: component.MustNewID("nop")},
 			},
+			ReleaseOnSuccess: true,
 		}, cfg)
 }
 
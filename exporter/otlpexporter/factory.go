@@ -63,6 +63,7 @@ func createTraces(
 		exporterhelper.WithQueue(oCfg.QueueConfig),
 		exporterhelper.WithBatcher(oCfg.BatcherConfig), //nolint:staticcheck // SA1019
 		exporterhelper.WithStart(oce.start),
+		exporterhelper.WithReleaseOnSuccess(oCfg.ReleaseOnSuccess),
 		exporterhelper.WithShutdown(oce.shutdown),
 	)
 }
@@ -82,6 +83,7 @@ func createMetrics(
 		exporterhelper.WithQueue(oCfg.QueueConfig),
 		exporterhelper.WithBatcher(oCfg.BatcherConfig), //nolint:staticcheck // SA1019
 		exporterhelper.WithStart(oce.start),
+		exporterhelper.WithReleaseOnSuccess(oCfg.ReleaseOnSuccess),
 		exporterhelper.WithShutdown(oce.shutdown),
 	)
 }
@@ -101,6 +103,7 @@ func createLogs(
 		exporterhelper.WithQueue(oCfg.QueueConfig),
 		exporterhelper.WithBatcher(oCfg.BatcherConfig), //nolint:staticcheck // SA1019
 		exporterhelper.WithStart(oce.start),
+		exporterhelper.WithReleaseOnSuccess(oCfg.ReleaseOnSuccess),
 		exporterhelper.WithShutdown(oce.shutdown),
 	)
 }
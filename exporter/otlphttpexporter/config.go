@@ -60,6 +60,12 @@ type Config struct {
 
 	// The encoding to export telemetry (default: "proto")
 	Encoding EncodingType `mapstructure:"encoding"`
+
+	// ReleaseOnSuccess returns the memory of the data exported successfully to pools, for the data
+	// received afterward to reuse it. Disabled by default.
+	//
+	// Experimental: This configuration is at the early stage of development and may change without backward compatibility.
+	ReleaseOnSuccess bool `mapstructure:"release_on_success"`
 }
 
 var _ component.Config = (*Config)(nil)
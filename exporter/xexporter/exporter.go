@@ -19,6 +19,16 @@ type Profiles interface {
 	xconsumer.Profiles
 }
 
+// DrainReporter is an optional interface implemented by exporters that queue data before sending it,
+// such as the exporters created with exporterhelper and a sending queue.
+// The service reports the data left by each exporter once it is shut down.
+type DrainReporter interface {
+	// DrainReport returns the number of requests left in a persistent queue, to be sent after restart,
+	// and the number of items dropped because the shutdown deadline was reached before the queue was drained.
+	// It is only meaningful once the exporter is shut down.
+	DrainReport() (queuedRequests, droppedItems int64)
+}
+
 type Factory interface {
 	exporter.Factory
 
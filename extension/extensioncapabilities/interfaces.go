@@ -21,6 +21,19 @@ type Dependent interface {
 	Dependencies() []component.ID
 }
 
+// ReadinessReporter is an optional interface that can be implemented by extensions
+// that are not ready to be used when Start returns, e.g. an authenticator fetching its keys.
+// Once ready, such an extension reports componentstatus.StatusOK through componentstatus.ReportStatus,
+// or an error status if it cannot become ready. The service waits until then, up to
+// service::startup::extensions_ready_timeout, before starting the extensions depending on it
+// and the components referencing it in their configuration.
+type ReadinessReporter interface {
+	extension.Extension
+	// ReportsReadiness returns whether the extension reports componentstatus.StatusOK once ready.
+	// When false, the extension is considered ready as soon as Start returns.
+	ReportsReadiness() bool
+}
+
 // PipelineWatcher is an extra interface for Extension hosted by the OpenTelemetry
 // Collector that is to be implemented by extensions interested in changes to pipeline
 // states. Typically this will be used by extensions that change their behavior if data is
@@ -0,0 +1,73 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package healthextension // import "go.opentelemetry.io/collector/extension/healthextension"
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+)
+
+// Config has the configuration for the health extension.
+type Config struct {
+	confighttp.ServerConfig `mapstructure:",squash"`
+
+	// StatusPath is the path serving the status of the collector, pipelines and components as JSON.
+	StatusPath string `mapstructure:"status_path"`
+
+	// LivenessPath is the path answering whether the collector is alive.
+	LivenessPath string `mapstructure:"liveness_path"`
+
+	// ReadinessPath is the path answering whether the collector is ready to accept data.
+	ReadinessPath string `mapstructure:"readiness_path"`
+
+	// ComponentHealth defines how component errors affect readiness.
+	ComponentHealth ComponentHealthConfig `mapstructure:"component_health"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// ComponentHealthConfig defines which component errors make the collector not ready.
+type ComponentHealthConfig struct {
+	// IncludePermanentErrors makes the collector not ready while a component reports a permanent error.
+	IncludePermanentErrors bool `mapstructure:"include_permanent_errors"`
+
+	// IncludeRecoverableErrors makes the collector not ready when a component reports a recoverable
+	// error for longer than RecoveryDuration.
+	IncludeRecoverableErrors bool `mapstructure:"include_recoverable_errors"`
+
+	// RecoveryDuration is how long a component can report a recoverable error before
+	// it affects readiness.
+	RecoveryDuration time.Duration `mapstructure:"recovery_duration"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks if the extension configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.Endpoint == "" {
+		return errors.New("\"endpoint\" is required when using the \"health\" extension")
+	}
+	paths := map[string]struct{}{}
+	for _, p := range []string{cfg.StatusPath, cfg.LivenessPath, cfg.ReadinessPath} {
+		if !strings.HasPrefix(p, "/") {
+			return errors.New("\"status_path\", \"liveness_path\" and \"readiness_path\" must start with \"/\"")
+		}
+		if _, ok := paths[p]; ok {
+			return errors.New("\"status_path\", \"liveness_path\" and \"readiness_path\" must be different")
+		}
+		paths[p] = struct{}{}
+	}
+	if cfg.ComponentHealth.RecoveryDuration < 0 {
+		return errors.New("\"recovery_duration\" must be non-negative")
+	}
+	return nil
+}
@@ -0,0 +1,8 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate mdatagen metadata.yaml
+
+// Package healthextension implements an extension that serves the status of the
+// collector, its pipelines and components, with liveness and readiness endpoints.
+package healthextension // import "go.opentelemetry.io/collector/extension/healthextension"
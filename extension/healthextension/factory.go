@@ -0,0 +1,48 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package healthextension // import "go.opentelemetry.io/collector/extension/healthextension"
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/extension"
+	"go.opentelemetry.io/collector/extension/healthextension/internal/metadata"
+)
+
+const (
+	defaultEndpoint         = "localhost:13133"
+	defaultStatusPath       = "/status"
+	defaultLivenessPath     = "/livez"
+	defaultReadinessPath    = "/readyz"
+	defaultRecoveryDuration = 5 * time.Minute
+)
+
+// NewFactory creates a factory for the health extension.
+func NewFactory() extension.Factory {
+	return extension.NewFactory(metadata.Type, createDefaultConfig, create, metadata.ExtensionStability)
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{
+		ServerConfig: confighttp.ServerConfig{
+			Endpoint: defaultEndpoint,
+		},
+		StatusPath:    defaultStatusPath,
+		LivenessPath:  defaultLivenessPath,
+		ReadinessPath: defaultReadinessPath,
+		ComponentHealth: ComponentHealthConfig{
+			IncludePermanentErrors:   true,
+			IncludeRecoverableErrors: true,
+			RecoveryDuration:         defaultRecoveryDuration,
+		},
+	}
+}
+
+// create creates the extension based on this config.
+func create(_ context.Context, set extension.Settings, cfg component.Config) (extension.Extension, error) {
+	return newHealthExtension(cfg.(*Config), set.TelemetrySettings), nil
+}
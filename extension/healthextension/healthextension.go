@@ -0,0 +1,117 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package healthextension // import "go.opentelemetry.io/collector/extension/healthextension"
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/extension/extensioncapabilities"
+)
+
+var (
+	_ componentstatus.Watcher                = (*healthExtension)(nil)
+	_ extensioncapabilities.PipelineWatcher = (*healthExtension)(nil)
+)
+
+type healthExtension struct {
+	config     *Config
+	telemetry  component.TelemetrySettings
+	aggregator *aggregator
+	server     *http.Server
+	stopCh     chan struct{}
+}
+
+func newHealthExtension(config *Config, telemetry component.TelemetrySettings) *healthExtension {
+	return &healthExtension{
+		config:     config,
+		telemetry:  telemetry,
+		aggregator: newAggregator(config.ComponentHealth),
+	}
+}
+
+func (he *healthExtension) Start(ctx context.Context, host component.Host) error {
+	mux := http.NewServeMux()
+	mux.HandleFunc(he.config.StatusPath, he.handleStatus)
+	mux.HandleFunc(he.config.LivenessPath, he.handleProbe(he.aggregator.live))
+	mux.HandleFunc(he.config.ReadinessPath, he.handleProbe(he.aggregator.ready))
+
+	// Start the listener here so we can have earlier failure if port is
+	// already in use.
+	ln, err := he.config.ToListener(ctx)
+	if err != nil {
+		return err
+	}
+
+	he.telemetry.Logger.Info("Starting health extension", zap.String("endpoint", ln.Addr().String()))
+	he.server, err = he.config.ToServer(ctx, host, he.telemetry, mux)
+	if err != nil {
+		return errors.Join(err, ln.Close())
+	}
+	he.stopCh = make(chan struct{})
+	go func() {
+		defer close(he.stopCh)
+
+		if errHTTP := he.server.Serve(ln); errHTTP != nil && !errors.Is(errHTTP, http.ErrServerClosed) {
+			componentstatus.ReportStatus(host, componentstatus.NewFatalErrorEvent(errHTTP))
+		}
+	}()
+
+	return nil
+}
+
+func (he *healthExtension) Shutdown(context.Context) error {
+	if he.server == nil {
+		return nil
+	}
+	err := he.server.Close()
+	if he.stopCh != nil {
+		<-he.stopCh
+	}
+	return err
+}
+
+// ComponentStatusChanged implements componentstatus.Watcher.
+func (he *healthExtension) ComponentStatusChanged(source *componentstatus.InstanceID, event *componentstatus.Event) {
+	he.aggregator.record(source, event)
+}
+
+// Ready implements extensioncapabilities.PipelineWatcher.
+func (he *healthExtension) Ready() error {
+	he.aggregator.setPipelinesReady(true)
+	return nil
+}
+
+// NotReady implements extensioncapabilities.PipelineWatcher.
+func (he *healthExtension) NotReady() error {
+	he.aggregator.setPipelinesReady(false)
+	return nil
+}
+
+func (he *healthExtension) handleStatus(w http.ResponseWriter, _ *http.Request) {
+	details := he.aggregator.details()
+	w.Header().Set("Content-Type", "application/json")
+	if !details.Ready {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	if err := json.NewEncoder(w).Encode(details); err != nil {
+		he.telemetry.Logger.Debug("Failed to write the status", zap.Error(err))
+	}
+}
+
+func (he *healthExtension) handleProbe(check func() bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		if !check() {
+			http.Error(w, "not ok", http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte("ok\n"))
+	}
+}
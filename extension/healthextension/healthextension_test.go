@@ -0,0 +1,73 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package healthextension
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/internal/testutil"
+)
+
+func TestHealthExtensionEndpoints(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.ServerConfig = confighttp.ServerConfig{Endpoint: testutil.GetAvailableLocalAddress(t)}
+	he := newHealthExtension(cfg, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, he.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, he.Shutdown(context.Background())) })
+
+	get := func(path string) *http.Response {
+		resp, err := http.Get("http://" + cfg.Endpoint + path)
+		require.NoError(t, err)
+		t.Cleanup(func() { require.NoError(t, resp.Body.Close()) })
+		return resp
+	}
+
+	he.ComponentStatusChanged(receiverID, componentstatus.NewEvent(componentstatus.StatusStarting))
+	assert.Equal(t, http.StatusOK, get("/livez").StatusCode)
+	assert.Equal(t, http.StatusServiceUnavailable, get("/readyz").StatusCode)
+
+	he.ComponentStatusChanged(receiverID, componentstatus.NewEvent(componentstatus.StatusOK))
+	require.NoError(t, he.Ready())
+	assert.Equal(t, http.StatusOK, get("/readyz").StatusCode)
+
+	resp := get("/status")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+	var details collectorDetails
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&details))
+	assert.Equal(t, "StatusOK", details.Status)
+	assert.True(t, details.Ready)
+	assert.Contains(t, details.Pipelines["traces"].Components, "receiver:otlp")
+
+	he.ComponentStatusChanged(exporterID, componentstatus.NewFatalErrorEvent(errors.New("port in use")))
+	assert.Equal(t, http.StatusServiceUnavailable, get("/livez").StatusCode)
+	assert.Equal(t, http.StatusServiceUnavailable, get("/status").StatusCode)
+
+	require.NoError(t, he.NotReady())
+	assert.Equal(t, http.StatusServiceUnavailable, get("/readyz").StatusCode)
+}
+
+func TestHealthExtensionPortInUse(t *testing.T) {
+	endpoint := testutil.GetAvailableLocalAddress(t)
+	cfg := createDefaultConfig().(*Config)
+	cfg.ServerConfig = confighttp.ServerConfig{Endpoint: endpoint}
+
+	first := newHealthExtension(cfg, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, first.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, first.Shutdown(context.Background())) })
+
+	second := newHealthExtension(cfg, componenttest.NewNopTelemetrySettings())
+	require.Error(t, second.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, second.Shutdown(context.Background()))
+}
@@ -0,0 +1,16 @@
+// Code generated by mdatagen. DO NOT EDIT.
+
+package metadata
+
+import (
+	"go.opentelemetry.io/collector/component"
+)
+
+var (
+	Type      = component.MustNewType("health")
+	ScopeName = "go.opentelemetry.io/collector/extension/healthextension"
+)
+
+const (
+	ExtensionStability = component.StabilityLevelDevelopment
+)
@@ -0,0 +1,210 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package healthextension // import "go.opentelemetry.io/collector/extension/healthextension"
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+// statusPriority lists the statuses from the most to the least important one.
+// An aggregated status is the most important status of its parts.
+var statusPriority = []componentstatus.Status{
+	componentstatus.StatusFatalError,
+	componentstatus.StatusPermanentError,
+	componentstatus.StatusRecoverableError,
+	componentstatus.StatusStarting,
+	componentstatus.StatusStopping,
+	componentstatus.StatusStopped,
+	componentstatus.StatusOK,
+	componentstatus.StatusNone,
+}
+
+// statusDetails is the JSON representation of the status of a component, or of an aggregate.
+type statusDetails struct {
+	Status    string    `json:"status"`
+	Healthy   bool      `json:"healthy"`
+	Error     string    `json:"error,omitempty"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+type pipelineDetails struct {
+	statusDetails
+	Components map[string]*statusDetails `json:"components"`
+}
+
+type collectorDetails struct {
+	statusDetails
+	Live       bool                        `json:"live"`
+	Ready      bool                        `json:"ready"`
+	Pipelines  map[string]*pipelineDetails `json:"pipelines"`
+	Extensions map[string]*statusDetails   `json:"extensions"`
+}
+
+// aggregator keeps the latest status event of every component instance.
+type aggregator struct {
+	cfg ComponentHealthConfig
+	now func() time.Time
+
+	mu             sync.RWMutex
+	events         map[componentstatus.InstanceID]*componentstatus.Event
+	pipelinesReady bool
+}
+
+func newAggregator(cfg ComponentHealthConfig) *aggregator {
+	return &aggregator{
+		cfg:    cfg,
+		now:    time.Now,
+		events: make(map[componentstatus.InstanceID]*componentstatus.Event),
+	}
+}
+
+func (a *aggregator) record(source *componentstatus.InstanceID, event *componentstatus.Event) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.events[*source] = event
+}
+
+func (a *aggregator) setPipelinesReady(ready bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.pipelinesReady = ready
+}
+
+// healthy reports whether the event should be considered healthy according to the configuration.
+func (a *aggregator) healthy(ev *componentstatus.Event) bool {
+	switch ev.Status() {
+	case componentstatus.StatusFatalError:
+		return false
+	case componentstatus.StatusPermanentError:
+		return !a.cfg.IncludePermanentErrors
+	case componentstatus.StatusRecoverableError:
+		return !a.cfg.IncludeRecoverableErrors || a.now().Sub(ev.Timestamp()) < a.cfg.RecoveryDuration
+	default:
+		return true
+	}
+}
+
+// live reports whether no component reported a fatal error.
+func (a *aggregator) live() bool {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	for _, ev := range a.events {
+		if ev.Status() == componentstatus.StatusFatalError {
+			return false
+		}
+	}
+	return true
+}
+
+// ready reports whether the pipelines are running and every component is healthy.
+func (a *aggregator) ready() bool {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.readyLocked()
+}
+
+func (a *aggregator) readyLocked() bool {
+	if !a.pipelinesReady {
+		return false
+	}
+	for _, ev := range a.events {
+		switch ev.Status() {
+		case componentstatus.StatusStarting, componentstatus.StatusStopping, componentstatus.StatusStopped:
+			return false
+		}
+		if !a.healthy(ev) {
+			return false
+		}
+	}
+	return true
+}
+
+// details returns the status of the collector, every pipeline and every component.
+func (a *aggregator) details() *collectorDetails {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	cd := &collectorDetails{
+		Live:       true,
+		Ready:      a.readyLocked(),
+		Pipelines:  make(map[string]*pipelineDetails),
+		Extensions: make(map[string]*statusDetails),
+	}
+	pipelineEvents := make(map[string][]*componentstatus.Event)
+	var all []*componentstatus.Event
+	for id, ev := range a.events {
+		all = append(all, ev)
+		if ev.Status() == componentstatus.StatusFatalError {
+			cd.Live = false
+		}
+		sd := a.componentDetails(ev)
+		if id.Kind() == component.KindExtension {
+			cd.Extensions[id.ComponentID().String()] = sd
+			continue
+		}
+		id.AllPipelineIDs(func(pipelineID pipeline.ID) bool {
+			name := pipelineID.String()
+			pd, ok := cd.Pipelines[name]
+			if !ok {
+				pd = &pipelineDetails{Components: make(map[string]*statusDetails)}
+				cd.Pipelines[name] = pd
+			}
+			pd.Components[strings.ToLower(id.Kind().String())+":"+id.ComponentID().String()] = sd
+			pipelineEvents[name] = append(pipelineEvents[name], ev)
+			return true
+		})
+	}
+	for name, pd := range cd.Pipelines {
+		pd.statusDetails = a.aggregate(pipelineEvents[name])
+	}
+	cd.statusDetails = a.aggregate(all)
+	return cd
+}
+
+func (a *aggregator) componentDetails(ev *componentstatus.Event) *statusDetails {
+	sd := &statusDetails{
+		Status:    ev.Status().String(),
+		Healthy:   a.healthy(ev),
+		Timestamp: ev.Timestamp(),
+	}
+	if ev.Err() != nil {
+		sd.Error = ev.Err().Error()
+	}
+	return sd
+}
+
+// aggregate returns the most important status of the events, healthy only if all of them are.
+// The timestamp is the latest one of the events with the aggregated status.
+func (a *aggregator) aggregate(events []*componentstatus.Event) statusDetails {
+	sd := statusDetails{Healthy: true}
+	current := len(statusPriority) - 1
+	for _, ev := range events {
+		sd.Healthy = sd.Healthy && a.healthy(ev)
+		priority := statusIndex(ev.Status())
+		switch {
+		case priority < current:
+			current = priority
+			sd.Timestamp = ev.Timestamp()
+		case priority == current && ev.Timestamp().After(sd.Timestamp):
+			sd.Timestamp = ev.Timestamp()
+		}
+	}
+	sd.Status = statusPriority[current].String()
+	return sd
+}
+
+func statusIndex(status componentstatus.Status) int {
+	for i, s := range statusPriority {
+		if s == status {
+			return i
+		}
+	}
+	return len(statusPriority) - 1
+}
@@ -0,0 +1,107 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package healthextension
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+var (
+	tracesID    = pipeline.NewID(pipeline.SignalTraces)
+	metricsID   = pipeline.NewID(pipeline.SignalMetrics)
+	receiverID  = componentstatus.NewInstanceID(component.MustNewID("otlp"), component.KindReceiver, tracesID, metricsID)
+	exporterID  = componentstatus.NewInstanceID(component.MustNewID("otlp"), component.KindExporter, tracesID)
+	extensionID = componentstatus.NewInstanceID(component.MustNewID("zpages"), component.KindExtension)
+)
+
+func newTestAggregator(cfg ComponentHealthConfig) *aggregator {
+	a := newAggregator(cfg)
+	a.record(receiverID, componentstatus.NewEvent(componentstatus.StatusOK))
+	a.record(exporterID, componentstatus.NewEvent(componentstatus.StatusOK))
+	a.record(extensionID, componentstatus.NewEvent(componentstatus.StatusOK))
+	return a
+}
+
+func TestAggregatorReadiness(t *testing.T) {
+	a := newTestAggregator(createDefaultConfig().(*Config).ComponentHealth)
+	assert.True(t, a.live())
+	assert.False(t, a.ready(), "not ready until pipelines are ready")
+
+	a.setPipelinesReady(true)
+	assert.True(t, a.ready())
+
+	a.record(exporterID, componentstatus.NewEvent(componentstatus.StatusStopping))
+	assert.False(t, a.ready())
+	assert.True(t, a.live())
+
+	a.record(exporterID, componentstatus.NewPermanentErrorEvent(errors.New("bad config")))
+	assert.False(t, a.ready())
+	assert.True(t, a.live())
+
+	a.record(exporterID, componentstatus.NewFatalErrorEvent(errors.New("port in use")))
+	assert.False(t, a.ready())
+	assert.False(t, a.live())
+}
+
+func TestAggregatorRecoverableErrors(t *testing.T) {
+	a := newTestAggregator(ComponentHealthConfig{IncludeRecoverableErrors: true, RecoveryDuration: time.Minute})
+	a.setPipelinesReady(true)
+
+	now := time.Now()
+	a.now = func() time.Time { return now }
+	a.record(exporterID, componentstatus.NewRecoverableErrorEvent(errors.New("unavailable")))
+	assert.True(t, a.ready(), "recoverable errors are tolerated during recovery_duration")
+
+	now = now.Add(2 * time.Minute)
+	assert.False(t, a.ready())
+
+	a.cfg.IncludeRecoverableErrors = false
+	assert.True(t, a.ready())
+
+	a.record(exporterID, componentstatus.NewPermanentErrorEvent(errors.New("bad config")))
+	assert.True(t, a.ready(), "permanent errors are ignored when not included")
+}
+
+func TestAggregatorDetails(t *testing.T) {
+	a := newTestAggregator(createDefaultConfig().(*Config).ComponentHealth)
+	a.setPipelinesReady(true)
+	a.record(exporterID, componentstatus.NewPermanentErrorEvent(errors.New("bad config")))
+
+	details := a.details()
+	assert.True(t, details.Live)
+	assert.False(t, details.Ready)
+	assert.False(t, details.Healthy)
+	assert.Equal(t, "StatusPermanentError", details.Status)
+
+	require.Len(t, details.Pipelines, 2)
+	traces := details.Pipelines["traces"]
+	assert.Equal(t, "StatusPermanentError", traces.Status)
+	assert.False(t, traces.Healthy)
+	require.Len(t, traces.Components, 2)
+	assert.Equal(t, "bad config", traces.Components["exporter:otlp"].Error)
+	assert.True(t, traces.Components["receiver:otlp"].Healthy)
+
+	metrics := details.Pipelines["metrics"]
+	assert.Equal(t, "StatusOK", metrics.Status)
+	assert.True(t, metrics.Healthy)
+	require.Len(t, metrics.Components, 1)
+
+	require.Len(t, details.Extensions, 1)
+	assert.Equal(t, "StatusOK", details.Extensions["zpages"].Status)
+}
+
+func TestAggregateEmpty(t *testing.T) {
+	sd := newAggregator(ComponentHealthConfig{}).aggregate(nil)
+	assert.Equal(t, "StatusNone", sd.Status)
+	assert.True(t, sd.Healthy)
+}
@@ -16,8 +16,9 @@ import (
 
 // NewLogs wraps multiple log consumers in a single one.
 // It fans out the incoming data to all the consumers, and does smart routing:
-//   - Clones only to the consumer that needs to mutate the data.
-//   - If all consumers needs to mutate the data one will get the original mutable data.
+//   - Shares the data with the consumers that need to mutate it, see plog.Logs.Share:
+//     only the ResourceLogs they modify are copied.
+//   - If the only consumer needs to mutate the data it will get the original mutable data.
 func NewLogs(lcs []consumer.Logs) consumer.Logs {
 	// Don't wrap if there is only one non-mutating consumer.
 	if len(lcs) == 1 && !lcs[0].Capabilities().MutatesData {
@@ -41,28 +42,24 @@ type logsConsumer struct {
 }
 
 func (lsc *logsConsumer) Capabilities() consumer.Capabilities {
-	// If all consumers are mutating, then the original data will be passed to one of them.
-	return consumer.Capabilities{MutatesData: len(lsc.mutable) > 0 && len(lsc.readonly) == 0}
+	// If the only consumer is mutating, then the original data will be passed to it.
+	return consumer.Capabilities{MutatesData: len(lsc.mutable) == 1 && len(lsc.readonly) == 0}
 }
 
 // ConsumeLogs exports the plog.Logs to all consumers wrapped by the current one.
 func (lsc *logsConsumer) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
 	var errs error
 
-	if len(lsc.mutable) > 0 {
-		// Clone the data before sending to all mutating consumers except the last one.
-		for i := 0; i < len(lsc.mutable)-1; i++ {
-			errs = multierr.Append(errs, lsc.mutable[i].ConsumeLogs(ctx, cloneLogs(ld)))
-		}
-		// Send data as is to the last mutating consumer only if there are no other non-mutating consumers and the
-		// data is mutable. Never share the same data between a mutating and a non-mutating consumer since the
-		// non-mutating consumer may process data async and the mutating consumer may change the data before that.
-		lastConsumer := lsc.mutable[len(lsc.mutable)-1]
-		if len(lsc.readonly) == 0 && !ld.IsReadOnly() {
-			errs = multierr.Append(errs, lastConsumer.ConsumeLogs(ctx, ld))
-		} else {
-			errs = multierr.Append(errs, lastConsumer.ConsumeLogs(ctx, cloneLogs(ld)))
-		}
+	// Send data as is to the mutating consumer only if there are no other consumers and the data is mutable.
+	if len(lsc.mutable) == 1 && len(lsc.readonly) == 0 && !ld.IsReadOnly() {
+		return lsc.mutable[0].ConsumeLogs(ctx, ld)
+	}
+
+	// Otherwise the mutating consumers get copy-on-write Logs sharing the ResourceLogs of the data, which is
+	// marked as read-only: the ResourceLogs are copied by each consumer before being modified, so that the
+	// non-mutating consumers, which may process the data async, never see the modifications.
+	for _, lc := range lsc.mutable {
+		errs = multierr.Append(errs, lc.ConsumeLogs(ctx, ld.Share()))
 	}
 
 	// Mark the data as read-only if it will be sent to more than one read-only consumer.
@@ -75,9 +72,3 @@ func (lsc *logsConsumer) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
 
 	return errs
 }
-
-func cloneLogs(ld plog.Logs) plog.Logs {
-	clonedLogs := plog.NewLogs()
-	ld.CopyTo(clonedLogs)
-	return clonedLogs
-}
@@ -13,6 +13,7 @@ import (
 
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/testdata"
 )
 
@@ -70,35 +71,28 @@ func TestLogsMultiplexingMutating(t *testing.T) {
 	p3 := &mutatingLogsSink{LogsSink: new(consumertest.LogsSink)}
 
 	lfc := NewLogs([]consumer.Logs{p1, p2, p3})
-	assert.True(t, lfc.Capabilities().MutatesData)
+	assert.False(t, lfc.Capabilities().MutatesData)
 	ld := testdata.GenerateLogs(1)
 
 	for i := 0; i < 2; i++ {
-		err := lfc.ConsumeLogs(context.Background(), ld)
-		if err != nil {
-			t.Errorf("Wanted nil got error")
-			return
-		}
+		require.NoError(t, lfc.ConsumeLogs(context.Background(), ld))
 	}
 
-	assert.NotSame(t, &ld, &p1.AllLogs()[0])
-	assert.NotSame(t, &ld, &p1.AllLogs()[1])
-	assert.Equal(t, ld, p1.AllLogs()[0])
-	assert.Equal(t, ld, p1.AllLogs()[1])
-
-	assert.NotSame(t, &ld, &p2.AllLogs()[0])
-	assert.NotSame(t, &ld, &p2.AllLogs()[1])
-	assert.Equal(t, ld, p2.AllLogs()[0])
-	assert.Equal(t, ld, p2.AllLogs()[1])
+	// All consumers should receive mutable data sharing the initial data.
+	for _, p := range []*mutatingLogsSink{p1, p2, p3} {
+		for _, received := range p.AllLogs() {
+			assert.False(t, received.IsReadOnly())
+			assertLogsContentEqual(t, ld, received)
+		}
+	}
 
-	// For this consumer, will receive the initial data.
-	assert.Equal(t, ld, p3.AllLogs()[0])
-	assert.Equal(t, ld, p3.AllLogs()[1])
-	assert.Equal(t, ld, p3.AllLogs()[0])
-	assert.Equal(t, ld, p3.AllLogs()[1])
+	// The modifications of a consumer are not seen by the other consumers.
+	p1.AllLogs()[0].ResourceLogs().At(0).Resource().Attributes().PutStr("mutated", "true")
+	assertLogsContentEqual(t, testdata.GenerateLogs(1), ld)
+	assertLogsContentEqual(t, testdata.GenerateLogs(1), p2.AllLogs()[0])
 
-	// The data should not be marked as read only.
-	assert.False(t, ld.IsReadOnly())
+	// The data should be marked as read only, as it is shared with the consumers.
+	assert.True(t, ld.IsReadOnly())
 }
 
 func TestReadOnlyLogsMultiplexingMutating(t *testing.T) {
@@ -107,35 +101,21 @@ func TestReadOnlyLogsMultiplexingMutating(t *testing.T) {
 	p3 := &mutatingLogsSink{LogsSink: new(consumertest.LogsSink)}
 
 	lfc := NewLogs([]consumer.Logs{p1, p2, p3})
-	assert.True(t, lfc.Capabilities().MutatesData)
-	ldOrig := testdata.GenerateLogs(1)
+	assert.False(t, lfc.Capabilities().MutatesData)
 	ld := testdata.GenerateLogs(1)
 	ld.MarkReadOnly()
 
 	for i := 0; i < 2; i++ {
-		err := lfc.ConsumeLogs(context.Background(), ld)
-		if err != nil {
-			t.Errorf("Wanted nil got error")
-			return
-		}
+		require.NoError(t, lfc.ConsumeLogs(context.Background(), ld))
 	}
 
-	// All consumers should receive the cloned data.
-
-	assert.NotEqual(t, ld, p1.AllLogs()[0])
-	assert.NotEqual(t, ld, p1.AllLogs()[1])
-	assert.Equal(t, ldOrig, p1.AllLogs()[0])
-	assert.Equal(t, ldOrig, p1.AllLogs()[1])
-
-	assert.NotEqual(t, ld, p2.AllLogs()[0])
-	assert.NotEqual(t, ld, p2.AllLogs()[1])
-	assert.Equal(t, ldOrig, p2.AllLogs()[0])
-	assert.Equal(t, ldOrig, p2.AllLogs()[1])
-
-	assert.NotEqual(t, ld, p3.AllLogs()[0])
-	assert.NotEqual(t, ld, p3.AllLogs()[1])
-	assert.Equal(t, ldOrig, p3.AllLogs()[0])
-	assert.Equal(t, ldOrig, p3.AllLogs()[1])
+	// All consumers should receive mutable data sharing the initial data.
+	for _, p := range []*mutatingLogsSink{p1, p2, p3} {
+		for _, received := range p.AllLogs() {
+			assert.False(t, received.IsReadOnly())
+			assertLogsContentEqual(t, testdata.GenerateLogs(1), received)
+		}
+	}
 }
 
 func TestLogsMultiplexingMixLastMutating(t *testing.T) {
@@ -148,32 +128,27 @@ func TestLogsMultiplexingMixLastMutating(t *testing.T) {
 	ld := testdata.GenerateLogs(1)
 
 	for i := 0; i < 2; i++ {
-		err := lfc.ConsumeLogs(context.Background(), ld)
-		if err != nil {
-			t.Errorf("Wanted nil got error")
-			return
-		}
+		require.NoError(t, lfc.ConsumeLogs(context.Background(), ld))
 	}
 
-	assert.NotSame(t, &ld, &p1.AllLogs()[0])
-	assert.NotSame(t, &ld, &p1.AllLogs()[1])
-	assert.Equal(t, ld, p1.AllLogs()[0])
-	assert.Equal(t, ld, p1.AllLogs()[1])
+	// The mutating consumers should receive mutable data sharing the initial data.
+	for _, p := range []*mutatingLogsSink{p1, p3} {
+		for _, received := range p.AllLogs() {
+			assert.False(t, received.IsReadOnly())
+			assertLogsContentEqual(t, ld, received)
+		}
+	}
 
 	// For this consumer, will receive the initial data.
 	assert.Equal(t, ld, p2.AllLogs()[0])
 	assert.Equal(t, ld, p2.AllLogs()[1])
-	assert.Equal(t, ld, p2.AllLogs()[0])
-	assert.Equal(t, ld, p2.AllLogs()[1])
 
-	// For this consumer, will clone the initial data.
-	assert.NotSame(t, &ld, &p3.AllLogs()[0])
-	assert.NotSame(t, &ld, &p3.AllLogs()[1])
-	assert.Equal(t, ld, p3.AllLogs()[0])
-	assert.Equal(t, ld, p3.AllLogs()[1])
+	// The modifications of the mutating consumers are not seen by the non-mutating one.
+	p3.AllLogs()[0].ResourceLogs().At(0).Resource().Attributes().PutStr("mutated", "true")
+	assertLogsContentEqual(t, testdata.GenerateLogs(1), p2.AllLogs()[0])
 
-	// The data should not be marked as read only.
-	assert.False(t, ld.IsReadOnly())
+	// The data should be marked as read only, as it is shared with the consumers.
+	assert.True(t, ld.IsReadOnly())
 }
 
 func TestLogsMultiplexingMixLastNonMutating(t *testing.T) {
@@ -186,31 +161,23 @@ func TestLogsMultiplexingMixLastNonMutating(t *testing.T) {
 	ld := testdata.GenerateLogs(1)
 
 	for i := 0; i < 2; i++ {
-		err := lfc.ConsumeLogs(context.Background(), ld)
-		if err != nil {
-			t.Errorf("Wanted nil got error")
-			return
-		}
+		require.NoError(t, lfc.ConsumeLogs(context.Background(), ld))
 	}
 
-	assert.NotSame(t, &ld, &p1.AllLogs()[0])
-	assert.NotSame(t, &ld, &p1.AllLogs()[1])
-	assert.Equal(t, ld, p1.AllLogs()[0])
-	assert.Equal(t, ld, p1.AllLogs()[1])
-
-	assert.NotSame(t, &ld, &p2.AllLogs()[0])
-	assert.NotSame(t, &ld, &p2.AllLogs()[1])
-	assert.Equal(t, ld, p2.AllLogs()[0])
-	assert.Equal(t, ld, p2.AllLogs()[1])
+	// The mutating consumers should receive mutable data sharing the initial data.
+	for _, p := range []*mutatingLogsSink{p1, p2} {
+		for _, received := range p.AllLogs() {
+			assert.False(t, received.IsReadOnly())
+			assertLogsContentEqual(t, ld, received)
+		}
+	}
 
 	// For this consumer, will receive the initial data.
 	assert.Equal(t, ld, p3.AllLogs()[0])
 	assert.Equal(t, ld, p3.AllLogs()[1])
-	assert.Equal(t, ld, p3.AllLogs()[0])
-	assert.Equal(t, ld, p3.AllLogs()[1])
 
-	// The data should not be marked as read only.
-	assert.False(t, ld.IsReadOnly())
+	// The data should be marked as read only, as it is shared with the consumers.
+	assert.True(t, ld.IsReadOnly())
 }
 
 func TestLogsWhenErrors(t *testing.T) {
@@ -246,3 +213,13 @@ type mutatingErr struct {
 func (mts mutatingErr) Capabilities() consumer.Capabilities {
 	return consumer.Capabilities{MutatesData: true}
 }
+
+// assertLogsContentEqual asserts that the data of the Logs are equal, regardless of their state.
+func assertLogsContentEqual(t *testing.T, expected, actual plog.Logs) {
+	marshaler := &plog.ProtoMarshaler{}
+	expectedBuf, err := marshaler.MarshalLogs(expected)
+	require.NoError(t, err)
+	actualBuf, err := marshaler.MarshalLogs(actual)
+	require.NoError(t, err)
+	assert.Equal(t, expectedBuf, actualBuf)
+}
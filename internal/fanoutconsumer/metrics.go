@@ -14,8 +14,9 @@ import (
 
 // NewMetrics wraps multiple metrics consumers in a single one.
 // It fans out the incoming data to all the consumers, and does smart routing:
-//   - Clones only to the consumer that needs to mutate the data.
-//   - If all consumers needs to mutate the data one will get the original mutable data.
+//   - Shares the data with the consumers that need to mutate it, see pmetric.Metrics.Share:
+//     only the ResourceMetrics they modify are copied.
+//   - If the only consumer needs to mutate the data it will get the original mutable data.
 func NewMetrics(mcs []consumer.Metrics) consumer.Metrics {
 	// Don't wrap if there is only one non-mutating consumer.
 	if len(mcs) == 1 && !mcs[0].Capabilities().MutatesData {
@@ -39,28 +40,24 @@ type metricsConsumer struct {
 }
 
 func (msc *metricsConsumer) Capabilities() consumer.Capabilities {
-	// If all consumers are mutating, then the original data will be passed to one of them.
-	return consumer.Capabilities{MutatesData: len(msc.mutable) > 0 && len(msc.readonly) == 0}
+	// If the only consumer is mutating, then the original data will be passed to it.
+	return consumer.Capabilities{MutatesData: len(msc.mutable) == 1 && len(msc.readonly) == 0}
 }
 
 // ConsumeMetrics exports the pmetric.Metrics to all consumers wrapped by the current one.
 func (msc *metricsConsumer) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
 	var errs error
 
-	if len(msc.mutable) > 0 {
-		// Clone the data before sending to all mutating consumers except the last one.
-		for i := 0; i < len(msc.mutable)-1; i++ {
-			errs = multierr.Append(errs, msc.mutable[i].ConsumeMetrics(ctx, cloneMetrics(md)))
-		}
-		// Send data as is to the last mutating consumer only if there are no other non-mutating consumers and the
-		// data is mutable. Never share the same data between a mutating and a non-mutating consumer since the
-		// non-mutating consumer may process data async and the mutating consumer may change the data before that.
-		lastConsumer := msc.mutable[len(msc.mutable)-1]
-		if len(msc.readonly) == 0 && !md.IsReadOnly() {
-			errs = multierr.Append(errs, lastConsumer.ConsumeMetrics(ctx, md))
-		} else {
-			errs = multierr.Append(errs, lastConsumer.ConsumeMetrics(ctx, cloneMetrics(md)))
-		}
+	// Send data as is to the mutating consumer only if there are no other consumers and the data is mutable.
+	if len(msc.mutable) == 1 && len(msc.readonly) == 0 && !md.IsReadOnly() {
+		return msc.mutable[0].ConsumeMetrics(ctx, md)
+	}
+
+	// Otherwise the mutating consumers get copy-on-write Metrics sharing the ResourceMetrics of the data, which is
+	// marked as read-only: the ResourceMetrics are copied by each consumer before being modified, so that the
+	// non-mutating consumers, which may process the data async, never see the modifications.
+	for _, mc := range msc.mutable {
+		errs = multierr.Append(errs, mc.ConsumeMetrics(ctx, md.Share()))
 	}
 
 	// Mark the data as read-only if it will be sent to more than one read-only consumer.
@@ -73,9 +70,3 @@ func (msc *metricsConsumer) ConsumeMetrics(ctx context.Context, md pmetric.Metri
 
 	return errs
 }
-
-func cloneMetrics(md pmetric.Metrics) pmetric.Metrics {
-	clonedMetrics := pmetric.NewMetrics()
-	md.CopyTo(clonedMetrics)
-	return clonedMetrics
-}
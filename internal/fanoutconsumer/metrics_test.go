@@ -13,6 +13,7 @@ import (
 
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/testdata"
 )
 
@@ -70,35 +71,28 @@ func TestMetricsMultiplexingMutating(t *testing.T) {
 	p3 := &mutatingMetricsSink{MetricsSink: new(consumertest.MetricsSink)}
 
 	mfc := NewMetrics([]consumer.Metrics{p1, p2, p3})
-	assert.True(t, mfc.Capabilities().MutatesData)
+	assert.False(t, mfc.Capabilities().MutatesData)
 	md := testdata.GenerateMetrics(1)
 
 	for i := 0; i < 2; i++ {
-		err := mfc.ConsumeMetrics(context.Background(), md)
-		if err != nil {
-			t.Errorf("Wanted nil got error")
-			return
-		}
+		require.NoError(t, mfc.ConsumeMetrics(context.Background(), md))
 	}
 
-	assert.NotSame(t, &md, &p1.AllMetrics()[0])
-	assert.NotSame(t, &md, &p1.AllMetrics()[1])
-	assert.Equal(t, md, p1.AllMetrics()[0])
-	assert.Equal(t, md, p1.AllMetrics()[1])
+	// All consumers should receive mutable data sharing the initial data.
+	for _, p := range []*mutatingMetricsSink{p1, p2, p3} {
+		for _, received := range p.AllMetrics() {
+			assert.False(t, received.IsReadOnly())
+			assertMetricsContentEqual(t, md, received)
+		}
+	}
 
-	assert.NotSame(t, &md, &p2.AllMetrics()[0])
-	assert.NotSame(t, &md, &p2.AllMetrics()[1])
-	assert.Equal(t, md, p2.AllMetrics()[0])
-	assert.Equal(t, md, p2.AllMetrics()[1])
+	// The modifications of a consumer are not seen by the other consumers.
+	p1.AllMetrics()[0].ResourceMetrics().At(0).Resource().Attributes().PutStr("mutated", "true")
+	assertMetricsContentEqual(t, testdata.GenerateMetrics(1), md)
+	assertMetricsContentEqual(t, testdata.GenerateMetrics(1), p2.AllMetrics()[0])
 
-	// For this consumer, will receive the initial data.
-	assert.Equal(t, md, p3.AllMetrics()[0])
-	assert.Equal(t, md, p3.AllMetrics()[1])
-	assert.Equal(t, md, p3.AllMetrics()[0])
-	assert.Equal(t, md, p3.AllMetrics()[1])
-
-	// The data should not be marked as read only.
-	assert.False(t, md.IsReadOnly())
+	// The data should be marked as read only, as it is shared with the consumers.
+	assert.True(t, md.IsReadOnly())
 }
 
 func TestReadOnlyMetricsMultiplexingMixFirstMutating(t *testing.T) {
@@ -107,35 +101,21 @@ func TestReadOnlyMetricsMultiplexingMixFirstMutating(t *testing.T) {
 	p3 := &mutatingMetricsSink{MetricsSink: new(consumertest.MetricsSink)}
 
 	mfc := NewMetrics([]consumer.Metrics{p1, p2, p3})
-	assert.True(t, mfc.Capabilities().MutatesData)
-	mdOrig := testdata.GenerateMetrics(1)
+	assert.False(t, mfc.Capabilities().MutatesData)
 	md := testdata.GenerateMetrics(1)
 	md.MarkReadOnly()
 
 	for i := 0; i < 2; i++ {
-		err := mfc.ConsumeMetrics(context.Background(), md)
-		if err != nil {
-			t.Errorf("Wanted nil got error")
-			return
-		}
+		require.NoError(t, mfc.ConsumeMetrics(context.Background(), md))
 	}
 
-	// All consumers should receive the cloned data.
-
-	assert.NotEqual(t, md, p1.AllMetrics()[0])
-	assert.NotEqual(t, md, p1.AllMetrics()[1])
-	assert.Equal(t, mdOrig, p1.AllMetrics()[0])
-	assert.Equal(t, mdOrig, p1.AllMetrics()[1])
-
-	assert.NotEqual(t, md, p2.AllMetrics()[0])
-	assert.NotEqual(t, md, p2.AllMetrics()[1])
-	assert.Equal(t, mdOrig, p2.AllMetrics()[0])
-	assert.Equal(t, mdOrig, p2.AllMetrics()[1])
-
-	assert.NotEqual(t, md, p3.AllMetrics()[0])
-	assert.NotEqual(t, md, p3.AllMetrics()[1])
-	assert.Equal(t, mdOrig, p3.AllMetrics()[0])
-	assert.Equal(t, mdOrig, p3.AllMetrics()[1])
+	// All consumers should receive mutable data sharing the initial data.
+	for _, p := range []*mutatingMetricsSink{p1, p2, p3} {
+		for _, received := range p.AllMetrics() {
+			assert.False(t, received.IsReadOnly())
+			assertMetricsContentEqual(t, testdata.GenerateMetrics(1), received)
+		}
+	}
 }
 
 func TestMetricsMultiplexingMixLastMutating(t *testing.T) {
@@ -148,32 +128,27 @@ func TestMetricsMultiplexingMixLastMutating(t *testing.T) {
 	md := testdata.GenerateMetrics(1)
 
 	for i := 0; i < 2; i++ {
-		err := mfc.ConsumeMetrics(context.Background(), md)
-		if err != nil {
-			t.Errorf("Wanted nil got error")
-			return
-		}
+		require.NoError(t, mfc.ConsumeMetrics(context.Background(), md))
 	}
 
-	assert.NotSame(t, &md, &p1.AllMetrics()[0])
-	assert.NotSame(t, &md, &p1.AllMetrics()[1])
-	assert.Equal(t, md, p1.AllMetrics()[0])
-	assert.Equal(t, md, p1.AllMetrics()[1])
+	// The mutating consumers should receive mutable data sharing the initial data.
+	for _, p := range []*mutatingMetricsSink{p1, p3} {
+		for _, received := range p.AllMetrics() {
+			assert.False(t, received.IsReadOnly())
+			assertMetricsContentEqual(t, md, received)
+		}
+	}
 
 	// For this consumer, will receive the initial data.
 	assert.Equal(t, md, p2.AllMetrics()[0])
 	assert.Equal(t, md, p2.AllMetrics()[1])
-	assert.Equal(t, md, p2.AllMetrics()[0])
-	assert.Equal(t, md, p2.AllMetrics()[1])
 
-	// For this consumer, will clone the initial data.
-	assert.NotSame(t, &md, &p3.AllMetrics()[0])
-	assert.NotSame(t, &md, &p3.AllMetrics()[1])
-	assert.Equal(t, md, p3.AllMetrics()[0])
-	assert.Equal(t, md, p3.AllMetrics()[1])
+	// The modifications of the mutating consumers are not seen by the non-mutating one.
+	p3.AllMetrics()[0].ResourceMetrics().At(0).Resource().Attributes().PutStr("mutated", "true")
+	assertMetricsContentEqual(t, testdata.GenerateMetrics(1), p2.AllMetrics()[0])
 
-	// The data should not be marked as read only.
-	assert.False(t, md.IsReadOnly())
+	// The data should be marked as read only, as it is shared with the consumers.
+	assert.True(t, md.IsReadOnly())
 }
 
 func TestMetricsMultiplexingMixLastNonMutating(t *testing.T) {
@@ -186,31 +161,23 @@ func TestMetricsMultiplexingMixLastNonMutating(t *testing.T) {
 	md := testdata.GenerateMetrics(1)
 
 	for i := 0; i < 2; i++ {
-		err := mfc.ConsumeMetrics(context.Background(), md)
-		if err != nil {
-			t.Errorf("Wanted nil got error")
-			return
-		}
+		require.NoError(t, mfc.ConsumeMetrics(context.Background(), md))
 	}
 
-	assert.NotSame(t, &md, &p1.AllMetrics()[0])
-	assert.NotSame(t, &md, &p1.AllMetrics()[1])
-	assert.Equal(t, md, p1.AllMetrics()[0])
-	assert.Equal(t, md, p1.AllMetrics()[1])
-
-	assert.NotSame(t, &md, &p2.AllMetrics()[0])
-	assert.NotSame(t, &md, &p2.AllMetrics()[1])
-	assert.Equal(t, md, p2.AllMetrics()[0])
-	assert.Equal(t, md, p2.AllMetrics()[1])
+	// The mutating consumers should receive mutable data sharing the initial data.
+	for _, p := range []*mutatingMetricsSink{p1, p2} {
+		for _, received := range p.AllMetrics() {
+			assert.False(t, received.IsReadOnly())
+			assertMetricsContentEqual(t, md, received)
+		}
+	}
 
 	// For this consumer, will receive the initial data.
 	assert.Equal(t, md, p3.AllMetrics()[0])
 	assert.Equal(t, md, p3.AllMetrics()[1])
-	assert.Equal(t, md, p3.AllMetrics()[0])
-	assert.Equal(t, md, p3.AllMetrics()[1])
 
-	// The data should not be marked as read only.
-	assert.False(t, md.IsReadOnly())
+	// The data should be marked as read only, as it is shared with the consumers.
+	assert.True(t, md.IsReadOnly())
 }
 
 func TestMetricsWhenErrors(t *testing.T) {
@@ -238,3 +205,13 @@ type mutatingMetricsSink struct {
 func (mts *mutatingMetricsSink) Capabilities() consumer.Capabilities {
 	return consumer.Capabilities{MutatesData: true}
 }
+
+// assertMetricsContentEqual asserts that the data of the Metrics are equal, regardless of their state.
+func assertMetricsContentEqual(t *testing.T, expected, actual pmetric.Metrics) {
+	marshaler := &pmetric.ProtoMarshaler{}
+	expectedBuf, err := marshaler.MarshalMetrics(expected)
+	require.NoError(t, err)
+	actualBuf, err := marshaler.MarshalMetrics(actual)
+	require.NoError(t, err)
+	assert.Equal(t, expectedBuf, actualBuf)
+}
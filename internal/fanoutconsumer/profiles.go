@@ -15,8 +15,9 @@ import (
 
 // NewProfiles wraps multiple profile consumers in a single one.
 // It fans out the incoming data to all the consumers, and does smart routing:
-//   - Clones only to the consumer that needs to mutate the data.
-//   - If all consumers needs to mutate the data one will get the original mutable data.
+//   - Shares the data with the consumers that need to mutate it, see pprofile.Profiles.Share:
+//     only the ResourceProfiles they modify are copied.
+//   - If the only consumer needs to mutate the data it will get the original mutable data.
 func NewProfiles(tcs []xconsumer.Profiles) xconsumer.Profiles {
 	// Don't wrap if there is only one non-mutating consumer.
 	if len(tcs) == 1 && !tcs[0].Capabilities().MutatesData {
@@ -40,28 +41,24 @@ type profilesConsumer struct {
 }
 
 func (tsc *profilesConsumer) Capabilities() consumer.Capabilities {
-	// If all consumers are mutating, then the original data will be passed to one of them.
-	return consumer.Capabilities{MutatesData: len(tsc.mutable) > 0 && len(tsc.readonly) == 0}
+	// If the only consumer is mutating, then the original data will be passed to it.
+	return consumer.Capabilities{MutatesData: len(tsc.mutable) == 1 && len(tsc.readonly) == 0}
 }
 
 // ConsumeProfiles exports the pprofile.Profiles to all consumers wrapped by the current one.
 func (tsc *profilesConsumer) ConsumeProfiles(ctx context.Context, td pprofile.Profiles) error {
 	var errs error
 
-	if len(tsc.mutable) > 0 {
-		// Clone the data before sending to all mutating consumers except the last one.
-		for i := 0; i < len(tsc.mutable)-1; i++ {
-			errs = multierr.Append(errs, tsc.mutable[i].ConsumeProfiles(ctx, cloneProfiles(td)))
-		}
-		// Send data as is to the last mutating consumer only if there are no other non-mutating consumers and the
-		// data is mutable. Never share the same data between a mutating and a non-mutating consumer since the
-		// non-mutating consumer may process data async and the mutating consumer may change the data before that.
-		lastConsumer := tsc.mutable[len(tsc.mutable)-1]
-		if len(tsc.readonly) == 0 && !td.IsReadOnly() {
-			errs = multierr.Append(errs, lastConsumer.ConsumeProfiles(ctx, td))
-		} else {
-			errs = multierr.Append(errs, lastConsumer.ConsumeProfiles(ctx, cloneProfiles(td)))
-		}
+	// Send data as is to the mutating consumer only if there are no other consumers and the data is mutable.
+	if len(tsc.mutable) == 1 && len(tsc.readonly) == 0 && !td.IsReadOnly() {
+		return tsc.mutable[0].ConsumeProfiles(ctx, td)
+	}
+
+	// Otherwise the mutating consumers get copy-on-write Profiles sharing the ResourceProfiles of the data, which is
+	// marked as read-only: the ResourceProfiles are copied by each consumer before being modified, so that the
+	// non-mutating consumers, which may process the data async, never see the modifications.
+	for _, tc := range tsc.mutable {
+		errs = multierr.Append(errs, tc.ConsumeProfiles(ctx, td.Share()))
 	}
 
 	// Mark the data as read-only if it will be sent to more than one read-only consumer.
@@ -74,9 +71,3 @@ func (tsc *profilesConsumer) ConsumeProfiles(ctx context.Context, td pprofile.Pr
 
 	return errs
 }
-
-func cloneProfiles(td pprofile.Profiles) pprofile.Profiles {
-	clonedProfiles := pprofile.NewProfiles()
-	td.CopyTo(clonedProfiles)
-	return clonedProfiles
-}
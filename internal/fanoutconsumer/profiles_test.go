@@ -14,6 +14,7 @@ import (
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/consumer/xconsumer"
+	"go.opentelemetry.io/collector/pdata/pprofile"
 	"go.opentelemetry.io/collector/pdata/testdata"
 )
 
@@ -71,35 +72,28 @@ func TestProfilesMultiplexingMutating(t *testing.T) {
 	p3 := &mutatingProfilesSink{ProfilesSink: new(consumertest.ProfilesSink)}
 
 	tfc := NewProfiles([]xconsumer.Profiles{p1, p2, p3})
-	assert.True(t, tfc.Capabilities().MutatesData)
+	assert.False(t, tfc.Capabilities().MutatesData)
 	td := testdata.GenerateProfiles(1)
 
 	for i := 0; i < 2; i++ {
-		err := tfc.ConsumeProfiles(context.Background(), td)
-		if err != nil {
-			t.Errorf("Wanted nil got error")
-			return
-		}
+		require.NoError(t, tfc.ConsumeProfiles(context.Background(), td))
 	}
 
-	assert.NotSame(t, &td, &p1.AllProfiles()[0])
-	assert.NotSame(t, &td, &p1.AllProfiles()[1])
-	assert.Equal(t, td, p1.AllProfiles()[0])
-	assert.Equal(t, td, p1.AllProfiles()[1])
+	// All consumers should receive mutable data sharing the initial data.
+	for _, p := range []*mutatingProfilesSink{p1, p2, p3} {
+		for _, received := range p.AllProfiles() {
+			assert.False(t, received.IsReadOnly())
+			assertProfilesContentEqual(t, td, received)
+		}
+	}
 
-	assert.NotSame(t, &td, &p2.AllProfiles()[0])
-	assert.NotSame(t, &td, &p2.AllProfiles()[1])
-	assert.Equal(t, td, p2.AllProfiles()[0])
-	assert.Equal(t, td, p2.AllProfiles()[1])
+	// The modifications of a consumer are not seen by the other consumers.
+	p1.AllProfiles()[0].ResourceProfiles().At(0).Resource().Attributes().PutStr("mutated", "true")
+	assertProfilesContentEqual(t, testdata.GenerateProfiles(1), td)
+	assertProfilesContentEqual(t, testdata.GenerateProfiles(1), p2.AllProfiles()[0])
 
-	// For this consumer, will receive the initial data.
-	assert.Equal(t, td, p3.AllProfiles()[0])
-	assert.Equal(t, td, p3.AllProfiles()[1])
-	assert.Equal(t, td, p3.AllProfiles()[0])
-	assert.Equal(t, td, p3.AllProfiles()[1])
-
-	// The data should not be marked as read only.
-	assert.False(t, td.IsReadOnly())
+	// The data should be marked as read only, as it is shared with the consumers.
+	assert.True(t, td.IsReadOnly())
 }
 
 func TestReadOnlyProfilesMultiplexingMutating(t *testing.T) {
@@ -108,36 +102,21 @@ func TestReadOnlyProfilesMultiplexingMutating(t *testing.T) {
 	p3 := &mutatingProfilesSink{ProfilesSink: new(consumertest.ProfilesSink)}
 
 	tfc := NewProfiles([]xconsumer.Profiles{p1, p2, p3})
-	assert.True(t, tfc.Capabilities().MutatesData)
-
-	tdOrig := testdata.GenerateProfiles(1)
+	assert.False(t, tfc.Capabilities().MutatesData)
 	td := testdata.GenerateProfiles(1)
 	td.MarkReadOnly()
 
 	for i := 0; i < 2; i++ {
-		err := tfc.ConsumeProfiles(context.Background(), td)
-		if err != nil {
-			t.Errorf("Wanted nil got error")
-			return
-		}
+		require.NoError(t, tfc.ConsumeProfiles(context.Background(), td))
 	}
 
-	// All consumers should receive the cloned data.
-
-	assert.NotEqual(t, td, p1.AllProfiles()[0])
-	assert.NotEqual(t, td, p1.AllProfiles()[1])
-	assert.Equal(t, tdOrig, p1.AllProfiles()[0])
-	assert.Equal(t, tdOrig, p1.AllProfiles()[1])
-
-	assert.NotEqual(t, td, p2.AllProfiles()[0])
-	assert.NotEqual(t, td, p2.AllProfiles()[1])
-	assert.Equal(t, tdOrig, p2.AllProfiles()[0])
-	assert.Equal(t, tdOrig, p2.AllProfiles()[1])
-
-	assert.NotEqual(t, td, p3.AllProfiles()[0])
-	assert.NotEqual(t, td, p3.AllProfiles()[1])
-	assert.Equal(t, tdOrig, p3.AllProfiles()[0])
-	assert.Equal(t, tdOrig, p3.AllProfiles()[1])
+	// All consumers should receive mutable data sharing the initial data.
+	for _, p := range []*mutatingProfilesSink{p1, p2, p3} {
+		for _, received := range p.AllProfiles() {
+			assert.False(t, received.IsReadOnly())
+			assertProfilesContentEqual(t, testdata.GenerateProfiles(1), received)
+		}
+	}
 }
 
 func TestProfilesMultiplexingMixLastMutating(t *testing.T) {
@@ -150,32 +129,27 @@ func TestProfilesMultiplexingMixLastMutating(t *testing.T) {
 	td := testdata.GenerateProfiles(1)
 
 	for i := 0; i < 2; i++ {
-		err := tfc.ConsumeProfiles(context.Background(), td)
-		if err != nil {
-			t.Errorf("Wanted nil got error")
-			return
-		}
+		require.NoError(t, tfc.ConsumeProfiles(context.Background(), td))
 	}
 
-	assert.NotSame(t, &td, &p1.AllProfiles()[0])
-	assert.NotSame(t, &td, &p1.AllProfiles()[1])
-	assert.Equal(t, td, p1.AllProfiles()[0])
-	assert.Equal(t, td, p1.AllProfiles()[1])
+	// The mutating consumers should receive mutable data sharing the initial data.
+	for _, p := range []*mutatingProfilesSink{p1, p3} {
+		for _, received := range p.AllProfiles() {
+			assert.False(t, received.IsReadOnly())
+			assertProfilesContentEqual(t, td, received)
+		}
+	}
 
 	// For this consumer, will receive the initial data.
 	assert.Equal(t, td, p2.AllProfiles()[0])
 	assert.Equal(t, td, p2.AllProfiles()[1])
-	assert.Equal(t, td, p2.AllProfiles()[0])
-	assert.Equal(t, td, p2.AllProfiles()[1])
 
-	// For this consumer, will clone the initial data.
-	assert.NotSame(t, &td, &p3.AllProfiles()[0])
-	assert.NotSame(t, &td, &p3.AllProfiles()[1])
-	assert.Equal(t, td, p3.AllProfiles()[0])
-	assert.Equal(t, td, p3.AllProfiles()[1])
+	// The modifications of the mutating consumers are not seen by the non-mutating one.
+	p3.AllProfiles()[0].ResourceProfiles().At(0).Resource().Attributes().PutStr("mutated", "true")
+	assertProfilesContentEqual(t, testdata.GenerateProfiles(1), p2.AllProfiles()[0])
 
-	// The data should not be marked as read only.
-	assert.False(t, td.IsReadOnly())
+	// The data should be marked as read only, as it is shared with the consumers.
+	assert.True(t, td.IsReadOnly())
 }
 
 func TestProfilesMultiplexingMixLastNonMutating(t *testing.T) {
@@ -188,31 +162,23 @@ func TestProfilesMultiplexingMixLastNonMutating(t *testing.T) {
 	td := testdata.GenerateProfiles(1)
 
 	for i := 0; i < 2; i++ {
-		err := tfc.ConsumeProfiles(context.Background(), td)
-		if err != nil {
-			t.Errorf("Wanted nil got error")
-			return
-		}
+		require.NoError(t, tfc.ConsumeProfiles(context.Background(), td))
 	}
 
-	assert.NotSame(t, &td, &p1.AllProfiles()[0])
-	assert.NotSame(t, &td, &p1.AllProfiles()[1])
-	assert.Equal(t, td, p1.AllProfiles()[0])
-	assert.Equal(t, td, p1.AllProfiles()[1])
-
-	assert.NotSame(t, &td, &p2.AllProfiles()[0])
-	assert.NotSame(t, &td, &p2.AllProfiles()[1])
-	assert.Equal(t, td, p2.AllProfiles()[0])
-	assert.Equal(t, td, p2.AllProfiles()[1])
+	// The mutating consumers should receive mutable data sharing the initial data.
+	for _, p := range []*mutatingProfilesSink{p1, p2} {
+		for _, received := range p.AllProfiles() {
+			assert.False(t, received.IsReadOnly())
+			assertProfilesContentEqual(t, td, received)
+		}
+	}
 
 	// For this consumer, will receive the initial data.
 	assert.Equal(t, td, p3.AllProfiles()[0])
 	assert.Equal(t, td, p3.AllProfiles()[1])
-	assert.Equal(t, td, p3.AllProfiles()[0])
-	assert.Equal(t, td, p3.AllProfiles()[1])
 
-	// The data should not be marked as read only.
-	assert.False(t, td.IsReadOnly())
+	// The data should be marked as read only, as it is shared with the consumers.
+	assert.True(t, td.IsReadOnly())
 }
 
 func TestProfilesWhenErrors(t *testing.T) {
@@ -240,3 +206,13 @@ type mutatingProfilesSink struct {
 func (mts *mutatingProfilesSink) Capabilities() consumer.Capabilities {
 	return consumer.Capabilities{MutatesData: true}
 }
+
+// assertProfilesContentEqual asserts that the data of the Profiles are equal, regardless of their state.
+func assertProfilesContentEqual(t *testing.T, expected, actual pprofile.Profiles) {
+	marshaler := &pprofile.ProtoMarshaler{}
+	expectedBuf, err := marshaler.MarshalProfiles(expected)
+	require.NoError(t, err)
+	actualBuf, err := marshaler.MarshalProfiles(actual)
+	require.NoError(t, err)
+	assert.Equal(t, expectedBuf, actualBuf)
+}
@@ -14,8 +14,9 @@ import (
 
 // NewTraces wraps multiple trace consumers in a single one.
 // It fans out the incoming data to all the consumers, and does smart routing:
-//   - Clones only to the consumer that needs to mutate the data.
-//   - If all consumers needs to mutate the data one will get the original mutable data.
+//   - Shares the data with the consumers that need to mutate it, see ptrace.Traces.Share:
+//     only the ResourceSpans they modify are copied.
+//   - If the only consumer needs to mutate the data it will get the original mutable data.
 func NewTraces(tcs []consumer.Traces) consumer.Traces {
 	// Don't wrap if there is only one non-mutating consumer.
 	if len(tcs) == 1 && !tcs[0].Capabilities().MutatesData {
@@ -39,28 +40,24 @@ type tracesConsumer struct {
 }
 
 func (tsc *tracesConsumer) Capabilities() consumer.Capabilities {
-	// If all consumers are mutating, then the original data will be passed to one of them.
-	return consumer.Capabilities{MutatesData: len(tsc.mutable) > 0 && len(tsc.readonly) == 0}
+	// If the only consumer is mutating, then the original data will be passed to it.
+	return consumer.Capabilities{MutatesData: len(tsc.mutable) == 1 && len(tsc.readonly) == 0}
 }
 
 // ConsumeTraces exports the ptrace.Traces to all consumers wrapped by the current one.
 func (tsc *tracesConsumer) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
 	var errs error
 
-	if len(tsc.mutable) > 0 {
-		// Clone the data before sending to all mutating consumers except the last one.
-		for i := 0; i < len(tsc.mutable)-1; i++ {
-			errs = multierr.Append(errs, tsc.mutable[i].ConsumeTraces(ctx, cloneTraces(td)))
-		}
-		// Send data as is to the last mutating consumer only if there are no other non-mutating consumers and the
-		// data is mutable. Never share the same data between a mutating and a non-mutating consumer since the
-		// non-mutating consumer may process data async and the mutating consumer may change the data before that.
-		lastConsumer := tsc.mutable[len(tsc.mutable)-1]
-		if len(tsc.readonly) == 0 && !td.IsReadOnly() {
-			errs = multierr.Append(errs, lastConsumer.ConsumeTraces(ctx, td))
-		} else {
-			errs = multierr.Append(errs, lastConsumer.ConsumeTraces(ctx, cloneTraces(td)))
-		}
+	// Send data as is to the mutating consumer only if there are no other consumers and the data is mutable.
+	if len(tsc.mutable) == 1 && len(tsc.readonly) == 0 && !td.IsReadOnly() {
+		return tsc.mutable[0].ConsumeTraces(ctx, td)
+	}
+
+	// Otherwise the mutating consumers get copy-on-write Traces sharing the ResourceSpans of the data, which is
+	// marked as read-only: the ResourceSpans are copied by each consumer before being modified, so that the
+	// non-mutating consumers, which may process the data async, never see the modifications.
+	for _, tc := range tsc.mutable {
+		errs = multierr.Append(errs, tc.ConsumeTraces(ctx, td.Share()))
 	}
 
 	// Mark the data as read-only if it will be sent to more than one read-only consumer.
@@ -73,9 +70,3 @@ func (tsc *tracesConsumer) ConsumeTraces(ctx context.Context, td ptrace.Traces)
 
 	return errs
 }
-
-func cloneTraces(td ptrace.Traces) ptrace.Traces {
-	clonedTraces := ptrace.NewTraces()
-	td.CopyTo(clonedTraces)
-	return clonedTraces
-}
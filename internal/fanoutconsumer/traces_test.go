@@ -6,6 +6,7 @@ package fanoutconsumer
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -13,6 +14,7 @@ import (
 
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/pdata/testdata"
 )
 
@@ -70,35 +72,28 @@ func TestTracesMultiplexingMutating(t *testing.T) {
 	p3 := &mutatingTracesSink{TracesSink: new(consumertest.TracesSink)}
 
 	tfc := NewTraces([]consumer.Traces{p1, p2, p3})
-	assert.True(t, tfc.Capabilities().MutatesData)
+	assert.False(t, tfc.Capabilities().MutatesData)
 	td := testdata.GenerateTraces(1)
 
 	for i := 0; i < 2; i++ {
-		err := tfc.ConsumeTraces(context.Background(), td)
-		if err != nil {
-			t.Errorf("Wanted nil got error")
-			return
-		}
+		require.NoError(t, tfc.ConsumeTraces(context.Background(), td))
 	}
 
-	assert.NotSame(t, &td, &p1.AllTraces()[0])
-	assert.NotSame(t, &td, &p1.AllTraces()[1])
-	assert.Equal(t, td, p1.AllTraces()[0])
-	assert.Equal(t, td, p1.AllTraces()[1])
-
-	assert.NotSame(t, &td, &p2.AllTraces()[0])
-	assert.NotSame(t, &td, &p2.AllTraces()[1])
-	assert.Equal(t, td, p2.AllTraces()[0])
-	assert.Equal(t, td, p2.AllTraces()[1])
+	// All consumers should receive mutable data sharing the initial data.
+	for _, p := range []*mutatingTracesSink{p1, p2, p3} {
+		for _, received := range p.AllTraces() {
+			assert.False(t, received.IsReadOnly())
+			assertTracesContentEqual(t, td, received)
+		}
+	}
 
-	// For this consumer, will receive the initial data.
-	assert.Equal(t, td, p3.AllTraces()[0])
-	assert.Equal(t, td, p3.AllTraces()[1])
-	assert.Equal(t, td, p3.AllTraces()[0])
-	assert.Equal(t, td, p3.AllTraces()[1])
+	// The modifications of a consumer are not seen by the other consumers.
+	p1.AllTraces()[0].ResourceSpans().At(0).Resource().Attributes().PutStr("mutated", "true")
+	assertTracesContentEqual(t, testdata.GenerateTraces(1), td)
+	assertTracesContentEqual(t, testdata.GenerateTraces(1), p2.AllTraces()[0])
 
-	// The data should not be marked as read only.
-	assert.False(t, td.IsReadOnly())
+	// The data should be marked as read only, as it is shared with the consumers.
+	assert.True(t, td.IsReadOnly())
 }
 
 func TestReadOnlyTracesMultiplexingMutating(t *testing.T) {
@@ -107,36 +102,21 @@ func TestReadOnlyTracesMultiplexingMutating(t *testing.T) {
 	p3 := &mutatingTracesSink{TracesSink: new(consumertest.TracesSink)}
 
 	tfc := NewTraces([]consumer.Traces{p1, p2, p3})
-	assert.True(t, tfc.Capabilities().MutatesData)
-
-	tdOrig := testdata.GenerateTraces(1)
+	assert.False(t, tfc.Capabilities().MutatesData)
 	td := testdata.GenerateTraces(1)
 	td.MarkReadOnly()
 
 	for i := 0; i < 2; i++ {
-		err := tfc.ConsumeTraces(context.Background(), td)
-		if err != nil {
-			t.Errorf("Wanted nil got error")
-			return
-		}
+		require.NoError(t, tfc.ConsumeTraces(context.Background(), td))
 	}
 
-	// All consumers should receive the cloned data.
-
-	assert.NotEqual(t, td, p1.AllTraces()[0])
-	assert.NotEqual(t, td, p1.AllTraces()[1])
-	assert.Equal(t, tdOrig, p1.AllTraces()[0])
-	assert.Equal(t, tdOrig, p1.AllTraces()[1])
-
-	assert.NotEqual(t, td, p2.AllTraces()[0])
-	assert.NotEqual(t, td, p2.AllTraces()[1])
-	assert.Equal(t, tdOrig, p2.AllTraces()[0])
-	assert.Equal(t, tdOrig, p2.AllTraces()[1])
-
-	assert.NotEqual(t, td, p3.AllTraces()[0])
-	assert.NotEqual(t, td, p3.AllTraces()[1])
-	assert.Equal(t, tdOrig, p3.AllTraces()[0])
-	assert.Equal(t, tdOrig, p3.AllTraces()[1])
+	// All consumers should receive mutable data sharing the initial data.
+	for _, p := range []*mutatingTracesSink{p1, p2, p3} {
+		for _, received := range p.AllTraces() {
+			assert.False(t, received.IsReadOnly())
+			assertTracesContentEqual(t, testdata.GenerateTraces(1), received)
+		}
+	}
 }
 
 func TestTracesMultiplexingMixLastMutating(t *testing.T) {
@@ -149,32 +129,27 @@ func TestTracesMultiplexingMixLastMutating(t *testing.T) {
 	td := testdata.GenerateTraces(1)
 
 	for i := 0; i < 2; i++ {
-		err := tfc.ConsumeTraces(context.Background(), td)
-		if err != nil {
-			t.Errorf("Wanted nil got error")
-			return
-		}
+		require.NoError(t, tfc.ConsumeTraces(context.Background(), td))
 	}
 
-	assert.NotSame(t, &td, &p1.AllTraces()[0])
-	assert.NotSame(t, &td, &p1.AllTraces()[1])
-	assert.Equal(t, td, p1.AllTraces()[0])
-	assert.Equal(t, td, p1.AllTraces()[1])
+	// The mutating consumers should receive mutable data sharing the initial data.
+	for _, p := range []*mutatingTracesSink{p1, p3} {
+		for _, received := range p.AllTraces() {
+			assert.False(t, received.IsReadOnly())
+			assertTracesContentEqual(t, td, received)
+		}
+	}
 
 	// For this consumer, will receive the initial data.
 	assert.Equal(t, td, p2.AllTraces()[0])
 	assert.Equal(t, td, p2.AllTraces()[1])
-	assert.Equal(t, td, p2.AllTraces()[0])
-	assert.Equal(t, td, p2.AllTraces()[1])
 
-	// For this consumer, will clone the initial data.
-	assert.NotSame(t, &td, &p3.AllTraces()[0])
-	assert.NotSame(t, &td, &p3.AllTraces()[1])
-	assert.Equal(t, td, p3.AllTraces()[0])
-	assert.Equal(t, td, p3.AllTraces()[1])
+	// The modifications of the mutating consumers are not seen by the non-mutating one.
+	p3.AllTraces()[0].ResourceSpans().At(0).Resource().Attributes().PutStr("mutated", "true")
+	assertTracesContentEqual(t, testdata.GenerateTraces(1), p2.AllTraces()[0])
 
-	// The data should not be marked as read only.
-	assert.False(t, td.IsReadOnly())
+	// The data should be marked as read only, as it is shared with the consumers.
+	assert.True(t, td.IsReadOnly())
 }
 
 func TestTracesMultiplexingMixLastNonMutating(t *testing.T) {
@@ -187,31 +162,23 @@ func TestTracesMultiplexingMixLastNonMutating(t *testing.T) {
 	td := testdata.GenerateTraces(1)
 
 	for i := 0; i < 2; i++ {
-		err := tfc.ConsumeTraces(context.Background(), td)
-		if err != nil {
-			t.Errorf("Wanted nil got error")
-			return
-		}
+		require.NoError(t, tfc.ConsumeTraces(context.Background(), td))
 	}
 
-	assert.NotSame(t, &td, &p1.AllTraces()[0])
-	assert.NotSame(t, &td, &p1.AllTraces()[1])
-	assert.Equal(t, td, p1.AllTraces()[0])
-	assert.Equal(t, td, p1.AllTraces()[1])
-
-	assert.NotSame(t, &td, &p2.AllTraces()[0])
-	assert.NotSame(t, &td, &p2.AllTraces()[1])
-	assert.Equal(t, td, p2.AllTraces()[0])
-	assert.Equal(t, td, p2.AllTraces()[1])
+	// The mutating consumers should receive mutable data sharing the initial data.
+	for _, p := range []*mutatingTracesSink{p1, p2} {
+		for _, received := range p.AllTraces() {
+			assert.False(t, received.IsReadOnly())
+			assertTracesContentEqual(t, td, received)
+		}
+	}
 
 	// For this consumer, will receive the initial data.
 	assert.Equal(t, td, p3.AllTraces()[0])
 	assert.Equal(t, td, p3.AllTraces()[1])
-	assert.Equal(t, td, p3.AllTraces()[0])
-	assert.Equal(t, td, p3.AllTraces()[1])
 
-	// The data should not be marked as read only.
-	assert.False(t, td.IsReadOnly())
+	// The data should be marked as read only, as it is shared with the consumers.
+	assert.True(t, td.IsReadOnly())
 }
 
 func TestTracesWhenErrors(t *testing.T) {
@@ -232,6 +199,67 @@ func TestTracesWhenErrors(t *testing.T) {
 	assert.Equal(t, td, p3.AllTraces()[1])
 }
 
+// BenchmarkTracesMultiplexingMutating compares the Traces shared with three mutating consumers, see
+// ptrace.Traces.Share, with the Traces copied for each of them.
+func BenchmarkTracesMultiplexingMutating(b *testing.B) {
+	td := ptrace.NewTraces()
+	for range 10 {
+		testdata.GenerateTraces(100).ResourceSpans().MoveAndAppendTo(td.ResourceSpans())
+	}
+
+	for _, modified := range []int{1, td.ResourceSpans().Len()} {
+		mutating := consumer.ConsumeTracesFunc(func(_ context.Context, td ptrace.Traces) error {
+			for i := range modified {
+				td.ResourceSpans().At(i).Resource().Attributes().PutStr("branch", "value")
+			}
+			return nil
+		})
+
+		b.Run(fmt.Sprintf("share/modified=%d", modified), func(b *testing.B) {
+			tfc := NewTraces([]consumer.Traces{
+				&mutatingTracesConsumer{mutating},
+				&mutatingTracesConsumer{mutating},
+				&mutatingTracesConsumer{mutating},
+			})
+			b.ReportAllocs()
+			b.ResetTimer()
+			b.StopTimer()
+			for range b.N {
+				data := ptrace.NewTraces()
+				td.CopyTo(data)
+				b.StartTimer()
+				require.NoError(b, tfc.ConsumeTraces(context.Background(), data))
+				b.StopTimer()
+			}
+		})
+
+		b.Run(fmt.Sprintf("clone/modified=%d", modified), func(b *testing.B) {
+			b.ReportAllocs()
+			b.ResetTimer()
+			b.StopTimer()
+			for range b.N {
+				data := ptrace.NewTraces()
+				td.CopyTo(data)
+				b.StartTimer()
+				for range 3 {
+					cloned := ptrace.NewTraces()
+					data.CopyTo(cloned)
+					require.NoError(b, mutating.ConsumeTraces(context.Background(), cloned))
+				}
+				b.StopTimer()
+			}
+		})
+	}
+}
+
+type mutatingTracesConsumer struct {
+	consumer.ConsumeTracesFunc
+}
+
+func (mtc *mutatingTracesConsumer) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: true}
+}
+
 type mutatingTracesSink struct {
 	*consumertest.TracesSink
 }
@@ -239,3 +267,13 @@ type mutatingTracesSink struct {
 func (mts *mutatingTracesSink) Capabilities() consumer.Capabilities {
 	return consumer.Capabilities{MutatesData: true}
 }
+
+// assertTracesContentEqual asserts that the data of the Traces are equal, regardless of their state.
+func assertTracesContentEqual(t *testing.T, expected, actual ptrace.Traces) {
+	marshaler := &ptrace.ProtoMarshaler{}
+	expectedBuf, err := marshaler.MarshalTraces(expected)
+	require.NoError(t, err)
+	actualBuf, err := marshaler.MarshalTraces(actual)
+	require.NoError(t, err)
+	assert.Equal(t, expectedBuf, actualBuf)
+}
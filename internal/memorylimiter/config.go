@@ -18,6 +18,20 @@ var (
 	errSpikeLimitPercentageOutOfRange = errors.New("'spike_limit_percentage' must be smaller than 'limit_percentage'")
 	errLimitPercentageOutOfRange      = errors.New(
 		"'limit_percentage' and 'spike_limit_percentage' must be greater than zero and less than or equal to hundred")
+	errUnknownMode = errors.New("'mode' must be either 'gc' or 'gomemlimit'")
+)
+
+// Mode is how the memory limiter keeps the memory usage within the limits.
+type Mode string
+
+const (
+	// ModeGC reads the memory statistics every check interval, and forces a GC when the
+	// memory usage is above the soft limit.
+	ModeGC Mode = "gc"
+	// ModeGoMemLimit sets the soft memory limit of the Go runtime, see debug.SetMemoryLimit,
+	// so that the runtime collects garbage as needed to stay within the limit. The memory
+	// usage is read from runtime/metrics, which does not stop the world, and no GC is forced.
+	ModeGoMemLimit Mode = "gomemlimit"
 )
 
 // Config defines configuration for memory memoryLimiter processor.
@@ -27,6 +41,9 @@ type Config struct {
 	// checks will be performed.
 	CheckInterval time.Duration `mapstructure:"check_interval"`
 
+	// Mode is how the memory usage is kept within the limits, either "gc", the default, or "gomemlimit".
+	Mode Mode `mapstructure:"mode"`
+
 	// MinGCIntervalWhenSoftLimited minimum interval between forced GC when in soft (=limit_mib - spike_limit_mib) limited mode.
 	// Zero value means no minimum interval.
 	// GCs is a CPU-heavy operation and executing it too frequently may affect the recovery capabilities of the collector.
@@ -67,6 +84,11 @@ func (cfg *Config) Validate() error {
 	if cfg.CheckInterval <= 0 {
 		return errCheckIntervalOutOfRange
 	}
+	switch cfg.Mode {
+	case "", ModeGC, ModeGoMemLimit:
+	default:
+		return errUnknownMode
+	}
 	if cfg.MinGCIntervalWhenSoftLimited < cfg.MinGCIntervalWhenHardLimited {
 		return errInconsistentGCMinInterval
 	}
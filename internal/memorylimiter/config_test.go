@@ -95,6 +95,24 @@ func TestConfigValidate(t *testing.T) {
 			},
 			err: errInconsistentGCMinInterval,
 		},
+		{
+			name: "valid gomemlimit mode",
+			cfg: &Config{
+				CheckInterval:         1 * time.Second,
+				Mode:                  ModeGoMemLimit,
+				MemoryLimitPercentage: 80,
+			},
+			err: nil,
+		},
+		{
+			name: "unknown mode",
+			cfg: &Config{
+				CheckInterval:  1 * time.Second,
+				Mode:           "unknown",
+				MemoryLimitMiB: 5722,
+			},
+			err: errUnknownMode,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
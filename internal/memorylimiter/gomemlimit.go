@@ -0,0 +1,135 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package memorylimiter // import "go.opentelemetry.io/collector/internal/memorylimiter"
+
+import (
+	"math"
+	"runtime/debug"
+	"runtime/metrics"
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+const (
+	heapLiveMetric = "/gc/heap/live:bytes"
+	heapGoalMetric = "/gc/heap/goal:bytes"
+)
+
+// heapStats is the state of the heap read from runtime/metrics.
+type heapStats struct {
+	// live is the heap memory marked live by the last GC.
+	live uint64
+	// goal is the heap size the GC targets at the end of the current cycle. It is capped
+	// by the soft memory limit of the runtime.
+	goal uint64
+}
+
+// readHeapStats reads the heap statistics without stopping the world, unlike runtime.ReadMemStats.
+func readHeapStats() heapStats {
+	samples := []metrics.Sample{{Name: heapLiveMetric}, {Name: heapGoalMetric}}
+	metrics.Read(samples)
+	var hs heapStats
+	if samples[0].Value.Kind() == metrics.KindUint64 {
+		hs.live = samples[0].Value.Uint64()
+	}
+	if samples[1].Value.Kind() == metrics.KindUint64 {
+		hs.goal = samples[1].Value.Uint64()
+	}
+	return hs
+}
+
+func heapStatsToZapFields(hs heapStats) []zap.Field {
+	return []zap.Field{
+		zap.Uint64("live_heap_mib", hs.live/mibBytes),
+		zap.Uint64("heap_goal_mib", hs.goal/mibBytes),
+	}
+}
+
+// memoryLimitOwner owns the soft memory limit of the Go runtime, shared by all the MemoryLimiters
+// in ModeGoMemLimit of the process. The runtime limit is the lowest limit of the started limiters,
+// and the limit set before the first of them started is restored once the last one is shut down.
+type memoryLimitOwner struct {
+	setMemoryLimitFn func(limit int64) int64
+
+	mu     sync.Mutex
+	limits map[*MemoryLimiter]int64
+	// prev is the limit set before the first limiter started, e.g. through the GOMEMLIMIT environment variable.
+	prev int64
+}
+
+// processMemoryLimit is the owner of the memory limit of the process.
+var processMemoryLimit = newMemoryLimitOwner(debug.SetMemoryLimit)
+
+func newMemoryLimitOwner(setMemoryLimitFn func(limit int64) int64) *memoryLimitOwner {
+	return &memoryLimitOwner{
+		setMemoryLimitFn: setMemoryLimitFn,
+		limits:           make(map[*MemoryLimiter]int64),
+	}
+}
+
+// acquire sets the memory limit of the runtime to the limit of ml, unless a lower limit is set,
+// either by another limiter or before the first limiter started.
+func (o *memoryLimitOwner) acquire(ml *MemoryLimiter, limit int64) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if len(o.limits) == 0 {
+		o.prev = o.setMemoryLimitFn(-1)
+	}
+	o.limits[ml] = limit
+	if o.prev < limit {
+		ml.logger.Info("Keeping the lower memory limit of the Go runtime.",
+			zap.Int64("gomemlimit_mib", o.prev/mibBytes))
+	}
+	o.apply()
+}
+
+// release removes the limit of ml, restoring the previous limit of the runtime after the last limiter.
+func (o *memoryLimitOwner) release(ml *MemoryLimiter) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	delete(o.limits, ml)
+	o.apply()
+}
+
+func (o *memoryLimitOwner) apply() {
+	limit := o.prev
+	for _, l := range o.limits {
+		limit = min(limit, l)
+	}
+	o.setMemoryLimitFn(limit)
+}
+
+// setMemoryLimit sets the soft memory limit of the runtime to the hard limit, see memoryLimitOwner.
+func (ml *MemoryLimiter) setMemoryLimit() {
+	limit := int64(math.MaxInt64)
+	if ml.usageChecker.memAllocLimit < math.MaxInt64 {
+		limit = int64(ml.usageChecker.memAllocLimit)
+	}
+	ml.memoryLimit.acquire(ml, limit)
+}
+
+// checkHeapLimits refuses data while the heap memory marked live by the last GC is above the soft
+// limit, or while the heap goal, capped by the memory limit, leaves less than the spike limit
+// above the live heap. The runtime collects garbage as needed to stay within the memory limit,
+// so no GC is forced.
+func (ml *MemoryLimiter) checkHeapLimits() {
+	hs := ml.readHeapStatsFn()
+	ml.logger.Debug("Currently used memory.", heapStatsToZapFields(hs)...)
+
+	softLimit := ml.usageChecker.memAllocLimit - ml.usageChecker.memSpikeLimit
+	mustRefuse := hs.live >= softLimit
+	if !mustRefuse && hs.goal >= softLimit {
+		// The memory limit caps the heap goal, make sure a spike still fits below it.
+		mustRefuse = hs.goal < hs.live+ml.usageChecker.memSpikeLimit
+	}
+
+	switch {
+	case mustRefuse && !ml.mustRefuse.Load():
+		ml.logger.Warn("Memory usage is above soft limit. Refusing data.", heapStatsToZapFields(hs)...)
+	case !mustRefuse && ml.mustRefuse.Load():
+		ml.logger.Info("Memory usage back within limits. Resuming normal operation.", heapStatsToZapFields(hs)...)
+	}
+	ml.mustRefuse.Store(mustRefuse)
+}
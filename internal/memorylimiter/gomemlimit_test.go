@@ -0,0 +1,122 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package memorylimiter
+
+import (
+	"context"
+	"math"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestGoMemLimitMode(t *testing.T) {
+	cfg := &Config{
+		CheckInterval:       1 * time.Minute,
+		Mode:                ModeGoMemLimit,
+		MemoryLimitMiB:      1000,
+		MemorySpikeLimitMiB: 200,
+	}
+	ml, err := NewMemoryLimiter(cfg, zap.NewNop())
+	require.NoError(t, err)
+	memoryLimit := int64(math.MaxInt64)
+	ml.memoryLimit = newMemoryLimitOwner(fakeSetMemoryLimit(&memoryLimit))
+	var hs heapStats
+	ml.readHeapStatsFn = func() heapStats { return hs }
+	ml.readMemStatsFn = func(*runtime.MemStats) { t.Fatal("memory statistics must not be read") }
+	ml.runGCFn = func() { t.Fatal("GC must not be forced") }
+
+	require.NoError(t, ml.Start(context.Background(), nil))
+	assert.Equal(t, int64(1000*mibBytes), memoryLimit)
+
+	// Below the soft limit, with room for a spike below the heap goal.
+	hs = heapStats{live: 500 * mibBytes, goal: 900 * mibBytes}
+	ml.CheckMemLimits()
+	assert.False(t, ml.MustRefuse())
+
+	// The heap goal, capped by the memory limit, leaves no room for a spike.
+	hs = heapStats{live: 750 * mibBytes, goal: 900 * mibBytes}
+	ml.CheckMemLimits()
+	assert.True(t, ml.MustRefuse())
+
+	// Above the soft limit.
+	hs = heapStats{live: 850 * mibBytes, goal: 1100 * mibBytes}
+	ml.CheckMemLimits()
+	assert.True(t, ml.MustRefuse())
+
+	// Back within the limits.
+	hs = heapStats{live: 100 * mibBytes, goal: 200 * mibBytes}
+	ml.CheckMemLimits()
+	assert.False(t, ml.MustRefuse())
+
+	require.NoError(t, ml.Shutdown(context.Background()))
+	assert.Equal(t, int64(math.MaxInt64), memoryLimit)
+}
+
+func TestGoMemLimitModeKeepsLowerLimit(t *testing.T) {
+	cfg := &Config{
+		CheckInterval:  1 * time.Minute,
+		Mode:           ModeGoMemLimit,
+		MemoryLimitMiB: 1000,
+	}
+	ml, err := NewMemoryLimiter(cfg, zap.NewNop())
+	require.NoError(t, err)
+	var calls []int64
+	ml.memoryLimit = newMemoryLimitOwner(func(limit int64) int64 {
+		calls = append(calls, limit)
+		return 500 * mibBytes
+	})
+
+	require.NoError(t, ml.Start(context.Background(), nil))
+	require.NoError(t, ml.Shutdown(context.Background()))
+	// The limit is read and kept, then restored.
+	assert.Equal(t, []int64{-1, 500 * mibBytes, 500 * mibBytes}, calls)
+}
+
+func TestGoMemLimitModeSharedLimit(t *testing.T) {
+	memoryLimit := int64(math.MaxInt64)
+	owner := newMemoryLimitOwner(fakeSetMemoryLimit(&memoryLimit))
+	newLimiter := func(limitMiB uint32) *MemoryLimiter {
+		ml, err := NewMemoryLimiter(&Config{CheckInterval: time.Minute, Mode: ModeGoMemLimit, MemoryLimitMiB: limitMiB}, zap.NewNop())
+		require.NoError(t, err)
+		ml.memoryLimit = owner
+		return ml
+	}
+	ml1 := newLimiter(1000)
+	ml2 := newLimiter(500)
+
+	// The lowest limit of the started limiters is set.
+	require.NoError(t, ml1.Start(context.Background(), nil))
+	assert.Equal(t, int64(1000*mibBytes), memoryLimit)
+	require.NoError(t, ml2.Start(context.Background(), nil))
+	assert.Equal(t, int64(500*mibBytes), memoryLimit)
+
+	// The limit of the limiter shut down first is not restored while the other one runs.
+	require.NoError(t, ml2.Shutdown(context.Background()))
+	assert.Equal(t, int64(1000*mibBytes), memoryLimit)
+	require.NoError(t, ml1.Shutdown(context.Background()))
+	assert.Equal(t, int64(math.MaxInt64), memoryLimit)
+}
+
+// fakeSetMemoryLimit returns a function behaving like debug.SetMemoryLimit for the limit.
+func fakeSetMemoryLimit(memoryLimit *int64) func(int64) int64 {
+	return func(limit int64) int64 {
+		prev := *memoryLimit
+		if limit >= 0 {
+			*memoryLimit = limit
+		}
+		return prev
+	}
+}
+
+func TestReadHeapStats(t *testing.T) {
+	runtime.GC()
+	hs := readHeapStats()
+	assert.Positive(t, hs.live)
+	assert.GreaterOrEqual(t, hs.goal, hs.live)
+}
@@ -0,0 +1,26 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build linux
+
+package iruntime // import "go.opentelemetry.io/collector/internal/memorylimiter/iruntime"
+
+import "go.opentelemetry.io/collector/internal/memorylimiter/cgroups"
+
+// CPUQuota returns the number of CPUs the process may use, and false if it is not limited.
+// This implementation is meant for linux and uses cgroups to determine the CPU quota.
+func CPUQuota() (float64, bool, error) {
+	isV2, err := cgroups.IsCGroupV2()
+	if err != nil {
+		return -1, false, err
+	}
+	if isV2 {
+		return cgroups.CPUQuotaV2()
+	}
+
+	cgv1, err := cgroups.NewCGroupsForCurrentProcess()
+	if err != nil {
+		return -1, false, err
+	}
+	return cgv1.CPUQuota()
+}
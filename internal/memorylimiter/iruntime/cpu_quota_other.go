@@ -0,0 +1,11 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !linux
+
+package iruntime // import "go.opentelemetry.io/collector/internal/memorylimiter/iruntime"
+
+// CPUQuota returns false for non-linux platforms, where the CPUs are not limited by cgroups.
+func CPUQuota() (float64, bool, error) {
+	return -1, false, nil
+}
@@ -38,6 +38,7 @@ var (
 // MemoryLimiter is used to prevent out of memory situations on the collector.
 type MemoryLimiter struct {
 	usageChecker memUsageChecker
+	mode         Mode
 
 	memCheckWait time.Duration
 
@@ -55,6 +56,10 @@ type MemoryLimiter struct {
 	readMemStatsFn func(m *runtime.MemStats)
 	runGCFn        func()
 
+	// Used in ModeGoMemLimit instead of readMemStatsFn and runGCFn.
+	readHeapStatsFn func() heapStats
+	memoryLimit     *memoryLimitOwner
+
 	// Fields used for logging.
 	logger *zap.Logger
 
@@ -71,13 +76,19 @@ func NewMemoryLimiter(cfg *Config, logger *zap.Logger) (*MemoryLimiter, error) {
 		return nil, err
 	}
 
+	mode := cfg.Mode
+	if mode == "" {
+		mode = ModeGC
+	}
 	logger.Info("Memory limiter configured",
 		zap.Uint64("limit_mib", usageChecker.memAllocLimit/mibBytes),
 		zap.Uint64("spike_limit_mib", usageChecker.memSpikeLimit/mibBytes),
-		zap.Duration("check_interval", cfg.CheckInterval))
+		zap.Duration("check_interval", cfg.CheckInterval),
+		zap.String("mode", string(mode)))
 
 	return &MemoryLimiter{
 		usageChecker:                 *usageChecker,
+		mode:                         mode,
 		memCheckWait:                 cfg.CheckInterval,
 		ticker:                       time.NewTicker(cfg.CheckInterval),
 		minGCIntervalWhenSoftLimited: cfg.MinGCIntervalWhenSoftLimited,
@@ -85,6 +96,8 @@ func NewMemoryLimiter(cfg *Config, logger *zap.Logger) (*MemoryLimiter, error) {
 		lastGCDone:                   time.Now(),
 		readMemStatsFn:               ReadMemStatsFn,
 		runGCFn:                      runtime.GC,
+		readHeapStatsFn:              readHeapStats,
+		memoryLimit:                  processMemoryLimit,
 		logger:                       logger,
 		mustRefuse:                   &atomic.Bool{},
 	}, nil
@@ -96,6 +109,9 @@ func (ml *MemoryLimiter) Start(_ context.Context, _ component.Host) error {
 
 	ml.refCounter++
 	if ml.refCounter == 1 {
+		if ml.mode == ModeGoMemLimit {
+			ml.setMemoryLimit()
+		}
 		ml.closed = make(chan struct{})
 		ml.waitGroup.Add(1)
 		go func() {
@@ -126,6 +142,9 @@ func (ml *MemoryLimiter) Shutdown(context.Context) error {
 		ml.ticker.Stop()
 		close(ml.closed)
 		ml.waitGroup.Wait()
+		if ml.mode == ModeGoMemLimit {
+			ml.memoryLimit.release(ml)
+		}
 	}
 	ml.refCounter--
 	return nil
@@ -174,6 +193,10 @@ func (ml *MemoryLimiter) doGCandReadMemStats() *runtime.MemStats {
 
 // CheckMemLimits inspects current memory usage against threshold and toggle mustRefuse when threshold is exceeded
 func (ml *MemoryLimiter) CheckMemLimits() {
+	if ml.mode == ModeGoMemLimit {
+		ml.checkHeapLimits()
+		return
+	}
 	ms := ml.readMemStats()
 
 	ml.logger.Debug("Currently used memory.", memstatToZapField(ms))
@@ -0,0 +1,49 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package reservation carries the memory reserved for a received request through the pipelines,
+// so that the components holding the request after the receiver returns keep the memory reserved.
+package reservation // import "go.opentelemetry.io/collector/internal/reservation"
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// Reservation is memory reserved for a request. It is released once all its holders released it.
+type Reservation struct {
+	refs    atomic.Int64
+	release func()
+}
+
+// New returns a Reservation held once, by the receiver, calling release once it is released by all its holders.
+func New(release func()) *Reservation {
+	r := &Reservation{release: release}
+	r.refs.Store(1)
+	return r
+}
+
+// Hold adds a holder to the reservation, the memory is not released until the holder calls Release.
+func (r *Reservation) Hold() {
+	r.refs.Add(1)
+}
+
+// Release removes a holder from the reservation, and releases the memory if it was the last one.
+func (r *Reservation) Release() {
+	if r.refs.Add(-1) == 0 {
+		r.release()
+	}
+}
+
+type reservationKey struct{}
+
+// NewContext returns a context carrying the reservation.
+func NewContext(ctx context.Context, r *Reservation) context.Context {
+	return context.WithValue(ctx, reservationKey{}, r)
+}
+
+// FromContext returns the reservation carried by ctx, or nil if there is none.
+func FromContext(ctx context.Context) *Reservation {
+	r, _ := ctx.Value(reservationKey{}).(*Reservation)
+	return r
+}
@@ -0,0 +1,31 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package reservation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReservation(t *testing.T) {
+	released := 0
+	r := New(func() { released++ })
+	r.Hold()
+	r.Hold()
+
+	r.Release()
+	r.Release()
+	assert.Equal(t, 0, released)
+	r.Release()
+	assert.Equal(t, 1, released)
+}
+
+func TestContext(t *testing.T) {
+	assert.Nil(t, FromContext(context.Background()))
+
+	r := New(func() {})
+	assert.Same(t, r, FromContext(NewContext(context.Background(), r)))
+}
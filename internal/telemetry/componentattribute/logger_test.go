@@ -117,6 +117,23 @@ func TestCore(t *testing.T) {
 				}, logAttributes)
 			},
 		},
+		{
+			name: "console + attribute set wrapper",
+			createLogger: func() (*zap.Logger, logRecorder) {
+				core, observed := createZapCore()
+				core = componentattribute.NewWrapperCoreWithAttributeSet(core, attribute.NewSet(), func(c zapcore.Core, set attribute.Set) zapcore.Core {
+					// Drop the logs of every component but filelog.
+					if v, ok := set.Value(componentattribute.ComponentIDKey); ok && v.AsString() == "filelog" {
+						return c
+					}
+					return zapcore.NewNopCore()
+				})
+				return zap.New(core), logRecorder{zapLogs: observed}
+			},
+			check: func(t *testing.T, rec logRecorder) {
+				checkZapLogs(t, rec.zapLogs)
+			},
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
@@ -13,7 +13,7 @@ import (
 
 // Interface for Zap cores that support setting and resetting a set of component attributes.
 //
-// There are three wrappers that implement this interface:
+// There are four wrappers that implement this interface:
 //
 //   - [NewConsoleCoreWithAttributes] injects component attributes as Zap fields.
 //
@@ -29,6 +29,11 @@ import (
 //     wrapper function when needed.
 //
 //     This is used when adding [zapcore.NewSamplerWithOptions] to our logger stack.
+//
+//   - [NewWrapperCoreWithAttributeSet] is like [NewWrapperCoreWithAttributes], but also passes the
+//     component attributes to the wrapper function.
+//
+//     This is used to apply per-component log levels.
 type coreWithAttributes interface {
 	zapcore.Core
 	withAttributeSet(attribute.Set) zapcore.Core
@@ -138,6 +143,31 @@ func (wcwa *wrapperCoreWithAttributes) withAttributeSet(attrs attribute.Set) zap
 	return NewWrapperCoreWithAttributes(tryWithAttributeSet(wcwa.from, attrs), wcwa.wrapper)
 }
 
+type attributeSetWrapperCore struct {
+	zapcore.Core
+	from    zapcore.Core
+	wrapper func(zapcore.Core, attribute.Set) zapcore.Core
+}
+
+var _ coreWithAttributes = (*attributeSetWrapperCore)(nil)
+
+// NewWrapperCoreWithAttributeSet applies a wrapper function to a core, like [NewWrapperCoreWithAttributes].
+// The wrapper function also receives the component attributes of the inner core, and is reapplied
+// with the new attributes when they are set.
+//
+// This is used to apply per-component log levels.
+func NewWrapperCoreWithAttributeSet(from zapcore.Core, attrs attribute.Set, wrapper func(zapcore.Core, attribute.Set) zapcore.Core) zapcore.Core {
+	return &attributeSetWrapperCore{
+		Core:    wrapper(from, attrs),
+		from:    from,
+		wrapper: wrapper,
+	}
+}
+
+func (aswc *attributeSetWrapperCore) withAttributeSet(attrs attribute.Set) zapcore.Core {
+	return NewWrapperCoreWithAttributeSet(tryWithAttributeSet(aswc.from, attrs), attrs, aswc.wrapper)
+}
+
 // ZapLoggerWithAttributes creates a Zap Logger with a new set of injected component attributes.
 func ZapLoggerWithAttributes(logger *zap.Logger, attrs attribute.Set) *zap.Logger {
 	return logger.WithOptions(zap.WrapCore(func(c zapcore.Core) zapcore.Core {
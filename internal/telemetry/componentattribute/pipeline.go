@@ -0,0 +1,146 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package componentattribute // import "go.opentelemetry.io/collector/internal/telemetry/componentattribute"
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+type pipelineContextKey struct{}
+
+// pipelineContext is carried by the context passed along with the data, from the receivers to the exporters.
+// It either carries the ID of the pipeline the data flows through, or collects the outcomes of the pipelines
+// a receiver passes the data to.
+type pipelineContext struct {
+	id         pipeline.ID
+	hasID      bool
+	onExported func(context.Context, error)
+	outcomes   *pipelineOutcomes
+}
+
+func pipelineContextFrom(ctx context.Context) *pipelineContext {
+	pc, _ := ctx.Value(pipelineContextKey{}).(*pipelineContext)
+	return pc
+}
+
+// ContextWithPipeline returns a copy of ctx carrying the ID of the pipeline the data flows through.
+// If onExported is not nil, it is called every time an exporter is done exporting the data, see PipelineExported.
+func ContextWithPipeline(ctx context.Context, id pipeline.ID, onExported func(context.Context, error)) context.Context {
+	return context.WithValue(ctx, pipelineContextKey{}, &pipelineContext{id: id, hasID: true, onExported: onExported})
+}
+
+// PipelineFromContext returns the ID of the pipeline carried by ctx, if any.
+func PipelineFromContext(ctx context.Context) (pipeline.ID, bool) {
+	if pc := pipelineContextFrom(ctx); pc != nil && pc.hasID {
+		return pc.id, true
+	}
+	return pipeline.ID{}, false
+}
+
+// PipelineExported reports that an exporter is done exporting the data passed along with ctx.
+func PipelineExported(ctx context.Context, err error) {
+	if pc := pipelineContextFrom(ctx); pc != nil && pc.onExported != nil {
+		pc.onExported(ctx, err)
+	}
+}
+
+// ContextWithMergedPipeline returns a copy of ctx carrying the pipeline of ctx1, for data merged from ctx1 and ctx2.
+// Both contexts must carry the same pipeline ID, the callbacks of both are called when the merged data is exported.
+func ContextWithMergedPipeline(ctx, ctx1, ctx2 context.Context) context.Context {
+	pc1, pc2 := pipelineContextFrom(ctx1), pipelineContextFrom(ctx2)
+	if pc1 == nil || !pc1.hasID {
+		return ctx
+	}
+	onExported := pc1.onExported
+	if pc2 != nil && pc2.onExported != nil {
+		onExported1, onExported2 := pc1.onExported, pc2.onExported
+		onExported = func(ctx context.Context, err error) {
+			if onExported1 != nil {
+				onExported1(ctx, err)
+			}
+			onExported2(ctx, err)
+		}
+	}
+	return ContextWithPipeline(ctx, pc1.id, onExported)
+}
+
+// PipelineOutcome is the outcome of passing data to a pipeline.
+type PipelineOutcome struct {
+	ID  pipeline.ID
+	Err error
+}
+
+type pipelineOutcomes struct {
+	mu       sync.Mutex
+	outcomes []PipelineOutcome
+}
+
+// ContextWithPipelineOutcomes returns a copy of ctx collecting the outcome of every pipeline the data is passed to.
+// Receivers use it so that their telemetry is reported for every pipeline. Pipelines further downstream, fed by
+// connectors, are not collected, since the data enters a pipeline with ContextWithPipeline.
+func ContextWithPipelineOutcomes(ctx context.Context) context.Context {
+	return context.WithValue(ctx, pipelineContextKey{}, &pipelineContext{outcomes: &pipelineOutcomes{}})
+}
+
+// RecordPipelineOutcome records the outcome of passing the data to the pipeline, if ctx collects them.
+func RecordPipelineOutcome(ctx context.Context, id pipeline.ID, err error) {
+	pc := pipelineContextFrom(ctx)
+	if pc == nil || pc.outcomes == nil {
+		return
+	}
+	pc.outcomes.mu.Lock()
+	pc.outcomes.outcomes = append(pc.outcomes.outcomes, PipelineOutcome{ID: id, Err: err})
+	pc.outcomes.mu.Unlock()
+}
+
+// PipelineOutcomesFromContext returns the outcomes recorded with ctx.
+func PipelineOutcomesFromContext(ctx context.Context) []PipelineOutcome {
+	pc := pipelineContextFrom(ctx)
+	if pc == nil || pc.outcomes == nil {
+		return nil
+	}
+	pc.outcomes.mu.Lock()
+	defer pc.outcomes.mu.Unlock()
+	return slices.Clone(pc.outcomes.outcomes)
+}
+
+// PipelineMeasurementOptions caches the measurement options of the metrics of a component, for every pipeline.
+type PipelineMeasurementOptions struct {
+	attrs      []attribute.KeyValue
+	noPipeline metric.MeasurementOption
+	pipelines  sync.Map // pipeline.ID -> metric.MeasurementOption
+}
+
+// NewPipelineMeasurementOptions returns the measurement options for the given attributes.
+func NewPipelineMeasurementOptions(attrs ...attribute.KeyValue) *PipelineMeasurementOptions {
+	return &PipelineMeasurementOptions{
+		attrs:      attrs,
+		noPipeline: metric.WithAttributeSet(attribute.NewSet(attrs...)),
+	}
+}
+
+// ForPipeline returns the measurement option with the attributes and the ID of the pipeline.
+func (o *PipelineMeasurementOptions) ForPipeline(id pipeline.ID) metric.MeasurementOption {
+	if opt, ok := o.pipelines.Load(id); ok {
+		return opt.(metric.MeasurementOption)
+	}
+	attrs := append(slices.Clone(o.attrs), attribute.String(PipelineIDKey, id.String()))
+	opt, _ := o.pipelines.LoadOrStore(id, metric.WithAttributeSet(attribute.NewSet(attrs...)))
+	return opt.(metric.MeasurementOption)
+}
+
+// ForContext returns the measurement option with the attributes and the ID of the pipeline carried by ctx, if any.
+func (o *PipelineMeasurementOptions) ForContext(ctx context.Context) metric.MeasurementOption {
+	if id, ok := PipelineFromContext(ctx); ok {
+		return o.ForPipeline(id)
+	}
+	return o.noPipeline
+}
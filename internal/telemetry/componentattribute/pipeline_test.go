@@ -0,0 +1,91 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package componentattribute_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"go.opentelemetry.io/collector/internal/telemetry/componentattribute"
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+func TestContextWithPipeline(t *testing.T) {
+	id := pipeline.NewIDWithName(pipeline.SignalTraces, "1")
+	_, ok := componentattribute.PipelineFromContext(context.Background())
+	assert.False(t, ok)
+	// Nothing to report without a pipeline.
+	componentattribute.PipelineExported(context.Background(), nil)
+
+	var exported []error
+	ctx := componentattribute.ContextWithPipeline(context.Background(), id, func(_ context.Context, err error) {
+		exported = append(exported, err)
+	})
+	got, ok := componentattribute.PipelineFromContext(ctx)
+	require.True(t, ok)
+	assert.Equal(t, id, got)
+
+	err := errors.New("failed")
+	componentattribute.PipelineExported(ctx, nil)
+	componentattribute.PipelineExported(ctx, err)
+	assert.Equal(t, []error{nil, err}, exported)
+}
+
+func TestContextWithMergedPipeline(t *testing.T) {
+	id := pipeline.NewIDWithName(pipeline.SignalTraces, "1")
+	exported := 0
+	onExported := func(context.Context, error) { exported++ }
+	ctx1 := componentattribute.ContextWithPipeline(context.Background(), id, onExported)
+	ctx2 := componentattribute.ContextWithPipeline(context.Background(), id, onExported)
+	ctx3 := componentattribute.ContextWithPipeline(context.Background(), id, nil)
+
+	merged := componentattribute.ContextWithMergedPipeline(context.Background(), ctx1, ctx2)
+	merged = componentattribute.ContextWithMergedPipeline(context.Background(), merged, ctx3)
+	got, ok := componentattribute.PipelineFromContext(merged)
+	require.True(t, ok)
+	assert.Equal(t, id, got)
+	componentattribute.PipelineExported(merged, nil)
+	assert.Equal(t, 2, exported)
+
+	// No pipeline to merge.
+	merged = componentattribute.ContextWithMergedPipeline(context.Background(), context.Background(), ctx1)
+	_, ok = componentattribute.PipelineFromContext(merged)
+	assert.False(t, ok)
+}
+
+func TestPipelineOutcomes(t *testing.T) {
+	id1 := pipeline.NewIDWithName(pipeline.SignalLogs, "1")
+	id2 := pipeline.NewIDWithName(pipeline.SignalLogs, "2")
+	err := errors.New("failed")
+
+	// Not collected.
+	componentattribute.RecordPipelineOutcome(context.Background(), id1, nil)
+	assert.Empty(t, componentattribute.PipelineOutcomesFromContext(context.Background()))
+
+	ctx := componentattribute.ContextWithPipelineOutcomes(context.Background())
+	componentattribute.RecordPipelineOutcome(ctx, id1, nil)
+	componentattribute.RecordPipelineOutcome(ctx, id2, err)
+	// Not collected once the data entered a pipeline.
+	componentattribute.RecordPipelineOutcome(componentattribute.ContextWithPipeline(ctx, id1, nil), id2, nil)
+	assert.Equal(t, []componentattribute.PipelineOutcome{{ID: id1}, {ID: id2, Err: err}}, componentattribute.PipelineOutcomesFromContext(ctx))
+}
+
+func TestPipelineMeasurementOptions(t *testing.T) {
+	id := pipeline.NewIDWithName(pipeline.SignalMetrics, "1")
+	opts := componentattribute.NewPipelineMeasurementOptions(attribute.String("exporter", "otlp"))
+
+	attrs := func(opt metric.MeasurementOption) attribute.Set {
+		return metric.NewAddConfig([]metric.AddOption{opt}).Attributes()
+	}
+	assert.Equal(t, attribute.NewSet(attribute.String("exporter", "otlp")), attrs(opts.ForContext(context.Background())))
+	withPipeline := attribute.NewSet(attribute.String("exporter", "otlp"), attribute.String(componentattribute.PipelineIDKey, "metrics/1"))
+	assert.Equal(t, withPipeline, attrs(opts.ForPipeline(id)))
+	assert.Equal(t, withPipeline, attrs(opts.ForContext(componentattribute.ContextWithPipeline(context.Background(), id, nil))))
+}
@@ -4,6 +4,8 @@
 package otelcol // import "go.opentelemetry.io/collector/otelcol"
 
 import (
+	"time"
+
 	"go.opentelemetry.io/collector/confmap"
 	"go.opentelemetry.io/collector/connector"
 	"go.opentelemetry.io/collector/exporter"
@@ -40,6 +42,9 @@ func unmarshal(v *confmap.Conf, factories Factories) (*configSettings, error) {
 		// TODO: Add a component.ServiceFactory to allow this to be defined by the Service.
 		Service: service.Config{
 			Telemetry: defaultTelConfig,
+			Startup: service.StartupConfig{
+				ExtensionsReadyTimeout: 30 * time.Second,
+			},
 		},
 	}
 
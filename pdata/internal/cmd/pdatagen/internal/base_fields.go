@@ -72,7 +72,7 @@ func (ms {{ .structName }}) {{ .fieldName }}() {{ .packageName }}{{ .returnType
 // Set{{ .fieldName }} replaces the {{ .lowerFieldName }} associated with this {{ .structName }}.
 func (ms {{ .structName }}) Set{{ .fieldName }}(v {{ .returnType }}) {
 	ms.{{ .stateAccessor }}.AssertMutable()
-	ms.{{ .origAccessor }}.{{ .originFieldName }} = v
+	ms.{{ .mutableOrigAccessor }}.{{ .originFieldName }} = v
 }`
 
 const accessorsPrimitiveSliceTemplate = `// {{ .fieldName }} returns the {{ .lowerFieldName }} associated with this {{ .structName }}.
@@ -337,7 +337,7 @@ func (sf *sliceField) templateFields(ms *messageValueStruct) map[string]any {
 			return ""
 		}(),
 		"returnType":         sf.returnSlice.getName(),
-		"origAccessor":       origAccessor(ms.packageName),
+		"origAccessor":       ms.origAccessor(true),
 		"stateAccessor":      stateAccessor(ms.packageName),
 		"isCommon":           usedByOtherDataTypes(sf.returnSlice.getPackageName()),
 		"isBaseStructCommon": usedByOtherDataTypes(ms.packageName),
@@ -402,7 +402,7 @@ func (mf *messageValueField) templateFields(ms *messageValueStruct) map[string]a
 			}
 			return ""
 		}(),
-		"origAccessor":  origAccessor(ms.packageName),
+		"origAccessor":  ms.origAccessor(true),
 		"stateAccessor": stateAccessor(ms.packageName),
 	}
 }
@@ -449,16 +449,17 @@ func (pf *primitiveField) GenerateCopyToValue(*messageValueStruct) string {
 
 func (pf *primitiveField) templateFields(ms *messageValueStruct) map[string]any {
 	return map[string]any{
-		"structName":       ms.getName(),
-		"packageName":      "",
-		"defaultVal":       pf.defaultVal,
-		"fieldName":        pf.fieldName,
-		"lowerFieldName":   strings.ToLower(pf.fieldName),
-		"testValue":        pf.testVal,
-		"returnType":       pf.returnType,
-		"origAccessor":     origAccessor(ms.packageName),
-		"stateAccessor":    stateAccessor(ms.packageName),
-		"originStructName": ms.originFullName,
+		"structName":          ms.getName(),
+		"packageName":         "",
+		"defaultVal":          pf.defaultVal,
+		"fieldName":           pf.fieldName,
+		"lowerFieldName":      strings.ToLower(pf.fieldName),
+		"testValue":           pf.testVal,
+		"returnType":          pf.returnType,
+		"origAccessor":        ms.origAccessor(false),
+		"mutableOrigAccessor": ms.origAccessor(true),
+		"stateAccessor":       stateAccessor(ms.packageName),
+		"originStructName":    ms.originFullName,
 		"originFieldName": func() string {
 			if pf.originFieldName == "" {
 				return pf.fieldName
@@ -591,7 +592,7 @@ func (psf *primitiveSliceField) templateFields(ms *messageValueStruct) map[strin
 		"fieldName":      psf.fieldName,
 		"lowerFieldName": strings.ToLower(psf.fieldName),
 		"testValue":      psf.testVal,
-		"origAccessor":   origAccessor(ms.packageName),
+		"origAccessor":   ms.origAccessor(true),
 		"stateAccessor":  stateAccessor(ms.packageName),
 	}
 }
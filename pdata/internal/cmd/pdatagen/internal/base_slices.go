@@ -17,6 +17,8 @@ type sliceOfPtrs struct {
 	structName  string
 	packageName string
 	element     *messageValueStruct
+	// copyOnWrite generates a slice whose elements may be shared with other slices, see internal.SharedNodes.
+	copyOnWrite bool
 }
 
 func (ss *sliceOfPtrs) getName() string {
@@ -49,6 +51,7 @@ func (ss *sliceOfPtrs) templateFields(packageInfo *PackageInfo) map[string]any {
 	return map[string]any{
 		"type":               "sliceOfPtrs",
 		"isCommon":           usedByOtherDataTypes(ss.packageName),
+		"copyOnWrite":        ss.copyOnWrite,
 		"structName":         ss.structName,
 		"elementName":        ss.element.structName,
 		"originName":         ss.element.originFullName,
@@ -22,12 +22,26 @@ type messageValueStruct struct {
 	description    string
 	originFullName string
 	fields         []baseField
+	// copyOnWrite generates a struct which may be shared with other slices, see internal.SharedNodes.
+	// It is copied by the accessors which may modify it.
+	copyOnWrite bool
 }
 
 func (ms *messageValueStruct) getName() string {
 	return ms.structName
 }
 
+// origAccessor returns the accessor of the orig of the struct, to modify it if mutable is set.
+func (ms *messageValueStruct) origAccessor(mutable bool) string {
+	if !ms.copyOnWrite {
+		return origAccessor(ms.packageName)
+	}
+	if mutable {
+		return "getMutableOrig()"
+	}
+	return "getOrig()"
+}
+
 func (ms *messageValueStruct) generate(packageInfo *PackageInfo) []byte {
 	var sb bytes.Buffer
 	if err := messageTemplate.Execute(&sb, ms.templateFields(packageInfo)); err != nil {
@@ -60,6 +74,7 @@ func (ms *messageValueStruct) templateFields(packageInfo *PackageInfo) map[strin
 		"originName":    ms.originFullName,
 		"description":   ms.description,
 		"isCommon":      usedByOtherDataTypes(ms.packageName),
+		"copyOnWrite":   ms.copyOnWrite,
 		"origAccessor":  origAccessor(ms.packageName),
 		"stateAccessor": stateAccessor(ms.packageName),
 		"packageName":   packageInfo.name,
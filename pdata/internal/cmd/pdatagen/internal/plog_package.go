@@ -38,14 +38,16 @@ var plog = &Package{
 }
 
 var resourceLogsSlice = &sliceOfPtrs{
-	structName: "ResourceLogsSlice",
-	element:    resourceLogs,
+	structName:  "ResourceLogsSlice",
+	element:     resourceLogs,
+	copyOnWrite: true,
 }
 
 var resourceLogs = &messageValueStruct{
 	structName:     "ResourceLogs",
 	description:    "// ResourceLogs is a collection of logs from a Resource.",
 	originFullName: "otlplogs.ResourceLogs",
+	copyOnWrite:    true,
 	fields: []baseField{
 		resourceField,
 		schemaURLField,
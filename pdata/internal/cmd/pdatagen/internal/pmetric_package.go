@@ -56,14 +56,16 @@ var pmetric = &Package{
 }
 
 var resourceMetricsSlice = &sliceOfPtrs{
-	structName: "ResourceMetricsSlice",
-	element:    resourceMetrics,
+	structName:  "ResourceMetricsSlice",
+	element:     resourceMetrics,
+	copyOnWrite: true,
 }
 
 var resourceMetrics = &messageValueStruct{
 	structName:     "ResourceMetrics",
 	description:    "// ResourceMetrics is a collection of metrics from a Resource.",
 	originFullName: "otlpmetrics.ResourceMetrics",
+	copyOnWrite:    true,
 	fields: []baseField{
 		resourceField,
 		schemaURLField,
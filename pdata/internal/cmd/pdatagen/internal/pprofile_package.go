@@ -53,14 +53,16 @@ var pprofile = &Package{
 }
 
 var resourceProfilesSlice = &sliceOfPtrs{
-	structName: "ResourceProfilesSlice",
-	element:    resourceProfiles,
+	structName:  "ResourceProfilesSlice",
+	element:     resourceProfiles,
+	copyOnWrite: true,
 }
 
 var resourceProfiles = &messageValueStruct{
 	structName:     "ResourceProfiles",
 	description:    "// ResourceProfiles is a collection of profiles from a Resource.",
 	originFullName: "otlpprofiles.ResourceProfiles",
+	copyOnWrite:    true,
 	fields: []baseField{
 		resourceField,
 		schemaURLField,
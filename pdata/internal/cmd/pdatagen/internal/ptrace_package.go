@@ -43,14 +43,16 @@ var ptrace = &Package{
 }
 
 var resourceSpansSlice = &sliceOfPtrs{
-	structName: "ResourceSpansSlice",
-	element:    resourceSpans,
+	structName:  "ResourceSpansSlice",
+	element:     resourceSpans,
+	copyOnWrite: true,
 }
 
 var resourceSpans = &messageValueStruct{
 	structName:     "ResourceSpans",
 	description:    "// ResourceSpans is a collection of spans from a Resource.",
 	originFullName: "otlptrace.ResourceSpans",
+	copyOnWrite:    true,
 	fields: []baseField{
 		resourceField,
 		schemaURLField,
@@ -0,0 +1,117 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal // import "go.opentelemetry.io/collector/pdata/internal"
+
+import (
+	otlpcommon "go.opentelemetry.io/collector/pdata/internal/data/protogen/common/v1"
+)
+
+// mapIndexMinLen is the number of entries from which the entries are indexed,
+// scanning fewer entries is faster than hashing the key.
+const mapIndexMinLen = 16
+
+// MapIndex holds the position of the entries of a Map by key. It is built on the first lookup, and
+// updated by the Map methods changing the entries. It is rebuilt when it finds that the entries
+// were resized or reallocated without it being updated, e.g. through another Map referring to
+// the same entries.
+//
+// The methods of a nil *MapIndex scan the entries.
+type MapIndex struct {
+	positions map[string]int
+	// duplicates is whether some keys are in several entries, which only happens with data
+	// unmarshaled from the wire.
+	duplicates bool
+	// first and length describe the entries when the positions were last updated.
+	first  *otlpcommon.KeyValue
+	length int
+}
+
+// Find returns the position of the first entry with the given key, or -1 if there is none.
+func (idx *MapIndex) Find(orig []otlpcommon.KeyValue, key string) int {
+	if idx == nil || len(orig) < mapIndexMinLen {
+		return scan(orig, key)
+	}
+	if !idx.isCurrent(orig) {
+		idx.rebuild(orig)
+	}
+	i, ok := idx.positions[key]
+	if !ok {
+		return -1
+	}
+	if orig[i].Key != key {
+		// The entries were changed in place.
+		idx.rebuild(orig)
+		if i, ok = idx.positions[key]; !ok {
+			return -1
+		}
+	}
+	return i
+}
+
+// Appended records that the last entry of orig was appended to the entries.
+func (idx *MapIndex) Appended(orig []otlpcommon.KeyValue) {
+	if idx == nil || idx.positions == nil {
+		return
+	}
+	if idx.length != len(orig)-1 {
+		idx.Reset()
+		return
+	}
+	last := len(orig) - 1
+	if _, ok := idx.positions[orig[last].Key]; !ok {
+		idx.positions[orig[last].Key] = last
+	}
+	idx.first, idx.length = &orig[0], len(orig)
+}
+
+// Removed records that the entry with the given key, at position i, was replaced by the last
+// entry, and that the entries were shortened by one.
+func (idx *MapIndex) Removed(orig []otlpcommon.KeyValue, key string, i int) {
+	if idx == nil || idx.positions == nil {
+		return
+	}
+	if idx.duplicates || idx.length != len(orig)+1 || len(orig) == 0 {
+		// Another entry may have the removed key, leave finding it to the next rebuild.
+		idx.Reset()
+		return
+	}
+	delete(idx.positions, key)
+	if i < len(orig) {
+		idx.positions[orig[i].Key] = i
+	}
+	idx.first, idx.length = &orig[0], len(orig)
+}
+
+// Reset drops the positions, they are rebuilt on the next lookup.
+func (idx *MapIndex) Reset() {
+	if idx == nil {
+		return
+	}
+	*idx = MapIndex{}
+}
+
+func (idx *MapIndex) isCurrent(orig []otlpcommon.KeyValue) bool {
+	return idx.positions != nil && idx.length == len(orig) && idx.first == &orig[0]
+}
+
+func (idx *MapIndex) rebuild(orig []otlpcommon.KeyValue) {
+	*idx = MapIndex{positions: make(map[string]int, len(orig))}
+	for i := range orig {
+		if _, ok := idx.positions[orig[i].Key]; ok {
+			idx.duplicates = true
+			continue
+		}
+		idx.positions[orig[i].Key] = i
+	}
+	idx.first, idx.length = &orig[0], len(orig)
+}
+
+func scan(orig []otlpcommon.KeyValue, key string) int {
+	for i := range orig {
+		if orig[i].Key == key {
+			return i
+		}
+	}
+	return -1
+}
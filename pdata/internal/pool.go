@@ -0,0 +1,52 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal // import "go.opentelemetry.io/collector/pdata/internal"
+
+import (
+	"sync"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// pool holds released values of type T, for the pooled unmarshaling to reuse.
+type pool[T any] struct {
+	p sync.Pool
+}
+
+// get returns a released value if any, or a new one.
+func (p *pool[T]) get() *T {
+	if v := p.p.Get(); v != nil {
+		return v.(*T)
+	}
+	return new(T)
+}
+
+// put returns v to the pool, v must have been reset to its zero value.
+func (p *pool[T]) put(v *T) {
+	p.p.Put(v)
+}
+
+// forEachField calls f for each field of the message in buf, with its number, its encoding
+// including the tag, and for the length-delimited fields their value.
+func forEachField(buf []byte, f func(num protowire.Number, typ protowire.Type, field, value []byte) error) error {
+	for len(buf) > 0 {
+		num, typ, n := protowire.ConsumeTag(buf)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		m := protowire.ConsumeFieldValue(num, typ, buf[n:])
+		if m < 0 {
+			return protowire.ParseError(m)
+		}
+		var value []byte
+		if typ == protowire.BytesType {
+			value, _ = protowire.ConsumeBytes(buf[n : n+m])
+		}
+		if err := f(num, typ, buf[:n+m], value); err != nil {
+			return err
+		}
+		buf = buf[n+m:]
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal // import "go.opentelemetry.io/collector/pdata/internal"
+
+import (
+	"google.golang.org/protobuf/encoding/protowire"
+
+	otlpcollectorlogs "go.opentelemetry.io/collector/pdata/internal/data/protogen/collector/logs/v1"
+	otlplogs "go.opentelemetry.io/collector/pdata/internal/data/protogen/logs/v1"
+)
+
+var (
+	logsRequestPool  pool[otlpcollectorlogs.ExportLogsServiceRequest]
+	resourceLogsPool pool[otlplogs.ResourceLogs]
+	scopeLogsPool    pool[otlplogs.ScopeLogs]
+	logRecordPool    pool[otlplogs.LogRecord]
+)
+
+// NewOrigLogs returns an empty request, reusing a released one if any.
+func NewOrigLogs() *otlpcollectorlogs.ExportLogsServiceRequest {
+	return logsRequestPool.get()
+}
+
+// ReleaseOrigLogs resets orig, its resources, scopes and log records, and returns them to the pools.
+// The resources in shared are not released, as other payloads use them.
+func ReleaseOrigLogs(orig *otlpcollectorlogs.ExportLogsServiceRequest, shared SharedNodes[otlplogs.ResourceLogs]) {
+	for _, rl := range orig.ResourceLogs {
+		if _, ok := shared[rl]; ok || rl == nil {
+			continue
+		}
+		for _, sl := range rl.ScopeLogs {
+			if sl == nil {
+				continue
+			}
+			for _, lr := range sl.LogRecords {
+				if lr == nil {
+					continue
+				}
+				*lr = otlplogs.LogRecord{}
+				logRecordPool.put(lr)
+			}
+			*sl = otlplogs.ScopeLogs{}
+			scopeLogsPool.put(sl)
+		}
+		*rl = otlplogs.ResourceLogs{}
+		resourceLogsPool.put(rl)
+	}
+	*orig = otlpcollectorlogs.ExportLogsServiceRequest{}
+	logsRequestPool.put(orig)
+}
+
+// LogsGRPCRequest decodes an OTLP/gRPC request with the gRPC proto codec, see UnmarshalOrigLogs.
+// It implements the protobuf message API of the codec.
+type LogsGRPCRequest struct {
+	Orig *otlpcollectorlogs.ExportLogsServiceRequest
+}
+
+func (r *LogsGRPCRequest) Reset() { *r.Orig = otlpcollectorlogs.ExportLogsServiceRequest{} }
+
+func (r *LogsGRPCRequest) String() string { return r.Orig.String() }
+
+func (*LogsGRPCRequest) ProtoMessage() {}
+
+func (r *LogsGRPCRequest) Size() int { return r.Orig.Size() }
+
+func (r *LogsGRPCRequest) Marshal() ([]byte, error) { return r.Orig.Marshal() }
+
+func (r *LogsGRPCRequest) Unmarshal(buf []byte) error { return UnmarshalOrigLogs(r.Orig, buf) }
+
+// UnmarshalOrigLogs merges the OTLP/protobuf request, or LogsData, in buf into orig.
+// The resources, scopes and log records are taken from the pools.
+func UnmarshalOrigLogs(orig *otlpcollectorlogs.ExportLogsServiceRequest, buf []byte) error {
+	return forEachField(buf, func(num protowire.Number, typ protowire.Type, field, value []byte) error {
+		if num != 1 || typ != protowire.BytesType {
+			return orig.Unmarshal(field)
+		}
+		rl := resourceLogsPool.get()
+		orig.ResourceLogs = append(orig.ResourceLogs, rl)
+		return unmarshalResourceLogs(rl, value)
+	})
+}
+
+func unmarshalResourceLogs(rl *otlplogs.ResourceLogs, buf []byte) error {
+	return forEachField(buf, func(num protowire.Number, typ protowire.Type, field, value []byte) error {
+		if num != 2 || typ != protowire.BytesType {
+			return rl.Unmarshal(field)
+		}
+		sl := scopeLogsPool.get()
+		rl.ScopeLogs = append(rl.ScopeLogs, sl)
+		return unmarshalScopeLogs(sl, value)
+	})
+}
+
+func unmarshalScopeLogs(sl *otlplogs.ScopeLogs, buf []byte) error {
+	return forEachField(buf, func(num protowire.Number, typ protowire.Type, field, value []byte) error {
+		if num != 2 || typ != protowire.BytesType {
+			return sl.Unmarshal(field)
+		}
+		lr := logRecordPool.get()
+		sl.LogRecords = append(sl.LogRecords, lr)
+		return lr.Unmarshal(value)
+	})
+}
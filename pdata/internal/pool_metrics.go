@@ -0,0 +1,104 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal // import "go.opentelemetry.io/collector/pdata/internal"
+
+import (
+	"google.golang.org/protobuf/encoding/protowire"
+
+	otlpcollectormetrics "go.opentelemetry.io/collector/pdata/internal/data/protogen/collector/metrics/v1"
+	otlpmetrics "go.opentelemetry.io/collector/pdata/internal/data/protogen/metrics/v1"
+)
+
+var (
+	metricsRequestPool  pool[otlpcollectormetrics.ExportMetricsServiceRequest]
+	resourceMetricsPool pool[otlpmetrics.ResourceMetrics]
+	scopeMetricsPool    pool[otlpmetrics.ScopeMetrics]
+	metricPool          pool[otlpmetrics.Metric]
+)
+
+// NewOrigMetrics returns an empty request, reusing a released one if any.
+func NewOrigMetrics() *otlpcollectormetrics.ExportMetricsServiceRequest {
+	return metricsRequestPool.get()
+}
+
+// ReleaseOrigMetrics resets orig, its resources, scopes and metrics, and returns them to the pools.
+// The resources in shared are not released, as other payloads use them.
+func ReleaseOrigMetrics(orig *otlpcollectormetrics.ExportMetricsServiceRequest, shared SharedNodes[otlpmetrics.ResourceMetrics]) {
+	for _, rm := range orig.ResourceMetrics {
+		if _, ok := shared[rm]; ok || rm == nil {
+			continue
+		}
+		for _, sm := range rm.ScopeMetrics {
+			if sm == nil {
+				continue
+			}
+			for _, m := range sm.Metrics {
+				if m == nil {
+					continue
+				}
+				*m = otlpmetrics.Metric{}
+				metricPool.put(m)
+			}
+			*sm = otlpmetrics.ScopeMetrics{}
+			scopeMetricsPool.put(sm)
+		}
+		*rm = otlpmetrics.ResourceMetrics{}
+		resourceMetricsPool.put(rm)
+	}
+	*orig = otlpcollectormetrics.ExportMetricsServiceRequest{}
+	metricsRequestPool.put(orig)
+}
+
+// MetricsGRPCRequest decodes an OTLP/gRPC request with the gRPC proto codec, see UnmarshalOrigMetrics.
+// It implements the protobuf message API of the codec.
+type MetricsGRPCRequest struct {
+	Orig *otlpcollectormetrics.ExportMetricsServiceRequest
+}
+
+func (r *MetricsGRPCRequest) Reset() { *r.Orig = otlpcollectormetrics.ExportMetricsServiceRequest{} }
+
+func (r *MetricsGRPCRequest) String() string { return r.Orig.String() }
+
+func (*MetricsGRPCRequest) ProtoMessage() {}
+
+func (r *MetricsGRPCRequest) Size() int { return r.Orig.Size() }
+
+func (r *MetricsGRPCRequest) Marshal() ([]byte, error) { return r.Orig.Marshal() }
+
+func (r *MetricsGRPCRequest) Unmarshal(buf []byte) error { return UnmarshalOrigMetrics(r.Orig, buf) }
+
+// UnmarshalOrigMetrics merges the OTLP/protobuf request, or MetricsData, in buf into orig.
+// The resources, scopes and metrics are taken from the pools.
+func UnmarshalOrigMetrics(orig *otlpcollectormetrics.ExportMetricsServiceRequest, buf []byte) error {
+	return forEachField(buf, func(num protowire.Number, typ protowire.Type, field, value []byte) error {
+		if num != 1 || typ != protowire.BytesType {
+			return orig.Unmarshal(field)
+		}
+		rm := resourceMetricsPool.get()
+		orig.ResourceMetrics = append(orig.ResourceMetrics, rm)
+		return unmarshalResourceMetrics(rm, value)
+	})
+}
+
+func unmarshalResourceMetrics(rm *otlpmetrics.ResourceMetrics, buf []byte) error {
+	return forEachField(buf, func(num protowire.Number, typ protowire.Type, field, value []byte) error {
+		if num != 2 || typ != protowire.BytesType {
+			return rm.Unmarshal(field)
+		}
+		sm := scopeMetricsPool.get()
+		rm.ScopeMetrics = append(rm.ScopeMetrics, sm)
+		return unmarshalScopeMetrics(sm, value)
+	})
+}
+
+func unmarshalScopeMetrics(sm *otlpmetrics.ScopeMetrics, buf []byte) error {
+	return forEachField(buf, func(num protowire.Number, typ protowire.Type, field, value []byte) error {
+		if num != 2 || typ != protowire.BytesType {
+			return sm.Unmarshal(field)
+		}
+		m := metricPool.get()
+		sm.Metrics = append(sm.Metrics, m)
+		return m.Unmarshal(value)
+	})
+}
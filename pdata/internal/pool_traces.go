@@ -0,0 +1,104 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal // import "go.opentelemetry.io/collector/pdata/internal"
+
+import (
+	"google.golang.org/protobuf/encoding/protowire"
+
+	otlpcollectortrace "go.opentelemetry.io/collector/pdata/internal/data/protogen/collector/trace/v1"
+	otlptrace "go.opentelemetry.io/collector/pdata/internal/data/protogen/trace/v1"
+)
+
+var (
+	tracesRequestPool pool[otlpcollectortrace.ExportTraceServiceRequest]
+	resourceSpansPool pool[otlptrace.ResourceSpans]
+	scopeSpansPool    pool[otlptrace.ScopeSpans]
+	spanPool          pool[otlptrace.Span]
+)
+
+// NewOrigTraces returns an empty request, reusing a released one if any.
+func NewOrigTraces() *otlpcollectortrace.ExportTraceServiceRequest {
+	return tracesRequestPool.get()
+}
+
+// ReleaseOrigTraces resets orig, its resources, scopes and spans, and returns them to the pools.
+// The resources in shared are not released, as other payloads use them.
+func ReleaseOrigTraces(orig *otlpcollectortrace.ExportTraceServiceRequest, shared SharedNodes[otlptrace.ResourceSpans]) {
+	for _, rs := range orig.ResourceSpans {
+		if _, ok := shared[rs]; ok || rs == nil {
+			continue
+		}
+		for _, ss := range rs.ScopeSpans {
+			if ss == nil {
+				continue
+			}
+			for _, span := range ss.Spans {
+				if span == nil {
+					continue
+				}
+				*span = otlptrace.Span{}
+				spanPool.put(span)
+			}
+			*ss = otlptrace.ScopeSpans{}
+			scopeSpansPool.put(ss)
+		}
+		*rs = otlptrace.ResourceSpans{}
+		resourceSpansPool.put(rs)
+	}
+	*orig = otlpcollectortrace.ExportTraceServiceRequest{}
+	tracesRequestPool.put(orig)
+}
+
+// TracesGRPCRequest decodes an OTLP/gRPC request with the gRPC proto codec, see UnmarshalOrigTraces.
+// It implements the protobuf message API of the codec.
+type TracesGRPCRequest struct {
+	Orig *otlpcollectortrace.ExportTraceServiceRequest
+}
+
+func (r *TracesGRPCRequest) Reset() { *r.Orig = otlpcollectortrace.ExportTraceServiceRequest{} }
+
+func (r *TracesGRPCRequest) String() string { return r.Orig.String() }
+
+func (*TracesGRPCRequest) ProtoMessage() {}
+
+func (r *TracesGRPCRequest) Size() int { return r.Orig.Size() }
+
+func (r *TracesGRPCRequest) Marshal() ([]byte, error) { return r.Orig.Marshal() }
+
+func (r *TracesGRPCRequest) Unmarshal(buf []byte) error { return UnmarshalOrigTraces(r.Orig, buf) }
+
+// UnmarshalOrigTraces merges the OTLP/protobuf request, or TracesData, in buf into orig.
+// The resources, scopes and spans are taken from the pools.
+func UnmarshalOrigTraces(orig *otlpcollectortrace.ExportTraceServiceRequest, buf []byte) error {
+	return forEachField(buf, func(num protowire.Number, typ protowire.Type, field, value []byte) error {
+		if num != 1 || typ != protowire.BytesType {
+			return orig.Unmarshal(field)
+		}
+		rs := resourceSpansPool.get()
+		orig.ResourceSpans = append(orig.ResourceSpans, rs)
+		return unmarshalResourceSpans(rs, value)
+	})
+}
+
+func unmarshalResourceSpans(rs *otlptrace.ResourceSpans, buf []byte) error {
+	return forEachField(buf, func(num protowire.Number, typ protowire.Type, field, value []byte) error {
+		if num != 2 || typ != protowire.BytesType {
+			return rs.Unmarshal(field)
+		}
+		ss := scopeSpansPool.get()
+		rs.ScopeSpans = append(rs.ScopeSpans, ss)
+		return unmarshalScopeSpans(ss, value)
+	})
+}
+
+func unmarshalScopeSpans(ss *otlptrace.ScopeSpans, buf []byte) error {
+	return forEachField(buf, func(num protowire.Number, typ protowire.Type, field, value []byte) error {
+		if num != 2 || typ != protowire.BytesType {
+			return ss.Unmarshal(field)
+		}
+		span := spanPool.get()
+		ss.Spans = append(ss.Spans, span)
+		return span.Unmarshal(value)
+	})
+}
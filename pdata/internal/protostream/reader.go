@@ -0,0 +1,124 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package protostream reads the fields of a protobuf message one at a time from an io.Reader.
+package protostream // import "go.opentelemetry.io/collector/pdata/internal/protostream"
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+)
+
+// The wire types of the protobuf encoding.
+const (
+	wireVarint  = 0
+	wireFixed64 = 1
+	wireBytes   = 2
+	wireFixed32 = 5
+)
+
+// Reader reads the length-delimited fields of a protobuf message, e.g. the embedded messages of
+// a repeated field, from an io.Reader one at a time. Only the field being read is held in memory.
+type Reader struct {
+	r   *bufio.Reader
+	buf bytes.Buffer
+}
+
+// NewReader creates a Reader of the message read from r.
+func NewReader(r io.Reader) *Reader {
+	return &Reader{r: bufio.NewReader(r)}
+}
+
+// Next returns the value of the next field with the given number, skipping the other fields.
+// It returns io.EOF at the end of the message. The returned bytes are only valid until the next call.
+func (r *Reader) Next(num uint64) ([]byte, error) {
+	for {
+		tag, err := binary.ReadUvarint(r.r)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil, io.EOF
+			}
+			return nil, fmt.Errorf("failed to read field tag: %w", err)
+		}
+		fieldNum, wireType := tag>>3, tag&0x7
+		if fieldNum == 0 {
+			return nil, errors.New("invalid field number 0")
+		}
+
+		switch wireType {
+		case wireVarint:
+			_, err = binary.ReadUvarint(r.r)
+		case wireFixed64:
+			_, err = r.r.Discard(8)
+		case wireFixed32:
+			_, err = r.r.Discard(4)
+		case wireBytes:
+			if fieldNum != num {
+				err = r.skipBytes()
+				break
+			}
+			var value []byte
+			if value, err = r.readBytes(); err == nil {
+				return value, nil
+			}
+		default:
+			return nil, fmt.Errorf("field %d: unsupported wire type %d", fieldNum, wireType)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("field %d: %w", fieldNum, unexpectedEOF(err))
+		}
+		if fieldNum == num {
+			return nil, fmt.Errorf("field %d: unexpected wire type %d", fieldNum, wireType)
+		}
+	}
+}
+
+func (r *Reader) readLength() (int64, error) {
+	n, err := binary.ReadUvarint(r.r)
+	if err != nil {
+		return 0, err
+	}
+	if n > math.MaxInt32 {
+		return 0, fmt.Errorf("length %d too large", n)
+	}
+	return int64(n), nil
+}
+
+// readBytes reads a length-delimited value. The buffer grows as the value is read, so that
+// an invalid length does not cause a large allocation.
+func (r *Reader) readBytes() ([]byte, error) {
+	n, err := r.readLength()
+	if err != nil {
+		return nil, err
+	}
+	r.buf.Reset()
+	if _, err = r.buf.ReadFrom(io.LimitReader(r.r, n)); err != nil {
+		return nil, err
+	}
+	if int64(r.buf.Len()) < n {
+		return nil, io.ErrUnexpectedEOF
+	}
+	return r.buf.Bytes(), nil
+}
+
+func (r *Reader) skipBytes() error {
+	n, err := r.readLength()
+	if err != nil {
+		return err
+	}
+	_, err = r.r.Discard(int(n))
+	return err
+}
+
+// unexpectedEOF reports reaching the end of the message in the middle of a field as io.ErrUnexpectedEOF.
+func unexpectedEOF(err error) error {
+	if errors.Is(err, io.EOF) {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
@@ -0,0 +1,102 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package protostream
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+func appendBytesField(buf []byte, num protowire.Number, v string) []byte {
+	buf = protowire.AppendTag(buf, num, protowire.BytesType)
+	return protowire.AppendString(buf, v)
+}
+
+func TestReaderNext(t *testing.T) {
+	var buf []byte
+	buf = appendBytesField(buf, 1, "first")
+	buf = protowire.AppendTag(buf, 2, protowire.VarintType)
+	buf = protowire.AppendVarint(buf, 300)
+	buf = protowire.AppendTag(buf, 3, protowire.Fixed64Type)
+	buf = protowire.AppendFixed64(buf, 1)
+	buf = protowire.AppendTag(buf, 4, protowire.Fixed32Type)
+	buf = protowire.AppendFixed32(buf, 1)
+	buf = appendBytesField(buf, 5, "skipped")
+	buf = appendBytesField(buf, 1, "")
+	buf = appendBytesField(buf, 1, "third")
+
+	r := NewReader(bytes.NewReader(buf))
+	for _, want := range []string{"first", "", "third"} {
+		got, err := r.Next(1)
+		require.NoError(t, err)
+		assert.Equal(t, want, string(got))
+	}
+	_, err := r.Next(1)
+	require.ErrorIs(t, err, io.EOF)
+	_, err = r.Next(1)
+	require.ErrorIs(t, err, io.EOF)
+
+	_, err = NewReader(bytes.NewReader(nil)).Next(1)
+	require.ErrorIs(t, err, io.EOF)
+}
+
+func TestReaderNextErrors(t *testing.T) {
+	valid := appendBytesField(nil, 1, "value")
+	for _, tt := range []struct {
+		name    string
+		buf     []byte
+		wantErr string
+	}{
+		{
+			name:    "truncated-value",
+			buf:     valid[:len(valid)-1],
+			wantErr: "field 1: " + io.ErrUnexpectedEOF.Error(),
+		},
+		{
+			name:    "truncated-skipped-value",
+			buf:     appendBytesField(nil, 2, "value")[:4],
+			wantErr: "field 2: " + io.ErrUnexpectedEOF.Error(),
+		},
+		{
+			name:    "truncated-tag",
+			buf:     []byte{0x80},
+			wantErr: "failed to read field tag: " + io.ErrUnexpectedEOF.Error(),
+		},
+		{
+			name:    "truncated-fixed64",
+			buf:     protowire.AppendTag(nil, 2, protowire.Fixed64Type),
+			wantErr: "field 2: " + io.ErrUnexpectedEOF.Error(),
+		},
+		{
+			name:    "unexpected-wire-type",
+			buf:     protowire.AppendVarint(protowire.AppendTag(nil, 1, protowire.VarintType), 1),
+			wantErr: "field 1: unexpected wire type 0",
+		},
+		{
+			name:    "unsupported-wire-type",
+			buf:     protowire.AppendTag(nil, 2, protowire.StartGroupType),
+			wantErr: "field 2: unsupported wire type 3",
+		},
+		{
+			name:    "field-zero",
+			buf:     protowire.AppendVarint(protowire.AppendTag(nil, 0, protowire.VarintType), 1),
+			wantErr: "invalid field number 0",
+		},
+		{
+			name:    "length-too-large",
+			buf:     protowire.AppendVarint(protowire.AppendTag(nil, 1, protowire.BytesType), 1<<40),
+			wantErr: "field 1: length 1099511627776 too large",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewReader(bytes.NewReader(tt.buf)).Next(1)
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
@@ -3,6 +3,8 @@
 
 package internal // import "go.opentelemetry.io/collector/pdata/internal"
 
+import "slices"
+
 // State defines an ownership state of pmetric.Metrics, plog.Logs or ptrace.Traces.
 type State int32
 
@@ -12,11 +14,74 @@ const (
 
 	// StateReadOnly indicates that the data is shared with other consumers.
 	StateReadOnly
+
+	// StateReleased indicates that the data was released, and its memory may be reused.
+	StateReleased
 )
 
 // AssertMutable panics if the state is not StateMutable.
 func (state *State) AssertMutable() {
-	if *state != StateMutable {
+	switch *state {
+	case StateMutable:
+	case StateReleased:
+		panic("invalid access to released data")
+	default:
 		panic("invalid access to shared data")
 	}
 }
+
+// readOnlyState is the state of the read-only views of the nodes of a payload, it is never changed.
+var readOnlyState = StateReadOnly
+
+// ReadOnlyState returns the state of the read-only views of the nodes of a payload, e.g. the views
+// of the shared nodes, which are read-only regardless of the state of the payload.
+func ReadOnlyState() *State {
+	return &readOnlyState
+}
+
+// SharedNodes is the set of the top-level nodes of a payload, e.g. the ResourceSpans of ptrace.Traces,
+// which are shared with other payloads. Unlike StateReadOnly which applies to a whole payload, it allows
+// the payload to copy only the nodes which are modified: a shared node is read-only, and is replaced by
+// a copy the first time it may be modified from a mutable payload, see GetMutable. Each shared node is
+// mapped to its copy, or to nil if it was not copied yet.
+type SharedNodes[T any] map[*T]*T
+
+// NewSharedNodes returns the set of the given nodes.
+func NewSharedNodes[T any](nodes []*T) SharedNodes[T] {
+	shared := make(SharedNodes[T], len(nodes))
+	for _, node := range nodes {
+		shared[node] = nil
+	}
+	return shared
+}
+
+// Get returns the copy of the node if it was shared and was copied since, otherwise the node.
+func (s SharedNodes[T]) Get(node *T) *T {
+	if cp := s[node]; cp != nil {
+		return cp
+	}
+	return node
+}
+
+// GetMutable returns the node to modify. If it is shared and the state is mutable, the node is copied
+// with copyNode, and replaced by its copy in nodes, the first time only: the copy is returned afterward.
+func (s SharedNodes[T]) GetMutable(node *T, nodes *[]*T, state *State, copyNode func(dest, src *T)) *T {
+	cp, ok := s[node]
+	if !ok {
+		return node
+	}
+	if cp != nil {
+		return cp
+	}
+	if *state != StateMutable {
+		return node
+	}
+	cp = new(T)
+	copyNode(cp, node)
+	s[node] = cp
+	// A shared node is at most once in its slice.
+	if i := slices.Index(*nodes, node); i >= 0 {
+		(*nodes)[i] = cp
+	}
+	return cp
+}
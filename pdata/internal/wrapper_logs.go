@@ -9,8 +9,9 @@ import (
 )
 
 type Logs struct {
-	orig  *otlpcollectorlog.ExportLogsServiceRequest
-	state *State
+	orig   *otlpcollectorlog.ExportLogsServiceRequest
+	state  *State
+	shared SharedNodes[otlplogs.ResourceLogs]
 }
 
 func GetOrigLogs(ms Logs) *otlpcollectorlog.ExportLogsServiceRequest {
@@ -25,8 +26,12 @@ func SetLogsState(ms Logs, state State) {
 	*ms.state = state
 }
 
-func NewLogs(orig *otlpcollectorlog.ExportLogsServiceRequest, state *State) Logs {
-	return Logs{orig: orig, state: state}
+func GetLogsSharedNodes(ms Logs) SharedNodes[otlplogs.ResourceLogs] {
+	return ms.shared
+}
+
+func NewLogs(orig *otlpcollectorlog.ExportLogsServiceRequest, state *State, shared SharedNodes[otlplogs.ResourceLogs]) Logs {
+	return Logs{orig: orig, state: state, shared: shared}
 }
 
 // LogsToProto internal helper to convert Logs to protobuf representation.
@@ -42,5 +47,5 @@ func LogsFromProto(orig otlplogs.LogsData) Logs {
 	state := StateMutable
 	return NewLogs(&otlpcollectorlog.ExportLogsServiceRequest{
 		ResourceLogs: orig.ResourceLogs,
-	}, &state)
+	}, &state, nil)
 }
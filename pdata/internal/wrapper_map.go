@@ -10,6 +10,8 @@ import (
 type Map struct {
 	orig  *[]otlpcommon.KeyValue
 	state *State
+	// index is nil unless the Map was created with NewIndexedMap.
+	index *MapIndex
 }
 
 func GetOrigMap(ms Map) *[]otlpcommon.KeyValue {
@@ -20,10 +22,18 @@ func GetMapState(ms Map) *State {
 	return ms.state
 }
 
+func GetMapIndex(ms Map) *MapIndex {
+	return ms.index
+}
+
 func NewMap(orig *[]otlpcommon.KeyValue, state *State) Map {
 	return Map{orig: orig, state: state}
 }
 
+func NewIndexedMap(orig *[]otlpcommon.KeyValue, state *State) Map {
+	return Map{orig: orig, state: state, index: &MapIndex{}}
+}
+
 func GenerateTestMap() Map {
 	var orig []otlpcommon.KeyValue
 	state := StateMutable
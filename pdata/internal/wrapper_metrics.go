@@ -9,8 +9,9 @@ import (
 )
 
 type Metrics struct {
-	orig  *otlpcollectormetrics.ExportMetricsServiceRequest
-	state *State
+	orig   *otlpcollectormetrics.ExportMetricsServiceRequest
+	state  *State
+	shared SharedNodes[otlpmetrics.ResourceMetrics]
 }
 
 func GetOrigMetrics(ms Metrics) *otlpcollectormetrics.ExportMetricsServiceRequest {
@@ -25,8 +26,12 @@ func SetMetricsState(ms Metrics, state State) {
 	*ms.state = state
 }
 
-func NewMetrics(orig *otlpcollectormetrics.ExportMetricsServiceRequest, state *State) Metrics {
-	return Metrics{orig: orig, state: state}
+func GetMetricsSharedNodes(ms Metrics) SharedNodes[otlpmetrics.ResourceMetrics] {
+	return ms.shared
+}
+
+func NewMetrics(orig *otlpcollectormetrics.ExportMetricsServiceRequest, state *State, shared SharedNodes[otlpmetrics.ResourceMetrics]) Metrics {
+	return Metrics{orig: orig, state: state, shared: shared}
 }
 
 // MetricsToProto internal helper to convert Metrics to protobuf representation.
@@ -42,5 +47,5 @@ func MetricsFromProto(orig otlpmetrics.MetricsData) Metrics {
 	state := StateMutable
 	return NewMetrics(&otlpcollectormetrics.ExportMetricsServiceRequest{
 		ResourceMetrics: orig.ResourceMetrics,
-	}, &state)
+	}, &state, nil)
 }
@@ -9,8 +9,9 @@ import (
 )
 
 type Profiles struct {
-	orig  *otlpcollectorprofile.ExportProfilesServiceRequest
-	state *State
+	orig   *otlpcollectorprofile.ExportProfilesServiceRequest
+	state  *State
+	shared SharedNodes[otlpprofile.ResourceProfiles]
 }
 
 func GetOrigProfiles(ms Profiles) *otlpcollectorprofile.ExportProfilesServiceRequest {
@@ -25,8 +26,12 @@ func SetProfilesState(ms Profiles, state State) {
 	*ms.state = state
 }
 
-func NewProfiles(orig *otlpcollectorprofile.ExportProfilesServiceRequest, state *State) Profiles {
-	return Profiles{orig: orig, state: state}
+func GetProfilesSharedNodes(ms Profiles) SharedNodes[otlpprofile.ResourceProfiles] {
+	return ms.shared
+}
+
+func NewProfiles(orig *otlpcollectorprofile.ExportProfilesServiceRequest, state *State, shared SharedNodes[otlpprofile.ResourceProfiles]) Profiles {
+	return Profiles{orig: orig, state: state, shared: shared}
 }
 
 // ProfilesToProto internal helper to convert Profiles to protobuf representation.
@@ -42,5 +47,5 @@ func ProfilesFromProto(orig otlpprofile.ProfilesData) Profiles {
 	state := StateMutable
 	return NewProfiles(&otlpcollectorprofile.ExportProfilesServiceRequest{
 		ResourceProfiles: orig.ResourceProfiles,
-	}, &state)
+	}, &state, nil)
 }
@@ -9,8 +9,9 @@ import (
 )
 
 type Traces struct {
-	orig  *otlpcollectortrace.ExportTraceServiceRequest
-	state *State
+	orig   *otlpcollectortrace.ExportTraceServiceRequest
+	state  *State
+	shared SharedNodes[otlptrace.ResourceSpans]
 }
 
 func GetOrigTraces(ms Traces) *otlpcollectortrace.ExportTraceServiceRequest {
@@ -25,8 +26,12 @@ func SetTracesState(ms Traces, state State) {
 	*ms.state = state
 }
 
-func NewTraces(orig *otlpcollectortrace.ExportTraceServiceRequest, state *State) Traces {
-	return Traces{orig: orig, state: state}
+func GetTracesSharedNodes(ms Traces) SharedNodes[otlptrace.ResourceSpans] {
+	return ms.shared
+}
+
+func NewTraces(orig *otlpcollectortrace.ExportTraceServiceRequest, state *State, shared SharedNodes[otlptrace.ResourceSpans]) Traces {
+	return Traces{orig: orig, state: state, shared: shared}
 }
 
 // TracesToProto internal helper to convert Traces to protobuf representation.
@@ -42,5 +47,5 @@ func TracesFromProto(orig otlptrace.TracesData) Traces {
 	state := StateMutable
 	return NewTraces(&otlpcollectortrace.ExportTraceServiceRequest{
 		ResourceSpans: orig.ResourceSpans,
-	}, &state)
+	}, &state, nil)
 }
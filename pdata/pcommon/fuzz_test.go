@@ -0,0 +1,113 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pcommon
+
+import (
+	"encoding/binary"
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fuzzMap builds a map from fuzzing data. Keys are drawn from a small set so that maps built
+// from different data are often equal.
+type fuzzMap struct {
+	data []byte
+}
+
+func (f *fuzzMap) next() byte {
+	if len(f.data) == 0 {
+		return 0
+	}
+	b := f.data[0]
+	f.data = f.data[1:]
+	return b
+}
+
+func (f *fuzzMap) uint64() uint64 {
+	var buf [8]byte
+	for i := range buf {
+		buf[i] = f.next()
+	}
+	return binary.LittleEndian.Uint64(buf[:])
+}
+
+func (f *fuzzMap) str() string {
+	return string([]byte{'a' + f.next()%3})
+}
+
+func (f *fuzzMap) value(v Value, depth int) {
+	switch f.next() % 8 {
+	case 0:
+	case 1:
+		v.SetStr(f.str())
+	case 2:
+		v.SetBool(f.next()%2 == 0)
+	case 3:
+		v.SetInt(int64(f.next() % 3))
+	case 4:
+		switch f.next() % 4 {
+		case 0:
+			v.SetDouble(math.Copysign(0, -1))
+		case 1:
+			v.SetDouble(math.Float64frombits(f.uint64()))
+		default:
+			v.SetDouble(float64(f.next() % 3))
+		}
+	case 5:
+		v.SetEmptyBytes().FromRaw([]byte(f.str()))
+	case 6:
+		if depth > 0 {
+			f.entries(v.SetEmptyMap(), depth-1, false)
+		}
+	case 7:
+		if depth > 0 {
+			s := v.SetEmptySlice()
+			for n := f.next() % 3; n > 0; n-- {
+				f.value(s.AppendEmpty(), depth-1)
+			}
+		}
+	}
+}
+
+// entries fills m, in the reverse order of the data if reversed is set.
+func (f *fuzzMap) entries(m Map, depth int, reversed bool) {
+	tmp := NewMap()
+	for n := f.next() % 5; n > 0; n-- {
+		f.value(tmp.PutEmpty(f.str()), depth)
+	}
+	keys := make([]string, 0, tmp.Len())
+	for k := range tmp.All() {
+		keys = append(keys, k)
+	}
+	if reversed {
+		slices.Reverse(keys)
+	}
+	for _, k := range keys {
+		v, _ := tmp.Get(k)
+		v.CopyTo(m.PutEmpty(k))
+	}
+}
+
+func newFuzzMap(data []byte, reversed bool) Map {
+	m := NewMap()
+	(&fuzzMap{data: data}).entries(m, 2, reversed)
+	return m
+}
+
+func FuzzMapHash(f *testing.F) {
+	f.Add([]byte{4, 0, 1, 1, 3, 2, 1, 6, 2, 1, 1, 1, 1}, []byte{4, 1, 3, 2, 0, 1, 1, 6, 2, 1, 1, 1, 1})
+	f.Add([]byte{1, 0, 4, 0}, []byte{1, 0, 4, 2, 0})
+	f.Fuzz(func(t *testing.T, data1, data2 []byte) {
+		m1 := newFuzzMap(data1, false)
+		require.Equal(t, m1.Hash(), newFuzzMap(data1, true).Hash(), "the order of the entries changed the hash")
+
+		m2 := newFuzzMap(data2, false)
+		if m1.Equal(m2) {
+			require.Equal(t, m1.Hash(), m2.Hash(), "equal maps %v and %v have different hashes", m1.AsRaw(), m2.AsRaw())
+		}
+	})
+}
@@ -0,0 +1,128 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pcommon // import "go.opentelemetry.io/collector/pdata/pcommon"
+
+import (
+	"math"
+)
+
+// The parameters of the 64-bit FNV-1a hash.
+const (
+	fnvOffset64 = 14695981039346656037
+	fnvPrime64  = 1099511628211
+)
+
+// hasher computes the FNV-1a hash of the data written to it. Unlike hash/fnv, it does not allocate.
+type hasher uint64
+
+func newHasher() hasher {
+	return fnvOffset64
+}
+
+func (h *hasher) writeByte(b byte) {
+	*h = (*h ^ hasher(b)) * fnvPrime64
+}
+
+func (h *hasher) writeUint64(v uint64) {
+	for i := 0; i < 8; i++ {
+		h.writeByte(byte(v))
+		v >>= 8
+	}
+}
+
+// writeString writes the length of s before s, so that consecutive strings cannot be confused.
+func (h *hasher) writeString(s string) {
+	h.writeUint64(uint64(len(s)))
+	for i := 0; i < len(s); i++ {
+		h.writeByte(s[i])
+	}
+}
+
+func (h *hasher) writeBytes(b []byte) {
+	h.writeUint64(uint64(len(b)))
+	for _, c := range b {
+		h.writeByte(c)
+	}
+}
+
+func (h *hasher) writeValue(v Value) {
+	h.writeByte(byte(v.Type()))
+	switch v.Type() {
+	case ValueTypeStr:
+		h.writeString(v.Str())
+	case ValueTypeBool:
+		if v.Bool() {
+			h.writeByte(1)
+		} else {
+			h.writeByte(0)
+		}
+	case ValueTypeInt:
+		h.writeUint64(uint64(v.Int())) //nolint:gosec // the bits of the value are hashed
+	case ValueTypeDouble:
+		d := v.Double()
+		if d == 0 {
+			// -0 and 0 are equal, but have different bits.
+			d = 0
+		}
+		h.writeUint64(math.Float64bits(d))
+	case ValueTypeBytes:
+		h.writeBytes(v.getOrig().GetBytesValue())
+	case ValueTypeMap:
+		h.writeUint64(v.Map().Hash())
+	case ValueTypeSlice:
+		s := v.Slice()
+		h.writeUint64(uint64(s.Len()))
+		for i := 0; i < s.Len(); i++ {
+			h.writeValue(s.At(i))
+		}
+	}
+}
+
+// sum returns the hash, mixed so that all of its bits depend on all the data written,
+// which FNV-1a does not ensure for the high bits.
+func (h hasher) sum() uint64 {
+	x := uint64(h)
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
+
+// Hash returns a fingerprint of the map, for instance to group data by attributes.
+// Maps that are equal per Equal have the same hash, regardless of the order of their entries.
+// The type of the values is part of the hash: an Int and a Double holding the same number, or
+// an Str holding its text, have different hashes. The hash is stable across processes and releases.
+func (m Map) Hash() uint64 {
+	// The hashes of the entries are summed, so that the result does not depend on their order.
+	var sum uint64
+	orig := *m.getOrig()
+	for i := range orig {
+		h := newHasher()
+		h.writeString(orig[i].Key)
+		h.writeValue(newValue(&orig[i].Value, m.getState()))
+		sum += h.sum()
+	}
+	h := newHasher()
+	h.writeUint64(uint64(len(orig)))
+	h.writeUint64(sum)
+	return h.sum()
+}
+
+// Hash returns a fingerprint of the resource, computed from its attributes as Map.Hash.
+// The dropped attributes count is not part of the hash.
+func (ms Resource) Hash() uint64 {
+	return ms.Attributes().Hash()
+}
+
+// Hash returns a fingerprint of the instrumentation scope, computed from its name, version
+// and attributes. The dropped attributes count is not part of the hash.
+func (ms InstrumentationScope) Hash() uint64 {
+	h := newHasher()
+	h.writeString(ms.Name())
+	h.writeString(ms.Version())
+	h.writeUint64(ms.Attributes().Hash())
+	return h.sum()
+}
@@ -0,0 +1,139 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pcommon
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMapHash(t *testing.T) {
+	raw := map[string]any{
+		"str":    "value",
+		"int":    int64(1),
+		"double": 1.5,
+		"bool":   true,
+		"bytes":  []byte{1, 2},
+		"map":    map[string]any{"a": "b", "c": []any{int64(1), "d"}},
+		"slice":  []any{"e", 2.5, map[string]any{}},
+		"empty":  nil,
+	}
+	m := NewMap()
+	require.NoError(t, m.FromRaw(raw))
+
+	// The hash does not depend on the order of the entries.
+	reordered := NewMap()
+	keys := []string{"empty", "slice", "map", "bytes", "bool", "double", "int", "str"}
+	for _, k := range keys {
+		v, _ := m.Get(k)
+		v.CopyTo(reordered.PutEmpty(k))
+	}
+	require.True(t, m.Equal(reordered))
+	assert.Equal(t, m.Hash(), reordered.Hash())
+	assert.Equal(t, m.Hash(), m.Hash())
+
+	for _, tt := range []struct {
+		name   string
+		modify func(Map)
+	}{
+		{name: "remove", modify: func(m Map) { m.Remove("str") }},
+		{name: "add", modify: func(m Map) { m.PutStr("other", "value") }},
+		{name: "rename", modify: func(m Map) { m.Remove("str"); m.PutStr("str2", "value") }},
+		{name: "str-value", modify: func(m Map) { m.PutStr("str", "value2") }},
+		{name: "int-value", modify: func(m Map) { m.PutInt("int", 2) }},
+		{name: "int-to-double", modify: func(m Map) { m.PutDouble("int", 1) }},
+		{name: "int-to-str", modify: func(m Map) { m.PutStr("int", "1") }},
+		{name: "bool-value", modify: func(m Map) { m.PutBool("bool", false) }},
+		{name: "bytes-to-str", modify: func(m Map) { m.PutStr("bytes", "\x01\x02") }},
+		{name: "nested-map", modify: func(m Map) { v, _ := m.Get("map"); v.Map().PutStr("a", "c") }},
+		{name: "nested-slice", modify: func(m Map) { v, _ := m.Get("slice"); v.Slice().AppendEmpty() }},
+		{name: "empty-to-str", modify: func(m Map) { m.PutStr("empty", "") }},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			modified := NewMap()
+			m.CopyTo(modified)
+			tt.modify(modified)
+			assert.NotEqual(t, m.Hash(), modified.Hash())
+		})
+	}
+}
+
+func TestMapHashCollisions(t *testing.T) {
+	// The boundaries between keys and values, and the order in slices, are part of the hash.
+	pairs := [][2]map[string]any{
+		{{"ab": "c"}, {"a": "bc"}},
+		{{"a": []any{"b", "c"}}, {"a": []any{"c", "b"}}},
+		{{"a": []any{"bc"}}, {"a": []any{"b", "c"}}},
+		{{"a": "b", "c": "d"}, {"a": "d", "c": "b"}},
+		{{"a": "a", "b": "b"}, {"a": "b", "b": "a"}},
+		{{}, {"": nil}},
+	}
+	for _, pair := range pairs {
+		m1, m2 := NewMap(), NewMap()
+		require.NoError(t, m1.FromRaw(pair[0]))
+		require.NoError(t, m2.FromRaw(pair[1]))
+		assert.NotEqual(t, m1.Hash(), m2.Hash(), "%v and %v", pair[0], pair[1])
+	}
+}
+
+func TestMapHashDouble(t *testing.T) {
+	m1, m2 := NewMap(), NewMap()
+	m1.PutDouble("d", 0)
+	m2.PutDouble("d", math.Copysign(0, -1))
+	require.True(t, m1.Equal(m2))
+	assert.Equal(t, m1.Hash(), m2.Hash())
+}
+
+func TestResourceHash(t *testing.T) {
+	r1, r2 := NewResource(), NewResource()
+	r1.Attributes().PutStr("service.name", "a")
+	r1.Attributes().PutStr("host.name", "b")
+	r2.Attributes().PutStr("host.name", "b")
+	r2.Attributes().PutStr("service.name", "a")
+	r2.SetDroppedAttributesCount(1)
+	assert.Equal(t, r1.Hash(), r2.Hash())
+
+	r2.Attributes().PutStr("service.name", "c")
+	assert.NotEqual(t, r1.Hash(), r2.Hash())
+}
+
+func TestInstrumentationScopeHash(t *testing.T) {
+	s1, s2 := NewInstrumentationScope(), NewInstrumentationScope()
+	for _, s := range []InstrumentationScope{s1, s2} {
+		s.SetName("scope")
+		s.SetVersion("1.0")
+		s.Attributes().PutStr("a", "b")
+	}
+	s2.SetDroppedAttributesCount(1)
+	assert.Equal(t, s1.Hash(), s2.Hash())
+
+	s2.SetName("scope1.")
+	s2.SetVersion("0")
+	assert.NotEqual(t, s1.Hash(), s2.Hash())
+
+	s2.SetName("scope")
+	s2.SetVersion("1.0")
+	s2.Attributes().PutStr("a", "c")
+	assert.NotEqual(t, s1.Hash(), s2.Hash())
+}
+
+func BenchmarkMapHash(b *testing.B) {
+	m := NewMap()
+	m.PutStr("service.name", "checkout")
+	m.PutStr("service.namespace", "shop")
+	m.PutStr("service.instance.id", "627cc493-f310-47de-96bd-71410b7dec09")
+	m.PutStr("host.name", "ip-10-0-0-1.ec2.internal")
+	m.PutInt("process.pid", 1234)
+	m.PutDouble("sampling.ratio", 0.25)
+	m.PutBool("debug", false)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		m.Hash()
+	}
+}
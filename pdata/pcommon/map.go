@@ -33,6 +33,21 @@ func (m Map) getState() *internal.State {
 	return internal.GetMapState(internal.Map(m))
 }
 
+func (m Map) getIndex() *internal.MapIndex {
+	return internal.GetMapIndex(internal.Map(m))
+}
+
+// Indexed returns a Map referring to the same entries, which looks up the keys in a hash index
+// instead of scanning the entries, for large maps where many keys are looked up.
+//
+// The index is built on the first lookup, and kept up to date by the methods of the returned Map.
+// The entries must not be changed through another Map, e.g. obtained again from the attributes
+// of a span, while the returned Map is in use: only the changes resizing or reallocating the
+// entries are detected, the index is then rebuilt on the next lookup.
+func (m Map) Indexed() Map {
+	return Map(internal.NewIndexedMap(m.getOrig(), m.getState()))
+}
+
 func newMap(orig *[]otlpcommon.KeyValue, state *internal.State) Map {
 	return Map(internal.NewMap(orig, state))
 }
@@ -41,6 +56,7 @@ func newMap(orig *[]otlpcommon.KeyValue, state *internal.State) Map {
 func (m Map) Clear() {
 	m.getState().AssertMutable()
 	*m.getOrig() = nil
+	m.getIndex().Reset()
 }
 
 // EnsureCapacity increases the capacity of this Map instance, if necessary,
@@ -53,6 +69,7 @@ func (m Map) EnsureCapacity(capacity int) {
 	}
 	*m.getOrig() = make([]otlpcommon.KeyValue, len(oldOrig), capacity)
 	copy(*m.getOrig(), oldOrig)
+	m.getIndex().Reset()
 }
 
 // Get returns the Value associated with the key and true. Returned
@@ -63,11 +80,8 @@ func (m Map) EnsureCapacity(capacity int) {
 // If the key does not exist returns a zero-initialized KeyValue and false.
 // Calling any functions on the returned invalid instance may cause a panic.
 func (m Map) Get(key string) (Value, bool) {
-	for i := range *m.getOrig() {
-		akv := &(*m.getOrig())[i]
-		if akv.Key == key {
-			return newValue(&akv.Value, m.getState()), true
-		}
+	if i := m.getIndex().Find(*m.getOrig(), key); i >= 0 {
+		return newValue(&(*m.getOrig())[i].Value, m.getState()), true
 	}
 	return newValue(nil, m.getState()), false
 }
@@ -76,15 +90,14 @@ func (m Map) Get(key string) (Value, bool) {
 // was present in the map, otherwise returns false.
 func (m Map) Remove(key string) bool {
 	m.getState().AssertMutable()
-	for i := range *m.getOrig() {
-		akv := &(*m.getOrig())[i]
-		if akv.Key == key {
-			*akv = (*m.getOrig())[len(*m.getOrig())-1]
-			*m.getOrig() = (*m.getOrig())[:len(*m.getOrig())-1]
-			return true
-		}
+	i := m.getIndex().Find(*m.getOrig(), key)
+	if i < 0 {
+		return false
 	}
-	return false
+	(*m.getOrig())[i] = (*m.getOrig())[len(*m.getOrig())-1]
+	*m.getOrig() = (*m.getOrig())[:len(*m.getOrig())-1]
+	m.getIndex().Removed(*m.getOrig(), key, i)
+	return true
 }
 
 // RemoveIf removes the entries for which the function in question returns true
@@ -105,6 +118,7 @@ func (m Map) RemoveIf(f func(string, Value) bool) {
 		newLen++
 	}
 	*m.getOrig() = (*m.getOrig())[:newLen]
+	m.getIndex().Reset()
 }
 
 // PutEmpty inserts or updates an empty value to the map under given key
@@ -115,8 +129,14 @@ func (m Map) PutEmpty(k string) Value {
 		av.getOrig().Value = nil
 		return newValue(av.getOrig(), m.getState())
 	}
-	*m.getOrig() = append(*m.getOrig(), otlpcommon.KeyValue{Key: k})
-	return newValue(&(*m.getOrig())[len(*m.getOrig())-1].Value, m.getState())
+	return newValue(&m.appendEntry(otlpcommon.KeyValue{Key: k}).Value, m.getState())
+}
+
+// appendEntry appends kv, whose key must not be in the map, and returns the appended entry.
+func (m Map) appendEntry(kv otlpcommon.KeyValue) *otlpcommon.KeyValue {
+	*m.getOrig() = append(*m.getOrig(), kv)
+	m.getIndex().Appended(*m.getOrig())
+	return &(*m.getOrig())[len(*m.getOrig())-1]
 }
 
 // PutStr performs the Insert or Update action. The Value is
@@ -127,7 +147,7 @@ func (m Map) PutStr(k string, v string) {
 	if av, existing := m.Get(k); existing {
 		av.SetStr(v)
 	} else {
-		*m.getOrig() = append(*m.getOrig(), newKeyValueString(k, v))
+		m.appendEntry(newKeyValueString(k, v))
 	}
 }
 
@@ -139,7 +159,7 @@ func (m Map) PutInt(k string, v int64) {
 	if av, existing := m.Get(k); existing {
 		av.SetInt(v)
 	} else {
-		*m.getOrig() = append(*m.getOrig(), newKeyValueInt(k, v))
+		m.appendEntry(newKeyValueInt(k, v))
 	}
 }
 
@@ -151,7 +171,7 @@ func (m Map) PutDouble(k string, v float64) {
 	if av, existing := m.Get(k); existing {
 		av.SetDouble(v)
 	} else {
-		*m.getOrig() = append(*m.getOrig(), newKeyValueDouble(k, v))
+		m.appendEntry(newKeyValueDouble(k, v))
 	}
 }
 
@@ -163,7 +183,7 @@ func (m Map) PutBool(k string, v bool) {
 	if av, existing := m.Get(k); existing {
 		av.SetBool(v)
 	} else {
-		*m.getOrig() = append(*m.getOrig(), newKeyValueBool(k, v))
+		m.appendEntry(newKeyValueBool(k, v))
 	}
 }
 
@@ -174,7 +194,7 @@ func (m Map) PutEmptyBytes(k string) ByteSlice {
 	if av, existing := m.Get(k); existing {
 		av.getOrig().Value = &bv
 	} else {
-		*m.getOrig() = append(*m.getOrig(), otlpcommon.KeyValue{Key: k, Value: otlpcommon.AnyValue{Value: &bv}})
+		m.appendEntry(otlpcommon.KeyValue{Key: k, Value: otlpcommon.AnyValue{Value: &bv}})
 	}
 	return ByteSlice(internal.NewByteSlice(&bv.BytesValue, m.getState()))
 }
@@ -186,7 +206,7 @@ func (m Map) PutEmptyMap(k string) Map {
 	if av, existing := m.Get(k); existing {
 		av.getOrig().Value = &kvl
 	} else {
-		*m.getOrig() = append(*m.getOrig(), otlpcommon.KeyValue{Key: k, Value: otlpcommon.AnyValue{Value: &kvl}})
+		m.appendEntry(otlpcommon.KeyValue{Key: k, Value: otlpcommon.AnyValue{Value: &kvl}})
 	}
 	return Map(internal.NewMap(&kvl.KvlistValue.Values, m.getState()))
 }
@@ -198,7 +218,7 @@ func (m Map) PutEmptySlice(k string) Slice {
 	if av, existing := m.Get(k); existing {
 		av.getOrig().Value = &vl
 	} else {
-		*m.getOrig() = append(*m.getOrig(), otlpcommon.KeyValue{Key: k, Value: otlpcommon.AnyValue{Value: &vl}})
+		m.appendEntry(otlpcommon.KeyValue{Key: k, Value: otlpcommon.AnyValue{Value: &vl}})
 	}
 	return Slice(internal.NewSlice(&vl.ArrayValue.Values, m.getState()))
 }
@@ -250,11 +270,14 @@ func (m Map) MoveTo(dest Map) {
 	dest.getState().AssertMutable()
 	*dest.getOrig() = *m.getOrig()
 	*m.getOrig() = nil
+	m.getIndex().Reset()
+	dest.getIndex().Reset()
 }
 
 // CopyTo copies all elements from the current map overriding the destination.
 func (m Map) CopyTo(dest Map) {
 	dest.getState().AssertMutable()
+	dest.getIndex().Reset()
 	newLen := len(*m.getOrig())
 	oldCap := cap(*dest.getOrig())
 	if newLen <= oldCap {
@@ -292,6 +315,7 @@ func (m Map) AsRaw() map[string]any {
 // FromRaw overrides this Map instance from a standard go map.
 func (m Map) FromRaw(rawMap map[string]any) error {
 	m.getState().AssertMutable()
+	m.getIndex().Reset()
 	if len(rawMap) == 0 {
 		*m.getOrig() = nil
 		return nil
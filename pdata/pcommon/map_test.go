@@ -4,6 +4,9 @@
 package pcommon
 
 import (
+	"fmt"
+	"math/rand/v2"
+	"strconv"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -697,3 +700,181 @@ func BenchmarkMapEqual(b *testing.B) {
 		_ = m.Equal(cmp)
 	}
 }
+
+// requireIndexedConsistent checks that every key of the map is found in the indexed map.
+func requireIndexedConsistent(t *testing.T, indexed Map) {
+	for i, kv := range *indexed.getOrig() {
+		v, ok := indexed.Get(kv.Key)
+		require.True(t, ok, kv.Key)
+		require.Same(t, &(*indexed.getOrig())[i].Value, v.getOrig())
+	}
+	_, ok := indexed.Get("missing")
+	require.False(t, ok)
+}
+
+func TestMap_Indexed(t *testing.T) {
+	m := NewMap()
+	indexed := m.Indexed()
+	rnd := rand.New(rand.NewPCG(1, 2))
+	expected := map[string]int64{}
+	for i := 0; i < 2000; i++ {
+		k := "key" + strconv.Itoa(rnd.IntN(64))
+		switch rnd.IntN(3) {
+		case 0, 1:
+			indexed.PutInt(k, int64(i))
+			expected[k] = int64(i)
+		case 2:
+			_, ok := expected[k]
+			assert.Equal(t, ok, indexed.Remove(k))
+			delete(expected, k)
+		}
+		if i%100 == 0 {
+			requireIndexedConsistent(t, indexed)
+		}
+	}
+	assert.Equal(t, len(expected), m.Len())
+	for k, v := range expected {
+		got, ok := indexed.Get(k)
+		require.True(t, ok)
+		assert.Equal(t, v, got.Int())
+	}
+}
+
+func TestMap_IndexedChangedThroughAnotherMap(t *testing.T) {
+	m := NewMap()
+	for i := 0; i < 32; i++ {
+		m.PutInt("key"+strconv.Itoa(i), int64(i))
+	}
+	indexed := m.Indexed()
+	requireIndexedConsistent(t, indexed)
+
+	// Looking up an entry which was replaced in place rebuilds the index.
+	assert.True(t, m.Remove("key3"))
+	m.PutInt("other", 3)
+	_, ok := indexed.Get("key3")
+	assert.False(t, ok)
+	v, ok := indexed.Get("other")
+	require.True(t, ok)
+	assert.Equal(t, int64(3), v.Int())
+	requireIndexedConsistent(t, indexed)
+
+	m.RemoveIf(func(k string, _ Value) bool { return k == "key5" })
+	requireIndexedConsistent(t, indexed)
+	m.PutStr("new", "v")
+	requireIndexedConsistent(t, indexed)
+
+	src := NewMap()
+	for i := 0; i < 40; i++ {
+		src.PutInt("copied"+strconv.Itoa(i), int64(i))
+	}
+	src.CopyTo(m)
+	requireIndexedConsistent(t, indexed)
+	_, ok = indexed.Get("copied7")
+	assert.True(t, ok)
+
+	NewMap().MoveTo(m)
+	_, ok = indexed.Get("copied7")
+	assert.False(t, ok)
+}
+
+func TestMap_IndexedMoveToCopyTo(t *testing.T) {
+	src := NewMap().Indexed()
+	dest := NewMap().Indexed()
+	for i := 0; i < 32; i++ {
+		src.PutInt("src"+strconv.Itoa(i), int64(i))
+		dest.PutInt("dest"+strconv.Itoa(i), int64(i))
+	}
+	requireIndexedConsistent(t, src)
+	requireIndexedConsistent(t, dest)
+
+	src.CopyTo(dest)
+	requireIndexedConsistent(t, dest)
+	_, ok := dest.Get("src1")
+	assert.True(t, ok)
+	_, ok = dest.Get("dest1")
+	assert.False(t, ok)
+
+	dest.Clear()
+	src.MoveTo(dest)
+	assert.Equal(t, 0, src.Len())
+	_, ok = src.Get("src1")
+	assert.False(t, ok)
+	requireIndexedConsistent(t, dest)
+
+	require.NoError(t, dest.FromRaw(map[string]any{"raw": 1}))
+	_, ok = dest.Get("src1")
+	assert.False(t, ok)
+	requireIndexedConsistent(t, dest)
+}
+
+func TestMap_IndexedDuplicateKeys(t *testing.T) {
+	orig := make([]otlpcommon.KeyValue, 0, 32)
+	for i := 0; i < 32; i++ {
+		orig = append(orig, otlpcommon.KeyValue{Key: "key" + strconv.Itoa(i%16)})
+	}
+	state := internal.StateMutable
+	indexed := newMap(&orig, &state).Indexed()
+
+	for i := 0; i < 16; i++ {
+		assert.Same(t, &orig[i].Value, func() *otlpcommon.AnyValue {
+			v, ok := indexed.Get("key" + strconv.Itoa(i))
+			require.True(t, ok)
+			return v.getOrig()
+		}())
+	}
+	// Removing the first entry with a key leaves the other one.
+	assert.True(t, indexed.Remove("key0"))
+	_, ok := indexed.Get("key0")
+	assert.True(t, ok)
+	assert.True(t, indexed.Remove("key0"))
+	_, ok = indexed.Get("key0")
+	assert.False(t, ok)
+}
+
+func BenchmarkMapGet(b *testing.B) {
+	for _, size := range []int{8, 32, 128} {
+		m := NewMap()
+		for i := 0; i < size; i++ {
+			m.PutStr("attribute."+strconv.Itoa(i), "value")
+		}
+		for _, tt := range []struct {
+			name string
+			m    Map
+		}{
+			{name: "scan", m: m},
+			{name: "indexed", m: m.Indexed()},
+		} {
+			b.Run(fmt.Sprintf("%s/%d", tt.name, size), func(b *testing.B) {
+				b.ReportAllocs()
+				for n := 0; n < b.N; n++ {
+					_, _ = tt.m.Get("attribute." + strconv.Itoa(n%size))
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkMapPutRemove(b *testing.B) {
+	for _, size := range []int{8, 32, 128} {
+		m := NewMap()
+		for i := 0; i < size; i++ {
+			m.PutStr("attribute."+strconv.Itoa(i), "value")
+		}
+		for _, tt := range []struct {
+			name string
+			m    Map
+		}{
+			{name: "scan", m: m},
+			{name: "indexed", m: m.Indexed()},
+		} {
+			b.Run(fmt.Sprintf("%s/%d", tt.name, size), func(b *testing.B) {
+				b.ReportAllocs()
+				for n := 0; n < b.N; n++ {
+					k := "attribute." + strconv.Itoa(n%size)
+					tt.m.Remove(k)
+					tt.m.PutStr(k, "value")
+				}
+			})
+		}
+	}
+}
@@ -22,12 +22,36 @@ import (
 type ResourceLogs struct {
 	orig  *otlplogs.ResourceLogs
 	state *internal.State
+	// nodes is the slice of the ResourceLogs, if it may be shared with other slices.
+	nodes  *[]*otlplogs.ResourceLogs
+	shared internal.SharedNodes[otlplogs.ResourceLogs]
 }
 
 func newResourceLogs(orig *otlplogs.ResourceLogs, state *internal.State) ResourceLogs {
 	return ResourceLogs{orig: orig, state: state}
 }
 
+// newSharedResourceLogs returns the ResourceLogs of the given slice, which may be shared with other slices.
+func newSharedResourceLogs(orig *otlplogs.ResourceLogs, state *internal.State, nodes *[]*otlplogs.ResourceLogs, shared internal.SharedNodes[otlplogs.ResourceLogs]) ResourceLogs {
+	return ResourceLogs{orig: orig, state: state, nodes: nodes, shared: shared}
+}
+
+// getOrig returns the ResourceLogs to read: its copy if it was shared and was copied since.
+func (ms ResourceLogs) getOrig() *otlplogs.ResourceLogs {
+	return ms.shared.Get(ms.orig)
+}
+
+// getMutableOrig returns the ResourceLogs to modify: if it is shared with other slices, it is
+// first copied and replaced by its copy in its slice.
+func (ms ResourceLogs) getMutableOrig() *otlplogs.ResourceLogs {
+	return ms.shared.GetMutable(ms.orig, ms.nodes, ms.state, copyOrigResourceLogs)
+}
+
+func copyOrigResourceLogs(dest, src *otlplogs.ResourceLogs) {
+	state := internal.StateMutable
+	newResourceLogs(src, &state).CopyTo(newResourceLogs(dest, &state))
+}
+
 // NewResourceLogs creates a new empty ResourceLogs.
 //
 // This must be used only in testing code. Users should use "AppendEmpty" when part of a Slice,
@@ -42,34 +66,38 @@ func NewResourceLogs() ResourceLogs {
 func (ms ResourceLogs) MoveTo(dest ResourceLogs) {
 	ms.state.AssertMutable()
 	dest.state.AssertMutable()
-	*dest.orig = *ms.orig
-	*ms.orig = otlplogs.ResourceLogs{}
+	// The shared ResourceLogs are copied first, the other slices keep them.
+	orig := ms.getMutableOrig()
+	*dest.getMutableOrig() = *orig
+	*orig = otlplogs.ResourceLogs{}
 }
 
 // Resource returns the resource associated with this ResourceLogs.
 func (ms ResourceLogs) Resource() pcommon.Resource {
-	return pcommon.Resource(internal.NewResource(&ms.orig.Resource, ms.state))
+	return pcommon.Resource(internal.NewResource(&ms.getMutableOrig().Resource, ms.state))
 }
 
 // SchemaUrl returns the schemaurl associated with this ResourceLogs.
 func (ms ResourceLogs) SchemaUrl() string {
-	return ms.orig.SchemaUrl
+	return ms.getOrig().SchemaUrl
 }
 
 // SetSchemaUrl replaces the schemaurl associated with this ResourceLogs.
 func (ms ResourceLogs) SetSchemaUrl(v string) {
 	ms.state.AssertMutable()
-	ms.orig.SchemaUrl = v
+	ms.getMutableOrig().SchemaUrl = v
 }
 
 // ScopeLogs returns the ScopeLogs associated with this ResourceLogs.
 func (ms ResourceLogs) ScopeLogs() ScopeLogsSlice {
-	return newScopeLogsSlice(&ms.orig.ScopeLogs, ms.state)
+	return newScopeLogsSlice(&ms.getMutableOrig().ScopeLogs, ms.state)
 }
 
 // CopyTo copies all properties from the current struct overriding the destination.
 func (ms ResourceLogs) CopyTo(dest ResourceLogs) {
 	dest.state.AssertMutable()
+	// Reading ms does not copy it if it is shared.
+	ms = newResourceLogs(ms.getOrig(), ms.state)
 	ms.Resource().CopyTo(dest.Resource())
 	dest.SetSchemaUrl(ms.SchemaUrl())
 	ms.ScopeLogs().CopyTo(dest.ScopeLogs())
@@ -20,7 +20,7 @@ func TestResourceLogsSlice(t *testing.T) {
 	es := NewResourceLogsSlice()
 	assert.Equal(t, 0, es.Len())
 	state := internal.StateMutable
-	es = newResourceLogsSlice(&[]*otlplogs.ResourceLogs{}, &state)
+	es = newResourceLogsSlice(&[]*otlplogs.ResourceLogs{}, &state, nil)
 	assert.Equal(t, 0, es.Len())
 
 	emptyVal := NewResourceLogs()
@@ -36,7 +36,7 @@ func TestResourceLogsSlice(t *testing.T) {
 
 func TestResourceLogsSliceReadOnly(t *testing.T) {
 	sharedState := internal.StateReadOnly
-	es := newResourceLogsSlice(&[]*otlplogs.ResourceLogs{}, &sharedState)
+	es := newResourceLogsSlice(&[]*otlplogs.ResourceLogs{}, &sharedState, nil)
 	assert.Equal(t, 0, es.Len())
 	assert.Panics(t, func() { es.AppendEmpty() })
 	assert.Panics(t, func() { es.EnsureCapacity(2) })
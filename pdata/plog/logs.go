@@ -6,6 +6,7 @@ package plog // import "go.opentelemetry.io/collector/pdata/plog"
 import (
 	"go.opentelemetry.io/collector/pdata/internal"
 	otlpcollectorlog "go.opentelemetry.io/collector/pdata/internal/data/protogen/collector/logs/v1"
+	otlplogs "go.opentelemetry.io/collector/pdata/internal/data/protogen/logs/v1"
 )
 
 // Logs is the top-level struct that is propagated through the logs pipeline.
@@ -14,7 +15,7 @@ type Logs internal.Logs
 
 func newLogs(orig *otlpcollectorlog.ExportLogsServiceRequest) Logs {
 	state := internal.StateMutable
-	return Logs(internal.NewLogs(orig, &state))
+	return Logs(internal.NewLogs(orig, &state, nil))
 }
 
 func (ms Logs) getOrig() *otlpcollectorlog.ExportLogsServiceRequest {
@@ -25,9 +26,13 @@ func (ms Logs) getState() *internal.State {
 	return internal.GetLogsState(internal.Logs(ms))
 }
 
+func (ms Logs) getSharedNodes() internal.SharedNodes[otlplogs.ResourceLogs] {
+	return internal.GetLogsSharedNodes(internal.Logs(ms))
+}
+
 // NewLogs creates a new Logs struct.
 func NewLogs() Logs {
-	return newLogs(&otlpcollectorlog.ExportLogsServiceRequest{})
+	return newLogs(internal.NewOrigLogs())
 }
 
 // IsReadOnly returns true if this Logs instance is read-only.
@@ -35,6 +40,19 @@ func (ms Logs) IsReadOnly() bool {
 	return *ms.getState() == internal.StateReadOnly
 }
 
+// Release returns the memory of the Logs to pools, for the Logs unmarshaled from OTLP/protobuf to reuse.
+// It must only be called once the Logs, and everything obtained from it, are no longer used:
+// modifying them afterwards panics, and reading them returns undefined data.
+// Release does nothing if the Logs is read-only, as it is then shared with other consumers, and does
+// not release the ResourceLogs shared with other Logs, see Share.
+func (ms Logs) Release() {
+	if *ms.getState() != internal.StateMutable {
+		return
+	}
+	*ms.getState() = internal.StateReleased
+	internal.ReleaseOrigLogs(ms.getOrig(), ms.getSharedNodes())
+}
+
 // CopyTo copies the Logs instance overriding the destination.
 func (ms Logs) CopyTo(dest Logs) {
 	ms.ResourceLogs().CopyTo(dest.ResourceLogs())
@@ -43,10 +61,9 @@ func (ms Logs) CopyTo(dest Logs) {
 // LogRecordCount calculates the total number of log records.
 func (ms Logs) LogRecordCount() int {
 	logCount := 0
-	rss := ms.ResourceLogs()
-	for i := 0; i < rss.Len(); i++ {
-		rs := rss.At(i)
-		ill := rs.ScopeLogs()
+	// Does not use ResourceLogs.ScopeLogs, which copies the shared ResourceLogs.
+	for _, orig := range ms.getOrig().ResourceLogs {
+		ill := newResourceLogs(orig, ms.getState()).ScopeLogs()
 		for i := 0; i < ill.Len(); i++ {
 			logs := ill.At(i)
 			logCount += logs.LogRecords().Len()
@@ -57,10 +74,23 @@ func (ms Logs) LogRecordCount() int {
 
 // ResourceLogs returns the ResourceLogsSlice associated with this Logs.
 func (ms Logs) ResourceLogs() ResourceLogsSlice {
-	return newResourceLogsSlice(&ms.getOrig().ResourceLogs, internal.GetLogsState(internal.Logs(ms)))
+	return newResourceLogsSlice(&ms.getOrig().ResourceLogs, internal.GetLogsState(internal.Logs(ms)), ms.getSharedNodes())
 }
 
 // MarkReadOnly marks the Logs as shared so that no further modifications can be done on it.
 func (ms Logs) MarkReadOnly() {
 	internal.SetLogsState(internal.Logs(ms), internal.StateReadOnly)
 }
+
+// Share marks the Logs as read-only, and returns a mutable Logs sharing its ResourceLogs.
+// The shared ResourceLogs are copied on write: a shared ResourceLogs is copied the first time a method which
+// may modify it is called, e.g. ResourceLogs.Resource or ResourceLogs.SetSchemaUrl, so that only the ResourceLogs
+// which are modified are copied. Share can be used instead of CopyTo to send the same Logs to several
+// consumers modifying it.
+func (ms Logs) Share() Logs {
+	ms.MarkReadOnly()
+	orig := internal.NewOrigLogs()
+	orig.ResourceLogs = append(orig.ResourceLogs, ms.getOrig().ResourceLogs...)
+	state := internal.StateMutable
+	return Logs(internal.NewLogs(orig, &state, internal.NewSharedNodes(orig.ResourceLogs)))
+}
@@ -116,6 +116,22 @@ func TestLogsCopyTo(t *testing.T) {
 	assert.Equal(t, logs, logsCopy)
 }
 
+func TestLogsRelease(t *testing.T) {
+	logs := NewLogs()
+	fillTestResourceLogsSlice(logs.ResourceLogs())
+	logs.Release()
+	assert.PanicsWithValue(t, "invalid access to released data", func() { logs.ResourceLogs().AppendEmpty() })
+	assert.Equal(t, NewLogs(), NewLogs())
+}
+
+func TestLogsReleaseReadOnly(t *testing.T) {
+	logs := NewLogs()
+	logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().SetSeverityText("value")
+	logs.MarkReadOnly()
+	logs.Release()
+	assert.Equal(t, "value", logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).SeverityText())
+}
+
 func TestReadOnlyLogsInvalidUsage(t *testing.T) {
 	logs := NewLogs()
 	assert.False(t, logs.IsReadOnly())
@@ -126,6 +142,24 @@ func TestReadOnlyLogsInvalidUsage(t *testing.T) {
 	assert.Panics(t, func() { res.Attributes().PutStr("k2", "v2") })
 }
 
+func TestLogsShare(t *testing.T) {
+	logs := NewLogs()
+	fillTestResourceLogsSlice(logs.ResourceLogs())
+	shared := logs.Share()
+	assert.True(t, logs.IsReadOnly())
+	assert.False(t, shared.IsReadOnly())
+	assert.Equal(t, logs.LogRecordCount(), shared.LogRecordCount())
+	for i, orig := range shared.getOrig().ResourceLogs {
+		assert.Same(t, logs.getOrig().ResourceLogs[i], orig)
+	}
+
+	// Only the accessed ResourceLogs is copied, the original is not modified.
+	shared.ResourceLogs().At(1).SetSchemaUrl("changed")
+	assert.NotSame(t, logs.getOrig().ResourceLogs[1], shared.getOrig().ResourceLogs[1])
+	assert.Same(t, logs.getOrig().ResourceLogs[2], shared.getOrig().ResourceLogs[2])
+	assert.Equal(t, *generateTestResourceLogsSlice().orig, logs.getOrig().ResourceLogs)
+}
+
 func BenchmarkLogsUsage(b *testing.B) {
 	logs := NewLogs()
 	fillTestResourceLogsSlice(logs.ResourceLogs())
@@ -5,7 +5,6 @@ package plog // import "go.opentelemetry.io/collector/pdata/plog"
 
 import (
 	"go.opentelemetry.io/collector/pdata/internal"
-	otlplogs "go.opentelemetry.io/collector/pdata/internal/data/protogen/logs/v1"
 )
 
 var _ MarshalSizer = (*ProtoMarshaler)(nil)
@@ -39,7 +38,7 @@ var _ Unmarshaler = (*ProtoUnmarshaler)(nil)
 type ProtoUnmarshaler struct{}
 
 func (d *ProtoUnmarshaler) UnmarshalLogs(buf []byte) (Logs, error) {
-	pb := otlplogs.LogsData{}
-	err := pb.Unmarshal(buf)
-	return Logs(internal.LogsFromProto(pb)), err
+	orig := internal.NewOrigLogs()
+	err := internal.UnmarshalOrigLogs(orig, buf)
+	return newLogs(orig), err
 }
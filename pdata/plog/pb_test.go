@@ -10,6 +10,8 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"go.opentelemetry.io/collector/pdata/internal"
+	otlplogs "go.opentelemetry.io/collector/pdata/internal/data/protogen/logs/v1"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 )
 
@@ -19,6 +21,22 @@ func TestProtoLogsUnmarshalerError(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestProtoLogsUnmarshalerPooled(t *testing.T) {
+	logs := NewLogs()
+	fillTestResourceLogsSlice(logs.ResourceLogs())
+	buf, err := (&ProtoMarshaler{}).MarshalLogs(logs)
+	require.NoError(t, err)
+
+	expected := otlplogs.LogsData{}
+	require.NoError(t, expected.Unmarshal(buf))
+	for i := 0; i < 3; i++ {
+		got, err := (&ProtoUnmarshaler{}).UnmarshalLogs(buf)
+		require.NoError(t, err)
+		assert.Equal(t, Logs(internal.LogsFromProto(expected)), got)
+		got.Release()
+	}
+}
+
 func TestProtoSizer(t *testing.T) {
 	marshaler := &ProtoMarshaler{}
 	ld := NewLogs()
@@ -74,8 +74,43 @@ func (*UnimplementedGRPCServer) Export(context.Context, ExportRequest) (ExportRe
 func (*UnimplementedGRPCServer) unexported() {}
 
 // RegisterGRPCServer registers the Server to the grpc.Server.
+// The requests are decoded into the memory released with plog.Logs.Release, if any.
 func RegisterGRPCServer(s *grpc.Server, srv GRPCServer) {
-	otlpcollectorlog.RegisterLogsServiceServer(s, &rawLogsServer{srv: srv})
+	s.RegisterService(&serviceDesc, &rawLogsServer{srv: srv})
+}
+
+// serviceDesc is the service of the generated otlpcollectorlog.RegisterLogsServiceServer, decoding the
+// requests from the pools.
+var serviceDesc = grpc.ServiceDesc{
+	ServiceName: "opentelemetry.proto.collector.logs.v1.LogsService",
+	HandlerType: (*otlpcollectorlog.LogsServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Export",
+			Handler:    exportHandler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "opentelemetry/proto/collector/logs/v1/logs_service.proto",
+}
+
+func exportHandler(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+	in := &internal.LogsGRPCRequest{Orig: internal.NewOrigLogs()}
+	if err := dec(in); err != nil {
+		internal.ReleaseOrigLogs(in.Orig, nil)
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(otlpcollectorlog.LogsServiceServer).Export(ctx, in.Orig)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/opentelemetry.proto.collector.logs.v1.LogsService/Export",
+	}
+	handler := func(ctx context.Context, req any) (any, error) {
+		return srv.(otlpcollectorlog.LogsServiceServer).Export(ctx, req.(*internal.LogsGRPCRequest).Orig)
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
 type rawLogsServer struct {
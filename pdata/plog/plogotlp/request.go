@@ -8,6 +8,7 @@ import (
 
 	"go.opentelemetry.io/collector/pdata/internal"
 	otlpcollectorlog "go.opentelemetry.io/collector/pdata/internal/data/protogen/collector/logs/v1"
+	otlplogs "go.opentelemetry.io/collector/pdata/internal/data/protogen/logs/v1"
 	"go.opentelemetry.io/collector/pdata/internal/json"
 	"go.opentelemetry.io/collector/pdata/internal/otlp"
 	"go.opentelemetry.io/collector/pdata/plog"
@@ -18,15 +19,16 @@ var jsonUnmarshaler = &plog.JSONUnmarshaler{}
 // ExportRequest represents the request for gRPC/HTTP client/server.
 // It's a wrapper for plog.Logs data.
 type ExportRequest struct {
-	orig  *otlpcollectorlog.ExportLogsServiceRequest
-	state *internal.State
+	orig   *otlpcollectorlog.ExportLogsServiceRequest
+	state  *internal.State
+	shared internal.SharedNodes[otlplogs.ResourceLogs]
 }
 
 // NewExportRequest returns an empty ExportRequest.
 func NewExportRequest() ExportRequest {
 	state := internal.StateMutable
 	return ExportRequest{
-		orig:  &otlpcollectorlog.ExportLogsServiceRequest{},
+		orig:  internal.NewOrigLogs(),
 		state: &state,
 	}
 }
@@ -36,8 +38,9 @@ func NewExportRequest() ExportRequest {
 // any changes to the provided Logs struct will be reflected in the ExportRequest and vice versa.
 func NewExportRequestFromLogs(ld plog.Logs) ExportRequest {
 	return ExportRequest{
-		orig:  internal.GetOrigLogs(internal.Logs(ld)),
-		state: internal.GetLogsState(internal.Logs(ld)),
+		orig:   internal.GetOrigLogs(internal.Logs(ld)),
+		state:  internal.GetLogsState(internal.Logs(ld)),
+		shared: internal.GetLogsSharedNodes(internal.Logs(ld)),
 	}
 }
 
@@ -48,7 +51,7 @@ func (ms ExportRequest) MarshalProto() ([]byte, error) {
 
 // UnmarshalProto unmarshalls ExportRequest from proto bytes.
 func (ms ExportRequest) UnmarshalProto(data []byte) error {
-	if err := ms.orig.Unmarshal(data); err != nil {
+	if err := internal.UnmarshalOrigLogs(ms.orig, data); err != nil {
 		return err
 	}
 	otlp.MigrateLogs(ms.orig.ResourceLogs)
@@ -75,5 +78,5 @@ func (ms ExportRequest) UnmarshalJSON(data []byte) error {
 }
 
 func (ms ExportRequest) Logs() plog.Logs {
-	return plog.Logs(internal.NewLogs(ms.orig, ms.state))
+	return plog.Logs(internal.NewLogs(ms.orig, ms.state, ms.shared))
 }
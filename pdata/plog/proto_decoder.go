@@ -0,0 +1,39 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package plog // import "go.opentelemetry.io/collector/pdata/plog"
+
+import (
+	"io"
+
+	"go.opentelemetry.io/collector/pdata/internal"
+	otlplogs "go.opentelemetry.io/collector/pdata/internal/data/protogen/logs/v1"
+	"go.opentelemetry.io/collector/pdata/internal/otlp"
+	"go.opentelemetry.io/collector/pdata/internal/protostream"
+)
+
+// ProtoDecoder decodes logs in the OTLP/protobuf format, i.e. a marshaled ExportLogsServiceRequest or LogsData,
+// from an io.Reader one ResourceLogs at a time, so that only one ResourceLogs is held in memory at once.
+type ProtoDecoder struct {
+	r *protostream.Reader
+}
+
+// NewProtoDecoder creates a ProtoDecoder reading from r.
+func NewProtoDecoder(r io.Reader) *ProtoDecoder {
+	return &ProtoDecoder{r: protostream.NewReader(r)}
+}
+
+// DecodeResourceLogs returns the next ResourceLogs. It returns io.EOF once all the ResourceLogs were read.
+func (d *ProtoDecoder) DecodeResourceLogs() (ResourceLogs, error) {
+	buf, err := d.r.Next(1)
+	if err != nil {
+		return ResourceLogs{}, err
+	}
+	orig := &otlplogs.ResourceLogs{}
+	if err = orig.Unmarshal(buf); err != nil {
+		return ResourceLogs{}, err
+	}
+	otlp.MigrateLogs([]*otlplogs.ResourceLogs{orig})
+	state := internal.StateMutable
+	return newResourceLogs(orig, &state), nil
+}
@@ -0,0 +1,49 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package plog
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProtoDecoder(t *testing.T) {
+	ld := NewLogs()
+	fillTestResourceLogs(ld.ResourceLogs().AppendEmpty())
+	ld.ResourceLogs().AppendEmpty()
+	fillTestResourceLogs(ld.ResourceLogs().AppendEmpty())
+	ld.ResourceLogs().At(2).Resource().Attributes().PutStr("last", "true")
+	buf, err := (&ProtoMarshaler{}).MarshalLogs(ld)
+	require.NoError(t, err)
+
+	decoded := NewLogs()
+	d := NewProtoDecoder(bytes.NewReader(buf))
+	for {
+		rl, err := d.DecodeResourceLogs()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		rl.MoveTo(decoded.ResourceLogs().AppendEmpty())
+	}
+	assert.Equal(t, ld, decoded)
+}
+
+func TestProtoDecoderEmpty(t *testing.T) {
+	_, err := NewProtoDecoder(bytes.NewReader(nil)).DecodeResourceLogs()
+	assert.ErrorIs(t, err, io.EOF)
+}
+
+func TestProtoDecoderInvalid(t *testing.T) {
+	_, err := NewProtoDecoder(bytes.NewReader([]byte{0x0a, 0x02, 0x0a, 0x05})).DecodeResourceLogs()
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, io.EOF)
+
+	_, err = NewProtoDecoder(bytes.NewReader([]byte{0x0a, 0x05, 0x0a})).DecodeResourceLogs()
+	assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+}
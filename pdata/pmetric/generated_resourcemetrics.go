@@ -22,12 +22,36 @@ import (
 type ResourceMetrics struct {
 	orig  *otlpmetrics.ResourceMetrics
 	state *internal.State
+	// nodes is the slice of the ResourceMetrics, if it may be shared with other slices.
+	nodes  *[]*otlpmetrics.ResourceMetrics
+	shared internal.SharedNodes[otlpmetrics.ResourceMetrics]
 }
 
 func newResourceMetrics(orig *otlpmetrics.ResourceMetrics, state *internal.State) ResourceMetrics {
 	return ResourceMetrics{orig: orig, state: state}
 }
 
+// newSharedResourceMetrics returns the ResourceMetrics of the given slice, which may be shared with other slices.
+func newSharedResourceMetrics(orig *otlpmetrics.ResourceMetrics, state *internal.State, nodes *[]*otlpmetrics.ResourceMetrics, shared internal.SharedNodes[otlpmetrics.ResourceMetrics]) ResourceMetrics {
+	return ResourceMetrics{orig: orig, state: state, nodes: nodes, shared: shared}
+}
+
+// getOrig returns the ResourceMetrics to read: its copy if it was shared and was copied since.
+func (ms ResourceMetrics) getOrig() *otlpmetrics.ResourceMetrics {
+	return ms.shared.Get(ms.orig)
+}
+
+// getMutableOrig returns the ResourceMetrics to modify: if it is shared with other slices, it is
+// first copied and replaced by its copy in its slice.
+func (ms ResourceMetrics) getMutableOrig() *otlpmetrics.ResourceMetrics {
+	return ms.shared.GetMutable(ms.orig, ms.nodes, ms.state, copyOrigResourceMetrics)
+}
+
+func copyOrigResourceMetrics(dest, src *otlpmetrics.ResourceMetrics) {
+	state := internal.StateMutable
+	newResourceMetrics(src, &state).CopyTo(newResourceMetrics(dest, &state))
+}
+
 // NewResourceMetrics creates a new empty ResourceMetrics.
 //
 // This must be used only in testing code. Users should use "AppendEmpty" when part of a Slice,
@@ -42,34 +66,38 @@ func NewResourceMetrics() ResourceMetrics {
 func (ms ResourceMetrics) MoveTo(dest ResourceMetrics) {
 	ms.state.AssertMutable()
 	dest.state.AssertMutable()
-	*dest.orig = *ms.orig
-	*ms.orig = otlpmetrics.ResourceMetrics{}
+	// The shared ResourceMetrics are copied first, the other slices keep them.
+	orig := ms.getMutableOrig()
+	*dest.getMutableOrig() = *orig
+	*orig = otlpmetrics.ResourceMetrics{}
 }
 
 // Resource returns the resource associated with this ResourceMetrics.
 func (ms ResourceMetrics) Resource() pcommon.Resource {
-	return pcommon.Resource(internal.NewResource(&ms.orig.Resource, ms.state))
+	return pcommon.Resource(internal.NewResource(&ms.getMutableOrig().Resource, ms.state))
 }
 
 // SchemaUrl returns the schemaurl associated with this ResourceMetrics.
 func (ms ResourceMetrics) SchemaUrl() string {
-	return ms.orig.SchemaUrl
+	return ms.getOrig().SchemaUrl
 }
 
 // SetSchemaUrl replaces the schemaurl associated with this ResourceMetrics.
 func (ms ResourceMetrics) SetSchemaUrl(v string) {
 	ms.state.AssertMutable()
-	ms.orig.SchemaUrl = v
+	ms.getMutableOrig().SchemaUrl = v
 }
 
 // ScopeMetrics returns the ScopeMetrics associated with this ResourceMetrics.
 func (ms ResourceMetrics) ScopeMetrics() ScopeMetricsSlice {
-	return newScopeMetricsSlice(&ms.orig.ScopeMetrics, ms.state)
+	return newScopeMetricsSlice(&ms.getMutableOrig().ScopeMetrics, ms.state)
 }
 
 // CopyTo copies all properties from the current struct overriding the destination.
 func (ms ResourceMetrics) CopyTo(dest ResourceMetrics) {
 	dest.state.AssertMutable()
+	// Reading ms does not copy it if it is shared.
+	ms = newResourceMetrics(ms.getOrig(), ms.state)
 	ms.Resource().CopyTo(dest.Resource())
 	dest.SetSchemaUrl(ms.SchemaUrl())
 	ms.ScopeMetrics().CopyTo(dest.ScopeMetrics())
@@ -22,12 +22,13 @@ import (
 // Must use NewResourceMetricsSlice function to create new instances.
 // Important: zero-initialized instance is not valid for use.
 type ResourceMetricsSlice struct {
-	orig  *[]*otlpmetrics.ResourceMetrics
-	state *internal.State
+	orig   *[]*otlpmetrics.ResourceMetrics
+	state  *internal.State
+	shared internal.SharedNodes[otlpmetrics.ResourceMetrics]
 }
 
-func newResourceMetricsSlice(orig *[]*otlpmetrics.ResourceMetrics, state *internal.State) ResourceMetricsSlice {
-	return ResourceMetricsSlice{orig: orig, state: state}
+func newResourceMetricsSlice(orig *[]*otlpmetrics.ResourceMetrics, state *internal.State, shared internal.SharedNodes[otlpmetrics.ResourceMetrics]) ResourceMetricsSlice {
+	return ResourceMetricsSlice{orig: orig, state: state, shared: shared}
 }
 
 // NewResourceMetricsSlice creates a ResourceMetricsSlice with 0 elements.
@@ -35,7 +36,7 @@ func newResourceMetricsSlice(orig *[]*otlpmetrics.ResourceMetrics, state *intern
 func NewResourceMetricsSlice() ResourceMetricsSlice {
 	orig := []*otlpmetrics.ResourceMetrics(nil)
 	state := internal.StateMutable
-	return newResourceMetricsSlice(&orig, &state)
+	return newResourceMetricsSlice(&orig, &state, nil)
 }
 
 // Len returns the number of elements in the slice.
@@ -53,10 +54,25 @@ func (es ResourceMetricsSlice) Len() int {
 //	    e := es.At(i)
 //	    ... // Do something with the element
 //	}
+//
+// If the element is shared with other slices, it is not copied until it may be modified: by its setters,
+// or by the accessors of its fields, since the values they return refer to its memory. Use View to read
+// its fields without copying it.
 func (es ResourceMetricsSlice) At(i int) ResourceMetrics {
+	if es.shared != nil {
+		return newSharedResourceMetrics((*es.orig)[i], es.state, es.orig, es.shared)
+	}
 	return newResourceMetrics((*es.orig)[i], es.state)
 }
 
+// View returns a read-only view of the element at the given index.
+//
+// Unlike At, the element is never copied if it is shared with other slices, including when its fields
+// are accessed. The element cannot be modified through the view, call At to modify it.
+func (es ResourceMetricsSlice) View(i int) ResourceMetrics {
+	return newResourceMetrics((*es.orig)[i], internal.ReadOnlyState())
+}
+
 // All returns an iterator over index-value pairs in the slice.
 //
 //	for i, v := range es.All() {
@@ -109,6 +125,22 @@ func (es ResourceMetricsSlice) AppendEmpty() ResourceMetrics {
 func (es ResourceMetricsSlice) MoveAndAppendTo(dest ResourceMetricsSlice) {
 	es.state.AssertMutable()
 	dest.state.AssertMutable()
+	if len(es.shared) > 0 {
+		for i, orig := range *es.orig {
+			if _, ok := es.shared[orig]; !ok {
+				continue
+			}
+			if _, ok := dest.shared[orig]; ok || dest.shared == nil {
+				// The element cannot be marked as shared in dest, or is already in dest, copy it.
+				cp := &otlpmetrics.ResourceMetrics{}
+				copyOrigResourceMetrics(cp, orig)
+				(*es.orig)[i] = cp
+				continue
+			}
+			dest.shared[orig] = nil
+		}
+		clear(es.shared)
+	}
 	if *dest.orig == nil {
 		// We can simply move the entire vector and avoid any allocations.
 		*dest.orig = *es.orig
@@ -120,6 +152,9 @@ func (es ResourceMetricsSlice) MoveAndAppendTo(dest ResourceMetricsSlice) {
 
 // RemoveIf calls f sequentially for each element present in the slice.
 // If f returns true, the element is removed from the slice.
+//
+// The elements are passed to f as with At, the shared elements are copied if f modifies them.
+// Use RemoveIfView to remove the elements without copying them when f reads their fields.
 func (es ResourceMetricsSlice) RemoveIf(f func(ResourceMetrics) bool) {
 	es.state.AssertMutable()
 	newLen := 0
@@ -138,11 +173,33 @@ func (es ResourceMetricsSlice) RemoveIf(f func(ResourceMetrics) bool) {
 	*es.orig = (*es.orig)[:newLen]
 }
 
+// RemoveIfView calls f sequentially with a read-only view of each element present in the slice,
+// as returned by View. If f returns true, the element is removed from the slice.
+//
+// Unlike RemoveIf, the shared elements are not copied, and the elements kept remain shared.
+func (es ResourceMetricsSlice) RemoveIfView(f func(ResourceMetrics) bool) {
+	es.state.AssertMutable()
+	newLen := 0
+	for i := 0; i < len(*es.orig); i++ {
+		if f(es.View(i)) {
+			continue
+		}
+		(*es.orig)[newLen] = (*es.orig)[i]
+		newLen++
+	}
+	*es.orig = (*es.orig)[:newLen]
+}
+
 // CopyTo copies all elements from the current slice overriding the destination.
 func (es ResourceMetricsSlice) CopyTo(dest ResourceMetricsSlice) {
 	dest.state.AssertMutable()
 	srcLen := es.Len()
 	destCap := cap(*dest.orig)
+	if len(dest.shared) > 0 {
+		// The shared elements of dest must not be overridden, allocate new ones.
+		clear(dest.shared)
+		destCap = 0
+	}
 	if srcLen <= destCap {
 		(*dest.orig) = (*dest.orig)[:srcLen:destCap]
 		for i := range *es.orig {
@@ -162,7 +219,10 @@ func (es ResourceMetricsSlice) CopyTo(dest ResourceMetricsSlice) {
 // Sort sorts the ResourceMetrics elements within ResourceMetricsSlice given the
 // provided less function so that two instances of ResourceMetricsSlice
 // can be compared.
+//
+// The elements are passed to less as read-only views, see View.
 func (es ResourceMetricsSlice) Sort(less func(a, b ResourceMetrics) bool) {
 	es.state.AssertMutable()
-	sort.SliceStable(*es.orig, func(i, j int) bool { return less(es.At(i), es.At(j)) })
+	// The elements are compared without copying the shared ones, which remain shared once moved.
+	sort.SliceStable(*es.orig, func(i, j int) bool { return less(es.View(i), es.View(j)) })
 }
@@ -20,7 +20,7 @@ func TestResourceMetricsSlice(t *testing.T) {
 	es := NewResourceMetricsSlice()
 	assert.Equal(t, 0, es.Len())
 	state := internal.StateMutable
-	es = newResourceMetricsSlice(&[]*otlpmetrics.ResourceMetrics{}, &state)
+	es = newResourceMetricsSlice(&[]*otlpmetrics.ResourceMetrics{}, &state, nil)
 	assert.Equal(t, 0, es.Len())
 
 	emptyVal := NewResourceMetrics()
@@ -36,7 +36,7 @@ func TestResourceMetricsSlice(t *testing.T) {
 
 func TestResourceMetricsSliceReadOnly(t *testing.T) {
 	sharedState := internal.StateReadOnly
-	es := newResourceMetricsSlice(&[]*otlpmetrics.ResourceMetrics{}, &sharedState)
+	es := newResourceMetricsSlice(&[]*otlpmetrics.ResourceMetrics{}, &sharedState, nil)
 	assert.Equal(t, 0, es.Len())
 	assert.Panics(t, func() { es.AppendEmpty() })
 	assert.Panics(t, func() { es.EnsureCapacity(2) })
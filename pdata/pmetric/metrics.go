@@ -6,6 +6,7 @@ package pmetric // import "go.opentelemetry.io/collector/pdata/pmetric"
 import (
 	"go.opentelemetry.io/collector/pdata/internal"
 	otlpcollectormetrics "go.opentelemetry.io/collector/pdata/internal/data/protogen/collector/metrics/v1"
+	otlpmetrics "go.opentelemetry.io/collector/pdata/internal/data/protogen/metrics/v1"
 )
 
 // Metrics is the top-level struct that is propagated through the metrics pipeline.
@@ -14,7 +15,7 @@ type Metrics internal.Metrics
 
 func newMetrics(orig *otlpcollectormetrics.ExportMetricsServiceRequest) Metrics {
 	state := internal.StateMutable
-	return Metrics(internal.NewMetrics(orig, &state))
+	return Metrics(internal.NewMetrics(orig, &state, nil))
 }
 
 func (ms Metrics) getOrig() *otlpcollectormetrics.ExportMetricsServiceRequest {
@@ -25,9 +26,13 @@ func (ms Metrics) getState() *internal.State {
 	return internal.GetMetricsState(internal.Metrics(ms))
 }
 
+func (ms Metrics) getSharedNodes() internal.SharedNodes[otlpmetrics.ResourceMetrics] {
+	return internal.GetMetricsSharedNodes(internal.Metrics(ms))
+}
+
 // NewMetrics creates a new Metrics struct.
 func NewMetrics() Metrics {
-	return newMetrics(&otlpcollectormetrics.ExportMetricsServiceRequest{})
+	return newMetrics(internal.NewOrigMetrics())
 }
 
 // IsReadOnly returns true if this Metrics instance is read-only.
@@ -35,6 +40,19 @@ func (ms Metrics) IsReadOnly() bool {
 	return *ms.getState() == internal.StateReadOnly
 }
 
+// Release returns the memory of the Metrics to pools, for the Metrics unmarshaled from OTLP/protobuf to reuse.
+// It must only be called once the Metrics, and everything obtained from it, are no longer used:
+// modifying them afterwards panics, and reading them returns undefined data.
+// Release does nothing if the Metrics is read-only, as it is then shared with other consumers, and does
+// not release the ResourceMetrics shared with other Metrics, see Share.
+func (ms Metrics) Release() {
+	if *ms.getState() != internal.StateMutable {
+		return
+	}
+	*ms.getState() = internal.StateReleased
+	internal.ReleaseOrigMetrics(ms.getOrig(), ms.getSharedNodes())
+}
+
 // CopyTo copies the Metrics instance overriding the destination.
 func (ms Metrics) CopyTo(dest Metrics) {
 	ms.ResourceMetrics().CopyTo(dest.ResourceMetrics())
@@ -42,16 +60,15 @@ func (ms Metrics) CopyTo(dest Metrics) {
 
 // ResourceMetrics returns the ResourceMetricsSlice associated with this Metrics.
 func (ms Metrics) ResourceMetrics() ResourceMetricsSlice {
-	return newResourceMetricsSlice(&ms.getOrig().ResourceMetrics, internal.GetMetricsState(internal.Metrics(ms)))
+	return newResourceMetricsSlice(&ms.getOrig().ResourceMetrics, internal.GetMetricsState(internal.Metrics(ms)), ms.getSharedNodes())
 }
 
 // MetricCount calculates the total number of metrics.
 func (ms Metrics) MetricCount() int {
 	metricCount := 0
-	rms := ms.ResourceMetrics()
-	for i := 0; i < rms.Len(); i++ {
-		rm := rms.At(i)
-		ilms := rm.ScopeMetrics()
+	// Does not use ResourceMetrics.ScopeMetrics, which copies the shared ResourceMetrics.
+	for _, orig := range ms.getOrig().ResourceMetrics {
+		ilms := newResourceMetrics(orig, ms.getState()).ScopeMetrics()
 		for j := 0; j < ilms.Len(); j++ {
 			ilm := ilms.At(j)
 			metricCount += ilm.Metrics().Len()
@@ -62,10 +79,8 @@ func (ms Metrics) MetricCount() int {
 
 // DataPointCount calculates the total number of data points.
 func (ms Metrics) DataPointCount() (dataPointCount int) {
-	rms := ms.ResourceMetrics()
-	for i := 0; i < rms.Len(); i++ {
-		rm := rms.At(i)
-		ilms := rm.ScopeMetrics()
+	for _, orig := range ms.getOrig().ResourceMetrics {
+		ilms := newResourceMetrics(orig, ms.getState()).ScopeMetrics()
 		for j := 0; j < ilms.Len(); j++ {
 			ilm := ilms.At(j)
 			ms := ilm.Metrics()
@@ -93,3 +108,16 @@ func (ms Metrics) DataPointCount() (dataPointCount int) {
 func (ms Metrics) MarkReadOnly() {
 	internal.SetMetricsState(internal.Metrics(ms), internal.StateReadOnly)
 }
+
+// Share marks the Metrics as read-only, and returns a mutable Metrics sharing its ResourceMetrics.
+// The shared ResourceMetrics are copied on write: a shared ResourceMetrics is copied the first time a method which
+// may modify it is called, e.g. ResourceMetrics.Resource or ResourceMetrics.SetSchemaUrl, so that only the ResourceMetrics
+// which are modified are copied. Share can be used instead of CopyTo to send the same Metrics to several
+// consumers modifying it.
+func (ms Metrics) Share() Metrics {
+	ms.MarkReadOnly()
+	orig := internal.NewOrigMetrics()
+	orig.ResourceMetrics = append(orig.ResourceMetrics, ms.getOrig().ResourceMetrics...)
+	state := internal.StateMutable
+	return Metrics(internal.NewMetrics(orig, &state, internal.NewSharedNodes(orig.ResourceMetrics)))
+}
@@ -637,6 +637,22 @@ func TestMetricsCopyTo(t *testing.T) {
 	assert.Equal(t, metrics, metricsCopy)
 }
 
+func TestMetricsRelease(t *testing.T) {
+	metrics := NewMetrics()
+	fillTestResourceMetricsSlice(metrics.ResourceMetrics())
+	metrics.Release()
+	assert.PanicsWithValue(t, "invalid access to released data", func() { metrics.ResourceMetrics().AppendEmpty() })
+	assert.Equal(t, NewMetrics(), NewMetrics())
+}
+
+func TestMetricsReleaseReadOnly(t *testing.T) {
+	metrics := NewMetrics()
+	metrics.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetName("value")
+	metrics.MarkReadOnly()
+	metrics.Release()
+	assert.Equal(t, "value", metrics.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Name())
+}
+
 func TestReadOnlyMetricsInvalidUsage(t *testing.T) {
 	metrics := NewMetrics()
 	assert.False(t, metrics.IsReadOnly())
@@ -647,6 +663,24 @@ func TestReadOnlyMetricsInvalidUsage(t *testing.T) {
 	assert.Panics(t, func() { res.Attributes().PutStr("k2", "v2") })
 }
 
+func TestMetricsShare(t *testing.T) {
+	metrics := NewMetrics()
+	fillTestResourceMetricsSlice(metrics.ResourceMetrics())
+	shared := metrics.Share()
+	assert.True(t, metrics.IsReadOnly())
+	assert.False(t, shared.IsReadOnly())
+	assert.Equal(t, metrics.DataPointCount(), shared.DataPointCount())
+	for i, orig := range shared.getOrig().ResourceMetrics {
+		assert.Same(t, metrics.getOrig().ResourceMetrics[i], orig)
+	}
+
+	// Only the accessed ResourceMetrics is copied, the original is not modified.
+	shared.ResourceMetrics().At(1).SetSchemaUrl("changed")
+	assert.NotSame(t, metrics.getOrig().ResourceMetrics[1], shared.getOrig().ResourceMetrics[1])
+	assert.Same(t, metrics.getOrig().ResourceMetrics[2], shared.getOrig().ResourceMetrics[2])
+	assert.Equal(t, *generateTestResourceMetricsSlice().orig, metrics.getOrig().ResourceMetrics)
+}
+
 func BenchmarkOtlpToFromInternal_PassThrough(b *testing.B) {
 	req := &otlpcollectormetrics.ExportMetricsServiceRequest{
 		ResourceMetrics: []*otlpmetrics.ResourceMetrics{
@@ -5,7 +5,6 @@ package pmetric // import "go.opentelemetry.io/collector/pdata/pmetric"
 
 import (
 	"go.opentelemetry.io/collector/pdata/internal"
-	otlpmetrics "go.opentelemetry.io/collector/pdata/internal/data/protogen/metrics/v1"
 )
 
 var _ MarshalSizer = (*ProtoMarshaler)(nil)
@@ -53,7 +52,7 @@ func (e *ProtoMarshaler) ExponentialHistogramDataPointSize(ehdp ExponentialHisto
 type ProtoUnmarshaler struct{}
 
 func (d *ProtoUnmarshaler) UnmarshalMetrics(buf []byte) (Metrics, error) {
-	pb := otlpmetrics.MetricsData{}
-	err := pb.Unmarshal(buf)
-	return Metrics(internal.MetricsFromProto(pb)), err
+	orig := internal.NewOrigMetrics()
+	err := internal.UnmarshalOrigMetrics(orig, buf)
+	return newMetrics(orig), err
 }
@@ -10,6 +10,8 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"go.opentelemetry.io/collector/pdata/internal"
+	otlpmetrics "go.opentelemetry.io/collector/pdata/internal/data/protogen/metrics/v1"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 )
 
@@ -19,6 +21,22 @@ func TestProtoMetricsUnmarshalerError(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestProtoMetricsUnmarshalerPooled(t *testing.T) {
+	metrics := NewMetrics()
+	fillTestResourceMetricsSlice(metrics.ResourceMetrics())
+	buf, err := (&ProtoMarshaler{}).MarshalMetrics(metrics)
+	require.NoError(t, err)
+
+	expected := otlpmetrics.MetricsData{}
+	require.NoError(t, expected.Unmarshal(buf))
+	for i := 0; i < 3; i++ {
+		got, err := (&ProtoUnmarshaler{}).UnmarshalMetrics(buf)
+		require.NoError(t, err)
+		assert.Equal(t, Metrics(internal.MetricsFromProto(expected)), got)
+		got.Release()
+	}
+}
+
 func TestProtoSizer(t *testing.T) {
 	marshaler := &ProtoMarshaler{}
 	md := NewMetrics()
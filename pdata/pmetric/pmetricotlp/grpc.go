@@ -74,8 +74,43 @@ func (*UnimplementedGRPCServer) Export(context.Context, ExportRequest) (ExportRe
 func (*UnimplementedGRPCServer) unexported() {}
 
 // RegisterGRPCServer registers the GRPCServer to the grpc.Server.
+// The requests are decoded into the memory released with pmetric.Metrics.Release, if any.
 func RegisterGRPCServer(s *grpc.Server, srv GRPCServer) {
-	otlpcollectormetrics.RegisterMetricsServiceServer(s, &rawMetricsServer{srv: srv})
+	s.RegisterService(&serviceDesc, &rawMetricsServer{srv: srv})
+}
+
+// serviceDesc is the service of the generated otlpcollectormetrics.RegisterMetricsServiceServer, decoding the
+// requests from the pools.
+var serviceDesc = grpc.ServiceDesc{
+	ServiceName: "opentelemetry.proto.collector.metrics.v1.MetricsService",
+	HandlerType: (*otlpcollectormetrics.MetricsServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Export",
+			Handler:    exportHandler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "opentelemetry/proto/collector/metrics/v1/metrics_service.proto",
+}
+
+func exportHandler(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+	in := &internal.MetricsGRPCRequest{Orig: internal.NewOrigMetrics()}
+	if err := dec(in); err != nil {
+		internal.ReleaseOrigMetrics(in.Orig, nil)
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(otlpcollectormetrics.MetricsServiceServer).Export(ctx, in.Orig)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/opentelemetry.proto.collector.metrics.v1.MetricsService/Export",
+	}
+	handler := func(ctx context.Context, req any) (any, error) {
+		return srv.(otlpcollectormetrics.MetricsServiceServer).Export(ctx, req.(*internal.MetricsGRPCRequest).Orig)
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
 type rawMetricsServer struct {
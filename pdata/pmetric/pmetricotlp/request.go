@@ -8,6 +8,7 @@ import (
 
 	"go.opentelemetry.io/collector/pdata/internal"
 	otlpcollectormetrics "go.opentelemetry.io/collector/pdata/internal/data/protogen/collector/metrics/v1"
+	otlpmetrics "go.opentelemetry.io/collector/pdata/internal/data/protogen/metrics/v1"
 	"go.opentelemetry.io/collector/pdata/internal/json"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 )
@@ -17,15 +18,16 @@ var jsonUnmarshaler = &pmetric.JSONUnmarshaler{}
 // ExportRequest represents the request for gRPC/HTTP client/server.
 // It's a wrapper for pmetric.Metrics data.
 type ExportRequest struct {
-	orig  *otlpcollectormetrics.ExportMetricsServiceRequest
-	state *internal.State
+	orig   *otlpcollectormetrics.ExportMetricsServiceRequest
+	state  *internal.State
+	shared internal.SharedNodes[otlpmetrics.ResourceMetrics]
 }
 
 // NewExportRequest returns an empty ExportRequest.
 func NewExportRequest() ExportRequest {
 	state := internal.StateMutable
 	return ExportRequest{
-		orig:  &otlpcollectormetrics.ExportMetricsServiceRequest{},
+		orig:  internal.NewOrigMetrics(),
 		state: &state,
 	}
 }
@@ -35,8 +37,9 @@ func NewExportRequest() ExportRequest {
 // any changes to the provided Metrics struct will be reflected in the ExportRequest and vice versa.
 func NewExportRequestFromMetrics(md pmetric.Metrics) ExportRequest {
 	return ExportRequest{
-		orig:  internal.GetOrigMetrics(internal.Metrics(md)),
-		state: internal.GetMetricsState(internal.Metrics(md)),
+		orig:   internal.GetOrigMetrics(internal.Metrics(md)),
+		state:  internal.GetMetricsState(internal.Metrics(md)),
+		shared: internal.GetMetricsSharedNodes(internal.Metrics(md)),
 	}
 }
 
@@ -47,7 +50,7 @@ func (ms ExportRequest) MarshalProto() ([]byte, error) {
 
 // UnmarshalProto unmarshalls ExportRequest from proto bytes.
 func (ms ExportRequest) UnmarshalProto(data []byte) error {
-	return ms.orig.Unmarshal(data)
+	return internal.UnmarshalOrigMetrics(ms.orig, data)
 }
 
 // MarshalJSON marshals ExportRequest into JSON bytes.
@@ -70,5 +73,5 @@ func (ms ExportRequest) UnmarshalJSON(data []byte) error {
 }
 
 func (ms ExportRequest) Metrics() pmetric.Metrics {
-	return pmetric.Metrics(internal.NewMetrics(ms.orig, ms.state))
+	return pmetric.Metrics(internal.NewMetrics(ms.orig, ms.state, ms.shared))
 }
@@ -0,0 +1,39 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pmetric // import "go.opentelemetry.io/collector/pdata/pmetric"
+
+import (
+	"io"
+
+	"go.opentelemetry.io/collector/pdata/internal"
+	otlpmetrics "go.opentelemetry.io/collector/pdata/internal/data/protogen/metrics/v1"
+	"go.opentelemetry.io/collector/pdata/internal/otlp"
+	"go.opentelemetry.io/collector/pdata/internal/protostream"
+)
+
+// ProtoDecoder decodes metrics in the OTLP/protobuf format, i.e. a marshaled ExportMetricsServiceRequest or MetricsData,
+// from an io.Reader one ResourceMetrics at a time, so that only one ResourceMetrics is held in memory at once.
+type ProtoDecoder struct {
+	r *protostream.Reader
+}
+
+// NewProtoDecoder creates a ProtoDecoder reading from r.
+func NewProtoDecoder(r io.Reader) *ProtoDecoder {
+	return &ProtoDecoder{r: protostream.NewReader(r)}
+}
+
+// DecodeResourceMetrics returns the next ResourceMetrics. It returns io.EOF once all the ResourceMetrics were read.
+func (d *ProtoDecoder) DecodeResourceMetrics() (ResourceMetrics, error) {
+	buf, err := d.r.Next(1)
+	if err != nil {
+		return ResourceMetrics{}, err
+	}
+	orig := &otlpmetrics.ResourceMetrics{}
+	if err = orig.Unmarshal(buf); err != nil {
+		return ResourceMetrics{}, err
+	}
+	otlp.MigrateMetrics([]*otlpmetrics.ResourceMetrics{orig})
+	state := internal.StateMutable
+	return newResourceMetrics(orig, &state), nil
+}
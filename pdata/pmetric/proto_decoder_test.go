@@ -0,0 +1,49 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pmetric
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProtoDecoder(t *testing.T) {
+	md := NewMetrics()
+	fillTestResourceMetrics(md.ResourceMetrics().AppendEmpty())
+	md.ResourceMetrics().AppendEmpty()
+	fillTestResourceMetrics(md.ResourceMetrics().AppendEmpty())
+	md.ResourceMetrics().At(2).Resource().Attributes().PutStr("last", "true")
+	buf, err := (&ProtoMarshaler{}).MarshalMetrics(md)
+	require.NoError(t, err)
+
+	decoded := NewMetrics()
+	d := NewProtoDecoder(bytes.NewReader(buf))
+	for {
+		rm, err := d.DecodeResourceMetrics()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		rm.MoveTo(decoded.ResourceMetrics().AppendEmpty())
+	}
+	assert.Equal(t, md, decoded)
+}
+
+func TestProtoDecoderEmpty(t *testing.T) {
+	_, err := NewProtoDecoder(bytes.NewReader(nil)).DecodeResourceMetrics()
+	assert.ErrorIs(t, err, io.EOF)
+}
+
+func TestProtoDecoderInvalid(t *testing.T) {
+	_, err := NewProtoDecoder(bytes.NewReader([]byte{0x0a, 0x02, 0x0a, 0x05})).DecodeResourceMetrics()
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, io.EOF)
+
+	_, err = NewProtoDecoder(bytes.NewReader([]byte{0x0a, 0x05, 0x0a})).DecodeResourceMetrics()
+	assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+}
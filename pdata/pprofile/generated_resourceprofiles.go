@@ -22,12 +22,36 @@ import (
 type ResourceProfiles struct {
 	orig  *otlpprofiles.ResourceProfiles
 	state *internal.State
+	// nodes is the slice of the ResourceProfiles, if it may be shared with other slices.
+	nodes  *[]*otlpprofiles.ResourceProfiles
+	shared internal.SharedNodes[otlpprofiles.ResourceProfiles]
 }
 
 func newResourceProfiles(orig *otlpprofiles.ResourceProfiles, state *internal.State) ResourceProfiles {
 	return ResourceProfiles{orig: orig, state: state}
 }
 
+// newSharedResourceProfiles returns the ResourceProfiles of the given slice, which may be shared with other slices.
+func newSharedResourceProfiles(orig *otlpprofiles.ResourceProfiles, state *internal.State, nodes *[]*otlpprofiles.ResourceProfiles, shared internal.SharedNodes[otlpprofiles.ResourceProfiles]) ResourceProfiles {
+	return ResourceProfiles{orig: orig, state: state, nodes: nodes, shared: shared}
+}
+
+// getOrig returns the ResourceProfiles to read: its copy if it was shared and was copied since.
+func (ms ResourceProfiles) getOrig() *otlpprofiles.ResourceProfiles {
+	return ms.shared.Get(ms.orig)
+}
+
+// getMutableOrig returns the ResourceProfiles to modify: if it is shared with other slices, it is
+// first copied and replaced by its copy in its slice.
+func (ms ResourceProfiles) getMutableOrig() *otlpprofiles.ResourceProfiles {
+	return ms.shared.GetMutable(ms.orig, ms.nodes, ms.state, copyOrigResourceProfiles)
+}
+
+func copyOrigResourceProfiles(dest, src *otlpprofiles.ResourceProfiles) {
+	state := internal.StateMutable
+	newResourceProfiles(src, &state).CopyTo(newResourceProfiles(dest, &state))
+}
+
 // NewResourceProfiles creates a new empty ResourceProfiles.
 //
 // This must be used only in testing code. Users should use "AppendEmpty" when part of a Slice,
@@ -42,34 +66,38 @@ func NewResourceProfiles() ResourceProfiles {
 func (ms ResourceProfiles) MoveTo(dest ResourceProfiles) {
 	ms.state.AssertMutable()
 	dest.state.AssertMutable()
-	*dest.orig = *ms.orig
-	*ms.orig = otlpprofiles.ResourceProfiles{}
+	// The shared ResourceProfiles are copied first, the other slices keep them.
+	orig := ms.getMutableOrig()
+	*dest.getMutableOrig() = *orig
+	*orig = otlpprofiles.ResourceProfiles{}
 }
 
 // Resource returns the resource associated with this ResourceProfiles.
 func (ms ResourceProfiles) Resource() pcommon.Resource {
-	return pcommon.Resource(internal.NewResource(&ms.orig.Resource, ms.state))
+	return pcommon.Resource(internal.NewResource(&ms.getMutableOrig().Resource, ms.state))
 }
 
 // SchemaUrl returns the schemaurl associated with this ResourceProfiles.
 func (ms ResourceProfiles) SchemaUrl() string {
-	return ms.orig.SchemaUrl
+	return ms.getOrig().SchemaUrl
 }
 
 // SetSchemaUrl replaces the schemaurl associated with this ResourceProfiles.
 func (ms ResourceProfiles) SetSchemaUrl(v string) {
 	ms.state.AssertMutable()
-	ms.orig.SchemaUrl = v
+	ms.getMutableOrig().SchemaUrl = v
 }
 
 // ScopeProfiles returns the ScopeProfiles associated with this ResourceProfiles.
 func (ms ResourceProfiles) ScopeProfiles() ScopeProfilesSlice {
-	return newScopeProfilesSlice(&ms.orig.ScopeProfiles, ms.state)
+	return newScopeProfilesSlice(&ms.getMutableOrig().ScopeProfiles, ms.state)
 }
 
 // CopyTo copies all properties from the current struct overriding the destination.
 func (ms ResourceProfiles) CopyTo(dest ResourceProfiles) {
 	dest.state.AssertMutable()
+	// Reading ms does not copy it if it is shared.
+	ms = newResourceProfiles(ms.getOrig(), ms.state)
 	ms.Resource().CopyTo(dest.Resource())
 	dest.SetSchemaUrl(ms.SchemaUrl())
 	ms.ScopeProfiles().CopyTo(dest.ScopeProfiles())
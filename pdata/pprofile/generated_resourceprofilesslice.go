@@ -22,12 +22,13 @@ import (
 // Must use NewResourceProfilesSlice function to create new instances.
 // Important: zero-initialized instance is not valid for use.
 type ResourceProfilesSlice struct {
-	orig  *[]*otlpprofiles.ResourceProfiles
-	state *internal.State
+	orig   *[]*otlpprofiles.ResourceProfiles
+	state  *internal.State
+	shared internal.SharedNodes[otlpprofiles.ResourceProfiles]
 }
 
-func newResourceProfilesSlice(orig *[]*otlpprofiles.ResourceProfiles, state *internal.State) ResourceProfilesSlice {
-	return ResourceProfilesSlice{orig: orig, state: state}
+func newResourceProfilesSlice(orig *[]*otlpprofiles.ResourceProfiles, state *internal.State, shared internal.SharedNodes[otlpprofiles.ResourceProfiles]) ResourceProfilesSlice {
+	return ResourceProfilesSlice{orig: orig, state: state, shared: shared}
 }
 
 // NewResourceProfilesSlice creates a ResourceProfilesSlice with 0 elements.
@@ -35,7 +36,7 @@ func newResourceProfilesSlice(orig *[]*otlpprofiles.ResourceProfiles, state *int
 func NewResourceProfilesSlice() ResourceProfilesSlice {
 	orig := []*otlpprofiles.ResourceProfiles(nil)
 	state := internal.StateMutable
-	return newResourceProfilesSlice(&orig, &state)
+	return newResourceProfilesSlice(&orig, &state, nil)
 }
 
 // Len returns the number of elements in the slice.
@@ -53,10 +54,25 @@ func (es ResourceProfilesSlice) Len() int {
 //	    e := es.At(i)
 //	    ... // Do something with the element
 //	}
+//
+// If the element is shared with other slices, it is not copied until it may be modified: by its setters,
+// or by the accessors of its fields, since the values they return refer to its memory. Use View to read
+// its fields without copying it.
 func (es ResourceProfilesSlice) At(i int) ResourceProfiles {
+	if es.shared != nil {
+		return newSharedResourceProfiles((*es.orig)[i], es.state, es.orig, es.shared)
+	}
 	return newResourceProfiles((*es.orig)[i], es.state)
 }
 
+// View returns a read-only view of the element at the given index.
+//
+// Unlike At, the element is never copied if it is shared with other slices, including when its fields
+// are accessed. The element cannot be modified through the view, call At to modify it.
+func (es ResourceProfilesSlice) View(i int) ResourceProfiles {
+	return newResourceProfiles((*es.orig)[i], internal.ReadOnlyState())
+}
+
 // All returns an iterator over index-value pairs in the slice.
 //
 //	for i, v := range es.All() {
@@ -109,6 +125,22 @@ func (es ResourceProfilesSlice) AppendEmpty() ResourceProfiles {
 func (es ResourceProfilesSlice) MoveAndAppendTo(dest ResourceProfilesSlice) {
 	es.state.AssertMutable()
 	dest.state.AssertMutable()
+	if len(es.shared) > 0 {
+		for i, orig := range *es.orig {
+			if _, ok := es.shared[orig]; !ok {
+				continue
+			}
+			if _, ok := dest.shared[orig]; ok || dest.shared == nil {
+				// The element cannot be marked as shared in dest, or is already in dest, copy it.
+				cp := &otlpprofiles.ResourceProfiles{}
+				copyOrigResourceProfiles(cp, orig)
+				(*es.orig)[i] = cp
+				continue
+			}
+			dest.shared[orig] = nil
+		}
+		clear(es.shared)
+	}
 	if *dest.orig == nil {
 		// We can simply move the entire vector and avoid any allocations.
 		*dest.orig = *es.orig
@@ -120,6 +152,9 @@ func (es ResourceProfilesSlice) MoveAndAppendTo(dest ResourceProfilesSlice) {
 
 // RemoveIf calls f sequentially for each element present in the slice.
 // If f returns true, the element is removed from the slice.
+//
+// The elements are passed to f as with At, the shared elements are copied if f modifies them.
+// Use RemoveIfView to remove the elements without copying them when f reads their fields.
 func (es ResourceProfilesSlice) RemoveIf(f func(ResourceProfiles) bool) {
 	es.state.AssertMutable()
 	newLen := 0
@@ -138,11 +173,33 @@ func (es ResourceProfilesSlice) RemoveIf(f func(ResourceProfiles) bool) {
 	*es.orig = (*es.orig)[:newLen]
 }
 
+// RemoveIfView calls f sequentially with a read-only view of each element present in the slice,
+// as returned by View. If f returns true, the element is removed from the slice.
+//
+// Unlike RemoveIf, the shared elements are not copied, and the elements kept remain shared.
+func (es ResourceProfilesSlice) RemoveIfView(f func(ResourceProfiles) bool) {
+	es.state.AssertMutable()
+	newLen := 0
+	for i := 0; i < len(*es.orig); i++ {
+		if f(es.View(i)) {
+			continue
+		}
+		(*es.orig)[newLen] = (*es.orig)[i]
+		newLen++
+	}
+	*es.orig = (*es.orig)[:newLen]
+}
+
 // CopyTo copies all elements from the current slice overriding the destination.
 func (es ResourceProfilesSlice) CopyTo(dest ResourceProfilesSlice) {
 	dest.state.AssertMutable()
 	srcLen := es.Len()
 	destCap := cap(*dest.orig)
+	if len(dest.shared) > 0 {
+		// The shared elements of dest must not be overridden, allocate new ones.
+		clear(dest.shared)
+		destCap = 0
+	}
 	if srcLen <= destCap {
 		(*dest.orig) = (*dest.orig)[:srcLen:destCap]
 		for i := range *es.orig {
@@ -162,7 +219,10 @@ func (es ResourceProfilesSlice) CopyTo(dest ResourceProfilesSlice) {
 // Sort sorts the ResourceProfiles elements within ResourceProfilesSlice given the
 // provided less function so that two instances of ResourceProfilesSlice
 // can be compared.
+//
+// The elements are passed to less as read-only views, see View.
 func (es ResourceProfilesSlice) Sort(less func(a, b ResourceProfiles) bool) {
 	es.state.AssertMutable()
-	sort.SliceStable(*es.orig, func(i, j int) bool { return less(es.At(i), es.At(j)) })
+	// The elements are compared without copying the shared ones, which remain shared once moved.
+	sort.SliceStable(*es.orig, func(i, j int) bool { return less(es.View(i), es.View(j)) })
 }
@@ -20,7 +20,7 @@ func TestResourceProfilesSlice(t *testing.T) {
 	es := NewResourceProfilesSlice()
 	assert.Equal(t, 0, es.Len())
 	state := internal.StateMutable
-	es = newResourceProfilesSlice(&[]*otlpprofiles.ResourceProfiles{}, &state)
+	es = newResourceProfilesSlice(&[]*otlpprofiles.ResourceProfiles{}, &state, nil)
 	assert.Equal(t, 0, es.Len())
 
 	emptyVal := NewResourceProfiles()
@@ -36,7 +36,7 @@ func TestResourceProfilesSlice(t *testing.T) {
 
 func TestResourceProfilesSliceReadOnly(t *testing.T) {
 	sharedState := internal.StateReadOnly
-	es := newResourceProfilesSlice(&[]*otlpprofiles.ResourceProfiles{}, &sharedState)
+	es := newResourceProfilesSlice(&[]*otlpprofiles.ResourceProfiles{}, &sharedState, nil)
 	assert.Equal(t, 0, es.Len())
 	assert.Panics(t, func() { es.AppendEmpty() })
 	assert.Panics(t, func() { es.EnsureCapacity(2) })
@@ -0,0 +1,470 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pprofile // import "go.opentelemetry.io/collector/pdata/pprofile"
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// The attributes holding the fields of the pprof format that have no equivalent in a Profile.
+const (
+	pprofBuildIDKey    = "pprof.mapping.build_id"
+	pprofDropFramesKey = "pprof.profile.drop_frames"
+	pprofKeepFramesKey = "pprof.profile.keep_frames"
+	pprofDocURLKey     = "pprof.profile.doc_url"
+)
+
+var _ Marshaler = (*PprofMarshaler)(nil)
+
+// PprofMarshaler marshals pprofile.Profiles holding a single Profile to the gzip-compressed
+// pprof profile.proto format, as written by runtime/pprof and read by `go tool pprof`.
+//
+// The string table of the Profile is kept, and the mapping, location and function tables are
+// kept in order, with IDs starting at 1. The attributes of the samples become labels: integer
+// attributes become numeric labels, with the unit set in the attribute units of the Profile,
+// other attributes become string labels. The build ID of the mappings, and the drop frames,
+// keep frames and documentation URL of the profile, are read from the "pprof.mapping.build_id",
+// "pprof.profile.drop_frames", "pprof.profile.keep_frames" and "pprof.profile.doc_url" attributes.
+type PprofMarshaler struct{}
+
+// MarshalProfiles to the pprof format.
+func (*PprofMarshaler) MarshalProfiles(pd Profiles) ([]byte, error) {
+	var src Profile
+	found := 0
+	for _, rp := range pd.ResourceProfiles().All() {
+		for _, sp := range rp.ScopeProfiles().All() {
+			for _, p := range sp.Profiles().All() {
+				src = p
+				found++
+			}
+		}
+	}
+	if found != 1 {
+		return nil, fmt.Errorf("the pprof format holds a single profile, got %d", found)
+	}
+
+	exp := pprofExporter{src: src, strindices: make(map[string]int64)}
+	pb := exp.export()
+	if exp.err != nil {
+		return nil, exp.err
+	}
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(pb.marshal(nil)); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+var _ Unmarshaler = (*PprofUnmarshaler)(nil)
+
+// PprofUnmarshaler unmarshals profiles in the pprof profile.proto format, gzip-compressed or not,
+// into pprofile.Profiles holding a single Profile. See PprofMarshaler for how the fields of the
+// pprof format are kept.
+type PprofUnmarshaler struct{}
+
+// UnmarshalProfiles from the pprof format into pprofile.Profiles.
+func (*PprofUnmarshaler) UnmarshalProfiles(buf []byte) (Profiles, error) {
+	if len(buf) >= 2 && buf[0] == 0x1f && buf[1] == 0x8b {
+		zr, err := gzip.NewReader(bytes.NewReader(buf))
+		if err != nil {
+			return Profiles{}, err
+		}
+		if buf, err = io.ReadAll(zr); err != nil {
+			return Profiles{}, err
+		}
+	}
+
+	var pb pprofProfile
+	if err := pb.unmarshal(buf); err != nil {
+		return Profiles{}, fmt.Errorf("invalid pprof profile: %w", err)
+	}
+
+	pd := NewProfiles()
+	dest := pd.ResourceProfiles().AppendEmpty().ScopeProfiles().AppendEmpty().Profiles().AppendEmpty()
+	imp := pprofImporter{src: &pb, dest: dest}
+	imp.importProfile()
+	if imp.err != nil {
+		return Profiles{}, fmt.Errorf("invalid pprof profile: %w", imp.err)
+	}
+	return pd, nil
+}
+
+// pprofImporter converts a pprof profile to a Profile, recording the first error it meets.
+type pprofImporter struct {
+	src  *pprofProfile
+	dest Profile
+	err  error
+}
+
+func (imp *pprofImporter) fail(err error) {
+	if imp.err == nil {
+		imp.err = err
+	}
+}
+
+func (imp *pprofImporter) strindex(i int64) int32 {
+	if i < 0 || i >= int64(len(imp.src.stringTable)) || i > math.MaxInt32 {
+		imp.fail(fmt.Errorf("string index %d out of range", i))
+		return 0
+	}
+	return int32(i)
+}
+
+func (imp *pprofImporter) str(i int64) string {
+	return imp.src.stringTable[imp.strindex(i)]
+}
+
+// addStrAttribute adds a string attribute to record, unless its string index is 0, i.e. the field is not set.
+func (imp *pprofImporter) addStrAttribute(record attributable, key string, strindex int64) {
+	if strindex == 0 {
+		return
+	}
+	if err := AddAttribute(imp.dest.AttributeTable(), record, key, pcommon.NewValueStr(imp.str(strindex))); err != nil {
+		imp.fail(err)
+	}
+}
+
+// indices maps the IDs of the entries of a pprof table to their index.
+func indices(n int, id func(int) uint64) (map[uint64]int32, error) {
+	if n > math.MaxInt32 {
+		return nil, fmt.Errorf("too many entries: %d", n)
+	}
+	m := make(map[uint64]int32, n)
+	for i := 0; i < n; i++ {
+		if id(i) == 0 {
+			return nil, errors.New("ID 0 is reserved")
+		}
+		m[id(i)] = int32(i) //nolint:gosec // overflow checked
+	}
+	return m, nil
+}
+
+func (imp *pprofImporter) importProfile() {
+	src, dest := imp.src, imp.dest
+	if len(src.stringTable) == 0 || src.stringTable[0] != "" {
+		imp.fail(errors.New("the first string of the string table must be empty"))
+		return
+	}
+	dest.StringTable().FromRaw(src.stringTable)
+
+	for _, st := range src.sampleTypes {
+		imp.importValueType(st, dest.SampleType().AppendEmpty())
+	}
+	if src.periodType != (pprofValueType{}) {
+		imp.importValueType(src.periodType, dest.PeriodType())
+	}
+	dest.SetPeriod(src.period)
+	dest.SetTime(pcommon.Timestamp(src.timeNanos))         //nolint:gosec // timestamps are positive
+	dest.SetDuration(pcommon.Timestamp(src.durationNanos)) //nolint:gosec // durations are positive
+	for _, c := range src.commentStrindices {
+		dest.CommentStrindices().Append(imp.strindex(c))
+	}
+	dest.SetDefaultSampleTypeStrindex(imp.strindex(src.defaultSampleTypeStrindex))
+	imp.addStrAttribute(dest, pprofDropFramesKey, src.dropFramesStrindex)
+	imp.addStrAttribute(dest, pprofKeepFramesKey, src.keepFramesStrindex)
+	imp.addStrAttribute(dest, pprofDocURLKey, src.docURLStrindex)
+
+	mappingIndices, err := indices(len(src.mappings), func(i int) uint64 { return src.mappings[i].id })
+	if err != nil {
+		imp.fail(fmt.Errorf("mapping table: %w", err))
+		return
+	}
+	dest.MappingTable().EnsureCapacity(len(src.mappings))
+	for _, m := range src.mappings {
+		mapping := dest.MappingTable().AppendEmpty()
+		mapping.SetMemoryStart(m.memoryStart)
+		mapping.SetMemoryLimit(m.memoryLimit)
+		mapping.SetFileOffset(m.fileOffset)
+		mapping.SetFilenameStrindex(imp.strindex(m.filenameStrindex))
+		mapping.SetHasFunctions(m.hasFunctions)
+		mapping.SetHasFilenames(m.hasFilenames)
+		mapping.SetHasLineNumbers(m.hasLineNumbers)
+		mapping.SetHasInlineFrames(m.hasInlineFrames)
+		imp.addStrAttribute(mapping, pprofBuildIDKey, m.buildIDStrindex)
+	}
+
+	functionIndices, err := indices(len(src.functions), func(i int) uint64 { return src.functions[i].id })
+	if err != nil {
+		imp.fail(fmt.Errorf("function table: %w", err))
+		return
+	}
+	dest.FunctionTable().EnsureCapacity(len(src.functions))
+	for _, f := range src.functions {
+		function := dest.FunctionTable().AppendEmpty()
+		function.SetNameStrindex(imp.strindex(f.nameStrindex))
+		function.SetSystemNameStrindex(imp.strindex(f.systemNameStrindex))
+		function.SetFilenameStrindex(imp.strindex(f.filenameStrindex))
+		function.SetStartLine(f.startLine)
+	}
+
+	locationIndices, err := indices(len(src.locations), func(i int) uint64 { return src.locations[i].id })
+	if err != nil {
+		imp.fail(fmt.Errorf("location table: %w", err))
+		return
+	}
+	dest.LocationTable().EnsureCapacity(len(src.locations))
+	for _, l := range src.locations {
+		location := dest.LocationTable().AppendEmpty()
+		if l.mappingID != 0 {
+			idx, ok := mappingIndices[l.mappingID]
+			if !ok {
+				imp.fail(fmt.Errorf("location %d: unknown mapping ID %d", l.id, l.mappingID))
+			}
+			location.SetMappingIndex(idx)
+		}
+		location.SetAddress(l.address)
+		location.SetIsFolded(l.isFolded)
+		location.Line().EnsureCapacity(len(l.lines))
+		for _, ln := range l.lines {
+			idx, ok := functionIndices[ln.functionID]
+			if !ok {
+				imp.fail(fmt.Errorf("location %d: unknown function ID %d", l.id, ln.functionID))
+			}
+			line := location.Line().AppendEmpty()
+			line.SetFunctionIndex(idx)
+			line.SetLine(ln.line)
+			line.SetColumn(ln.column)
+		}
+	}
+
+	units := make(map[int64]struct{})
+	dest.Sample().EnsureCapacity(len(src.samples))
+	for _, s := range src.samples {
+		sample := dest.Sample().AppendEmpty()
+		sample.SetLocationsStartIndex(int32(dest.LocationIndices().Len())) //nolint:gosec // bounded by the number of samples
+		sample.SetLocationsLength(int32(len(s.locationIDs)))               //nolint:gosec // bounded by the number of locations
+		for _, id := range s.locationIDs {
+			idx, ok := locationIndices[id]
+			if !ok {
+				imp.fail(fmt.Errorf("sample: unknown location ID %d", id))
+			}
+			dest.LocationIndices().Append(idx)
+		}
+		sample.Value().FromRaw(s.values)
+
+		for _, l := range s.labels {
+			value := pcommon.NewValueInt(l.num)
+			if l.strStrindex != 0 {
+				value = pcommon.NewValueStr(imp.str(l.strStrindex))
+			}
+			if err := AddAttribute(dest.AttributeTable(), sample, imp.str(l.keyStrindex), value); err != nil {
+				imp.fail(err)
+			}
+			if _, ok := units[l.keyStrindex]; l.numUnitStrindex != 0 && !ok {
+				units[l.keyStrindex] = struct{}{}
+				unit := dest.AttributeUnits().AppendEmpty()
+				unit.SetAttributeKeyStrindex(imp.strindex(l.keyStrindex))
+				unit.SetUnitStrindex(imp.strindex(l.numUnitStrindex))
+			}
+		}
+	}
+}
+
+func (imp *pprofImporter) importValueType(src pprofValueType, dest ValueType) {
+	dest.SetTypeStrindex(imp.strindex(src.typeStrindex))
+	dest.SetUnitStrindex(imp.strindex(src.unitStrindex))
+}
+
+// pprofExporter converts a Profile to a pprof profile, recording the first error it meets.
+type pprofExporter struct {
+	src Profile
+	err error
+
+	stringTable []string
+	// strindices indexes the string table, it is filled the first time a string is looked up.
+	strindices map[string]int64
+}
+
+func (exp *pprofExporter) fail(err error) {
+	if exp.err == nil {
+		exp.err = err
+	}
+}
+
+// strindex checks a string index of the Profile, which is also an index of the pprof string table.
+func (exp *pprofExporter) strindex(i int32) int64 {
+	if i < 0 || int(i) >= len(exp.stringTable) {
+		exp.fail(fmt.Errorf("string index %d out of range", i))
+		return 0
+	}
+	return int64(i)
+}
+
+// strindexOf returns the index of s in the pprof string table, adding it if needed.
+func (exp *pprofExporter) strindexOf(s string) int64 {
+	if len(exp.strindices) == 0 {
+		for i := len(exp.stringTable) - 1; i >= 0; i-- {
+			exp.strindices[exp.stringTable[i]] = int64(i)
+		}
+	}
+	if i, ok := exp.strindices[s]; ok {
+		return i
+	}
+	exp.stringTable = append(exp.stringTable, s)
+	exp.strindices[s] = int64(len(exp.stringTable) - 1)
+	return exp.strindices[s]
+}
+
+// attribute returns the attribute of the Profile at index i.
+func (exp *pprofExporter) attribute(i int32) (Attribute, bool) {
+	if i < 0 || int(i) >= exp.src.AttributeTable().Len() {
+		exp.fail(fmt.Errorf("attribute index %d out of range", i))
+		return Attribute{}, false
+	}
+	return exp.src.AttributeTable().At(int(i)), true
+}
+
+// strAttribute returns the string index of the value of the attribute of record with the given key, or 0.
+func (exp *pprofExporter) strAttribute(record attributable, key string) int64 {
+	for _, i := range record.AttributeIndices().All() {
+		if attr, ok := exp.attribute(i); ok && attr.Key() == key {
+			return exp.strindexOf(attr.Value().AsString())
+		}
+	}
+	return 0
+}
+
+// id checks an index in a table of the Profile, and returns the ID of the pprof entry.
+func (exp *pprofExporter) id(table string, i int32, n int) uint64 {
+	if i < 0 || int(i) >= n {
+		exp.fail(fmt.Errorf("%s index %d out of range", table, i))
+		return 0
+	}
+	return uint64(i) + 1
+}
+
+func (exp *pprofExporter) export() *pprofProfile {
+	src := exp.src
+	exp.stringTable = src.StringTable().AsRaw()
+	if len(exp.stringTable) == 0 {
+		exp.stringTable = []string{""}
+	} else if exp.stringTable[0] != "" {
+		exp.fail(errors.New("the first string of the string table must be empty"))
+		return nil
+	}
+
+	pb := &pprofProfile{
+		sampleTypes:               make([]pprofValueType, 0, src.SampleType().Len()),
+		timeNanos:                 int64(src.Time()),     //nolint:gosec // timestamps fit in an int64
+		durationNanos:             int64(src.Duration()), //nolint:gosec // durations fit in an int64
+		period:                    src.Period(),
+		defaultSampleTypeStrindex: exp.strindex(src.DefaultSampleTypeStrindex()),
+		dropFramesStrindex:        exp.strAttribute(src, pprofDropFramesKey),
+		keepFramesStrindex:        exp.strAttribute(src, pprofKeepFramesKey),
+		docURLStrindex:            exp.strAttribute(src, pprofDocURLKey),
+	}
+	for _, st := range src.SampleType().All() {
+		pb.sampleTypes = append(pb.sampleTypes, exp.exportValueType(st))
+	}
+	pb.periodType = exp.exportValueType(src.PeriodType())
+	for _, c := range src.CommentStrindices().All() {
+		pb.commentStrindices = append(pb.commentStrindices, exp.strindex(c))
+	}
+
+	pb.mappings = make([]pprofMapping, 0, src.MappingTable().Len())
+	for i, m := range src.MappingTable().All() {
+		pb.mappings = append(pb.mappings, pprofMapping{
+			id:               uint64(i) + 1, //nolint:gosec // i is positive
+			memoryStart:      m.MemoryStart(),
+			memoryLimit:      m.MemoryLimit(),
+			fileOffset:       m.FileOffset(),
+			filenameStrindex: exp.strindex(m.FilenameStrindex()),
+			buildIDStrindex:  exp.strAttribute(m, pprofBuildIDKey),
+			hasFunctions:     m.HasFunctions(),
+			hasFilenames:     m.HasFilenames(),
+			hasLineNumbers:   m.HasLineNumbers(),
+			hasInlineFrames:  m.HasInlineFrames(),
+		})
+	}
+
+	pb.functions = make([]pprofFunction, 0, src.FunctionTable().Len())
+	for i, f := range src.FunctionTable().All() {
+		pb.functions = append(pb.functions, pprofFunction{
+			id:                 uint64(i) + 1, //nolint:gosec // i is positive
+			nameStrindex:       exp.strindex(f.NameStrindex()),
+			systemNameStrindex: exp.strindex(f.SystemNameStrindex()),
+			filenameStrindex:   exp.strindex(f.FilenameStrindex()),
+			startLine:          f.StartLine(),
+		})
+	}
+
+	pb.locations = make([]pprofLocation, 0, src.LocationTable().Len())
+	for i, l := range src.LocationTable().All() {
+		location := pprofLocation{
+			id:       uint64(i) + 1, //nolint:gosec // i is positive
+			address:  l.Address(),
+			isFolded: l.IsFolded(),
+		}
+		if l.HasMappingIndex() {
+			location.mappingID = exp.id("mapping", l.MappingIndex(), src.MappingTable().Len())
+		}
+		for _, ln := range l.Line().All() {
+			location.lines = append(location.lines, pprofLine{
+				functionID: exp.id("function", ln.FunctionIndex(), src.FunctionTable().Len()),
+				line:       ln.Line(),
+				column:     ln.Column(),
+			})
+		}
+		pb.locations = append(pb.locations, location)
+	}
+
+	units := make(map[string]string, src.AttributeUnits().Len())
+	for _, u := range src.AttributeUnits().All() {
+		units[exp.stringTable[exp.strindex(u.AttributeKeyStrindex())]] = exp.stringTable[exp.strindex(u.UnitStrindex())]
+	}
+	pb.samples = make([]pprofSample, 0, src.Sample().Len())
+	for _, s := range src.Sample().All() {
+		var sample pprofSample
+		start, length := int(s.LocationsStartIndex()), int(s.LocationsLength())
+		if start < 0 || length < 0 || start+length > src.LocationIndices().Len() {
+			exp.fail(fmt.Errorf("sample locations [%d:%d] out of range", start, start+length))
+			return nil
+		}
+		for _, idx := range src.LocationIndices().AsRaw()[start : start+length] {
+			sample.locationIDs = append(sample.locationIDs, exp.id("location", idx, src.LocationTable().Len()))
+		}
+		if s.Value().Len() > 0 {
+			sample.values = s.Value().AsRaw()
+		}
+		for _, i := range s.AttributeIndices().All() {
+			attr, ok := exp.attribute(i)
+			if !ok {
+				continue
+			}
+			label := pprofLabel{keyStrindex: exp.strindexOf(attr.Key())}
+			if attr.Value().Type() == pcommon.ValueTypeInt {
+				label.num = attr.Value().Int()
+				if unit, ok := units[attr.Key()]; ok {
+					label.numUnitStrindex = exp.strindexOf(unit)
+				}
+			} else {
+				label.strStrindex = exp.strindexOf(attr.Value().AsString())
+			}
+			sample.labels = append(sample.labels, label)
+		}
+		pb.samples = append(pb.samples, sample)
+	}
+
+	pb.stringTable = exp.stringTable
+	return pb
+}
+
+func (exp *pprofExporter) exportValueType(vt ValueType) pprofValueType {
+	return pprofValueType{
+		typeStrindex: exp.strindex(vt.TypeStrindex()),
+		unitStrindex: exp.strindex(vt.UnitStrindex()),
+	}
+}
@@ -0,0 +1,481 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pprofile // import "go.opentelemetry.io/collector/pdata/pprofile"
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// The messages of the pprof profile.proto format, see
+// https://github.com/google/pprof/blob/main/proto/profile.proto.
+// Fields holding an index in the string table are named with a "Strindex" suffix.
+
+type pprofProfile struct {
+	sampleTypes               []pprofValueType
+	samples                   []pprofSample
+	mappings                  []pprofMapping
+	locations                 []pprofLocation
+	functions                 []pprofFunction
+	stringTable               []string
+	dropFramesStrindex        int64
+	keepFramesStrindex        int64
+	timeNanos                 int64
+	durationNanos             int64
+	periodType                pprofValueType
+	period                    int64
+	commentStrindices         []int64
+	defaultSampleTypeStrindex int64
+	docURLStrindex            int64
+}
+
+type pprofValueType struct {
+	typeStrindex int64
+	unitStrindex int64
+}
+
+type pprofSample struct {
+	locationIDs []uint64
+	values      []int64
+	labels      []pprofLabel
+}
+
+type pprofLabel struct {
+	keyStrindex     int64
+	strStrindex     int64
+	num             int64
+	numUnitStrindex int64
+}
+
+type pprofMapping struct {
+	id               uint64
+	memoryStart      uint64
+	memoryLimit      uint64
+	fileOffset       uint64
+	filenameStrindex int64
+	buildIDStrindex  int64
+	hasFunctions     bool
+	hasFilenames     bool
+	hasLineNumbers   bool
+	hasInlineFrames  bool
+}
+
+type pprofLocation struct {
+	id        uint64
+	mappingID uint64
+	address   uint64
+	lines     []pprofLine
+	isFolded  bool
+}
+
+type pprofLine struct {
+	functionID uint64
+	line       int64
+	column     int64
+}
+
+type pprofFunction struct {
+	id                 uint64
+	nameStrindex       int64
+	systemNameStrindex int64
+	filenameStrindex   int64
+	startLine          int64
+}
+
+// decodeFields calls fn for each field of the message in buf, with the bytes following the tag of the field.
+// fn returns the length of the value of the field, or 0 to skip it.
+func decodeFields(buf []byte, fn func(num protowire.Number, typ protowire.Type, buf []byte) (int, error)) error {
+	for len(buf) > 0 {
+		num, typ, n := protowire.ConsumeTag(buf)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		buf = buf[n:]
+		n, err := fn(num, typ, buf)
+		if err != nil {
+			return fmt.Errorf("field %d: %w", num, err)
+		}
+		if n == 0 {
+			n = protowire.ConsumeFieldValue(num, typ, buf)
+		}
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		buf = buf[n:]
+	}
+	return nil
+}
+
+func decodeVarint(typ protowire.Type, buf []byte, dst *uint64) (int, error) {
+	if typ != protowire.VarintType {
+		return 0, fmt.Errorf("unexpected wire type %d", typ)
+	}
+	v, n := protowire.ConsumeVarint(buf)
+	if n < 0 {
+		return 0, protowire.ParseError(n)
+	}
+	*dst = v
+	return n, nil
+}
+
+func decodeInt64(typ protowire.Type, buf []byte, dst *int64) (int, error) {
+	var v uint64
+	n, err := decodeVarint(typ, buf, &v)
+	*dst = int64(v) //nolint:gosec // int64 fields are encoded as their two's complement
+	return n, err
+}
+
+func decodeBool(typ protowire.Type, buf []byte, dst *bool) (int, error) {
+	var v uint64
+	n, err := decodeVarint(typ, buf, &v)
+	*dst = v != 0
+	return n, err
+}
+
+func decodeBytes(typ protowire.Type, buf []byte) ([]byte, int, error) {
+	if typ != protowire.BytesType {
+		return nil, 0, fmt.Errorf("unexpected wire type %d", typ)
+	}
+	v, n := protowire.ConsumeBytes(buf)
+	if n < 0 {
+		return nil, 0, protowire.ParseError(n)
+	}
+	return v, n, nil
+}
+
+// decodeRepeatedVarint appends a repeated varint field to dst, packed or not.
+func decodeRepeatedVarint(typ protowire.Type, buf []byte, dst *[]uint64) (int, error) {
+	if typ == protowire.VarintType {
+		var v uint64
+		n, err := decodeVarint(typ, buf, &v)
+		*dst = append(*dst, v)
+		return n, err
+	}
+	packed, n, err := decodeBytes(typ, buf)
+	if err != nil {
+		return 0, err
+	}
+	for len(packed) > 0 {
+		v, m := protowire.ConsumeVarint(packed)
+		if m < 0 {
+			return 0, protowire.ParseError(m)
+		}
+		*dst = append(*dst, v)
+		packed = packed[m:]
+	}
+	return n, nil
+}
+
+func decodeRepeatedInt64(typ protowire.Type, buf []byte, dst *[]int64) (int, error) {
+	var vs []uint64
+	n, err := decodeRepeatedVarint(typ, buf, &vs)
+	for _, v := range vs {
+		*dst = append(*dst, int64(v)) //nolint:gosec // int64 fields are encoded as their two's complement
+	}
+	return n, err
+}
+
+// decodeMessage decodes the embedded message of a field with decode.
+func decodeMessage(typ protowire.Type, buf []byte, decode func([]byte) error) (int, error) {
+	msg, n, err := decodeBytes(typ, buf)
+	if err != nil {
+		return 0, err
+	}
+	return n, decode(msg)
+}
+
+func (p *pprofProfile) unmarshal(buf []byte) error {
+	return decodeFields(buf, func(num protowire.Number, typ protowire.Type, buf []byte) (int, error) {
+		switch num {
+		case 1:
+			p.sampleTypes = append(p.sampleTypes, pprofValueType{})
+			return decodeMessage(typ, buf, p.sampleTypes[len(p.sampleTypes)-1].unmarshal)
+		case 2:
+			p.samples = append(p.samples, pprofSample{})
+			return decodeMessage(typ, buf, p.samples[len(p.samples)-1].unmarshal)
+		case 3:
+			p.mappings = append(p.mappings, pprofMapping{})
+			return decodeMessage(typ, buf, p.mappings[len(p.mappings)-1].unmarshal)
+		case 4:
+			p.locations = append(p.locations, pprofLocation{})
+			return decodeMessage(typ, buf, p.locations[len(p.locations)-1].unmarshal)
+		case 5:
+			p.functions = append(p.functions, pprofFunction{})
+			return decodeMessage(typ, buf, p.functions[len(p.functions)-1].unmarshal)
+		case 6:
+			s, n, err := decodeBytes(typ, buf)
+			p.stringTable = append(p.stringTable, string(s))
+			return n, err
+		case 7:
+			return decodeInt64(typ, buf, &p.dropFramesStrindex)
+		case 8:
+			return decodeInt64(typ, buf, &p.keepFramesStrindex)
+		case 9:
+			return decodeInt64(typ, buf, &p.timeNanos)
+		case 10:
+			return decodeInt64(typ, buf, &p.durationNanos)
+		case 11:
+			return decodeMessage(typ, buf, p.periodType.unmarshal)
+		case 12:
+			return decodeInt64(typ, buf, &p.period)
+		case 13:
+			return decodeRepeatedInt64(typ, buf, &p.commentStrindices)
+		case 14:
+			return decodeInt64(typ, buf, &p.defaultSampleTypeStrindex)
+		case 15:
+			return decodeInt64(typ, buf, &p.docURLStrindex)
+		}
+		return 0, nil
+	})
+}
+
+func (vt *pprofValueType) unmarshal(buf []byte) error {
+	return decodeFields(buf, func(num protowire.Number, typ protowire.Type, buf []byte) (int, error) {
+		switch num {
+		case 1:
+			return decodeInt64(typ, buf, &vt.typeStrindex)
+		case 2:
+			return decodeInt64(typ, buf, &vt.unitStrindex)
+		}
+		return 0, nil
+	})
+}
+
+func (s *pprofSample) unmarshal(buf []byte) error {
+	return decodeFields(buf, func(num protowire.Number, typ protowire.Type, buf []byte) (int, error) {
+		switch num {
+		case 1:
+			return decodeRepeatedVarint(typ, buf, &s.locationIDs)
+		case 2:
+			return decodeRepeatedInt64(typ, buf, &s.values)
+		case 3:
+			s.labels = append(s.labels, pprofLabel{})
+			return decodeMessage(typ, buf, s.labels[len(s.labels)-1].unmarshal)
+		}
+		return 0, nil
+	})
+}
+
+func (l *pprofLabel) unmarshal(buf []byte) error {
+	return decodeFields(buf, func(num protowire.Number, typ protowire.Type, buf []byte) (int, error) {
+		switch num {
+		case 1:
+			return decodeInt64(typ, buf, &l.keyStrindex)
+		case 2:
+			return decodeInt64(typ, buf, &l.strStrindex)
+		case 3:
+			return decodeInt64(typ, buf, &l.num)
+		case 4:
+			return decodeInt64(typ, buf, &l.numUnitStrindex)
+		}
+		return 0, nil
+	})
+}
+
+func (m *pprofMapping) unmarshal(buf []byte) error {
+	return decodeFields(buf, func(num protowire.Number, typ protowire.Type, buf []byte) (int, error) {
+		switch num {
+		case 1:
+			return decodeVarint(typ, buf, &m.id)
+		case 2:
+			return decodeVarint(typ, buf, &m.memoryStart)
+		case 3:
+			return decodeVarint(typ, buf, &m.memoryLimit)
+		case 4:
+			return decodeVarint(typ, buf, &m.fileOffset)
+		case 5:
+			return decodeInt64(typ, buf, &m.filenameStrindex)
+		case 6:
+			return decodeInt64(typ, buf, &m.buildIDStrindex)
+		case 7:
+			return decodeBool(typ, buf, &m.hasFunctions)
+		case 8:
+			return decodeBool(typ, buf, &m.hasFilenames)
+		case 9:
+			return decodeBool(typ, buf, &m.hasLineNumbers)
+		case 10:
+			return decodeBool(typ, buf, &m.hasInlineFrames)
+		}
+		return 0, nil
+	})
+}
+
+func (l *pprofLocation) unmarshal(buf []byte) error {
+	return decodeFields(buf, func(num protowire.Number, typ protowire.Type, buf []byte) (int, error) {
+		switch num {
+		case 1:
+			return decodeVarint(typ, buf, &l.id)
+		case 2:
+			return decodeVarint(typ, buf, &l.mappingID)
+		case 3:
+			return decodeVarint(typ, buf, &l.address)
+		case 4:
+			l.lines = append(l.lines, pprofLine{})
+			return decodeMessage(typ, buf, l.lines[len(l.lines)-1].unmarshal)
+		case 5:
+			return decodeBool(typ, buf, &l.isFolded)
+		}
+		return 0, nil
+	})
+}
+
+func (l *pprofLine) unmarshal(buf []byte) error {
+	return decodeFields(buf, func(num protowire.Number, typ protowire.Type, buf []byte) (int, error) {
+		switch num {
+		case 1:
+			return decodeVarint(typ, buf, &l.functionID)
+		case 2:
+			return decodeInt64(typ, buf, &l.line)
+		case 3:
+			return decodeInt64(typ, buf, &l.column)
+		}
+		return 0, nil
+	})
+}
+
+func (f *pprofFunction) unmarshal(buf []byte) error {
+	return decodeFields(buf, func(num protowire.Number, typ protowire.Type, buf []byte) (int, error) {
+		switch num {
+		case 1:
+			return decodeVarint(typ, buf, &f.id)
+		case 2:
+			return decodeInt64(typ, buf, &f.nameStrindex)
+		case 3:
+			return decodeInt64(typ, buf, &f.systemNameStrindex)
+		case 4:
+			return decodeInt64(typ, buf, &f.filenameStrindex)
+		case 5:
+			return decodeInt64(typ, buf, &f.startLine)
+		}
+		return 0, nil
+	})
+}
+
+// appendVarint appends a varint field, omitted when zero as any proto3 scalar field.
+func appendVarint(buf []byte, num protowire.Number, v uint64) []byte {
+	if v == 0 {
+		return buf
+	}
+	buf = protowire.AppendTag(buf, num, protowire.VarintType)
+	return protowire.AppendVarint(buf, v)
+}
+
+func appendInt64(buf []byte, num protowire.Number, v int64) []byte {
+	return appendVarint(buf, num, uint64(v)) //nolint:gosec // int64 fields are encoded as their two's complement
+}
+
+func appendBool(buf []byte, num protowire.Number, v bool) []byte {
+	return appendVarint(buf, num, protowire.EncodeBool(v))
+}
+
+// appendPacked appends a packed repeated varint field.
+func appendPacked[T int64 | uint64](buf []byte, num protowire.Number, vs []T) []byte {
+	if len(vs) == 0 {
+		return buf
+	}
+	var packed []byte
+	for _, v := range vs {
+		packed = protowire.AppendVarint(packed, uint64(v)) //nolint:gosec // int64 fields are encoded as their two's complement
+	}
+	buf = protowire.AppendTag(buf, num, protowire.BytesType)
+	return protowire.AppendBytes(buf, packed)
+}
+
+// appendMessage appends an embedded message field, encoded by marshal.
+func appendMessage(buf []byte, num protowire.Number, marshal func([]byte) []byte) []byte {
+	buf = protowire.AppendTag(buf, num, protowire.BytesType)
+	return protowire.AppendBytes(buf, marshal(nil))
+}
+
+func (p *pprofProfile) marshal(buf []byte) []byte {
+	for i := range p.sampleTypes {
+		buf = appendMessage(buf, 1, p.sampleTypes[i].marshal)
+	}
+	for i := range p.samples {
+		buf = appendMessage(buf, 2, p.samples[i].marshal)
+	}
+	for i := range p.mappings {
+		buf = appendMessage(buf, 3, p.mappings[i].marshal)
+	}
+	for i := range p.locations {
+		buf = appendMessage(buf, 4, p.locations[i].marshal)
+	}
+	for i := range p.functions {
+		buf = appendMessage(buf, 5, p.functions[i].marshal)
+	}
+	for _, s := range p.stringTable {
+		buf = protowire.AppendTag(buf, 6, protowire.BytesType)
+		buf = protowire.AppendString(buf, s)
+	}
+	buf = appendInt64(buf, 7, p.dropFramesStrindex)
+	buf = appendInt64(buf, 8, p.keepFramesStrindex)
+	buf = appendInt64(buf, 9, p.timeNanos)
+	buf = appendInt64(buf, 10, p.durationNanos)
+	if p.periodType != (pprofValueType{}) {
+		buf = appendMessage(buf, 11, p.periodType.marshal)
+	}
+	buf = appendInt64(buf, 12, p.period)
+	buf = appendPacked(buf, 13, p.commentStrindices)
+	buf = appendInt64(buf, 14, p.defaultSampleTypeStrindex)
+	return appendInt64(buf, 15, p.docURLStrindex)
+}
+
+func (vt *pprofValueType) marshal(buf []byte) []byte {
+	buf = appendInt64(buf, 1, vt.typeStrindex)
+	return appendInt64(buf, 2, vt.unitStrindex)
+}
+
+func (s *pprofSample) marshal(buf []byte) []byte {
+	buf = appendPacked(buf, 1, s.locationIDs)
+	buf = appendPacked(buf, 2, s.values)
+	for i := range s.labels {
+		buf = appendMessage(buf, 3, s.labels[i].marshal)
+	}
+	return buf
+}
+
+func (l *pprofLabel) marshal(buf []byte) []byte {
+	buf = appendInt64(buf, 1, l.keyStrindex)
+	buf = appendInt64(buf, 2, l.strStrindex)
+	buf = appendInt64(buf, 3, l.num)
+	return appendInt64(buf, 4, l.numUnitStrindex)
+}
+
+func (m *pprofMapping) marshal(buf []byte) []byte {
+	buf = appendVarint(buf, 1, m.id)
+	buf = appendVarint(buf, 2, m.memoryStart)
+	buf = appendVarint(buf, 3, m.memoryLimit)
+	buf = appendVarint(buf, 4, m.fileOffset)
+	buf = appendInt64(buf, 5, m.filenameStrindex)
+	buf = appendInt64(buf, 6, m.buildIDStrindex)
+	buf = appendBool(buf, 7, m.hasFunctions)
+	buf = appendBool(buf, 8, m.hasFilenames)
+	buf = appendBool(buf, 9, m.hasLineNumbers)
+	return appendBool(buf, 10, m.hasInlineFrames)
+}
+
+func (l *pprofLocation) marshal(buf []byte) []byte {
+	buf = appendVarint(buf, 1, l.id)
+	buf = appendVarint(buf, 2, l.mappingID)
+	buf = appendVarint(buf, 3, l.address)
+	for i := range l.lines {
+		buf = appendMessage(buf, 4, l.lines[i].marshal)
+	}
+	return appendBool(buf, 5, l.isFolded)
+}
+
+func (l *pprofLine) marshal(buf []byte) []byte {
+	buf = appendVarint(buf, 1, l.functionID)
+	buf = appendInt64(buf, 2, l.line)
+	return appendInt64(buf, 3, l.column)
+}
+
+func (f *pprofFunction) marshal(buf []byte) []byte {
+	buf = appendVarint(buf, 1, f.id)
+	buf = appendInt64(buf, 2, f.nameStrindex)
+	buf = appendInt64(buf, 3, f.systemNameStrindex)
+	buf = appendInt64(buf, 4, f.filenameStrindex)
+	return appendInt64(buf, 5, f.startLine)
+}
@@ -0,0 +1,288 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pprofile
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"io"
+	"runtime"
+	"runtime/pprof"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// decodePprof decodes a gzip-compressed pprof profile.
+func decodePprof(t *testing.T, buf []byte) pprofProfile {
+	zr, err := gzip.NewReader(bytes.NewReader(buf))
+	require.NoError(t, err)
+	raw, err := io.ReadAll(zr)
+	require.NoError(t, err)
+	var pb pprofProfile
+	require.NoError(t, pb.unmarshal(raw))
+	return pb
+}
+
+func cpuProfile(t *testing.T) []byte {
+	var buf bytes.Buffer
+	require.NoError(t, pprof.StartCPUProfile(&buf))
+	sum := sha256.Sum256(nil)
+	for deadline := time.Now().Add(100 * time.Millisecond); time.Now().Before(deadline); {
+		sum = sha256.Sum256(sum[:])
+	}
+	pprof.StopCPUProfile()
+	return buf.Bytes()
+}
+
+func heapProfile(t *testing.T) []byte {
+	runtime.GC()
+	var buf bytes.Buffer
+	require.NoError(t, pprof.Lookup("heap").WriteTo(&buf, 0))
+	return buf.Bytes()
+}
+
+func TestPprofRoundTripGoProfiles(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		profile func(*testing.T) []byte
+	}{
+		{name: "cpu", profile: cpuProfile},
+		{name: "heap", profile: heapProfile},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := tt.profile(t)
+			pd, err := (&PprofUnmarshaler{}).UnmarshalProfiles(buf)
+			require.NoError(t, err)
+			require.Equal(t, 1, pd.ResourceProfiles().Len())
+			profile := pd.ResourceProfiles().At(0).ScopeProfiles().At(0).Profiles().At(0)
+			assert.Positive(t, profile.SampleType().Len())
+			assert.Positive(t, profile.StringTable().Len())
+			assert.Positive(t, profile.MappingTable().Len())
+
+			out, err := (&PprofMarshaler{}).MarshalProfiles(pd)
+			require.NoError(t, err)
+			assert.Equal(t, decodePprof(t, buf), decodePprof(t, out))
+
+			roundTrip, err := (&PprofUnmarshaler{}).UnmarshalProfiles(out)
+			require.NoError(t, err)
+			assert.Equal(t, pd, roundTrip)
+		})
+	}
+}
+
+// testPprofProfile uses every field of the pprof format.
+func testPprofProfile() pprofProfile {
+	return pprofProfile{
+		stringTable: []string{
+			"", "cpu", "nanoseconds", "samples", "count", "/bin/app", "abc123", "main", "main.main",
+			"main.go", "runtime.*", "main.*", "a comment", "https://example.com", "thread", "worker", "size", "bytes",
+		},
+		sampleTypes: []pprofValueType{{typeStrindex: 3, unitStrindex: 4}, {typeStrindex: 1, unitStrindex: 2}},
+		samples: []pprofSample{
+			{
+				locationIDs: []uint64{2, 1},
+				values:      []int64{1, 10000000},
+				labels: []pprofLabel{
+					{keyStrindex: 14, strStrindex: 15},
+					{keyStrindex: 16, num: 512, numUnitStrindex: 17},
+				},
+			},
+			{locationIDs: []uint64{1}, values: []int64{2, 20000000}},
+		},
+		mappings: []pprofMapping{{
+			id:               1,
+			memoryStart:      0x400000,
+			memoryLimit:      0x800000,
+			fileOffset:       0x1000,
+			filenameStrindex: 5,
+			buildIDStrindex:  6,
+			hasFunctions:     true,
+			hasFilenames:     true,
+			hasLineNumbers:   true,
+			hasInlineFrames:  true,
+		}},
+		locations: []pprofLocation{
+			{id: 1, mappingID: 1, address: 0x401000, lines: []pprofLine{{functionID: 1, line: 10, column: 2}}},
+			{id: 2, address: 0x402000, lines: []pprofLine{{functionID: 2, line: 20}, {functionID: 1, line: 12}}, isFolded: true},
+		},
+		functions: []pprofFunction{
+			{id: 1, nameStrindex: 7, systemNameStrindex: 7, filenameStrindex: 9, startLine: 5},
+			{id: 2, nameStrindex: 8, systemNameStrindex: 8, filenameStrindex: 9, startLine: 15},
+		},
+		dropFramesStrindex:        10,
+		keepFramesStrindex:        11,
+		timeNanos:                 1700000000000000000,
+		durationNanos:             int64(10 * time.Second),
+		periodType:                pprofValueType{typeStrindex: 1, unitStrindex: 2},
+		period:                    10000000,
+		commentStrindices:         []int64{12},
+		defaultSampleTypeStrindex: 1,
+		docURLStrindex:            13,
+	}
+}
+
+func TestPprofRoundTrip(t *testing.T) {
+	pb := testPprofProfile()
+	pd, err := (&PprofUnmarshaler{}).UnmarshalProfiles(pb.marshal(nil))
+	require.NoError(t, err)
+
+	profile := pd.ResourceProfiles().At(0).ScopeProfiles().At(0).Profiles().At(0)
+	assert.Equal(t, pb.stringTable, profile.StringTable().AsRaw())
+	assert.Equal(t, pcommon.Timestamp(1700000000000000000), profile.Time())
+	assert.Equal(t, map[string]any{
+		pprofDropFramesKey: "runtime.*",
+		pprofKeepFramesKey: "main.*",
+		pprofDocURLKey:     "https://example.com",
+	}, FromAttributeIndices(profile.AttributeTable(), profile).AsRaw())
+	assert.Equal(t, map[string]any{pprofBuildIDKey: "abc123"},
+		FromAttributeIndices(profile.AttributeTable(), profile.MappingTable().At(0)).AsRaw())
+	assert.Equal(t, []int32{1, 0, 0}, profile.LocationIndices().AsRaw())
+	sample := profile.Sample().At(0)
+	assert.Equal(t, int32(0), sample.LocationsStartIndex())
+	assert.Equal(t, int32(2), sample.LocationsLength())
+	assert.Equal(t, map[string]any{"thread": "worker", "size": int64(512)},
+		FromAttributeIndices(profile.AttributeTable(), sample).AsRaw())
+	require.Equal(t, 1, profile.AttributeUnits().Len())
+	assert.Equal(t, int32(16), profile.AttributeUnits().At(0).AttributeKeyStrindex())
+	assert.Equal(t, int32(17), profile.AttributeUnits().At(0).UnitStrindex())
+	assert.False(t, profile.LocationTable().At(1).HasMappingIndex())
+
+	out, err := (&PprofMarshaler{}).MarshalProfiles(pd)
+	require.NoError(t, err)
+	assert.Equal(t, pb, decodePprof(t, out))
+}
+
+func TestPprofMarshalAddsStrings(t *testing.T) {
+	pd := NewProfiles()
+	profile := pd.ResourceProfiles().AppendEmpty().ScopeProfiles().AppendEmpty().Profiles().AppendEmpty()
+	sample := profile.Sample().AppendEmpty()
+	sample.Value().Append(1)
+	require.NoError(t, AddAttribute(profile.AttributeTable(), sample, "enabled", pcommon.NewValueBool(true)))
+
+	out, err := (&PprofMarshaler{}).MarshalProfiles(pd)
+	require.NoError(t, err)
+	pb := decodePprof(t, out)
+	assert.Equal(t, []string{"", "enabled", "true"}, pb.stringTable)
+	assert.Equal(t, []pprofSample{{values: []int64{1}, labels: []pprofLabel{{keyStrindex: 1, strStrindex: 2}}}}, pb.samples)
+}
+
+func TestPprofUnmarshalErrors(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		modify  func(*pprofProfile)
+		wantErr string
+	}{
+		{
+			name:    "no-empty-string",
+			modify:  func(pb *pprofProfile) { pb.stringTable[0] = "first" },
+			wantErr: "the first string of the string table must be empty",
+		},
+		{
+			name:    "string-index",
+			modify:  func(pb *pprofProfile) { pb.functions[0].nameStrindex = 100 },
+			wantErr: "string index 100 out of range",
+		},
+		{
+			name:    "mapping-id",
+			modify:  func(pb *pprofProfile) { pb.locations[0].mappingID = 5 },
+			wantErr: "location 1: unknown mapping ID 5",
+		},
+		{
+			name:    "function-id",
+			modify:  func(pb *pprofProfile) { pb.locations[1].lines[0].functionID = 5 },
+			wantErr: "location 2: unknown function ID 5",
+		},
+		{
+			name:    "location-id",
+			modify:  func(pb *pprofProfile) { pb.samples[1].locationIDs[0] = 5 },
+			wantErr: "sample: unknown location ID 5",
+		},
+		{
+			name:    "zero-id",
+			modify:  func(pb *pprofProfile) { pb.functions[1].id = 0 },
+			wantErr: "function table: ID 0 is reserved",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			pb := testPprofProfile()
+			tt.modify(&pb)
+			_, err := (&PprofUnmarshaler{}).UnmarshalProfiles(pb.marshal(nil))
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+
+	_, err := (&PprofUnmarshaler{}).UnmarshalProfiles([]byte{0x0a, 0xff})
+	require.ErrorContains(t, err, "invalid pprof profile")
+	_, err = (&PprofUnmarshaler{}).UnmarshalProfiles([]byte{0x1f, 0x8b, 0x00})
+	assert.Error(t, err)
+}
+
+func TestPprofMarshalErrors(t *testing.T) {
+	_, err := (&PprofMarshaler{}).MarshalProfiles(NewProfiles())
+	require.EqualError(t, err, "the pprof format holds a single profile, got 0")
+
+	pd, err := (&PprofUnmarshaler{}).UnmarshalProfiles(func() []byte { pb := testPprofProfile(); return pb.marshal(nil) }())
+	require.NoError(t, err)
+	profile := pd.ResourceProfiles().At(0).ScopeProfiles().At(0).Profiles().At(0)
+
+	for _, tt := range []struct {
+		name    string
+		modify  func(Profile)
+		wantErr string
+	}{
+		{
+			name:    "no-empty-string",
+			modify:  func(p Profile) { p.StringTable().SetAt(0, "first") },
+			wantErr: "the first string of the string table must be empty",
+		},
+		{
+			name:    "string-index",
+			modify:  func(p Profile) { p.SampleType().At(0).SetTypeStrindex(100) },
+			wantErr: "string index 100 out of range",
+		},
+		{
+			name:    "mapping-index",
+			modify:  func(p Profile) { p.LocationTable().At(0).SetMappingIndex(3) },
+			wantErr: "mapping index 3 out of range",
+		},
+		{
+			name:    "function-index",
+			modify:  func(p Profile) { p.LocationTable().At(0).Line().At(0).SetFunctionIndex(-1) },
+			wantErr: "function index -1 out of range",
+		},
+		{
+			name:    "location-index",
+			modify:  func(p Profile) { p.LocationIndices().SetAt(0, 7) },
+			wantErr: "location index 7 out of range",
+		},
+		{
+			name:    "sample-locations",
+			modify:  func(p Profile) { p.Sample().At(0).SetLocationsLength(10) },
+			wantErr: "sample locations [0:10] out of range",
+		},
+		{
+			name:    "attribute-index",
+			modify:  func(p Profile) { p.Sample().At(0).AttributeIndices().Append(50) },
+			wantErr: "attribute index 50 out of range",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			invalid := NewProfiles()
+			pd.CopyTo(invalid)
+			tt.modify(invalid.ResourceProfiles().At(0).ScopeProfiles().At(0).Profiles().At(0))
+			_, err := (&PprofMarshaler{}).MarshalProfiles(invalid)
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+
+	profile.CopyTo(pd.ResourceProfiles().At(0).ScopeProfiles().At(0).Profiles().AppendEmpty())
+	_, err = (&PprofMarshaler{}).MarshalProfiles(pd)
+	assert.EqualError(t, err, "the pprof format holds a single profile, got 2")
+}
@@ -8,6 +8,7 @@ import (
 
 	"go.opentelemetry.io/collector/pdata/internal"
 	otlpcollectorprofile "go.opentelemetry.io/collector/pdata/internal/data/protogen/collector/profiles/v1development"
+	otlpprofile "go.opentelemetry.io/collector/pdata/internal/data/protogen/profiles/v1development"
 	"go.opentelemetry.io/collector/pdata/internal/json"
 	"go.opentelemetry.io/collector/pdata/internal/otlp"
 	"go.opentelemetry.io/collector/pdata/pprofile"
@@ -18,8 +19,9 @@ var jsonUnmarshaler = &pprofile.JSONUnmarshaler{}
 // ExportRequest represents the request for gRPC/HTTP client/server.
 // It's a wrapper for pprofile.Profiles data.
 type ExportRequest struct {
-	orig  *otlpcollectorprofile.ExportProfilesServiceRequest
-	state *internal.State
+	orig   *otlpcollectorprofile.ExportProfilesServiceRequest
+	state  *internal.State
+	shared internal.SharedNodes[otlpprofile.ResourceProfiles]
 }
 
 // NewExportRequest returns an empty ExportRequest.
@@ -36,8 +38,9 @@ func NewExportRequest() ExportRequest {
 // any changes to the provided Profiles struct will be reflected in the ExportRequest and vice versa.
 func NewExportRequestFromProfiles(td pprofile.Profiles) ExportRequest {
 	return ExportRequest{
-		orig:  internal.GetOrigProfiles(internal.Profiles(td)),
-		state: internal.GetProfilesState(internal.Profiles(td)),
+		orig:   internal.GetOrigProfiles(internal.Profiles(td)),
+		state:  internal.GetProfilesState(internal.Profiles(td)),
+		shared: internal.GetProfilesSharedNodes(internal.Profiles(td)),
 	}
 }
 
@@ -75,5 +78,5 @@ func (ms ExportRequest) UnmarshalJSON(data []byte) error {
 }
 
 func (ms ExportRequest) Profiles() pprofile.Profiles {
-	return pprofile.Profiles(internal.NewProfiles(ms.orig, ms.state))
+	return pprofile.Profiles(internal.NewProfiles(ms.orig, ms.state, ms.shared))
 }
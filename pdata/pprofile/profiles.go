@@ -6,6 +6,7 @@ package pprofile // import "go.opentelemetry.io/collector/pdata/pprofile"
 import (
 	"go.opentelemetry.io/collector/pdata/internal"
 	otlpcollectorprofile "go.opentelemetry.io/collector/pdata/internal/data/protogen/collector/profiles/v1development"
+	otlpprofile "go.opentelemetry.io/collector/pdata/internal/data/protogen/profiles/v1development"
 )
 
 // Profiles is the top-level struct that is propagated through the profiles pipeline.
@@ -14,7 +15,7 @@ type Profiles internal.Profiles
 
 func newProfiles(orig *otlpcollectorprofile.ExportProfilesServiceRequest) Profiles {
 	state := internal.StateMutable
-	return Profiles(internal.NewProfiles(orig, &state))
+	return Profiles(internal.NewProfiles(orig, &state, nil))
 }
 
 func (ms Profiles) getOrig() *otlpcollectorprofile.ExportProfilesServiceRequest {
@@ -25,6 +26,10 @@ func (ms Profiles) getState() *internal.State {
 	return internal.GetProfilesState(internal.Profiles(ms))
 }
 
+func (ms Profiles) getSharedNodes() internal.SharedNodes[otlpprofile.ResourceProfiles] {
+	return internal.GetProfilesSharedNodes(internal.Profiles(ms))
+}
+
 // NewProfiles creates a new Profiles struct.
 func NewProfiles() Profiles {
 	return newProfiles(&otlpcollectorprofile.ExportProfilesServiceRequest{})
@@ -42,7 +47,7 @@ func (ms Profiles) CopyTo(dest Profiles) {
 
 // ResourceProfiles returns the ResourceProfilesSlice associated with this Profiles.
 func (ms Profiles) ResourceProfiles() ResourceProfilesSlice {
-	return newResourceProfilesSlice(&ms.getOrig().ResourceProfiles, internal.GetProfilesState(internal.Profiles(ms)))
+	return newResourceProfilesSlice(&ms.getOrig().ResourceProfiles, internal.GetProfilesState(internal.Profiles(ms)), ms.getSharedNodes())
 }
 
 // MarkReadOnly marks the ResourceProfiles as shared so that no further modifications can be done on it.
@@ -50,13 +55,25 @@ func (ms Profiles) MarkReadOnly() {
 	internal.SetProfilesState(internal.Profiles(ms), internal.StateReadOnly)
 }
 
+// Share marks the Profiles as read-only, and returns a mutable Profiles sharing its ResourceProfiles.
+// The shared ResourceProfiles are copied on write: a shared ResourceProfiles is copied the first time a method which
+// may modify it is called, e.g. ResourceProfiles.Resource or ResourceProfiles.SetSchemaUrl, so that only the ResourceProfiles
+// which are modified are copied. Share can be used instead of CopyTo to send the same Profiles to several
+// consumers modifying it.
+func (ms Profiles) Share() Profiles {
+	ms.MarkReadOnly()
+	orig := &otlpcollectorprofile.ExportProfilesServiceRequest{}
+	orig.ResourceProfiles = append(orig.ResourceProfiles, ms.getOrig().ResourceProfiles...)
+	state := internal.StateMutable
+	return Profiles(internal.NewProfiles(orig, &state, internal.NewSharedNodes(orig.ResourceProfiles)))
+}
+
 // SampleCount calculates the total number of samples.
 func (ms Profiles) SampleCount() int {
 	sampleCount := 0
-	rps := ms.ResourceProfiles()
-	for i := 0; i < rps.Len(); i++ {
-		rp := rps.At(i)
-		sps := rp.ScopeProfiles()
+	// Does not use ResourceProfiles.ScopeProfiles, which copies the shared ResourceProfiles.
+	for _, orig := range ms.getOrig().ResourceProfiles {
+		sps := newResourceProfiles(orig, ms.getState()).ScopeProfiles()
 		for j := 0; j < sps.Len(); j++ {
 			pcs := sps.At(j).Profiles()
 			for k := 0; k < pcs.Len(); k++ {
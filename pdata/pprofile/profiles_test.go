@@ -91,6 +91,24 @@ func TestSampleCountWithEmpty(t *testing.T) {
 	}).SampleCount())
 }
 
+func TestProfilesShare(t *testing.T) {
+	profiles := NewProfiles()
+	fillTestResourceProfilesSlice(profiles.ResourceProfiles())
+	shared := profiles.Share()
+	assert.True(t, profiles.IsReadOnly())
+	assert.False(t, shared.IsReadOnly())
+	assert.Equal(t, profiles.SampleCount(), shared.SampleCount())
+	for i, orig := range shared.getOrig().ResourceProfiles {
+		assert.Same(t, profiles.getOrig().ResourceProfiles[i], orig)
+	}
+
+	// Only the accessed ResourceProfiles is copied, the original is not modified.
+	shared.ResourceProfiles().At(1).SetSchemaUrl("changed")
+	assert.NotSame(t, profiles.getOrig().ResourceProfiles[1], shared.getOrig().ResourceProfiles[1])
+	assert.Same(t, profiles.getOrig().ResourceProfiles[2], shared.getOrig().ResourceProfiles[2])
+	assert.Equal(t, *generateTestResourceProfilesSlice().orig, profiles.getOrig().ResourceProfiles)
+}
+
 func BenchmarkProfilesUsage(b *testing.B) {
 	profiles := NewProfiles()
 	fillTestResourceProfilesSlice(profiles.ResourceProfiles())
@@ -22,12 +22,36 @@ import (
 type ResourceSpans struct {
 	orig  *otlptrace.ResourceSpans
 	state *internal.State
+	// nodes is the slice of the ResourceSpans, if it may be shared with other slices.
+	nodes  *[]*otlptrace.ResourceSpans
+	shared internal.SharedNodes[otlptrace.ResourceSpans]
 }
 
 func newResourceSpans(orig *otlptrace.ResourceSpans, state *internal.State) ResourceSpans {
 	return ResourceSpans{orig: orig, state: state}
 }
 
+// newSharedResourceSpans returns the ResourceSpans of the given slice, which may be shared with other slices.
+func newSharedResourceSpans(orig *otlptrace.ResourceSpans, state *internal.State, nodes *[]*otlptrace.ResourceSpans, shared internal.SharedNodes[otlptrace.ResourceSpans]) ResourceSpans {
+	return ResourceSpans{orig: orig, state: state, nodes: nodes, shared: shared}
+}
+
+// getOrig returns the ResourceSpans to read: its copy if it was shared and was copied since.
+func (ms ResourceSpans) getOrig() *otlptrace.ResourceSpans {
+	return ms.shared.Get(ms.orig)
+}
+
+// getMutableOrig returns the ResourceSpans to modify: if it is shared with other slices, it is
+// first copied and replaced by its copy in its slice.
+func (ms ResourceSpans) getMutableOrig() *otlptrace.ResourceSpans {
+	return ms.shared.GetMutable(ms.orig, ms.nodes, ms.state, copyOrigResourceSpans)
+}
+
+func copyOrigResourceSpans(dest, src *otlptrace.ResourceSpans) {
+	state := internal.StateMutable
+	newResourceSpans(src, &state).CopyTo(newResourceSpans(dest, &state))
+}
+
 // NewResourceSpans creates a new empty ResourceSpans.
 //
 // This must be used only in testing code. Users should use "AppendEmpty" when part of a Slice,
@@ -42,34 +66,38 @@ func NewResourceSpans() ResourceSpans {
 func (ms ResourceSpans) MoveTo(dest ResourceSpans) {
 	ms.state.AssertMutable()
 	dest.state.AssertMutable()
-	*dest.orig = *ms.orig
-	*ms.orig = otlptrace.ResourceSpans{}
+	// The shared ResourceSpans are copied first, the other slices keep them.
+	orig := ms.getMutableOrig()
+	*dest.getMutableOrig() = *orig
+	*orig = otlptrace.ResourceSpans{}
 }
 
 // Resource returns the resource associated with this ResourceSpans.
 func (ms ResourceSpans) Resource() pcommon.Resource {
-	return pcommon.Resource(internal.NewResource(&ms.orig.Resource, ms.state))
+	return pcommon.Resource(internal.NewResource(&ms.getMutableOrig().Resource, ms.state))
 }
 
 // SchemaUrl returns the schemaurl associated with this ResourceSpans.
 func (ms ResourceSpans) SchemaUrl() string {
-	return ms.orig.SchemaUrl
+	return ms.getOrig().SchemaUrl
 }
 
 // SetSchemaUrl replaces the schemaurl associated with this ResourceSpans.
 func (ms ResourceSpans) SetSchemaUrl(v string) {
 	ms.state.AssertMutable()
-	ms.orig.SchemaUrl = v
+	ms.getMutableOrig().SchemaUrl = v
 }
 
 // ScopeSpans returns the ScopeSpans associated with this ResourceSpans.
 func (ms ResourceSpans) ScopeSpans() ScopeSpansSlice {
-	return newScopeSpansSlice(&ms.orig.ScopeSpans, ms.state)
+	return newScopeSpansSlice(&ms.getMutableOrig().ScopeSpans, ms.state)
 }
 
 // CopyTo copies all properties from the current struct overriding the destination.
 func (ms ResourceSpans) CopyTo(dest ResourceSpans) {
 	dest.state.AssertMutable()
+	// Reading ms does not copy it if it is shared.
+	ms = newResourceSpans(ms.getOrig(), ms.state)
 	ms.Resource().CopyTo(dest.Resource())
 	dest.SetSchemaUrl(ms.SchemaUrl())
 	ms.ScopeSpans().CopyTo(dest.ScopeSpans())
@@ -22,12 +22,13 @@ import (
 // Must use NewResourceSpansSlice function to create new instances.
 // Important: zero-initialized instance is not valid for use.
 type ResourceSpansSlice struct {
-	orig  *[]*otlptrace.ResourceSpans
-	state *internal.State
+	orig   *[]*otlptrace.ResourceSpans
+	state  *internal.State
+	shared internal.SharedNodes[otlptrace.ResourceSpans]
 }
 
-func newResourceSpansSlice(orig *[]*otlptrace.ResourceSpans, state *internal.State) ResourceSpansSlice {
-	return ResourceSpansSlice{orig: orig, state: state}
+func newResourceSpansSlice(orig *[]*otlptrace.ResourceSpans, state *internal.State, shared internal.SharedNodes[otlptrace.ResourceSpans]) ResourceSpansSlice {
+	return ResourceSpansSlice{orig: orig, state: state, shared: shared}
 }
 
 // NewResourceSpansSlice creates a ResourceSpansSlice with 0 elements.
@@ -35,7 +36,7 @@ func newResourceSpansSlice(orig *[]*otlptrace.ResourceSpans, state *internal.Sta
 func NewResourceSpansSlice() ResourceSpansSlice {
 	orig := []*otlptrace.ResourceSpans(nil)
 	state := internal.StateMutable
-	return newResourceSpansSlice(&orig, &state)
+	return newResourceSpansSlice(&orig, &state, nil)
 }
 
 // Len returns the number of elements in the slice.
@@ -53,10 +54,25 @@ func (es ResourceSpansSlice) Len() int {
 //	    e := es.At(i)
 //	    ... // Do something with the element
 //	}
+//
+// If the element is shared with other slices, it is not copied until it may be modified: by its setters,
+// or by the accessors of its fields, since the values they return refer to its memory. Use View to read
+// its fields without copying it.
 func (es ResourceSpansSlice) At(i int) ResourceSpans {
+	if es.shared != nil {
+		return newSharedResourceSpans((*es.orig)[i], es.state, es.orig, es.shared)
+	}
 	return newResourceSpans((*es.orig)[i], es.state)
 }
 
+// View returns a read-only view of the element at the given index.
+//
+// Unlike At, the element is never copied if it is shared with other slices, including when its fields
+// are accessed. The element cannot be modified through the view, call At to modify it.
+func (es ResourceSpansSlice) View(i int) ResourceSpans {
+	return newResourceSpans((*es.orig)[i], internal.ReadOnlyState())
+}
+
 // All returns an iterator over index-value pairs in the slice.
 //
 //	for i, v := range es.All() {
@@ -109,6 +125,22 @@ func (es ResourceSpansSlice) AppendEmpty() ResourceSpans {
 func (es ResourceSpansSlice) MoveAndAppendTo(dest ResourceSpansSlice) {
 	es.state.AssertMutable()
 	dest.state.AssertMutable()
+	if len(es.shared) > 0 {
+		for i, orig := range *es.orig {
+			if _, ok := es.shared[orig]; !ok {
+				continue
+			}
+			if _, ok := dest.shared[orig]; ok || dest.shared == nil {
+				// The element cannot be marked as shared in dest, or is already in dest, copy it.
+				cp := &otlptrace.ResourceSpans{}
+				copyOrigResourceSpans(cp, orig)
+				(*es.orig)[i] = cp
+				continue
+			}
+			dest.shared[orig] = nil
+		}
+		clear(es.shared)
+	}
 	if *dest.orig == nil {
 		// We can simply move the entire vector and avoid any allocations.
 		*dest.orig = *es.orig
@@ -120,6 +152,9 @@ func (es ResourceSpansSlice) MoveAndAppendTo(dest ResourceSpansSlice) {
 
 // RemoveIf calls f sequentially for each element present in the slice.
 // If f returns true, the element is removed from the slice.
+//
+// The elements are passed to f as with At, the shared elements are copied if f modifies them.
+// Use RemoveIfView to remove the elements without copying them when f reads their fields.
 func (es ResourceSpansSlice) RemoveIf(f func(ResourceSpans) bool) {
 	es.state.AssertMutable()
 	newLen := 0
@@ -138,11 +173,33 @@ func (es ResourceSpansSlice) RemoveIf(f func(ResourceSpans) bool) {
 	*es.orig = (*es.orig)[:newLen]
 }
 
+// RemoveIfView calls f sequentially with a read-only view of each element present in the slice,
+// as returned by View. If f returns true, the element is removed from the slice.
+//
+// Unlike RemoveIf, the shared elements are not copied, and the elements kept remain shared.
+func (es ResourceSpansSlice) RemoveIfView(f func(ResourceSpans) bool) {
+	es.state.AssertMutable()
+	newLen := 0
+	for i := 0; i < len(*es.orig); i++ {
+		if f(es.View(i)) {
+			continue
+		}
+		(*es.orig)[newLen] = (*es.orig)[i]
+		newLen++
+	}
+	*es.orig = (*es.orig)[:newLen]
+}
+
 // CopyTo copies all elements from the current slice overriding the destination.
 func (es ResourceSpansSlice) CopyTo(dest ResourceSpansSlice) {
 	dest.state.AssertMutable()
 	srcLen := es.Len()
 	destCap := cap(*dest.orig)
+	if len(dest.shared) > 0 {
+		// The shared elements of dest must not be overridden, allocate new ones.
+		clear(dest.shared)
+		destCap = 0
+	}
 	if srcLen <= destCap {
 		(*dest.orig) = (*dest.orig)[:srcLen:destCap]
 		for i := range *es.orig {
@@ -162,7 +219,10 @@ func (es ResourceSpansSlice) CopyTo(dest ResourceSpansSlice) {
 // Sort sorts the ResourceSpans elements within ResourceSpansSlice given the
 // provided less function so that two instances of ResourceSpansSlice
 // can be compared.
+//
+// The elements are passed to less as read-only views, see View.
 func (es ResourceSpansSlice) Sort(less func(a, b ResourceSpans) bool) {
 	es.state.AssertMutable()
-	sort.SliceStable(*es.orig, func(i, j int) bool { return less(es.At(i), es.At(j)) })
+	// The elements are compared without copying the shared ones, which remain shared once moved.
+	sort.SliceStable(*es.orig, func(i, j int) bool { return less(es.View(i), es.View(j)) })
 }
@@ -20,7 +20,7 @@ func TestResourceSpansSlice(t *testing.T) {
 	es := NewResourceSpansSlice()
 	assert.Equal(t, 0, es.Len())
 	state := internal.StateMutable
-	es = newResourceSpansSlice(&[]*otlptrace.ResourceSpans{}, &state)
+	es = newResourceSpansSlice(&[]*otlptrace.ResourceSpans{}, &state, nil)
 	assert.Equal(t, 0, es.Len())
 
 	emptyVal := NewResourceSpans()
@@ -36,7 +36,7 @@ func TestResourceSpansSlice(t *testing.T) {
 
 func TestResourceSpansSliceReadOnly(t *testing.T) {
 	sharedState := internal.StateReadOnly
-	es := newResourceSpansSlice(&[]*otlptrace.ResourceSpans{}, &sharedState)
+	es := newResourceSpansSlice(&[]*otlptrace.ResourceSpans{}, &sharedState, nil)
 	assert.Equal(t, 0, es.Len())
 	assert.Panics(t, func() { es.AppendEmpty() })
 	assert.Panics(t, func() { es.EnsureCapacity(2) })
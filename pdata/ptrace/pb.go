@@ -5,7 +5,6 @@ package ptrace // import "go.opentelemetry.io/collector/pdata/ptrace"
 
 import (
 	"go.opentelemetry.io/collector/pdata/internal"
-	otlptrace "go.opentelemetry.io/collector/pdata/internal/data/protogen/trace/v1"
 )
 
 var _ MarshalSizer = (*ProtoMarshaler)(nil)
@@ -37,7 +36,7 @@ func (e *ProtoMarshaler) SpanSize(span Span) int {
 type ProtoUnmarshaler struct{}
 
 func (d *ProtoUnmarshaler) UnmarshalTraces(buf []byte) (Traces, error) {
-	pb := otlptrace.TracesData{}
-	err := pb.Unmarshal(buf)
-	return Traces(internal.TracesFromProto(pb)), err
+	orig := internal.NewOrigTraces()
+	err := internal.UnmarshalOrigTraces(orig, buf)
+	return newTraces(orig), err
 }
@@ -4,12 +4,17 @@
 package ptrace
 
 import (
+	"strconv"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
 
+	"go.opentelemetry.io/collector/pdata/internal"
+	otlptrace "go.opentelemetry.io/collector/pdata/internal/data/protogen/trace/v1"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 )
 
@@ -19,6 +24,69 @@ func TestProtoTracesUnmarshalerError(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestProtoTracesUnmarshalerPooled(t *testing.T) {
+	td := NewTraces()
+	fillTestResourceSpansSlice(td.ResourceSpans())
+	buf, err := (&ProtoMarshaler{}).MarshalTraces(td)
+	require.NoError(t, err)
+	// An unknown field, and a deprecated one, are handled as by the generated unmarshaling.
+	buf = protowire.AppendTag(buf, 100, protowire.VarintType)
+	buf = protowire.AppendVarint(buf, 1)
+	deprecated := otlptrace.ResourceSpans{DeprecatedScopeSpans: []*otlptrace.ScopeSpans{{SchemaUrl: "url"}}}
+	deprecatedBuf, err := deprecated.Marshal()
+	require.NoError(t, err)
+	buf = protowire.AppendTag(buf, 1, protowire.BytesType)
+	buf = protowire.AppendBytes(buf, deprecatedBuf)
+
+	expected := otlptrace.TracesData{}
+	require.NoError(t, expected.Unmarshal(buf))
+	for i := 0; i < 3; i++ {
+		got, err := (&ProtoUnmarshaler{}).UnmarshalTraces(buf)
+		require.NoError(t, err)
+		assert.Equal(t, Traces(internal.TracesFromProto(expected)), got)
+		got.Release()
+	}
+}
+
+func TestProtoTracesUnmarshalerPooledError(t *testing.T) {
+	td := NewTraces()
+	td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("name")
+	buf, err := (&ProtoMarshaler{}).MarshalTraces(td)
+	require.NoError(t, err)
+	for i := 1; i < len(buf); i++ {
+		_, err = (&ProtoUnmarshaler{}).UnmarshalTraces(buf[:i])
+		assert.Error(t, err, i)
+	}
+}
+
+// TestProtoTracesUnmarshalerRelease checks, when run with the race detector, that the memory
+// of released traces is not used by two goroutines at once.
+func TestProtoTracesUnmarshalerRelease(t *testing.T) {
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				td := NewTraces()
+				name := strconv.Itoa(g) + "-" + strconv.Itoa(i)
+				td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName(name)
+				buf, err := (&ProtoMarshaler{}).MarshalTraces(td)
+				assert.NoError(t, err)
+				td.Release()
+
+				got, err := (&ProtoUnmarshaler{}).UnmarshalTraces(buf)
+				assert.NoError(t, err)
+				span := got.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0)
+				span.Attributes().PutStr("k", "v")
+				assert.Equal(t, name, span.Name())
+				got.Release()
+			}
+		}()
+	}
+	wg.Wait()
+}
+
 func TestProtoSizer(t *testing.T) {
 	marshaler := &ProtoMarshaler{}
 	td := NewTraces()
@@ -48,6 +116,20 @@ func BenchmarkTracesToProto(b *testing.B) {
 	}
 }
 
+func BenchmarkTracesFromProtoRelease(b *testing.B) {
+	marshaler := &ProtoMarshaler{}
+	unmarshaler := &ProtoUnmarshaler{}
+	buf, err := marshaler.MarshalTraces(generateBenchmarkTraces(128))
+	require.NoError(b, err)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		td, err := unmarshaler.UnmarshalTraces(buf)
+		require.NoError(b, err)
+		td.Release()
+	}
+}
+
 func BenchmarkTracesFromProto(b *testing.B) {
 	marshaler := &ProtoMarshaler{}
 	unmarshaler := &ProtoUnmarshaler{}
@@ -0,0 +1,39 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ptrace // import "go.opentelemetry.io/collector/pdata/ptrace"
+
+import (
+	"io"
+
+	"go.opentelemetry.io/collector/pdata/internal"
+	otlptrace "go.opentelemetry.io/collector/pdata/internal/data/protogen/trace/v1"
+	"go.opentelemetry.io/collector/pdata/internal/otlp"
+	"go.opentelemetry.io/collector/pdata/internal/protostream"
+)
+
+// ProtoDecoder decodes traces in the OTLP/protobuf format, i.e. a marshaled ExportTraceServiceRequest or TracesData,
+// from an io.Reader one ResourceSpans at a time, so that only one ResourceSpans is held in memory at once.
+type ProtoDecoder struct {
+	r *protostream.Reader
+}
+
+// NewProtoDecoder creates a ProtoDecoder reading from r.
+func NewProtoDecoder(r io.Reader) *ProtoDecoder {
+	return &ProtoDecoder{r: protostream.NewReader(r)}
+}
+
+// DecodeResourceSpans returns the next ResourceSpans. It returns io.EOF once all the ResourceSpans were read.
+func (d *ProtoDecoder) DecodeResourceSpans() (ResourceSpans, error) {
+	buf, err := d.r.Next(1)
+	if err != nil {
+		return ResourceSpans{}, err
+	}
+	orig := &otlptrace.ResourceSpans{}
+	if err = orig.Unmarshal(buf); err != nil {
+		return ResourceSpans{}, err
+	}
+	otlp.MigrateTraces([]*otlptrace.ResourceSpans{orig})
+	state := internal.StateMutable
+	return newResourceSpans(orig, &state), nil
+}
@@ -0,0 +1,49 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ptrace
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProtoDecoder(t *testing.T) {
+	td := NewTraces()
+	fillTestResourceSpans(td.ResourceSpans().AppendEmpty())
+	td.ResourceSpans().AppendEmpty()
+	fillTestResourceSpans(td.ResourceSpans().AppendEmpty())
+	td.ResourceSpans().At(2).Resource().Attributes().PutStr("last", "true")
+	buf, err := (&ProtoMarshaler{}).MarshalTraces(td)
+	require.NoError(t, err)
+
+	decoded := NewTraces()
+	d := NewProtoDecoder(bytes.NewReader(buf))
+	for {
+		rs, err := d.DecodeResourceSpans()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		rs.MoveTo(decoded.ResourceSpans().AppendEmpty())
+	}
+	assert.Equal(t, td, decoded)
+}
+
+func TestProtoDecoderEmpty(t *testing.T) {
+	_, err := NewProtoDecoder(bytes.NewReader(nil)).DecodeResourceSpans()
+	assert.ErrorIs(t, err, io.EOF)
+}
+
+func TestProtoDecoderInvalid(t *testing.T) {
+	_, err := NewProtoDecoder(bytes.NewReader([]byte{0x0a, 0x02, 0x0a, 0x05})).DecodeResourceSpans()
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, io.EOF)
+
+	_, err = NewProtoDecoder(bytes.NewReader([]byte{0x0a, 0x05, 0x0a})).DecodeResourceSpans()
+	assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+}
@@ -75,8 +75,43 @@ func (*UnimplementedGRPCServer) Export(context.Context, ExportRequest) (ExportRe
 func (*UnimplementedGRPCServer) unexported() {}
 
 // RegisterGRPCServer registers the GRPCServer to the grpc.Server.
+// The requests are decoded into the memory released with ptrace.Traces.Release, if any.
 func RegisterGRPCServer(s *grpc.Server, srv GRPCServer) {
-	otlpcollectortrace.RegisterTraceServiceServer(s, &rawTracesServer{srv: srv})
+	s.RegisterService(&serviceDesc, &rawTracesServer{srv: srv})
+}
+
+// serviceDesc is the service of the generated otlpcollectortrace.RegisterTraceServiceServer, decoding the
+// requests from the pools.
+var serviceDesc = grpc.ServiceDesc{
+	ServiceName: "opentelemetry.proto.collector.trace.v1.TraceService",
+	HandlerType: (*otlpcollectortrace.TraceServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Export",
+			Handler:    exportHandler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "opentelemetry/proto/collector/trace/v1/trace_service.proto",
+}
+
+func exportHandler(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+	in := &internal.TracesGRPCRequest{Orig: internal.NewOrigTraces()}
+	if err := dec(in); err != nil {
+		internal.ReleaseOrigTraces(in.Orig, nil)
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(otlpcollectortrace.TraceServiceServer).Export(ctx, in.Orig)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/opentelemetry.proto.collector.trace.v1.TraceService/Export",
+	}
+	handler := func(ctx context.Context, req any) (any, error) {
+		return srv.(otlpcollectortrace.TraceServiceServer).Export(ctx, req.(*internal.TracesGRPCRequest).Orig)
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
 type rawTracesServer struct {
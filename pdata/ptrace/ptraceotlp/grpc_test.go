@@ -90,6 +90,58 @@ func TestGrpcError(t *testing.T) {
 	assert.Equal(t, ExportResponse{}, resp)
 }
 
+func TestGrpcReleasedRequests(t *testing.T) {
+	lis := bufconn.Listen(1024 * 1024)
+	var methods []string
+	s := grpc.NewServer(grpc.UnaryInterceptor(func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		methods = append(methods, info.FullMethod)
+		return handler(ctx, req)
+	}))
+	RegisterGRPCServer(s, &releasingTracesServer{fakeTracesServer{t: t}})
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		assert.NoError(t, s.Serve(lis))
+	}()
+	t.Cleanup(func() {
+		s.Stop()
+		wg.Wait()
+	})
+
+	cc, err := grpc.NewClient("bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return lis.Dial()
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		assert.NoError(t, cc.Close())
+	})
+
+	// The requests decoded after the previous ones were released are the same.
+	logClient := NewGRPCClient(cc)
+	for i := 0; i < 3; i++ {
+		_, err = logClient.Export(context.Background(), generateTracesRequest())
+		require.NoError(t, err)
+	}
+	assert.Equal(t, []string{
+		"/opentelemetry.proto.collector.trace.v1.TraceService/Export",
+		"/opentelemetry.proto.collector.trace.v1.TraceService/Export",
+		"/opentelemetry.proto.collector.trace.v1.TraceService/Export",
+	}, methods)
+}
+
+type releasingTracesServer struct {
+	fakeTracesServer
+}
+
+func (f releasingTracesServer) Export(ctx context.Context, request ExportRequest) (ExportResponse, error) {
+	resp, err := f.fakeTracesServer.Export(ctx, request)
+	request.Traces().Release()
+	return resp, err
+}
+
 type fakeTracesServer struct {
 	UnimplementedGRPCServer
 	t   *testing.T
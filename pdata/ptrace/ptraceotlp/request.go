@@ -8,6 +8,7 @@ import (
 
 	"go.opentelemetry.io/collector/pdata/internal"
 	otlpcollectortrace "go.opentelemetry.io/collector/pdata/internal/data/protogen/collector/trace/v1"
+	otlptrace "go.opentelemetry.io/collector/pdata/internal/data/protogen/trace/v1"
 	"go.opentelemetry.io/collector/pdata/internal/json"
 	"go.opentelemetry.io/collector/pdata/internal/otlp"
 	"go.opentelemetry.io/collector/pdata/ptrace"
@@ -18,15 +19,16 @@ var jsonUnmarshaler = &ptrace.JSONUnmarshaler{}
 // ExportRequest represents the request for gRPC/HTTP client/server.
 // It's a wrapper for ptrace.Traces data.
 type ExportRequest struct {
-	orig  *otlpcollectortrace.ExportTraceServiceRequest
-	state *internal.State
+	orig   *otlpcollectortrace.ExportTraceServiceRequest
+	state  *internal.State
+	shared internal.SharedNodes[otlptrace.ResourceSpans]
 }
 
 // NewExportRequest returns an empty ExportRequest.
 func NewExportRequest() ExportRequest {
 	state := internal.StateMutable
 	return ExportRequest{
-		orig:  &otlpcollectortrace.ExportTraceServiceRequest{},
+		orig:  internal.NewOrigTraces(),
 		state: &state,
 	}
 }
@@ -36,8 +38,9 @@ func NewExportRequest() ExportRequest {
 // any changes to the provided Traces struct will be reflected in the ExportRequest and vice versa.
 func NewExportRequestFromTraces(td ptrace.Traces) ExportRequest {
 	return ExportRequest{
-		orig:  internal.GetOrigTraces(internal.Traces(td)),
-		state: internal.GetTracesState(internal.Traces(td)),
+		orig:   internal.GetOrigTraces(internal.Traces(td)),
+		state:  internal.GetTracesState(internal.Traces(td)),
+		shared: internal.GetTracesSharedNodes(internal.Traces(td)),
 	}
 }
 
@@ -48,7 +51,7 @@ func (ms ExportRequest) MarshalProto() ([]byte, error) {
 
 // UnmarshalProto unmarshalls ExportRequest from proto bytes.
 func (ms ExportRequest) UnmarshalProto(data []byte) error {
-	if err := ms.orig.Unmarshal(data); err != nil {
+	if err := internal.UnmarshalOrigTraces(ms.orig, data); err != nil {
 		return err
 	}
 	otlp.MigrateTraces(ms.orig.ResourceSpans)
@@ -75,5 +78,5 @@ func (ms ExportRequest) UnmarshalJSON(data []byte) error {
 }
 
 func (ms ExportRequest) Traces() ptrace.Traces {
-	return ptrace.Traces(internal.NewTraces(ms.orig, ms.state))
+	return ptrace.Traces(internal.NewTraces(ms.orig, ms.state, ms.shared))
 }
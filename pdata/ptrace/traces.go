@@ -6,6 +6,7 @@ package ptrace // import "go.opentelemetry.io/collector/pdata/ptrace"
 import (
 	"go.opentelemetry.io/collector/pdata/internal"
 	otlpcollectortrace "go.opentelemetry.io/collector/pdata/internal/data/protogen/collector/trace/v1"
+	otlptrace "go.opentelemetry.io/collector/pdata/internal/data/protogen/trace/v1"
 )
 
 // Traces is the top-level struct that is propagated through the traces pipeline.
@@ -14,7 +15,7 @@ type Traces internal.Traces
 
 func newTraces(orig *otlpcollectortrace.ExportTraceServiceRequest) Traces {
 	state := internal.StateMutable
-	return Traces(internal.NewTraces(orig, &state))
+	return Traces(internal.NewTraces(orig, &state, nil))
 }
 
 func (ms Traces) getOrig() *otlpcollectortrace.ExportTraceServiceRequest {
@@ -25,9 +26,13 @@ func (ms Traces) getState() *internal.State {
 	return internal.GetTracesState(internal.Traces(ms))
 }
 
+func (ms Traces) getSharedNodes() internal.SharedNodes[otlptrace.ResourceSpans] {
+	return internal.GetTracesSharedNodes(internal.Traces(ms))
+}
+
 // NewTraces creates a new Traces struct.
 func NewTraces() Traces {
-	return newTraces(&otlpcollectortrace.ExportTraceServiceRequest{})
+	return newTraces(internal.NewOrigTraces())
 }
 
 // IsReadOnly returns true if this Traces instance is read-only.
@@ -35,6 +40,19 @@ func (ms Traces) IsReadOnly() bool {
 	return *ms.getState() == internal.StateReadOnly
 }
 
+// Release returns the memory of the Traces to pools, for the Traces unmarshaled from OTLP/protobuf to reuse.
+// It must only be called once the Traces, and everything obtained from it, are no longer used:
+// modifying them afterwards panics, and reading them returns undefined data.
+// Release does nothing if the Traces is read-only, as it is then shared with other consumers, and does
+// not release the ResourceSpans shared with other Traces, see Share.
+func (ms Traces) Release() {
+	if *ms.getState() != internal.StateMutable {
+		return
+	}
+	*ms.getState() = internal.StateReleased
+	internal.ReleaseOrigTraces(ms.getOrig(), ms.getSharedNodes())
+}
+
 // CopyTo copies the Traces instance overriding the destination.
 func (ms Traces) CopyTo(dest Traces) {
 	ms.ResourceSpans().CopyTo(dest.ResourceSpans())
@@ -43,10 +61,9 @@ func (ms Traces) CopyTo(dest Traces) {
 // SpanCount calculates the total number of spans.
 func (ms Traces) SpanCount() int {
 	spanCount := 0
-	rss := ms.ResourceSpans()
-	for i := 0; i < rss.Len(); i++ {
-		rs := rss.At(i)
-		ilss := rs.ScopeSpans()
+	// Does not use ResourceSpans.ScopeSpans, which copies the shared ResourceSpans.
+	for _, orig := range ms.getOrig().ResourceSpans {
+		ilss := newResourceSpans(orig, ms.getState()).ScopeSpans()
 		for j := 0; j < ilss.Len(); j++ {
 			spanCount += ilss.At(j).Spans().Len()
 		}
@@ -56,10 +73,23 @@ func (ms Traces) SpanCount() int {
 
 // ResourceSpans returns the ResourceSpansSlice associated with this Metrics.
 func (ms Traces) ResourceSpans() ResourceSpansSlice {
-	return newResourceSpansSlice(&ms.getOrig().ResourceSpans, internal.GetTracesState(internal.Traces(ms)))
+	return newResourceSpansSlice(&ms.getOrig().ResourceSpans, internal.GetTracesState(internal.Traces(ms)), ms.getSharedNodes())
 }
 
 // MarkReadOnly marks the Traces as shared so that no further modifications can be done on it.
 func (ms Traces) MarkReadOnly() {
 	internal.SetTracesState(internal.Traces(ms), internal.StateReadOnly)
 }
+
+// Share marks the Traces as read-only, and returns a mutable Traces sharing its ResourceSpans.
+// The shared ResourceSpans are copied on write: a shared ResourceSpans is copied the first time a method which
+// may modify it is called, e.g. ResourceSpans.Resource or ResourceSpans.SetSchemaUrl, so that only the ResourceSpans
+// which are modified are copied. Share can be used instead of CopyTo to send the same Traces to several
+// consumers modifying it.
+func (ms Traces) Share() Traces {
+	ms.MarkReadOnly()
+	orig := internal.NewOrigTraces()
+	orig.ResourceSpans = append(orig.ResourceSpans, ms.getOrig().ResourceSpans...)
+	state := internal.StateMutable
+	return Traces(internal.NewTraces(orig, &state, internal.NewSharedNodes(orig.ResourceSpans)))
+}
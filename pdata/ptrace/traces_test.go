@@ -127,6 +127,140 @@ func TestReadOnlyTracesInvalidUsage(t *testing.T) {
 	assert.Panics(t, func() { res.Attributes().PutStr("k2", "v2") })
 }
 
+func TestTracesRelease(t *testing.T) {
+	traces := NewTraces()
+	fillTestResourceSpansSlice(traces.ResourceSpans())
+	span := traces.ResourceSpans().At(0).ScopeSpans().At(0).Spans().AppendEmpty()
+	traces.Release()
+	assert.PanicsWithValue(t, "invalid access to released data", func() { span.SetName("name") })
+	assert.PanicsWithValue(t, "invalid access to released data", func() { traces.ResourceSpans().AppendEmpty() })
+
+	// Releasing again does nothing.
+	traces.Release()
+	assert.Equal(t, NewTraces(), NewTraces())
+}
+
+func TestTracesReleaseReadOnly(t *testing.T) {
+	traces := NewTraces()
+	traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("name")
+	traces.MarkReadOnly()
+	traces.Release()
+	assert.Equal(t, "name", traces.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Name())
+}
+
+func TestTracesShare(t *testing.T) {
+	traces := NewTraces()
+	fillTestResourceSpansSlice(traces.ResourceSpans())
+	shared := traces.Share()
+	assert.True(t, traces.IsReadOnly())
+	assert.False(t, shared.IsReadOnly())
+	assert.Equal(t, traces.getOrig(), shared.getOrig())
+
+	// Reading the counts does not copy the ResourceSpans.
+	assert.Equal(t, traces.SpanCount(), shared.SpanCount())
+	for i, orig := range shared.getOrig().ResourceSpans {
+		assert.Same(t, traces.getOrig().ResourceSpans[i], orig)
+	}
+
+	// At does not copy the ResourceSpans until it may be modified.
+	rs := shared.ResourceSpans().At(1)
+	other := shared.ResourceSpans().At(1)
+	assert.Equal(t, traces.ResourceSpans().At(1).SchemaUrl(), rs.SchemaUrl())
+	assert.Same(t, traces.getOrig().ResourceSpans[1], shared.getOrig().ResourceSpans[1])
+
+	// Only the modified ResourceSpans is copied, the original is not modified.
+	rs.Resource().Attributes().PutStr("k2", "v")
+	assert.NotSame(t, traces.getOrig().ResourceSpans[1], shared.getOrig().ResourceSpans[1])
+	// The ResourceSpans returned before the copy refer to the copy.
+	other.SetSchemaUrl("changed")
+	assert.Equal(t, "changed", rs.SchemaUrl())
+	assert.Equal(t, "changed", shared.ResourceSpans().At(1).SchemaUrl())
+	assert.Same(t, shared.getOrig().ResourceSpans[1], other.getMutableOrig())
+	assert.Same(t, traces.getOrig().ResourceSpans[2], shared.getOrig().ResourceSpans[2])
+	assert.Equal(t, *generateTestResourceSpansSlice().orig, traces.getOrig().ResourceSpans)
+	_, ok := traces.ResourceSpans().At(1).Resource().Attributes().Get("k2")
+	assert.False(t, ok)
+
+	// The read-only Traces are not copied.
+	assert.Same(t, traces.getOrig().ResourceSpans[3], traces.ResourceSpans().At(3).orig)
+}
+
+func TestTracesShareMoveAndCopy(t *testing.T) {
+	traces := NewTraces()
+	fillTestResourceSpansSlice(traces.ResourceSpans())
+	shared1 := traces.Share()
+	other := NewTraces()
+	other.ResourceSpans().AppendEmpty()
+	shared2 := other.Share()
+
+	// The shared ResourceSpans moved to a shared Traces are still shared.
+	shared1.ResourceSpans().MoveAndAppendTo(shared2.ResourceSpans())
+	assert.Equal(t, 8, shared2.ResourceSpans().Len())
+	assert.Same(t, traces.getOrig().ResourceSpans[0], shared2.getOrig().ResourceSpans[1])
+
+	// The shared ResourceSpans moved to a Traces which already shares them are copied,
+	// a shared ResourceSpans is at most once in a Traces.
+	traces.Share().ResourceSpans().MoveAndAppendTo(shared2.ResourceSpans())
+	assert.Equal(t, 15, shared2.ResourceSpans().Len())
+	for i := range 7 {
+		assert.Same(t, traces.getOrig().ResourceSpans[i], shared2.getOrig().ResourceSpans[i+1])
+		assert.NotSame(t, traces.getOrig().ResourceSpans[i], shared2.getOrig().ResourceSpans[i+8])
+	}
+
+	// The shared ResourceSpans moved to other Traces are copied.
+	dest := NewTraces()
+	shared2.ResourceSpans().MoveAndAppendTo(dest.ResourceSpans())
+	assert.Equal(t, 15, dest.ResourceSpans().Len())
+	for i, orig := range dest.getOrig().ResourceSpans[1:] {
+		assert.NotSame(t, traces.getOrig().ResourceSpans[i%7], orig)
+	}
+	dest.ResourceSpans().At(1).SetSchemaUrl("changed")
+	assert.Equal(t, *generateTestResourceSpansSlice().orig, traces.getOrig().ResourceSpans)
+
+	// The shared ResourceSpans are not overridden by CopyTo.
+	shared3 := traces.Share()
+	src := NewTraces()
+	fillTestResourceSpansSlice(src.ResourceSpans())
+	src.ResourceSpans().At(0).SetSchemaUrl("changed")
+	src.CopyTo(shared3)
+	assert.Empty(t, shared3.getSharedNodes())
+	assert.Equal(t, "changed", shared3.ResourceSpans().At(0).SchemaUrl())
+	assert.Equal(t, *generateTestResourceSpansSlice().orig, traces.getOrig().ResourceSpans)
+}
+
+func TestTracesShareView(t *testing.T) {
+	traces := NewTraces()
+	fillTestResourceSpansSlice(traces.ResourceSpans())
+	traces.ResourceSpans().At(2).SetSchemaUrl("remove")
+	shared := traces.Share()
+
+	// The views are read-only, and do not copy the ResourceSpans.
+	view := shared.ResourceSpans().View(1)
+	assert.Same(t, traces.getOrig().ResourceSpans[1], view.orig)
+	assert.Panics(t, func() { view.SetSchemaUrl("changed") })
+
+	// The ResourceSpans are removed and sorted without being copied, and remain shared.
+	shared.ResourceSpans().RemoveIfView(func(rs ResourceSpans) bool { return rs.SchemaUrl() == "remove" })
+	assert.Equal(t, 6, shared.ResourceSpans().Len())
+	shared.ResourceSpans().Sort(func(a, b ResourceSpans) bool { return a.SchemaUrl() > b.SchemaUrl() })
+	for _, orig := range shared.getOrig().ResourceSpans {
+		assert.Contains(t, shared.getSharedNodes(), orig)
+		assert.Contains(t, traces.getOrig().ResourceSpans, orig)
+	}
+	shared.ResourceSpans().At(0).SetSchemaUrl("changed")
+	assert.NotContains(t, traces.getOrig().ResourceSpans, shared.getOrig().ResourceSpans[0])
+}
+
+func TestTracesShareRelease(t *testing.T) {
+	traces := NewTraces()
+	fillTestResourceSpansSlice(traces.ResourceSpans())
+	shared := traces.Share()
+	shared.ResourceSpans().At(0).Resource().Attributes().PutStr("k2", "v")
+	shared.Release()
+	// Only the ResourceSpans copied by shared are released.
+	assert.Equal(t, *generateTestResourceSpansSlice().orig, traces.getOrig().ResourceSpans)
+}
+
 func BenchmarkTracesUsage(b *testing.B) {
 	traces := NewTraces()
 	fillTestResourceSpansSlice(traces.ResourceSpans())
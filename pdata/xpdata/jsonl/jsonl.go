@@ -0,0 +1,233 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package jsonl writes and reads telemetry in the OTLP JSON Lines format, i.e. one
+// OTLP/JSON encoded TracesData, MetricsData, LogsData or ProfilesData per line, as used
+// to keep telemetry in files, e.g. test fixtures or archives. The files may be compressed
+// with gzip or zstd.
+package jsonl // import "go.opentelemetry.io/collector/pdata/xpdata/jsonl"
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/klauspost/compress/zstd"
+
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// Compression is the compression of an OTLP JSON Lines file.
+type Compression string
+
+const (
+	// CompressionNone writes the lines uncompressed.
+	CompressionNone Compression = ""
+	// CompressionGzip compresses the lines with gzip.
+	CompressionGzip Compression = "gzip"
+	// CompressionZstd compresses the lines with zstd.
+	CompressionZstd Compression = "zstd"
+)
+
+var (
+	gzipMagic = []byte{0x1f, 0x8b}
+	zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+)
+
+// Writer writes telemetry as OTLP JSON Lines, one line per call.
+type Writer struct {
+	w io.Writer
+	// closeFn flushes and closes the compressor, if any.
+	closeFn func() error
+
+	tracesMarshaler   ptrace.JSONMarshaler
+	metricsMarshaler  pmetric.JSONMarshaler
+	logsMarshaler     plog.JSONMarshaler
+	profilesMarshaler pprofile.JSONMarshaler
+}
+
+// NewWriter creates a Writer writing to w with the given compression.
+// Close must be called once done writing, it does not close w.
+func NewWriter(w io.Writer, compression Compression) (*Writer, error) {
+	switch compression {
+	case CompressionNone:
+		return &Writer{w: w, closeFn: func() error { return nil }}, nil
+	case CompressionGzip:
+		zw := gzip.NewWriter(w)
+		return &Writer{w: zw, closeFn: zw.Close}, nil
+	case CompressionZstd:
+		zw, err := zstd.NewWriter(w)
+		if err != nil {
+			return nil, err
+		}
+		return &Writer{w: zw, closeFn: zw.Close}, nil
+	}
+	return nil, fmt.Errorf("unsupported compression %q", compression)
+}
+
+// WriteTraces writes td as a line.
+func (w *Writer) WriteTraces(td ptrace.Traces) error {
+	return w.writeLine(w.tracesMarshaler.MarshalTraces(td))
+}
+
+// WriteMetrics writes md as a line.
+func (w *Writer) WriteMetrics(md pmetric.Metrics) error {
+	return w.writeLine(w.metricsMarshaler.MarshalMetrics(md))
+}
+
+// WriteLogs writes ld as a line.
+func (w *Writer) WriteLogs(ld plog.Logs) error {
+	return w.writeLine(w.logsMarshaler.MarshalLogs(ld))
+}
+
+// WriteProfiles writes pd as a line.
+func (w *Writer) WriteProfiles(pd pprofile.Profiles) error {
+	return w.writeLine(w.profilesMarshaler.MarshalProfiles(pd))
+}
+
+func (w *Writer) writeLine(buf []byte, err error) error {
+	if err != nil {
+		return err
+	}
+	_, err = w.w.Write(append(buf, '\n'))
+	return err
+}
+
+// Close flushes the compressed data, if any. It does not close the underlying writer.
+func (w *Writer) Close() error {
+	return w.closeFn()
+}
+
+// LineError is returned by a Reader failing to read a line.
+type LineError struct {
+	// Line is the number of the line, starting at 1.
+	Line int
+	Err  error
+}
+
+func (e *LineError) Error() string {
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+func (e *LineError) Unwrap() error {
+	return e.Err
+}
+
+// Reader reads telemetry from OTLP JSON Lines one line at a time. Empty lines are skipped.
+type Reader struct {
+	r *bufio.Reader
+	// closeFn releases the decompressor, if any.
+	closeFn func() error
+	line    int
+
+	tracesUnmarshaler   ptrace.JSONUnmarshaler
+	metricsUnmarshaler  pmetric.JSONUnmarshaler
+	logsUnmarshaler     plog.JSONUnmarshaler
+	profilesUnmarshaler pprofile.JSONUnmarshaler
+}
+
+// NewReader creates a Reader reading from r. Compressed data is detected and decompressed.
+// Close must be called once done reading, it does not close r.
+func NewReader(r io.Reader) (*Reader, error) {
+	br := bufio.NewReader(r)
+	// Peek returns less bytes, and an error, for data shorter than the magic numbers.
+	magic, _ := br.Peek(len(zstdMagic))
+	switch {
+	case bytes.HasPrefix(magic, gzipMagic):
+		zr, err := gzip.NewReader(br)
+		if err != nil {
+			return nil, err
+		}
+		return &Reader{r: bufio.NewReader(zr), closeFn: zr.Close}, nil
+	case bytes.HasPrefix(magic, zstdMagic):
+		zr, err := zstd.NewReader(br)
+		if err != nil {
+			return nil, err
+		}
+		return &Reader{r: bufio.NewReader(zr), closeFn: func() error {
+			zr.Close()
+			return nil
+		}}, nil
+	}
+	return &Reader{r: br, closeFn: func() error { return nil }}, nil
+}
+
+// ReadTraces reads the next line as traces. It returns io.EOF once all the lines were read.
+func (r *Reader) ReadTraces() (ptrace.Traces, error) {
+	buf, err := r.nextLine()
+	if err != nil {
+		return ptrace.Traces{}, err
+	}
+	td, err := r.tracesUnmarshaler.UnmarshalTraces(buf)
+	if err != nil {
+		return ptrace.Traces{}, &LineError{Line: r.line, Err: err}
+	}
+	return td, nil
+}
+
+// ReadMetrics reads the next line as metrics. It returns io.EOF once all the lines were read.
+func (r *Reader) ReadMetrics() (pmetric.Metrics, error) {
+	buf, err := r.nextLine()
+	if err != nil {
+		return pmetric.Metrics{}, err
+	}
+	md, err := r.metricsUnmarshaler.UnmarshalMetrics(buf)
+	if err != nil {
+		return pmetric.Metrics{}, &LineError{Line: r.line, Err: err}
+	}
+	return md, nil
+}
+
+// ReadLogs reads the next line as logs. It returns io.EOF once all the lines were read.
+func (r *Reader) ReadLogs() (plog.Logs, error) {
+	buf, err := r.nextLine()
+	if err != nil {
+		return plog.Logs{}, err
+	}
+	ld, err := r.logsUnmarshaler.UnmarshalLogs(buf)
+	if err != nil {
+		return plog.Logs{}, &LineError{Line: r.line, Err: err}
+	}
+	return ld, nil
+}
+
+// ReadProfiles reads the next line as profiles. It returns io.EOF once all the lines were read.
+func (r *Reader) ReadProfiles() (pprofile.Profiles, error) {
+	buf, err := r.nextLine()
+	if err != nil {
+		return pprofile.Profiles{}, err
+	}
+	pd, err := r.profilesUnmarshaler.UnmarshalProfiles(buf)
+	if err != nil {
+		return pprofile.Profiles{}, &LineError{Line: r.line, Err: err}
+	}
+	return pd, nil
+}
+
+// nextLine returns the next line which is not empty, without its line ending.
+func (r *Reader) nextLine() ([]byte, error) {
+	for {
+		buf, err := r.r.ReadBytes('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, &LineError{Line: r.line + 1, Err: err}
+		}
+		if len(buf) == 0 && err != nil {
+			return nil, io.EOF
+		}
+		r.line++
+		if buf = bytes.TrimSpace(buf); len(buf) > 0 {
+			return buf, nil
+		}
+	}
+}
+
+// Close releases the decompressor, if any. It does not close the underlying reader.
+func (r *Reader) Close() error {
+	return r.closeFn()
+}
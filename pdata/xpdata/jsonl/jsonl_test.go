@@ -0,0 +1,119 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package jsonl
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/pdata/testdata"
+)
+
+func TestWriteRead(t *testing.T) {
+	for _, compression := range []Compression{CompressionNone, CompressionGzip, CompressionZstd} {
+		t.Run(string(compression), func(t *testing.T) {
+			td := testdata.GenerateTraces(2)
+			md := testdata.GenerateMetrics(2)
+			ld := testdata.GenerateLogs(2)
+			pd := testdata.GenerateProfiles(2)
+
+			var buf bytes.Buffer
+			w, err := NewWriter(&buf, compression)
+			require.NoError(t, err)
+			require.NoError(t, w.WriteTraces(td))
+			require.NoError(t, w.WriteMetrics(md))
+			require.NoError(t, w.WriteLogs(ld))
+			require.NoError(t, w.WriteProfiles(pd))
+			require.NoError(t, w.WriteTraces(td))
+			require.NoError(t, w.Close())
+			if compression == CompressionNone {
+				assert.Equal(t, 5, strings.Count(buf.String(), "\n"))
+			}
+
+			r, err := NewReader(&buf)
+			require.NoError(t, err)
+			gotTraces, err := r.ReadTraces()
+			require.NoError(t, err)
+			assert.Equal(t, td, gotTraces)
+			gotMetrics, err := r.ReadMetrics()
+			require.NoError(t, err)
+			assert.Equal(t, md, gotMetrics)
+			gotLogs, err := r.ReadLogs()
+			require.NoError(t, err)
+			assert.Equal(t, ld, gotLogs)
+			gotProfiles, err := r.ReadProfiles()
+			require.NoError(t, err)
+			assert.Equal(t, pd, gotProfiles)
+			gotTraces, err = r.ReadTraces()
+			require.NoError(t, err)
+			assert.Equal(t, td, gotTraces)
+
+			_, err = r.ReadTraces()
+			require.ErrorIs(t, err, io.EOF)
+			require.NoError(t, r.Close())
+		})
+	}
+}
+
+func TestReadLines(t *testing.T) {
+	r, err := NewReader(strings.NewReader("\n" +
+		`{"resourceLogs":[{"resource":{"attributes":[{"key":"line","value":{"intValue":"2"}}]}}]}` + "\r\n" +
+		"  \n" +
+		`{"resourceLogs":[{"resource":{"attributes":[{"key":"line","value":{"intValue":"4"}}]}}]}`))
+	require.NoError(t, err)
+	defer func() { require.NoError(t, r.Close()) }()
+
+	for _, line := range []int64{2, 4} {
+		ld, err := r.ReadLogs()
+		require.NoError(t, err)
+		value, ok := ld.ResourceLogs().At(0).Resource().Attributes().Get("line")
+		require.True(t, ok)
+		assert.Equal(t, line, value.Int())
+	}
+	_, err = r.ReadLogs()
+	assert.ErrorIs(t, err, io.EOF)
+}
+
+func TestReadLineError(t *testing.T) {
+	r, err := NewReader(strings.NewReader(`{"resourceSpans":[]}` + "\n\n" + `{"resourceSpans":[` + "\n"))
+	require.NoError(t, err)
+	defer func() { require.NoError(t, r.Close()) }()
+
+	_, err = r.ReadTraces()
+	require.NoError(t, err)
+	_, err = r.ReadTraces()
+	var lineErr *LineError
+	require.ErrorAs(t, err, &lineErr)
+	assert.Equal(t, 3, lineErr.Line)
+	assert.ErrorContains(t, err, "line 3: ")
+}
+
+func TestReadCompressionError(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, CompressionGzip)
+	require.NoError(t, err)
+	require.NoError(t, w.WriteMetrics(testdata.GenerateMetrics(1)))
+	require.NoError(t, w.Close())
+
+	r, err := NewReader(bytes.NewReader(buf.Bytes()[:buf.Len()/2]))
+	require.NoError(t, err)
+	_, err = r.ReadMetrics()
+	var lineErr *LineError
+	require.ErrorAs(t, err, &lineErr)
+	assert.Equal(t, 1, lineErr.Line)
+	require.ErrorIs(t, err, io.ErrUnexpectedEOF)
+
+	_, err = NewReader(bytes.NewReader([]byte{0x1f, 0x8b, 0x00}))
+	assert.Error(t, err)
+}
+
+func TestNewWriterUnsupportedCompression(t *testing.T) {
+	_, err := NewWriter(io.Discard, "lz4")
+	assert.EqualError(t, err, `unsupported compression "lz4"`)
+}
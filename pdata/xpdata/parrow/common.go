@@ -0,0 +1,465 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package parrow // import "go.opentelemetry.io/collector/pdata/xpdata/parrow"
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"google.golang.org/protobuf/encoding/protowire"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+var (
+	errInvalidValue    = errors.New("invalid OTLP/protobuf value")
+	errInvalidEncoding = errors.New("invalid arrow record")
+)
+
+// valueFields are the fields of the struct columns holding a pcommon.Value, only the field of
+// the type of the value is set. Arrow has no recursive types, the maps and the slices are set
+// in "ser" as the OTLP/protobuf encoded KeyValueList and ArrayValue.
+var valueFields = []arrow.Field{
+	{Name: "type", Type: arrow.PrimitiveTypes.Uint8},
+	{Name: "str", Type: arrow.BinaryTypes.String, Nullable: true},
+	{Name: "int", Type: arrow.PrimitiveTypes.Int64, Nullable: true},
+	{Name: "double", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+	{Name: "bool", Type: arrow.FixedWidthTypes.Boolean, Nullable: true},
+	{Name: "bytes", Type: arrow.BinaryTypes.Binary, Nullable: true},
+	{Name: "ser", Type: arrow.BinaryTypes.Binary, Nullable: true},
+}
+
+var (
+	valueType = arrow.StructOf(valueFields...)
+
+	attributesType = arrow.ListOf(arrow.StructOf(append([]arrow.Field{
+		{Name: "key", Type: arrow.BinaryTypes.String},
+	}, valueFields...)...))
+
+	resourceType = arrow.StructOf(
+		arrow.Field{Name: "attributes", Type: attributesType},
+		arrow.Field{Name: "dropped_attributes_count", Type: arrow.PrimitiveTypes.Uint32},
+	)
+
+	scopeType = arrow.StructOf(
+		arrow.Field{Name: "name", Type: arrow.BinaryTypes.String},
+		arrow.Field{Name: "version", Type: arrow.BinaryTypes.String},
+		arrow.Field{Name: "attributes", Type: attributesType},
+		arrow.Field{Name: "dropped_attributes_count", Type: arrow.PrimitiveTypes.Uint32},
+	)
+
+	traceIDType = &arrow.FixedSizeBinaryType{ByteWidth: 16}
+	spanIDType  = &arrow.FixedSizeBinaryType{ByteWidth: 8}
+)
+
+// recoverInvalid returns the panics of arrow-go as errors. The records decoded from untrusted
+// input may hold invalid offsets, which arrow-go does not validate before reading them.
+func recoverInvalid(err *error) {
+	if r := recover(); r != nil {
+		*err = fmt.Errorf("%w: %v", errInvalidEncoding, r)
+	}
+}
+
+type valueBuilder struct {
+	typ    *array.Uint8Builder
+	str    *array.StringBuilder
+	int    *array.Int64Builder
+	double *array.Float64Builder
+	bool   *array.BooleanBuilder
+	bytes  *array.BinaryBuilder
+	ser    *array.BinaryBuilder
+}
+
+// newValueBuilder creates a valueBuilder appending to the valueFields of sb starting at first.
+func newValueBuilder(sb *array.StructBuilder, first int) valueBuilder {
+	return valueBuilder{
+		typ:    sb.FieldBuilder(first).(*array.Uint8Builder),
+		str:    sb.FieldBuilder(first + 1).(*array.StringBuilder),
+		int:    sb.FieldBuilder(first + 2).(*array.Int64Builder),
+		double: sb.FieldBuilder(first + 3).(*array.Float64Builder),
+		bool:   sb.FieldBuilder(first + 4).(*array.BooleanBuilder),
+		bytes:  sb.FieldBuilder(first + 5).(*array.BinaryBuilder),
+		ser:    sb.FieldBuilder(first + 6).(*array.BinaryBuilder),
+	}
+}
+
+func (b valueBuilder) append(v pcommon.Value) {
+	b.typ.Append(uint8(v.Type()))
+	appendOrNull(b.str, v.Type() == pcommon.ValueTypeStr, v.Str)
+	appendOrNull(b.int, v.Type() == pcommon.ValueTypeInt, v.Int)
+	appendOrNull(b.double, v.Type() == pcommon.ValueTypeDouble, v.Double)
+	appendOrNull(b.bool, v.Type() == pcommon.ValueTypeBool, v.Bool)
+	appendOrNull(b.bytes, v.Type() == pcommon.ValueTypeBytes, func() []byte { return v.Bytes().AsRaw() })
+	switch v.Type() {
+	case pcommon.ValueTypeMap:
+		b.ser.Append(appendKeyValueList(nil, v.Map()))
+	case pcommon.ValueTypeSlice:
+		b.ser.Append(appendArrayValue(nil, v.Slice()))
+	default:
+		b.ser.AppendNull()
+	}
+}
+
+// appendOrNull appends the value returned by get if ok, or null otherwise.
+func appendOrNull[T any](b interface {
+	Append(T)
+	AppendNull()
+}, ok bool, get func() T,
+) {
+	if ok {
+		b.Append(get())
+		return
+	}
+	b.AppendNull()
+}
+
+type valueReader struct {
+	typ    *array.Uint8
+	str    *array.String
+	int    *array.Int64
+	double *array.Float64
+	bool   *array.Boolean
+	bytes  *array.Binary
+	ser    *array.Binary
+}
+
+func newValueReader(s *array.Struct, first int) valueReader {
+	return valueReader{
+		typ:    s.Field(first).(*array.Uint8),
+		str:    s.Field(first + 1).(*array.String),
+		int:    s.Field(first + 2).(*array.Int64),
+		double: s.Field(first + 3).(*array.Float64),
+		bool:   s.Field(first + 4).(*array.Boolean),
+		bytes:  s.Field(first + 5).(*array.Binary),
+		ser:    s.Field(first + 6).(*array.Binary),
+	}
+}
+
+func (r valueReader) read(i int, dest pcommon.Value) error {
+	switch pcommon.ValueType(r.typ.Value(i)) {
+	case pcommon.ValueTypeEmpty:
+	case pcommon.ValueTypeStr:
+		dest.SetStr(r.str.Value(i))
+	case pcommon.ValueTypeInt:
+		dest.SetInt(r.int.Value(i))
+	case pcommon.ValueTypeDouble:
+		dest.SetDouble(r.double.Value(i))
+	case pcommon.ValueTypeBool:
+		dest.SetBool(r.bool.Value(i))
+	case pcommon.ValueTypeBytes:
+		dest.SetEmptyBytes().FromRaw(r.bytes.Value(i))
+	case pcommon.ValueTypeMap:
+		return readKeyValueList(r.ser.Value(i), dest.SetEmptyMap())
+	case pcommon.ValueTypeSlice:
+		return readArrayValue(r.ser.Value(i), dest.SetEmptySlice())
+	default:
+		return fmt.Errorf("%w: unknown value type %d", errInvalidEncoding, r.typ.Value(i))
+	}
+	return nil
+}
+
+type attributesBuilder struct {
+	list  *array.ListBuilder
+	kv    *array.StructBuilder
+	key   *array.StringBuilder
+	value valueBuilder
+}
+
+func newAttributesBuilder(b array.Builder) attributesBuilder {
+	list := b.(*array.ListBuilder)
+	kv := list.ValueBuilder().(*array.StructBuilder)
+	return attributesBuilder{
+		list:  list,
+		kv:    kv,
+		key:   kv.FieldBuilder(0).(*array.StringBuilder),
+		value: newValueBuilder(kv, 1),
+	}
+}
+
+func (b attributesBuilder) append(m pcommon.Map) {
+	b.list.Append(true)
+	m.Range(func(k string, v pcommon.Value) bool {
+		b.kv.Append(true)
+		b.key.Append(k)
+		b.value.append(v)
+		return true
+	})
+}
+
+type attributesReader struct {
+	list  *array.List
+	key   *array.String
+	value valueReader
+}
+
+func newAttributesReader(a arrow.Array) attributesReader {
+	list := a.(*array.List)
+	kv := list.ListValues().(*array.Struct)
+	return attributesReader{
+		list:  list,
+		key:   kv.Field(0).(*array.String),
+		value: newValueReader(kv, 1),
+	}
+}
+
+func (r attributesReader) read(i int, dest pcommon.Map) error {
+	start, end := r.list.ValueOffsets(i)
+	dest.EnsureCapacity(int(end - start))
+	for j := int(start); j < int(end); j++ {
+		if err := r.value.read(j, dest.PutEmpty(r.key.Value(j))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type resourceBuilder struct {
+	s          *array.StructBuilder
+	attributes attributesBuilder
+	dropped    *array.Uint32Builder
+}
+
+func newResourceBuilder(b array.Builder) resourceBuilder {
+	s := b.(*array.StructBuilder)
+	return resourceBuilder{
+		s:          s,
+		attributes: newAttributesBuilder(s.FieldBuilder(0)),
+		dropped:    s.FieldBuilder(1).(*array.Uint32Builder),
+	}
+}
+
+func (b resourceBuilder) append(r pcommon.Resource) {
+	b.s.Append(true)
+	b.attributes.append(r.Attributes())
+	b.dropped.Append(r.DroppedAttributesCount())
+}
+
+type resourceReader struct {
+	attributes attributesReader
+	dropped    *array.Uint32
+}
+
+func newResourceReader(a arrow.Array) resourceReader {
+	s := a.(*array.Struct)
+	return resourceReader{
+		attributes: newAttributesReader(s.Field(0)),
+		dropped:    s.Field(1).(*array.Uint32),
+	}
+}
+
+func (r resourceReader) read(i int, dest pcommon.Resource) error {
+	dest.SetDroppedAttributesCount(r.dropped.Value(i))
+	return r.attributes.read(i, dest.Attributes())
+}
+
+type scopeBuilder struct {
+	s          *array.StructBuilder
+	name       *array.StringBuilder
+	version    *array.StringBuilder
+	attributes attributesBuilder
+	dropped    *array.Uint32Builder
+}
+
+func newScopeBuilder(b array.Builder) scopeBuilder {
+	s := b.(*array.StructBuilder)
+	return scopeBuilder{
+		s:          s,
+		name:       s.FieldBuilder(0).(*array.StringBuilder),
+		version:    s.FieldBuilder(1).(*array.StringBuilder),
+		attributes: newAttributesBuilder(s.FieldBuilder(2)),
+		dropped:    s.FieldBuilder(3).(*array.Uint32Builder),
+	}
+}
+
+func (b scopeBuilder) append(is pcommon.InstrumentationScope) {
+	b.s.Append(true)
+	b.name.Append(is.Name())
+	b.version.Append(is.Version())
+	b.attributes.append(is.Attributes())
+	b.dropped.Append(is.DroppedAttributesCount())
+}
+
+type scopeReader struct {
+	name       *array.String
+	version    *array.String
+	attributes attributesReader
+	dropped    *array.Uint32
+}
+
+func newScopeReader(a arrow.Array) scopeReader {
+	s := a.(*array.Struct)
+	return scopeReader{
+		name:       s.Field(0).(*array.String),
+		version:    s.Field(1).(*array.String),
+		attributes: newAttributesReader(s.Field(2)),
+		dropped:    s.Field(3).(*array.Uint32),
+	}
+}
+
+func (r scopeReader) read(i int, dest pcommon.InstrumentationScope) error {
+	dest.SetName(r.name.Value(i))
+	dest.SetVersion(r.version.Value(i))
+	dest.SetDroppedAttributesCount(r.dropped.Value(i))
+	return r.attributes.read(i, dest.Attributes())
+}
+
+// listRange returns the range of the values of the list at i.
+func listRange(l *array.List, i int) (int, int) {
+	start, end := l.ValueOffsets(i)
+	return int(start), int(end)
+}
+
+// OTLP/protobuf field numbers of AnyValue, ArrayValue, KeyValueList and KeyValue.
+const (
+	anyValueStr    protowire.Number = 1
+	anyValueBool   protowire.Number = 2
+	anyValueInt    protowire.Number = 3
+	anyValueDouble protowire.Number = 4
+	anyValueArray  protowire.Number = 5
+	anyValueKVList protowire.Number = 6
+	anyValueBytes  protowire.Number = 7
+
+	listValues protowire.Number = 1
+
+	keyValueKey   protowire.Number = 1
+	keyValueValue protowire.Number = 2
+)
+
+func appendAnyValue(b []byte, v pcommon.Value) []byte {
+	switch v.Type() {
+	case pcommon.ValueTypeStr:
+		b = protowire.AppendTag(b, anyValueStr, protowire.BytesType)
+		b = protowire.AppendString(b, v.Str())
+	case pcommon.ValueTypeBool:
+		b = protowire.AppendTag(b, anyValueBool, protowire.VarintType)
+		b = protowire.AppendVarint(b, protowire.EncodeBool(v.Bool()))
+	case pcommon.ValueTypeInt:
+		b = protowire.AppendTag(b, anyValueInt, protowire.VarintType)
+		b = protowire.AppendVarint(b, uint64(v.Int()))
+	case pcommon.ValueTypeDouble:
+		b = protowire.AppendTag(b, anyValueDouble, protowire.Fixed64Type)
+		b = protowire.AppendFixed64(b, math.Float64bits(v.Double()))
+	case pcommon.ValueTypeSlice:
+		b = protowire.AppendTag(b, anyValueArray, protowire.BytesType)
+		b = protowire.AppendBytes(b, appendArrayValue(nil, v.Slice()))
+	case pcommon.ValueTypeMap:
+		b = protowire.AppendTag(b, anyValueKVList, protowire.BytesType)
+		b = protowire.AppendBytes(b, appendKeyValueList(nil, v.Map()))
+	case pcommon.ValueTypeBytes:
+		b = protowire.AppendTag(b, anyValueBytes, protowire.BytesType)
+		b = protowire.AppendBytes(b, v.Bytes().AsRaw())
+	}
+	return b
+}
+
+func appendArrayValue(b []byte, s pcommon.Slice) []byte {
+	for i := 0; i < s.Len(); i++ {
+		b = protowire.AppendTag(b, listValues, protowire.BytesType)
+		b = protowire.AppendBytes(b, appendAnyValue(nil, s.At(i)))
+	}
+	return b
+}
+
+func appendKeyValueList(b []byte, m pcommon.Map) []byte {
+	m.Range(func(k string, v pcommon.Value) bool {
+		var kv []byte
+		kv = protowire.AppendTag(kv, keyValueKey, protowire.BytesType)
+		kv = protowire.AppendString(kv, k)
+		kv = protowire.AppendTag(kv, keyValueValue, protowire.BytesType)
+		kv = protowire.AppendBytes(kv, appendAnyValue(nil, v))
+		b = protowire.AppendTag(b, listValues, protowire.BytesType)
+		b = protowire.AppendBytes(b, kv)
+		return true
+	})
+	return b
+}
+
+// forEachField calls fn with the fields of the OTLP/protobuf message in buf. The value of the
+// varint and fixed64 fields is passed as num, the value of the bytes fields as val.
+func forEachField(buf []byte, fn func(num protowire.Number, typ protowire.Type, val []byte, num64 uint64) error) error {
+	for len(buf) > 0 {
+		field, typ, n := protowire.ConsumeTag(buf)
+		if n < 0 {
+			return errInvalidValue
+		}
+		buf = buf[n:]
+		var val []byte
+		var num64 uint64
+		switch typ {
+		case protowire.VarintType:
+			num64, n = protowire.ConsumeVarint(buf)
+		case protowire.Fixed64Type:
+			num64, n = protowire.ConsumeFixed64(buf)
+		case protowire.BytesType:
+			val, n = protowire.ConsumeBytes(buf)
+		default:
+			n = protowire.ConsumeFieldValue(field, typ, buf)
+		}
+		if n < 0 {
+			return errInvalidValue
+		}
+		buf = buf[n:]
+		if err := fn(field, typ, val, num64); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func readAnyValue(buf []byte, dest pcommon.Value) error {
+	return forEachField(buf, func(num protowire.Number, _ protowire.Type, val []byte, num64 uint64) error {
+		switch num {
+		case anyValueStr:
+			dest.SetStr(string(val))
+		case anyValueBool:
+			dest.SetBool(protowire.DecodeBool(num64))
+		case anyValueInt:
+			dest.SetInt(int64(num64))
+		case anyValueDouble:
+			dest.SetDouble(math.Float64frombits(num64))
+		case anyValueArray:
+			return readArrayValue(val, dest.SetEmptySlice())
+		case anyValueKVList:
+			return readKeyValueList(val, dest.SetEmptyMap())
+		case anyValueBytes:
+			dest.SetEmptyBytes().FromRaw(val)
+		}
+		return nil
+	})
+}
+
+func readArrayValue(buf []byte, dest pcommon.Slice) error {
+	return forEachField(buf, func(num protowire.Number, _ protowire.Type, val []byte, _ uint64) error {
+		if num != listValues {
+			return nil
+		}
+		return readAnyValue(val, dest.AppendEmpty())
+	})
+}
+
+func readKeyValueList(buf []byte, dest pcommon.Map) error {
+	return forEachField(buf, func(num protowire.Number, _ protowire.Type, val []byte, _ uint64) error {
+		if num != listValues {
+			return nil
+		}
+		var key string
+		var value []byte
+		err := forEachField(val, func(num protowire.Number, _ protowire.Type, val []byte, _ uint64) error {
+			switch num {
+			case keyValueKey:
+				key = string(val)
+			case keyValueValue:
+				value = val
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		return readAnyValue(value, dest.PutEmpty(key))
+	})
+}
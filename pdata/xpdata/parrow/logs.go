@@ -0,0 +1,223 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package parrow // import "go.opentelemetry.io/collector/pdata/xpdata/parrow"
+
+import (
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+var (
+	logRecordType = arrow.StructOf(
+		arrow.Field{Name: "time_unix_nano", Type: arrow.PrimitiveTypes.Uint64},
+		arrow.Field{Name: "observed_time_unix_nano", Type: arrow.PrimitiveTypes.Uint64},
+		arrow.Field{Name: "severity_number", Type: arrow.PrimitiveTypes.Int32},
+		arrow.Field{Name: "severity_text", Type: arrow.BinaryTypes.String},
+		arrow.Field{Name: "body", Type: valueType},
+		arrow.Field{Name: "attributes", Type: attributesType},
+		arrow.Field{Name: "dropped_attributes_count", Type: arrow.PrimitiveTypes.Uint32},
+		arrow.Field{Name: "flags", Type: arrow.PrimitiveTypes.Uint32},
+		arrow.Field{Name: "trace_id", Type: traceIDType},
+		arrow.Field{Name: "span_id", Type: spanIDType},
+		arrow.Field{Name: "event_name", Type: arrow.BinaryTypes.String},
+	)
+
+	// logsSchema is the schema of the logs records, with a row per ResourceLogs.
+	logsSchema = arrow.NewSchema([]arrow.Field{
+		{Name: "resource", Type: resourceType},
+		{Name: "schema_url", Type: arrow.BinaryTypes.String},
+		{Name: "scope_logs", Type: arrow.ListOf(arrow.StructOf(
+			arrow.Field{Name: "scope", Type: scopeType},
+			arrow.Field{Name: "schema_url", Type: arrow.BinaryTypes.String},
+			arrow.Field{Name: "log_records", Type: arrow.ListOf(logRecordType)},
+		))},
+	}, nil)
+)
+
+// LogsToRecord converts ld to an Arrow record, with a row per ResourceLogs.
+// The record must be released once no longer used.
+func LogsToRecord(ld plog.Logs) arrow.Record {
+	rb := array.NewRecordBuilder(memory.DefaultAllocator, logsSchema)
+	defer rb.Release()
+
+	resource := newResourceBuilder(rb.Field(0))
+	schemaURL := rb.Field(1).(*array.StringBuilder)
+	scopeLogsList := rb.Field(2).(*array.ListBuilder)
+	scopeLogs := scopeLogsList.ValueBuilder().(*array.StructBuilder)
+	scope := newScopeBuilder(scopeLogs.FieldBuilder(0))
+	scopeSchemaURL := scopeLogs.FieldBuilder(1).(*array.StringBuilder)
+	logRecordsList := scopeLogs.FieldBuilder(2).(*array.ListBuilder)
+	logRecords := newLogRecordBuilder(logRecordsList.ValueBuilder())
+
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		resource.append(rl.Resource())
+		schemaURL.Append(rl.SchemaUrl())
+		scopeLogsList.Append(true)
+		for j := 0; j < rl.ScopeLogs().Len(); j++ {
+			sl := rl.ScopeLogs().At(j)
+			scopeLogs.Append(true)
+			scope.append(sl.Scope())
+			scopeSchemaURL.Append(sl.SchemaUrl())
+			logRecordsList.Append(true)
+			for k := 0; k < sl.LogRecords().Len(); k++ {
+				logRecords.append(sl.LogRecords().At(k))
+			}
+		}
+	}
+	return rb.NewRecord()
+}
+
+// LogsFromRecord converts an Arrow record created by LogsToRecord back to plog.Logs.
+func LogsFromRecord(rec arrow.Record) (ld plog.Logs, err error) {
+	if !rec.Schema().Equal(logsSchema) {
+		return plog.Logs{}, errUnexpectedSchema
+	}
+	defer recoverInvalid(&err)
+
+	resource := newResourceReader(rec.Column(0))
+	schemaURL := rec.Column(1).(*array.String)
+	scopeLogsList := rec.Column(2).(*array.List)
+	scopeLogs := scopeLogsList.ListValues().(*array.Struct)
+	scope := newScopeReader(scopeLogs.Field(0))
+	scopeSchemaURL := scopeLogs.Field(1).(*array.String)
+	logRecordsList := scopeLogs.Field(2).(*array.List)
+	logRecords := newLogRecordReader(logRecordsList.ListValues())
+
+	ld = plog.NewLogs()
+	rls := ld.ResourceLogs()
+	rls.EnsureCapacity(int(rec.NumRows()))
+	for i := 0; i < int(rec.NumRows()); i++ {
+		rl := rls.AppendEmpty()
+		if err = resource.read(i, rl.Resource()); err != nil {
+			return plog.Logs{}, err
+		}
+		rl.SetSchemaUrl(schemaURL.Value(i))
+		start, end := listRange(scopeLogsList, i)
+		rl.ScopeLogs().EnsureCapacity(end - start)
+		for j := start; j < end; j++ {
+			sl := rl.ScopeLogs().AppendEmpty()
+			if err = scope.read(j, sl.Scope()); err != nil {
+				return plog.Logs{}, err
+			}
+			sl.SetSchemaUrl(scopeSchemaURL.Value(j))
+			recordsStart, recordsEnd := listRange(logRecordsList, j)
+			sl.LogRecords().EnsureCapacity(recordsEnd - recordsStart)
+			for k := recordsStart; k < recordsEnd; k++ {
+				if err = logRecords.read(k, sl.LogRecords().AppendEmpty()); err != nil {
+					return plog.Logs{}, err
+				}
+			}
+		}
+	}
+	return ld, nil
+}
+
+type logRecordBuilder struct {
+	s              *array.StructBuilder
+	time           *array.Uint64Builder
+	observedTime   *array.Uint64Builder
+	severityNumber *array.Int32Builder
+	severityText   *array.StringBuilder
+	bodyStruct     *array.StructBuilder
+	body           valueBuilder
+	attributes     attributesBuilder
+	dropped        *array.Uint32Builder
+	flags          *array.Uint32Builder
+	traceID        *array.FixedSizeBinaryBuilder
+	spanID         *array.FixedSizeBinaryBuilder
+	eventName      *array.StringBuilder
+}
+
+func newLogRecordBuilder(b array.Builder) logRecordBuilder {
+	s := b.(*array.StructBuilder)
+	body := s.FieldBuilder(4).(*array.StructBuilder)
+	return logRecordBuilder{
+		s:              s,
+		time:           s.FieldBuilder(0).(*array.Uint64Builder),
+		observedTime:   s.FieldBuilder(1).(*array.Uint64Builder),
+		severityNumber: s.FieldBuilder(2).(*array.Int32Builder),
+		severityText:   s.FieldBuilder(3).(*array.StringBuilder),
+		bodyStruct:     body,
+		body:           newValueBuilder(body, 0),
+		attributes:     newAttributesBuilder(s.FieldBuilder(5)),
+		dropped:        s.FieldBuilder(6).(*array.Uint32Builder),
+		flags:          s.FieldBuilder(7).(*array.Uint32Builder),
+		traceID:        s.FieldBuilder(8).(*array.FixedSizeBinaryBuilder),
+		spanID:         s.FieldBuilder(9).(*array.FixedSizeBinaryBuilder),
+		eventName:      s.FieldBuilder(10).(*array.StringBuilder),
+	}
+}
+
+func (b logRecordBuilder) append(lr plog.LogRecord) {
+	b.s.Append(true)
+	b.time.Append(uint64(lr.Timestamp()))
+	b.observedTime.Append(uint64(lr.ObservedTimestamp()))
+	b.severityNumber.Append(int32(lr.SeverityNumber()))
+	b.severityText.Append(lr.SeverityText())
+	b.bodyStruct.Append(true)
+	b.body.append(lr.Body())
+	b.attributes.append(lr.Attributes())
+	b.dropped.Append(lr.DroppedAttributesCount())
+	b.flags.Append(uint32(lr.Flags()))
+	traceID, spanID := lr.TraceID(), lr.SpanID()
+	b.traceID.Append(traceID[:])
+	b.spanID.Append(spanID[:])
+	b.eventName.Append(lr.EventName())
+}
+
+type logRecordReader struct {
+	time           *array.Uint64
+	observedTime   *array.Uint64
+	severityNumber *array.Int32
+	severityText   *array.String
+	body           valueReader
+	attributes     attributesReader
+	dropped        *array.Uint32
+	flags          *array.Uint32
+	traceID        *array.FixedSizeBinary
+	spanID         *array.FixedSizeBinary
+	eventName      *array.String
+}
+
+func newLogRecordReader(a arrow.Array) logRecordReader {
+	s := a.(*array.Struct)
+	return logRecordReader{
+		time:           s.Field(0).(*array.Uint64),
+		observedTime:   s.Field(1).(*array.Uint64),
+		severityNumber: s.Field(2).(*array.Int32),
+		severityText:   s.Field(3).(*array.String),
+		body:           newValueReader(s.Field(4).(*array.Struct), 0),
+		attributes:     newAttributesReader(s.Field(5)),
+		dropped:        s.Field(6).(*array.Uint32),
+		flags:          s.Field(7).(*array.Uint32),
+		traceID:        s.Field(8).(*array.FixedSizeBinary),
+		spanID:         s.Field(9).(*array.FixedSizeBinary),
+		eventName:      s.Field(10).(*array.String),
+	}
+}
+
+func (r logRecordReader) read(i int, dest plog.LogRecord) error {
+	dest.SetTimestamp(pcommon.Timestamp(r.time.Value(i)))
+	dest.SetObservedTimestamp(pcommon.Timestamp(r.observedTime.Value(i)))
+	dest.SetSeverityNumber(plog.SeverityNumber(r.severityNumber.Value(i)))
+	dest.SetSeverityText(r.severityText.Value(i))
+	if err := r.body.read(i, dest.Body()); err != nil {
+		return err
+	}
+	if err := r.attributes.read(i, dest.Attributes()); err != nil {
+		return err
+	}
+	dest.SetDroppedAttributesCount(r.dropped.Value(i))
+	dest.SetFlags(plog.LogRecordFlags(r.flags.Value(i)))
+	dest.SetTraceID(pcommon.TraceID(r.traceID.Value(i)))
+	dest.SetSpanID(pcommon.SpanID(r.spanID.Value(i)))
+	dest.SetEventName(r.eventName.Value(i))
+	return nil
+}
@@ -0,0 +1,929 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package parrow // import "go.opentelemetry.io/collector/pdata/xpdata/parrow"
+
+import (
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// pointFields are the fields common to all the data points.
+var pointFields = []arrow.Field{
+	{Name: "attributes", Type: attributesType},
+	{Name: "start_time_unix_nano", Type: arrow.PrimitiveTypes.Uint64},
+	{Name: "time_unix_nano", Type: arrow.PrimitiveTypes.Uint64},
+}
+
+var (
+	exemplarType = arrow.StructOf(
+		arrow.Field{Name: "filtered_attributes", Type: attributesType},
+		arrow.Field{Name: "time_unix_nano", Type: arrow.PrimitiveTypes.Uint64},
+		arrow.Field{Name: "value_type", Type: arrow.PrimitiveTypes.Uint8},
+		arrow.Field{Name: "int_value", Type: arrow.PrimitiveTypes.Int64, Nullable: true},
+		arrow.Field{Name: "double_value", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+		arrow.Field{Name: "span_id", Type: spanIDType},
+		arrow.Field{Name: "trace_id", Type: traceIDType},
+	)
+
+	numberDataPointType = arrow.StructOf(append(pointFields[:len(pointFields):len(pointFields)],
+		arrow.Field{Name: "value_type", Type: arrow.PrimitiveTypes.Uint8},
+		arrow.Field{Name: "int_value", Type: arrow.PrimitiveTypes.Int64, Nullable: true},
+		arrow.Field{Name: "double_value", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+		arrow.Field{Name: "exemplars", Type: arrow.ListOf(exemplarType)},
+		arrow.Field{Name: "flags", Type: arrow.PrimitiveTypes.Uint32},
+	)...)
+
+	histogramDataPointType = arrow.StructOf(append(pointFields[:len(pointFields):len(pointFields)],
+		arrow.Field{Name: "count", Type: arrow.PrimitiveTypes.Uint64},
+		arrow.Field{Name: "sum", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+		arrow.Field{Name: "bucket_counts", Type: arrow.ListOf(arrow.PrimitiveTypes.Uint64)},
+		arrow.Field{Name: "explicit_bounds", Type: arrow.ListOf(arrow.PrimitiveTypes.Float64)},
+		arrow.Field{Name: "exemplars", Type: arrow.ListOf(exemplarType)},
+		arrow.Field{Name: "flags", Type: arrow.PrimitiveTypes.Uint32},
+		arrow.Field{Name: "min", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+		arrow.Field{Name: "max", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+	)...)
+
+	bucketsType = arrow.StructOf(
+		arrow.Field{Name: "offset", Type: arrow.PrimitiveTypes.Int32},
+		arrow.Field{Name: "bucket_counts", Type: arrow.ListOf(arrow.PrimitiveTypes.Uint64)},
+	)
+
+	exponentialHistogramDataPointType = arrow.StructOf(append(pointFields[:len(pointFields):len(pointFields)],
+		arrow.Field{Name: "count", Type: arrow.PrimitiveTypes.Uint64},
+		arrow.Field{Name: "sum", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+		arrow.Field{Name: "scale", Type: arrow.PrimitiveTypes.Int32},
+		arrow.Field{Name: "zero_count", Type: arrow.PrimitiveTypes.Uint64},
+		arrow.Field{Name: "positive", Type: bucketsType},
+		arrow.Field{Name: "negative", Type: bucketsType},
+		arrow.Field{Name: "flags", Type: arrow.PrimitiveTypes.Uint32},
+		arrow.Field{Name: "exemplars", Type: arrow.ListOf(exemplarType)},
+		arrow.Field{Name: "min", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+		arrow.Field{Name: "max", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+		arrow.Field{Name: "zero_threshold", Type: arrow.PrimitiveTypes.Float64},
+	)...)
+
+	summaryDataPointType = arrow.StructOf(append(pointFields[:len(pointFields):len(pointFields)],
+		arrow.Field{Name: "count", Type: arrow.PrimitiveTypes.Uint64},
+		arrow.Field{Name: "sum", Type: arrow.PrimitiveTypes.Float64},
+		arrow.Field{Name: "quantile_values", Type: arrow.ListOf(arrow.StructOf(
+			arrow.Field{Name: "quantile", Type: arrow.PrimitiveTypes.Float64},
+			arrow.Field{Name: "value", Type: arrow.PrimitiveTypes.Float64},
+		))},
+		arrow.Field{Name: "flags", Type: arrow.PrimitiveTypes.Uint32},
+	)...)
+
+	// metricType holds all the kinds of metrics, only the data points of the type of the
+	// metric are set.
+	metricType = arrow.StructOf(
+		arrow.Field{Name: "name", Type: arrow.BinaryTypes.String},
+		arrow.Field{Name: "description", Type: arrow.BinaryTypes.String},
+		arrow.Field{Name: "unit", Type: arrow.BinaryTypes.String},
+		arrow.Field{Name: "metadata", Type: attributesType},
+		arrow.Field{Name: "type", Type: arrow.PrimitiveTypes.Uint8},
+		arrow.Field{Name: "aggregation_temporality", Type: arrow.PrimitiveTypes.Int32},
+		arrow.Field{Name: "is_monotonic", Type: arrow.FixedWidthTypes.Boolean},
+		arrow.Field{Name: "number_data_points", Type: arrow.ListOf(numberDataPointType)},
+		arrow.Field{Name: "histogram_data_points", Type: arrow.ListOf(histogramDataPointType)},
+		arrow.Field{Name: "exponential_histogram_data_points", Type: arrow.ListOf(exponentialHistogramDataPointType)},
+		arrow.Field{Name: "summary_data_points", Type: arrow.ListOf(summaryDataPointType)},
+	)
+
+	// metricsSchema is the schema of the metrics records, with a row per ResourceMetrics.
+	metricsSchema = arrow.NewSchema([]arrow.Field{
+		{Name: "resource", Type: resourceType},
+		{Name: "schema_url", Type: arrow.BinaryTypes.String},
+		{Name: "scope_metrics", Type: arrow.ListOf(arrow.StructOf(
+			arrow.Field{Name: "scope", Type: scopeType},
+			arrow.Field{Name: "schema_url", Type: arrow.BinaryTypes.String},
+			arrow.Field{Name: "metrics", Type: arrow.ListOf(metricType)},
+		))},
+	}, nil)
+)
+
+// MetricsToRecord converts md to an Arrow record, with a row per ResourceMetrics.
+// The record must be released once no longer used.
+func MetricsToRecord(md pmetric.Metrics) arrow.Record {
+	rb := array.NewRecordBuilder(memory.DefaultAllocator, metricsSchema)
+	defer rb.Release()
+
+	resource := newResourceBuilder(rb.Field(0))
+	schemaURL := rb.Field(1).(*array.StringBuilder)
+	scopeMetricsList := rb.Field(2).(*array.ListBuilder)
+	scopeMetrics := scopeMetricsList.ValueBuilder().(*array.StructBuilder)
+	scope := newScopeBuilder(scopeMetrics.FieldBuilder(0))
+	scopeSchemaURL := scopeMetrics.FieldBuilder(1).(*array.StringBuilder)
+	metricsList := scopeMetrics.FieldBuilder(2).(*array.ListBuilder)
+	metrics := newMetricBuilder(metricsList.ValueBuilder())
+
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		resource.append(rm.Resource())
+		schemaURL.Append(rm.SchemaUrl())
+		scopeMetricsList.Append(true)
+		for j := 0; j < rm.ScopeMetrics().Len(); j++ {
+			sm := rm.ScopeMetrics().At(j)
+			scopeMetrics.Append(true)
+			scope.append(sm.Scope())
+			scopeSchemaURL.Append(sm.SchemaUrl())
+			metricsList.Append(true)
+			for k := 0; k < sm.Metrics().Len(); k++ {
+				metrics.append(sm.Metrics().At(k))
+			}
+		}
+	}
+	return rb.NewRecord()
+}
+
+// MetricsFromRecord converts an Arrow record created by MetricsToRecord back to pmetric.Metrics.
+func MetricsFromRecord(rec arrow.Record) (md pmetric.Metrics, err error) {
+	if !rec.Schema().Equal(metricsSchema) {
+		return pmetric.Metrics{}, errUnexpectedSchema
+	}
+	defer recoverInvalid(&err)
+
+	resource := newResourceReader(rec.Column(0))
+	schemaURL := rec.Column(1).(*array.String)
+	scopeMetricsList := rec.Column(2).(*array.List)
+	scopeMetrics := scopeMetricsList.ListValues().(*array.Struct)
+	scope := newScopeReader(scopeMetrics.Field(0))
+	scopeSchemaURL := scopeMetrics.Field(1).(*array.String)
+	metricsList := scopeMetrics.Field(2).(*array.List)
+	metrics := newMetricReader(metricsList.ListValues())
+
+	md = pmetric.NewMetrics()
+	rms := md.ResourceMetrics()
+	rms.EnsureCapacity(int(rec.NumRows()))
+	for i := 0; i < int(rec.NumRows()); i++ {
+		rm := rms.AppendEmpty()
+		if err = resource.read(i, rm.Resource()); err != nil {
+			return pmetric.Metrics{}, err
+		}
+		rm.SetSchemaUrl(schemaURL.Value(i))
+		start, end := listRange(scopeMetricsList, i)
+		rm.ScopeMetrics().EnsureCapacity(end - start)
+		for j := start; j < end; j++ {
+			sm := rm.ScopeMetrics().AppendEmpty()
+			if err = scope.read(j, sm.Scope()); err != nil {
+				return pmetric.Metrics{}, err
+			}
+			sm.SetSchemaUrl(scopeSchemaURL.Value(j))
+			metricsStart, metricsEnd := listRange(metricsList, j)
+			sm.Metrics().EnsureCapacity(metricsEnd - metricsStart)
+			for k := metricsStart; k < metricsEnd; k++ {
+				if err = metrics.read(k, sm.Metrics().AppendEmpty()); err != nil {
+					return pmetric.Metrics{}, err
+				}
+			}
+		}
+	}
+	return md, nil
+}
+
+type metricBuilder struct {
+	s                         *array.StructBuilder
+	name                      *array.StringBuilder
+	description               *array.StringBuilder
+	unit                      *array.StringBuilder
+	metadata                  attributesBuilder
+	typ                       *array.Uint8Builder
+	temporality               *array.Int32Builder
+	monotonic                 *array.BooleanBuilder
+	numberPointsList          *array.ListBuilder
+	numberPoints              numberDataPointBuilder
+	histogramPointsList       *array.ListBuilder
+	histogramPoints           histogramDataPointBuilder
+	exponentialHistPointsList *array.ListBuilder
+	exponentialHistPoints     exponentialHistogramDataPointBuilder
+	summaryPointsList         *array.ListBuilder
+	summaryPoints             summaryDataPointBuilder
+}
+
+func newMetricBuilder(b array.Builder) metricBuilder {
+	s := b.(*array.StructBuilder)
+	numberPointsList := s.FieldBuilder(7).(*array.ListBuilder)
+	histogramPointsList := s.FieldBuilder(8).(*array.ListBuilder)
+	exponentialHistPointsList := s.FieldBuilder(9).(*array.ListBuilder)
+	summaryPointsList := s.FieldBuilder(10).(*array.ListBuilder)
+	return metricBuilder{
+		s:                         s,
+		name:                      s.FieldBuilder(0).(*array.StringBuilder),
+		description:               s.FieldBuilder(1).(*array.StringBuilder),
+		unit:                      s.FieldBuilder(2).(*array.StringBuilder),
+		metadata:                  newAttributesBuilder(s.FieldBuilder(3)),
+		typ:                       s.FieldBuilder(4).(*array.Uint8Builder),
+		temporality:               s.FieldBuilder(5).(*array.Int32Builder),
+		monotonic:                 s.FieldBuilder(6).(*array.BooleanBuilder),
+		numberPointsList:          numberPointsList,
+		numberPoints:              newNumberDataPointBuilder(numberPointsList.ValueBuilder()),
+		histogramPointsList:       histogramPointsList,
+		histogramPoints:           newHistogramDataPointBuilder(histogramPointsList.ValueBuilder()),
+		exponentialHistPointsList: exponentialHistPointsList,
+		exponentialHistPoints:     newExponentialHistogramDataPointBuilder(exponentialHistPointsList.ValueBuilder()),
+		summaryPointsList:         summaryPointsList,
+		summaryPoints:             newSummaryDataPointBuilder(summaryPointsList.ValueBuilder()),
+	}
+}
+
+func (b metricBuilder) append(m pmetric.Metric) {
+	b.s.Append(true)
+	b.name.Append(m.Name())
+	b.description.Append(m.Description())
+	b.unit.Append(m.Unit())
+	b.metadata.append(m.Metadata())
+	b.typ.Append(uint8(m.Type()))
+
+	temporality, monotonic := pmetric.AggregationTemporalityUnspecified, false
+	switch m.Type() {
+	case pmetric.MetricTypeSum:
+		temporality, monotonic = m.Sum().AggregationTemporality(), m.Sum().IsMonotonic()
+	case pmetric.MetricTypeHistogram:
+		temporality = m.Histogram().AggregationTemporality()
+	case pmetric.MetricTypeExponentialHistogram:
+		temporality = m.ExponentialHistogram().AggregationTemporality()
+	}
+	b.temporality.Append(int32(temporality))
+	b.monotonic.Append(monotonic)
+
+	b.numberPointsList.Append(true)
+	b.histogramPointsList.Append(true)
+	b.exponentialHistPointsList.Append(true)
+	b.summaryPointsList.Append(true)
+	switch m.Type() {
+	case pmetric.MetricTypeGauge:
+		for i := 0; i < m.Gauge().DataPoints().Len(); i++ {
+			b.numberPoints.append(m.Gauge().DataPoints().At(i))
+		}
+	case pmetric.MetricTypeSum:
+		for i := 0; i < m.Sum().DataPoints().Len(); i++ {
+			b.numberPoints.append(m.Sum().DataPoints().At(i))
+		}
+	case pmetric.MetricTypeHistogram:
+		for i := 0; i < m.Histogram().DataPoints().Len(); i++ {
+			b.histogramPoints.append(m.Histogram().DataPoints().At(i))
+		}
+	case pmetric.MetricTypeExponentialHistogram:
+		for i := 0; i < m.ExponentialHistogram().DataPoints().Len(); i++ {
+			b.exponentialHistPoints.append(m.ExponentialHistogram().DataPoints().At(i))
+		}
+	case pmetric.MetricTypeSummary:
+		for i := 0; i < m.Summary().DataPoints().Len(); i++ {
+			b.summaryPoints.append(m.Summary().DataPoints().At(i))
+		}
+	}
+}
+
+type metricReader struct {
+	name                      *array.String
+	description               *array.String
+	unit                      *array.String
+	metadata                  attributesReader
+	typ                       *array.Uint8
+	temporality               *array.Int32
+	monotonic                 *array.Boolean
+	numberPointsList          *array.List
+	numberPoints              numberDataPointReader
+	histogramPointsList       *array.List
+	histogramPoints           histogramDataPointReader
+	exponentialHistPointsList *array.List
+	exponentialHistPoints     exponentialHistogramDataPointReader
+	summaryPointsList         *array.List
+	summaryPoints             summaryDataPointReader
+}
+
+func newMetricReader(a arrow.Array) metricReader {
+	s := a.(*array.Struct)
+	numberPointsList := s.Field(7).(*array.List)
+	histogramPointsList := s.Field(8).(*array.List)
+	exponentialHistPointsList := s.Field(9).(*array.List)
+	summaryPointsList := s.Field(10).(*array.List)
+	return metricReader{
+		name:                      s.Field(0).(*array.String),
+		description:               s.Field(1).(*array.String),
+		unit:                      s.Field(2).(*array.String),
+		metadata:                  newAttributesReader(s.Field(3)),
+		typ:                       s.Field(4).(*array.Uint8),
+		temporality:               s.Field(5).(*array.Int32),
+		monotonic:                 s.Field(6).(*array.Boolean),
+		numberPointsList:          numberPointsList,
+		numberPoints:              newNumberDataPointReader(numberPointsList.ListValues()),
+		histogramPointsList:       histogramPointsList,
+		histogramPoints:           newHistogramDataPointReader(histogramPointsList.ListValues()),
+		exponentialHistPointsList: exponentialHistPointsList,
+		exponentialHistPoints:     newExponentialHistogramDataPointReader(exponentialHistPointsList.ListValues()),
+		summaryPointsList:         summaryPointsList,
+		summaryPoints:             newSummaryDataPointReader(summaryPointsList.ListValues()),
+	}
+}
+
+func (r metricReader) read(i int, dest pmetric.Metric) error {
+	dest.SetName(r.name.Value(i))
+	dest.SetDescription(r.description.Value(i))
+	dest.SetUnit(r.unit.Value(i))
+	if err := r.metadata.read(i, dest.Metadata()); err != nil {
+		return err
+	}
+	temporality := pmetric.AggregationTemporality(r.temporality.Value(i))
+	var err error
+	switch pmetric.MetricType(r.typ.Value(i)) {
+	case pmetric.MetricTypeEmpty:
+	case pmetric.MetricTypeGauge:
+		err = readNumberDataPoints(r, i, dest.SetEmptyGauge().DataPoints())
+	case pmetric.MetricTypeSum:
+		sum := dest.SetEmptySum()
+		sum.SetAggregationTemporality(temporality)
+		sum.SetIsMonotonic(r.monotonic.Value(i))
+		err = readNumberDataPoints(r, i, sum.DataPoints())
+	case pmetric.MetricTypeHistogram:
+		histogram := dest.SetEmptyHistogram()
+		histogram.SetAggregationTemporality(temporality)
+		start, end := listRange(r.histogramPointsList, i)
+		histogram.DataPoints().EnsureCapacity(end - start)
+		for j := start; j < end && err == nil; j++ {
+			err = r.histogramPoints.read(j, histogram.DataPoints().AppendEmpty())
+		}
+	case pmetric.MetricTypeExponentialHistogram:
+		histogram := dest.SetEmptyExponentialHistogram()
+		histogram.SetAggregationTemporality(temporality)
+		start, end := listRange(r.exponentialHistPointsList, i)
+		histogram.DataPoints().EnsureCapacity(end - start)
+		for j := start; j < end && err == nil; j++ {
+			err = r.exponentialHistPoints.read(j, histogram.DataPoints().AppendEmpty())
+		}
+	case pmetric.MetricTypeSummary:
+		summary := dest.SetEmptySummary()
+		start, end := listRange(r.summaryPointsList, i)
+		summary.DataPoints().EnsureCapacity(end - start)
+		for j := start; j < end && err == nil; j++ {
+			err = r.summaryPoints.read(j, summary.DataPoints().AppendEmpty())
+		}
+	default:
+		err = errInvalidEncoding
+	}
+	return err
+}
+
+func readNumberDataPoints(r metricReader, i int, dest pmetric.NumberDataPointSlice) error {
+	start, end := listRange(r.numberPointsList, i)
+	dest.EnsureCapacity(end - start)
+	for j := start; j < end; j++ {
+		if err := r.numberPoints.read(j, dest.AppendEmpty()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// pointBuilder appends the pointFields of the data points.
+type pointBuilder struct {
+	s          *array.StructBuilder
+	attributes attributesBuilder
+	start      *array.Uint64Builder
+	time       *array.Uint64Builder
+}
+
+func newPointBuilder(b array.Builder) pointBuilder {
+	s := b.(*array.StructBuilder)
+	return pointBuilder{
+		s:          s,
+		attributes: newAttributesBuilder(s.FieldBuilder(0)),
+		start:      s.FieldBuilder(1).(*array.Uint64Builder),
+		time:       s.FieldBuilder(2).(*array.Uint64Builder),
+	}
+}
+
+func (b pointBuilder) append(attributes pcommon.Map, start, ts pcommon.Timestamp) {
+	b.s.Append(true)
+	b.attributes.append(attributes)
+	b.start.Append(uint64(start))
+	b.time.Append(uint64(ts))
+}
+
+type pointReader struct {
+	attributes attributesReader
+	start      *array.Uint64
+	time       *array.Uint64
+}
+
+func newPointReader(s *array.Struct) pointReader {
+	return pointReader{
+		attributes: newAttributesReader(s.Field(0)),
+		start:      s.Field(1).(*array.Uint64),
+		time:       s.Field(2).(*array.Uint64),
+	}
+}
+
+// read reads the attributes and returns the start time and time of the data point at i.
+func (r pointReader) read(i int, attributes pcommon.Map) (pcommon.Timestamp, pcommon.Timestamp, error) {
+	return pcommon.Timestamp(r.start.Value(i)), pcommon.Timestamp(r.time.Value(i)), r.attributes.read(i, attributes)
+}
+
+type exemplarsBuilder struct {
+	list       *array.ListBuilder
+	s          *array.StructBuilder
+	attributes attributesBuilder
+	time       *array.Uint64Builder
+	valueType  *array.Uint8Builder
+	int        *array.Int64Builder
+	double     *array.Float64Builder
+	spanID     *array.FixedSizeBinaryBuilder
+	traceID    *array.FixedSizeBinaryBuilder
+}
+
+func newExemplarsBuilder(b array.Builder) exemplarsBuilder {
+	list := b.(*array.ListBuilder)
+	s := list.ValueBuilder().(*array.StructBuilder)
+	return exemplarsBuilder{
+		list:       list,
+		s:          s,
+		attributes: newAttributesBuilder(s.FieldBuilder(0)),
+		time:       s.FieldBuilder(1).(*array.Uint64Builder),
+		valueType:  s.FieldBuilder(2).(*array.Uint8Builder),
+		int:        s.FieldBuilder(3).(*array.Int64Builder),
+		double:     s.FieldBuilder(4).(*array.Float64Builder),
+		spanID:     s.FieldBuilder(5).(*array.FixedSizeBinaryBuilder),
+		traceID:    s.FieldBuilder(6).(*array.FixedSizeBinaryBuilder),
+	}
+}
+
+func (b exemplarsBuilder) append(es pmetric.ExemplarSlice) {
+	b.list.Append(true)
+	for i := 0; i < es.Len(); i++ {
+		e := es.At(i)
+		b.s.Append(true)
+		b.attributes.append(e.FilteredAttributes())
+		b.time.Append(uint64(e.Timestamp()))
+		b.valueType.Append(uint8(e.ValueType()))
+		appendOrNull(b.int, e.ValueType() == pmetric.ExemplarValueTypeInt, e.IntValue)
+		appendOrNull(b.double, e.ValueType() == pmetric.ExemplarValueTypeDouble, e.DoubleValue)
+		spanID, traceID := e.SpanID(), e.TraceID()
+		b.spanID.Append(spanID[:])
+		b.traceID.Append(traceID[:])
+	}
+}
+
+type exemplarsReader struct {
+	list       *array.List
+	attributes attributesReader
+	time       *array.Uint64
+	valueType  *array.Uint8
+	int        *array.Int64
+	double     *array.Float64
+	spanID     *array.FixedSizeBinary
+	traceID    *array.FixedSizeBinary
+}
+
+func newExemplarsReader(a arrow.Array) exemplarsReader {
+	list := a.(*array.List)
+	s := list.ListValues().(*array.Struct)
+	return exemplarsReader{
+		list:       list,
+		attributes: newAttributesReader(s.Field(0)),
+		time:       s.Field(1).(*array.Uint64),
+		valueType:  s.Field(2).(*array.Uint8),
+		int:        s.Field(3).(*array.Int64),
+		double:     s.Field(4).(*array.Float64),
+		spanID:     s.Field(5).(*array.FixedSizeBinary),
+		traceID:    s.Field(6).(*array.FixedSizeBinary),
+	}
+}
+
+func (r exemplarsReader) read(i int, dest pmetric.ExemplarSlice) error {
+	start, end := listRange(r.list, i)
+	dest.EnsureCapacity(end - start)
+	for j := start; j < end; j++ {
+		e := dest.AppendEmpty()
+		if err := r.attributes.read(j, e.FilteredAttributes()); err != nil {
+			return err
+		}
+		e.SetTimestamp(pcommon.Timestamp(r.time.Value(j)))
+		switch pmetric.ExemplarValueType(r.valueType.Value(j)) {
+		case pmetric.ExemplarValueTypeInt:
+			e.SetIntValue(r.int.Value(j))
+		case pmetric.ExemplarValueTypeDouble:
+			e.SetDoubleValue(r.double.Value(j))
+		}
+		e.SetSpanID(pcommon.SpanID(r.spanID.Value(j)))
+		e.SetTraceID(pcommon.TraceID(r.traceID.Value(j)))
+	}
+	return nil
+}
+
+type numberDataPointBuilder struct {
+	point     pointBuilder
+	valueType *array.Uint8Builder
+	int       *array.Int64Builder
+	double    *array.Float64Builder
+	exemplars exemplarsBuilder
+	flags     *array.Uint32Builder
+}
+
+func newNumberDataPointBuilder(b array.Builder) numberDataPointBuilder {
+	s := b.(*array.StructBuilder)
+	return numberDataPointBuilder{
+		point:     newPointBuilder(s),
+		valueType: s.FieldBuilder(3).(*array.Uint8Builder),
+		int:       s.FieldBuilder(4).(*array.Int64Builder),
+		double:    s.FieldBuilder(5).(*array.Float64Builder),
+		exemplars: newExemplarsBuilder(s.FieldBuilder(6)),
+		flags:     s.FieldBuilder(7).(*array.Uint32Builder),
+	}
+}
+
+func (b numberDataPointBuilder) append(dp pmetric.NumberDataPoint) {
+	b.point.append(dp.Attributes(), dp.StartTimestamp(), dp.Timestamp())
+	b.valueType.Append(uint8(dp.ValueType()))
+	appendOrNull(b.int, dp.ValueType() == pmetric.NumberDataPointValueTypeInt, dp.IntValue)
+	appendOrNull(b.double, dp.ValueType() == pmetric.NumberDataPointValueTypeDouble, dp.DoubleValue)
+	b.exemplars.append(dp.Exemplars())
+	b.flags.Append(uint32(dp.Flags()))
+}
+
+type numberDataPointReader struct {
+	point     pointReader
+	valueType *array.Uint8
+	int       *array.Int64
+	double    *array.Float64
+	exemplars exemplarsReader
+	flags     *array.Uint32
+}
+
+func newNumberDataPointReader(a arrow.Array) numberDataPointReader {
+	s := a.(*array.Struct)
+	return numberDataPointReader{
+		point:     newPointReader(s),
+		valueType: s.Field(3).(*array.Uint8),
+		int:       s.Field(4).(*array.Int64),
+		double:    s.Field(5).(*array.Float64),
+		exemplars: newExemplarsReader(s.Field(6)),
+		flags:     s.Field(7).(*array.Uint32),
+	}
+}
+
+func (r numberDataPointReader) read(i int, dest pmetric.NumberDataPoint) error {
+	start, ts, err := r.point.read(i, dest.Attributes())
+	if err != nil {
+		return err
+	}
+	dest.SetStartTimestamp(start)
+	dest.SetTimestamp(ts)
+	switch pmetric.NumberDataPointValueType(r.valueType.Value(i)) {
+	case pmetric.NumberDataPointValueTypeInt:
+		dest.SetIntValue(r.int.Value(i))
+	case pmetric.NumberDataPointValueTypeDouble:
+		dest.SetDoubleValue(r.double.Value(i))
+	}
+	dest.SetFlags(pmetric.DataPointFlags(r.flags.Value(i)))
+	return r.exemplars.read(i, dest.Exemplars())
+}
+
+type histogramDataPointBuilder struct {
+	point              pointBuilder
+	count              *array.Uint64Builder
+	sum                *array.Float64Builder
+	bucketCountsList   *array.ListBuilder
+	bucketCounts       *array.Uint64Builder
+	explicitBoundsList *array.ListBuilder
+	explicitBounds     *array.Float64Builder
+	exemplars          exemplarsBuilder
+	flags              *array.Uint32Builder
+	min                *array.Float64Builder
+	max                *array.Float64Builder
+}
+
+func newHistogramDataPointBuilder(b array.Builder) histogramDataPointBuilder {
+	s := b.(*array.StructBuilder)
+	bucketCountsList := s.FieldBuilder(5).(*array.ListBuilder)
+	explicitBoundsList := s.FieldBuilder(6).(*array.ListBuilder)
+	return histogramDataPointBuilder{
+		point:              newPointBuilder(s),
+		count:              s.FieldBuilder(3).(*array.Uint64Builder),
+		sum:                s.FieldBuilder(4).(*array.Float64Builder),
+		bucketCountsList:   bucketCountsList,
+		bucketCounts:       bucketCountsList.ValueBuilder().(*array.Uint64Builder),
+		explicitBoundsList: explicitBoundsList,
+		explicitBounds:     explicitBoundsList.ValueBuilder().(*array.Float64Builder),
+		exemplars:          newExemplarsBuilder(s.FieldBuilder(7)),
+		flags:              s.FieldBuilder(8).(*array.Uint32Builder),
+		min:                s.FieldBuilder(9).(*array.Float64Builder),
+		max:                s.FieldBuilder(10).(*array.Float64Builder),
+	}
+}
+
+func (b histogramDataPointBuilder) append(dp pmetric.HistogramDataPoint) {
+	b.point.append(dp.Attributes(), dp.StartTimestamp(), dp.Timestamp())
+	b.count.Append(dp.Count())
+	appendOrNull(b.sum, dp.HasSum(), dp.Sum)
+	b.bucketCountsList.Append(true)
+	b.bucketCounts.AppendValues(dp.BucketCounts().AsRaw(), nil)
+	b.explicitBoundsList.Append(true)
+	b.explicitBounds.AppendValues(dp.ExplicitBounds().AsRaw(), nil)
+	b.exemplars.append(dp.Exemplars())
+	b.flags.Append(uint32(dp.Flags()))
+	appendOrNull(b.min, dp.HasMin(), dp.Min)
+	appendOrNull(b.max, dp.HasMax(), dp.Max)
+}
+
+type histogramDataPointReader struct {
+	point              pointReader
+	count              *array.Uint64
+	sum                *array.Float64
+	bucketCountsList   *array.List
+	bucketCounts       *array.Uint64
+	explicitBoundsList *array.List
+	explicitBounds     *array.Float64
+	exemplars          exemplarsReader
+	flags              *array.Uint32
+	min                *array.Float64
+	max                *array.Float64
+}
+
+func newHistogramDataPointReader(a arrow.Array) histogramDataPointReader {
+	s := a.(*array.Struct)
+	bucketCountsList := s.Field(5).(*array.List)
+	explicitBoundsList := s.Field(6).(*array.List)
+	return histogramDataPointReader{
+		point:              newPointReader(s),
+		count:              s.Field(3).(*array.Uint64),
+		sum:                s.Field(4).(*array.Float64),
+		bucketCountsList:   bucketCountsList,
+		bucketCounts:       bucketCountsList.ListValues().(*array.Uint64),
+		explicitBoundsList: explicitBoundsList,
+		explicitBounds:     explicitBoundsList.ListValues().(*array.Float64),
+		exemplars:          newExemplarsReader(s.Field(7)),
+		flags:              s.Field(8).(*array.Uint32),
+		min:                s.Field(9).(*array.Float64),
+		max:                s.Field(10).(*array.Float64),
+	}
+}
+
+func (r histogramDataPointReader) read(i int, dest pmetric.HistogramDataPoint) error {
+	start, ts, err := r.point.read(i, dest.Attributes())
+	if err != nil {
+		return err
+	}
+	dest.SetStartTimestamp(start)
+	dest.SetTimestamp(ts)
+	dest.SetCount(r.count.Value(i))
+	if r.sum.IsValid(i) {
+		dest.SetSum(r.sum.Value(i))
+	}
+	bucketsStart, bucketsEnd := listRange(r.bucketCountsList, i)
+	dest.BucketCounts().FromRaw(r.bucketCounts.Uint64Values()[bucketsStart:bucketsEnd])
+	boundsStart, boundsEnd := listRange(r.explicitBoundsList, i)
+	dest.ExplicitBounds().FromRaw(r.explicitBounds.Float64Values()[boundsStart:boundsEnd])
+	dest.SetFlags(pmetric.DataPointFlags(r.flags.Value(i)))
+	if r.min.IsValid(i) {
+		dest.SetMin(r.min.Value(i))
+	}
+	if r.max.IsValid(i) {
+		dest.SetMax(r.max.Value(i))
+	}
+	return r.exemplars.read(i, dest.Exemplars())
+}
+
+type bucketsBuilder struct {
+	s                *array.StructBuilder
+	offset           *array.Int32Builder
+	bucketCountsList *array.ListBuilder
+	bucketCounts     *array.Uint64Builder
+}
+
+func newBucketsBuilder(b array.Builder) bucketsBuilder {
+	s := b.(*array.StructBuilder)
+	bucketCountsList := s.FieldBuilder(1).(*array.ListBuilder)
+	return bucketsBuilder{
+		s:                s,
+		offset:           s.FieldBuilder(0).(*array.Int32Builder),
+		bucketCountsList: bucketCountsList,
+		bucketCounts:     bucketCountsList.ValueBuilder().(*array.Uint64Builder),
+	}
+}
+
+func (b bucketsBuilder) append(buckets pmetric.ExponentialHistogramDataPointBuckets) {
+	b.s.Append(true)
+	b.offset.Append(buckets.Offset())
+	b.bucketCountsList.Append(true)
+	b.bucketCounts.AppendValues(buckets.BucketCounts().AsRaw(), nil)
+}
+
+type bucketsReader struct {
+	offset           *array.Int32
+	bucketCountsList *array.List
+	bucketCounts     *array.Uint64
+}
+
+func newBucketsReader(a arrow.Array) bucketsReader {
+	s := a.(*array.Struct)
+	bucketCountsList := s.Field(1).(*array.List)
+	return bucketsReader{
+		offset:           s.Field(0).(*array.Int32),
+		bucketCountsList: bucketCountsList,
+		bucketCounts:     bucketCountsList.ListValues().(*array.Uint64),
+	}
+}
+
+func (r bucketsReader) read(i int, dest pmetric.ExponentialHistogramDataPointBuckets) {
+	dest.SetOffset(r.offset.Value(i))
+	start, end := listRange(r.bucketCountsList, i)
+	dest.BucketCounts().FromRaw(r.bucketCounts.Uint64Values()[start:end])
+}
+
+type exponentialHistogramDataPointBuilder struct {
+	point         pointBuilder
+	count         *array.Uint64Builder
+	sum           *array.Float64Builder
+	scale         *array.Int32Builder
+	zeroCount     *array.Uint64Builder
+	positive      bucketsBuilder
+	negative      bucketsBuilder
+	flags         *array.Uint32Builder
+	exemplars     exemplarsBuilder
+	min           *array.Float64Builder
+	max           *array.Float64Builder
+	zeroThreshold *array.Float64Builder
+}
+
+func newExponentialHistogramDataPointBuilder(b array.Builder) exponentialHistogramDataPointBuilder {
+	s := b.(*array.StructBuilder)
+	return exponentialHistogramDataPointBuilder{
+		point:         newPointBuilder(s),
+		count:         s.FieldBuilder(3).(*array.Uint64Builder),
+		sum:           s.FieldBuilder(4).(*array.Float64Builder),
+		scale:         s.FieldBuilder(5).(*array.Int32Builder),
+		zeroCount:     s.FieldBuilder(6).(*array.Uint64Builder),
+		positive:      newBucketsBuilder(s.FieldBuilder(7)),
+		negative:      newBucketsBuilder(s.FieldBuilder(8)),
+		flags:         s.FieldBuilder(9).(*array.Uint32Builder),
+		exemplars:     newExemplarsBuilder(s.FieldBuilder(10)),
+		min:           s.FieldBuilder(11).(*array.Float64Builder),
+		max:           s.FieldBuilder(12).(*array.Float64Builder),
+		zeroThreshold: s.FieldBuilder(13).(*array.Float64Builder),
+	}
+}
+
+func (b exponentialHistogramDataPointBuilder) append(dp pmetric.ExponentialHistogramDataPoint) {
+	b.point.append(dp.Attributes(), dp.StartTimestamp(), dp.Timestamp())
+	b.count.Append(dp.Count())
+	appendOrNull(b.sum, dp.HasSum(), dp.Sum)
+	b.scale.Append(dp.Scale())
+	b.zeroCount.Append(dp.ZeroCount())
+	b.positive.append(dp.Positive())
+	b.negative.append(dp.Negative())
+	b.flags.Append(uint32(dp.Flags()))
+	b.exemplars.append(dp.Exemplars())
+	appendOrNull(b.min, dp.HasMin(), dp.Min)
+	appendOrNull(b.max, dp.HasMax(), dp.Max)
+	b.zeroThreshold.Append(dp.ZeroThreshold())
+}
+
+type exponentialHistogramDataPointReader struct {
+	point         pointReader
+	count         *array.Uint64
+	sum           *array.Float64
+	scale         *array.Int32
+	zeroCount     *array.Uint64
+	positive      bucketsReader
+	negative      bucketsReader
+	flags         *array.Uint32
+	exemplars     exemplarsReader
+	min           *array.Float64
+	max           *array.Float64
+	zeroThreshold *array.Float64
+}
+
+func newExponentialHistogramDataPointReader(a arrow.Array) exponentialHistogramDataPointReader {
+	s := a.(*array.Struct)
+	return exponentialHistogramDataPointReader{
+		point:         newPointReader(s),
+		count:         s.Field(3).(*array.Uint64),
+		sum:           s.Field(4).(*array.Float64),
+		scale:         s.Field(5).(*array.Int32),
+		zeroCount:     s.Field(6).(*array.Uint64),
+		positive:      newBucketsReader(s.Field(7)),
+		negative:      newBucketsReader(s.Field(8)),
+		flags:         s.Field(9).(*array.Uint32),
+		exemplars:     newExemplarsReader(s.Field(10)),
+		min:           s.Field(11).(*array.Float64),
+		max:           s.Field(12).(*array.Float64),
+		zeroThreshold: s.Field(13).(*array.Float64),
+	}
+}
+
+func (r exponentialHistogramDataPointReader) read(i int, dest pmetric.ExponentialHistogramDataPoint) error {
+	start, ts, err := r.point.read(i, dest.Attributes())
+	if err != nil {
+		return err
+	}
+	dest.SetStartTimestamp(start)
+	dest.SetTimestamp(ts)
+	dest.SetCount(r.count.Value(i))
+	if r.sum.IsValid(i) {
+		dest.SetSum(r.sum.Value(i))
+	}
+	dest.SetScale(r.scale.Value(i))
+	dest.SetZeroCount(r.zeroCount.Value(i))
+	r.positive.read(i, dest.Positive())
+	r.negative.read(i, dest.Negative())
+	dest.SetFlags(pmetric.DataPointFlags(r.flags.Value(i)))
+	if r.min.IsValid(i) {
+		dest.SetMin(r.min.Value(i))
+	}
+	if r.max.IsValid(i) {
+		dest.SetMax(r.max.Value(i))
+	}
+	dest.SetZeroThreshold(r.zeroThreshold.Value(i))
+	return r.exemplars.read(i, dest.Exemplars())
+}
+
+type summaryDataPointBuilder struct {
+	point         pointBuilder
+	count         *array.Uint64Builder
+	sum           *array.Float64Builder
+	quantilesList *array.ListBuilder
+	quantiles     *array.StructBuilder
+	quantile      *array.Float64Builder
+	quantileValue *array.Float64Builder
+	flags         *array.Uint32Builder
+}
+
+func newSummaryDataPointBuilder(b array.Builder) summaryDataPointBuilder {
+	s := b.(*array.StructBuilder)
+	quantilesList := s.FieldBuilder(5).(*array.ListBuilder)
+	quantiles := quantilesList.ValueBuilder().(*array.StructBuilder)
+	return summaryDataPointBuilder{
+		point:         newPointBuilder(s),
+		count:         s.FieldBuilder(3).(*array.Uint64Builder),
+		sum:           s.FieldBuilder(4).(*array.Float64Builder),
+		quantilesList: quantilesList,
+		quantiles:     quantiles,
+		quantile:      quantiles.FieldBuilder(0).(*array.Float64Builder),
+		quantileValue: quantiles.FieldBuilder(1).(*array.Float64Builder),
+		flags:         s.FieldBuilder(6).(*array.Uint32Builder),
+	}
+}
+
+func (b summaryDataPointBuilder) append(dp pmetric.SummaryDataPoint) {
+	b.point.append(dp.Attributes(), dp.StartTimestamp(), dp.Timestamp())
+	b.count.Append(dp.Count())
+	b.sum.Append(dp.Sum())
+	b.quantilesList.Append(true)
+	for i := 0; i < dp.QuantileValues().Len(); i++ {
+		qv := dp.QuantileValues().At(i)
+		b.quantiles.Append(true)
+		b.quantile.Append(qv.Quantile())
+		b.quantileValue.Append(qv.Value())
+	}
+	b.flags.Append(uint32(dp.Flags()))
+}
+
+type summaryDataPointReader struct {
+	point         pointReader
+	count         *array.Uint64
+	sum           *array.Float64
+	quantilesList *array.List
+	quantile      *array.Float64
+	quantileValue *array.Float64
+	flags         *array.Uint32
+}
+
+func newSummaryDataPointReader(a arrow.Array) summaryDataPointReader {
+	s := a.(*array.Struct)
+	quantilesList := s.Field(5).(*array.List)
+	quantiles := quantilesList.ListValues().(*array.Struct)
+	return summaryDataPointReader{
+		point:         newPointReader(s),
+		count:         s.Field(3).(*array.Uint64),
+		sum:           s.Field(4).(*array.Float64),
+		quantilesList: quantilesList,
+		quantile:      quantiles.Field(0).(*array.Float64),
+		quantileValue: quantiles.Field(1).(*array.Float64),
+		flags:         s.Field(6).(*array.Uint32),
+	}
+}
+
+func (r summaryDataPointReader) read(i int, dest pmetric.SummaryDataPoint) error {
+	start, ts, err := r.point.read(i, dest.Attributes())
+	if err != nil {
+		return err
+	}
+	dest.SetStartTimestamp(start)
+	dest.SetTimestamp(ts)
+	dest.SetCount(r.count.Value(i))
+	dest.SetSum(r.sum.Value(i))
+	qStart, qEnd := listRange(r.quantilesList, i)
+	dest.QuantileValues().EnsureCapacity(qEnd - qStart)
+	for j := qStart; j < qEnd; j++ {
+		qv := dest.QuantileValues().AppendEmpty()
+		qv.SetQuantile(r.quantile.Value(j))
+		qv.SetValue(r.quantileValue.Value(j))
+	}
+	dest.SetFlags(pmetric.DataPointFlags(r.flags.Value(i)))
+	return nil
+}
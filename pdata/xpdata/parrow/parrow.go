@@ -0,0 +1,177 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package parrow converts telemetry to and from Apache Arrow records, a columnar representation
+// compressing better than OTLP/protobuf as the values of each field are stored together.
+//
+// The records hold a row per ResourceSpans, ResourceMetrics or ResourceLogs, and mirror the OTLP
+// hierarchy with list and struct columns. The maps and slices nested in attribute values are
+// stored as OTLP/protobuf, as Arrow has no recursive types. The schemas are specific to this
+// package, and are not compatible with the OTel Arrow project ones, so the OTLP exporter and
+// receiver do not stream them: the OTel Arrow protocol is implemented by the otelarrow exporter
+// and receiver of the contrib repository. Profiles are not supported.
+//
+// The Arrow IPC decoder of arrow-go is not hardened against malicious input: corrupted streams
+// may exhaust the memory. Only decode the streams of trusted peers, e.g. other collectors.
+package parrow // import "go.opentelemetry.io/collector/pdata/xpdata/parrow"
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/ipc"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+var (
+	errUnexpectedSchema   = errors.New("unexpected arrow schema")
+	errAllocationTooLarge = errors.New("allocation larger than the input")
+)
+
+var (
+	_ ptrace.Marshaler    = (*Marshaler)(nil)
+	_ pmetric.Marshaler   = (*Marshaler)(nil)
+	_ plog.Marshaler      = (*Marshaler)(nil)
+	_ ptrace.Unmarshaler  = (*Unmarshaler)(nil)
+	_ pmetric.Unmarshaler = (*Unmarshaler)(nil)
+	_ plog.Unmarshaler    = (*Unmarshaler)(nil)
+)
+
+// Marshaler encodes telemetry as an Arrow IPC stream holding a single record.
+type Marshaler struct{}
+
+// MarshalTraces encodes td as an Arrow IPC stream.
+func (Marshaler) MarshalTraces(td ptrace.Traces) ([]byte, error) {
+	rec := TracesToRecord(td)
+	defer rec.Release()
+	return writeRecord(rec)
+}
+
+// MarshalMetrics encodes md as an Arrow IPC stream.
+func (Marshaler) MarshalMetrics(md pmetric.Metrics) ([]byte, error) {
+	rec := MetricsToRecord(md)
+	defer rec.Release()
+	return writeRecord(rec)
+}
+
+// MarshalLogs encodes ld as an Arrow IPC stream.
+func (Marshaler) MarshalLogs(ld plog.Logs) ([]byte, error) {
+	rec := LogsToRecord(ld)
+	defer rec.Release()
+	return writeRecord(rec)
+}
+
+func writeRecord(rec arrow.Record) ([]byte, error) {
+	var buf bytes.Buffer
+	w := ipc.NewWriter(&buf, ipc.WithSchema(rec.Schema()), ipc.WithAllocator(memory.DefaultAllocator))
+	if err := w.Write(rec); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Unmarshaler decodes telemetry from an Arrow IPC stream. The records of the streams holding
+// several records are appended together.
+type Unmarshaler struct{}
+
+// UnmarshalTraces decodes ptrace.Traces from an Arrow IPC stream.
+func (Unmarshaler) UnmarshalTraces(buf []byte) (ptrace.Traces, error) {
+	td := ptrace.NewTraces()
+	err := readRecords(buf, func(rec arrow.Record) error {
+		res, err := TracesFromRecord(rec)
+		if err != nil {
+			return err
+		}
+		res.ResourceSpans().MoveAndAppendTo(td.ResourceSpans())
+		return nil
+	})
+	if err != nil {
+		return ptrace.Traces{}, err
+	}
+	return td, nil
+}
+
+// UnmarshalMetrics decodes pmetric.Metrics from an Arrow IPC stream.
+func (Unmarshaler) UnmarshalMetrics(buf []byte) (pmetric.Metrics, error) {
+	md := pmetric.NewMetrics()
+	err := readRecords(buf, func(rec arrow.Record) error {
+		res, err := MetricsFromRecord(rec)
+		if err != nil {
+			return err
+		}
+		res.ResourceMetrics().MoveAndAppendTo(md.ResourceMetrics())
+		return nil
+	})
+	if err != nil {
+		return pmetric.Metrics{}, err
+	}
+	return md, nil
+}
+
+// UnmarshalLogs decodes plog.Logs from an Arrow IPC stream.
+func (Unmarshaler) UnmarshalLogs(buf []byte) (plog.Logs, error) {
+	ld := plog.NewLogs()
+	err := readRecords(buf, func(rec arrow.Record) error {
+		res, err := LogsFromRecord(rec)
+		if err != nil {
+			return err
+		}
+		res.ResourceLogs().MoveAndAppendTo(ld.ResourceLogs())
+		return nil
+	})
+	if err != nil {
+		return plog.Logs{}, err
+	}
+	return ld, nil
+}
+
+// readRecords calls fn with each record of the Arrow IPC stream in buf.
+func readRecords(buf []byte, fn func(arrow.Record) error) (err error) {
+	defer recoverInvalid(&err)
+	mem := boundedAllocator{Allocator: memory.DefaultAllocator, max: len(buf) + 64}
+	r, err := ipc.NewReader(bytes.NewReader(buf), ipc.WithAllocator(mem))
+	if err != nil {
+		return fmt.Errorf("%w: %w", errInvalidEncoding, err)
+	}
+	defer r.Release()
+	for r.Next() {
+		if err = fn(r.Record()); err != nil {
+			return err
+		}
+	}
+	if err = r.Err(); err != nil {
+		return fmt.Errorf("%w: %w", errInvalidEncoding, err)
+	}
+	return nil
+}
+
+// boundedAllocator refuses the allocations larger than max. The IPC reader allocates the messages
+// with the size read from the stream before reading them, so the sizes read from corrupted
+// streams must not exceed the size of the stream, which is known.
+type boundedAllocator struct {
+	memory.Allocator
+	max int
+}
+
+func (a boundedAllocator) Allocate(size int) []byte {
+	if size > a.max {
+		panic(errAllocationTooLarge)
+	}
+	return a.Allocator.Allocate(size)
+}
+
+func (a boundedAllocator) Reallocate(size int, b []byte) []byte {
+	if size > a.max {
+		panic(errAllocationTooLarge)
+	}
+	return a.Allocator.Reallocate(size, b)
+}
@@ -0,0 +1,249 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package parrow
+
+import (
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/testdata"
+)
+
+// fillValues sets values of all the types, nested in maps and slices, in m.
+func fillValues(m pcommon.Map) {
+	m.PutStr("str", "value")
+	m.PutStr("empty-str", "")
+	m.PutInt("int", -42)
+	m.PutDouble("double", 3.5)
+	m.PutBool("bool", true)
+	m.PutEmptyBytes("bytes").FromRaw([]byte{1, 2, 3})
+	m.PutEmpty("empty")
+	nested := m.PutEmptyMap("map")
+	nested.PutStr("str", "nested")
+	nested.PutEmptySlice("slice").AppendEmpty().SetDouble(1.5)
+	s := m.PutEmptySlice("slice")
+	s.AppendEmpty().SetInt(1)
+	s.AppendEmpty().SetStr("two")
+	s.AppendEmpty()
+	s.AppendEmpty().SetEmptyBytes().FromRaw([]byte{4})
+	s.AppendEmpty().SetEmptyMap().PutBool("bool", false)
+	m.PutEmptyMap("empty-map")
+	m.PutEmptySlice("empty-slice")
+}
+
+func TestTracesRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		td   ptrace.Traces
+	}{
+		{name: "empty", td: ptrace.NewTraces()},
+		{name: "empty resource", td: func() ptrace.Traces {
+			td := ptrace.NewTraces()
+			td.ResourceSpans().AppendEmpty()
+			return td
+		}()},
+		{name: "no spans", td: func() ptrace.Traces {
+			td := ptrace.NewTraces()
+			td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty()
+			return td
+		}()},
+		{name: "spans", td: testdata.GenerateTraces(5)},
+		{name: "values", td: func() ptrace.Traces {
+			td := testdata.GenerateTraces(2)
+			rs := td.ResourceSpans().At(0)
+			fillValues(rs.Resource().Attributes())
+			rs.SetSchemaUrl("https://opentelemetry.io/schemas/1.0.0")
+			ss := rs.ScopeSpans().At(0)
+			ss.Scope().SetName("scope")
+			ss.Scope().SetVersion("1.0")
+			fillValues(ss.Scope().Attributes())
+			span := ss.Spans().At(0)
+			span.SetTraceID(pcommon.TraceID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+			span.SetSpanID(pcommon.SpanID{1, 2, 3, 4, 5, 6, 7, 8})
+			span.SetParentSpanID(pcommon.SpanID{8, 7, 6, 5, 4, 3, 2, 1})
+			span.TraceState().FromRaw("key=value")
+			span.SetFlags(1)
+			span.SetKind(ptrace.SpanKindServer)
+			fillValues(span.Attributes())
+			link := span.Links().AppendEmpty()
+			link.SetTraceID(pcommon.TraceID{16})
+			link.SetSpanID(pcommon.SpanID{8})
+			link.TraceState().FromRaw("link=state")
+			link.SetFlags(2)
+			fillValues(link.Attributes())
+			span.SetDroppedLinksCount(3)
+			span.Status().SetCode(ptrace.StatusCodeError)
+			span.Status().SetMessage("error")
+			return td
+		}()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf, err := Marshaler{}.MarshalTraces(tt.td)
+			require.NoError(t, err)
+			got, err := Unmarshaler{}.UnmarshalTraces(buf)
+			require.NoError(t, err)
+			assert.Equal(t, tt.td, got)
+		})
+	}
+}
+
+func TestMetricsRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		md   pmetric.Metrics
+	}{
+		{name: "empty", md: pmetric.NewMetrics()},
+		{name: "empty types", md: testdata.GenerateMetricsAllTypesEmpty()},
+		{name: "invalid type", md: testdata.GenerateMetricsMetricTypeInvalid()},
+		{name: "all types", md: testdata.GenerateMetricsAllTypes()},
+		{name: "metrics", md: testdata.GenerateMetrics(10)},
+		{name: "values", md: func() pmetric.Metrics {
+			md := testdata.GenerateMetricsAllTypes()
+			ms := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+			fillValues(ms.At(0).Metadata())
+			for i := 0; i < ms.Len(); i++ {
+				if ms.At(i).Type() != pmetric.MetricTypeHistogram {
+					continue
+				}
+				dp := ms.At(i).Histogram().DataPoints().At(0)
+				dp.SetMin(1)
+				dp.SetMax(10)
+				ex := dp.Exemplars().AppendEmpty()
+				ex.SetIntValue(7)
+				ex.SetTraceID(pcommon.TraceID{1})
+				ex.SetSpanID(pcommon.SpanID{2})
+				fillValues(ex.FilteredAttributes())
+			}
+			return md
+		}()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf, err := Marshaler{}.MarshalMetrics(tt.md)
+			require.NoError(t, err)
+			got, err := Unmarshaler{}.UnmarshalMetrics(buf)
+			require.NoError(t, err)
+			assert.Equal(t, tt.md, got)
+		})
+	}
+}
+
+func TestLogsRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		ld   plog.Logs
+	}{
+		{name: "empty", ld: plog.NewLogs()},
+		{name: "empty resource", ld: func() plog.Logs {
+			ld := plog.NewLogs()
+			ld.ResourceLogs().AppendEmpty()
+			return ld
+		}()},
+		{name: "no records", ld: func() plog.Logs {
+			ld := plog.NewLogs()
+			ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty()
+			return ld
+		}()},
+		{name: "logs", ld: testdata.GenerateLogs(5)},
+		{name: "values", ld: func() plog.Logs {
+			ld := testdata.GenerateLogs(3)
+			lrs := ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+			fillValues(lrs.At(0).Body().SetEmptyMap())
+			lrs.At(1).Body().SetEmptyBytes().FromRaw([]byte("body"))
+			lrs.At(2).SetEventName("event")
+			lrs.At(2).SetTraceID(pcommon.TraceID{1})
+			lrs.At(2).SetSpanID(pcommon.SpanID{2})
+			lrs.At(2).SetFlags(plog.DefaultLogRecordFlags.WithIsSampled(true))
+			fillValues(lrs.At(2).Attributes())
+			return ld
+		}()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf, err := Marshaler{}.MarshalLogs(tt.ld)
+			require.NoError(t, err)
+			got, err := Unmarshaler{}.UnmarshalLogs(buf)
+			require.NoError(t, err)
+			assert.Equal(t, tt.ld, got)
+		})
+	}
+}
+
+func TestUnmarshalInvalid(t *testing.T) {
+	_, err := Unmarshaler{}.UnmarshalTraces([]byte("invalid"))
+	require.ErrorIs(t, err, errInvalidEncoding)
+
+	buf, err := Marshaler{}.MarshalLogs(testdata.GenerateLogs(1))
+	require.NoError(t, err)
+	_, err = Unmarshaler{}.UnmarshalTraces(buf)
+	require.ErrorIs(t, err, errUnexpectedSchema)
+	_, err = Unmarshaler{}.UnmarshalMetrics(buf)
+	require.ErrorIs(t, err, errUnexpectedSchema)
+
+	buf, err = Marshaler{}.MarshalTraces(testdata.GenerateTraces(1))
+	require.NoError(t, err)
+	_, err = Unmarshaler{}.UnmarshalLogs(buf)
+	require.ErrorIs(t, err, errUnexpectedSchema)
+
+	// Truncated streams fail to decode.
+	_, err = Unmarshaler{}.UnmarshalTraces(buf[:len(buf)/2])
+	require.ErrorIs(t, err, errInvalidEncoding)
+}
+
+func TestBoundedAllocator(t *testing.T) {
+	mem := boundedAllocator{Allocator: memory.DefaultAllocator, max: 64}
+	assert.Len(t, mem.Allocate(64), 64)
+	assert.PanicsWithValue(t, errAllocationTooLarge, func() { mem.Allocate(65) })
+	assert.PanicsWithValue(t, errAllocationTooLarge, func() { mem.Reallocate(65, nil) })
+}
+
+func TestReadAnyValueInvalid(t *testing.T) {
+	// A bytes field whose length exceeds the buffer.
+	require.ErrorIs(t, readAnyValue([]byte{0x0a, 0x05, 'a'}, pcommon.NewValueEmpty()), errInvalidValue)
+	require.ErrorIs(t, readKeyValueList([]byte{0x0a, 0x02, 0x0a}, pcommon.NewMap()), errInvalidValue)
+}
+
+func TestRecordColumns(t *testing.T) {
+	td := testdata.GenerateTraces(3)
+	rec := TracesToRecord(td)
+	defer rec.Release()
+	assert.EqualValues(t, td.ResourceSpans().Len(), rec.NumRows())
+	assert.Equal(t, tracesSchema, rec.Schema())
+
+	got, err := TracesFromRecord(rec)
+	require.NoError(t, err)
+	assert.Equal(t, td, got)
+
+	_, err = LogsFromRecord(rec)
+	require.ErrorIs(t, err, errUnexpectedSchema)
+}
+
+func BenchmarkMarshalTraces(b *testing.B) {
+	td := testdata.GenerateTraces(100)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, err := Marshaler{}.MarshalTraces(td)
+		require.NoError(b, err)
+	}
+}
+
+func BenchmarkUnmarshalTraces(b *testing.B) {
+	buf, err := Marshaler{}.MarshalTraces(testdata.GenerateTraces(100))
+	require.NoError(b, err)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, err := Unmarshaler{}.UnmarshalTraces(buf)
+		require.NoError(b, err)
+	}
+}
@@ -0,0 +1,374 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package parrow // import "go.opentelemetry.io/collector/pdata/xpdata/parrow"
+
+import (
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+var (
+	spanEventType = arrow.StructOf(
+		arrow.Field{Name: "time_unix_nano", Type: arrow.PrimitiveTypes.Uint64},
+		arrow.Field{Name: "name", Type: arrow.BinaryTypes.String},
+		arrow.Field{Name: "attributes", Type: attributesType},
+		arrow.Field{Name: "dropped_attributes_count", Type: arrow.PrimitiveTypes.Uint32},
+	)
+
+	spanLinkType = arrow.StructOf(
+		arrow.Field{Name: "trace_id", Type: traceIDType},
+		arrow.Field{Name: "span_id", Type: spanIDType},
+		arrow.Field{Name: "trace_state", Type: arrow.BinaryTypes.String},
+		arrow.Field{Name: "attributes", Type: attributesType},
+		arrow.Field{Name: "dropped_attributes_count", Type: arrow.PrimitiveTypes.Uint32},
+		arrow.Field{Name: "flags", Type: arrow.PrimitiveTypes.Uint32},
+	)
+
+	spanType = arrow.StructOf(
+		arrow.Field{Name: "trace_id", Type: traceIDType},
+		arrow.Field{Name: "span_id", Type: spanIDType},
+		arrow.Field{Name: "trace_state", Type: arrow.BinaryTypes.String},
+		arrow.Field{Name: "parent_span_id", Type: spanIDType},
+		arrow.Field{Name: "flags", Type: arrow.PrimitiveTypes.Uint32},
+		arrow.Field{Name: "name", Type: arrow.BinaryTypes.String},
+		arrow.Field{Name: "kind", Type: arrow.PrimitiveTypes.Int32},
+		arrow.Field{Name: "start_time_unix_nano", Type: arrow.PrimitiveTypes.Uint64},
+		arrow.Field{Name: "end_time_unix_nano", Type: arrow.PrimitiveTypes.Uint64},
+		arrow.Field{Name: "attributes", Type: attributesType},
+		arrow.Field{Name: "dropped_attributes_count", Type: arrow.PrimitiveTypes.Uint32},
+		arrow.Field{Name: "events", Type: arrow.ListOf(spanEventType)},
+		arrow.Field{Name: "dropped_events_count", Type: arrow.PrimitiveTypes.Uint32},
+		arrow.Field{Name: "links", Type: arrow.ListOf(spanLinkType)},
+		arrow.Field{Name: "dropped_links_count", Type: arrow.PrimitiveTypes.Uint32},
+		arrow.Field{Name: "status_code", Type: arrow.PrimitiveTypes.Int32},
+		arrow.Field{Name: "status_message", Type: arrow.BinaryTypes.String},
+	)
+
+	// tracesSchema is the schema of the traces records, with a row per ResourceSpans.
+	tracesSchema = arrow.NewSchema([]arrow.Field{
+		{Name: "resource", Type: resourceType},
+		{Name: "schema_url", Type: arrow.BinaryTypes.String},
+		{Name: "scope_spans", Type: arrow.ListOf(arrow.StructOf(
+			arrow.Field{Name: "scope", Type: scopeType},
+			arrow.Field{Name: "schema_url", Type: arrow.BinaryTypes.String},
+			arrow.Field{Name: "spans", Type: arrow.ListOf(spanType)},
+		))},
+	}, nil)
+)
+
+// TracesToRecord converts td to an Arrow record, with a row per ResourceSpans.
+// The record must be released once no longer used.
+func TracesToRecord(td ptrace.Traces) arrow.Record {
+	rb := array.NewRecordBuilder(memory.DefaultAllocator, tracesSchema)
+	defer rb.Release()
+
+	resource := newResourceBuilder(rb.Field(0))
+	schemaURL := rb.Field(1).(*array.StringBuilder)
+	scopeSpansList := rb.Field(2).(*array.ListBuilder)
+	scopeSpans := scopeSpansList.ValueBuilder().(*array.StructBuilder)
+	scope := newScopeBuilder(scopeSpans.FieldBuilder(0))
+	scopeSchemaURL := scopeSpans.FieldBuilder(1).(*array.StringBuilder)
+	spansList := scopeSpans.FieldBuilder(2).(*array.ListBuilder)
+	spans := newSpanBuilder(spansList.ValueBuilder())
+
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		resource.append(rs.Resource())
+		schemaURL.Append(rs.SchemaUrl())
+		scopeSpansList.Append(true)
+		for j := 0; j < rs.ScopeSpans().Len(); j++ {
+			ss := rs.ScopeSpans().At(j)
+			scopeSpans.Append(true)
+			scope.append(ss.Scope())
+			scopeSchemaURL.Append(ss.SchemaUrl())
+			spansList.Append(true)
+			for k := 0; k < ss.Spans().Len(); k++ {
+				spans.append(ss.Spans().At(k))
+			}
+		}
+	}
+	return rb.NewRecord()
+}
+
+// TracesFromRecord converts an Arrow record created by TracesToRecord back to ptrace.Traces.
+func TracesFromRecord(rec arrow.Record) (td ptrace.Traces, err error) {
+	if !rec.Schema().Equal(tracesSchema) {
+		return ptrace.Traces{}, errUnexpectedSchema
+	}
+	defer recoverInvalid(&err)
+
+	resource := newResourceReader(rec.Column(0))
+	schemaURL := rec.Column(1).(*array.String)
+	scopeSpansList := rec.Column(2).(*array.List)
+	scopeSpans := scopeSpansList.ListValues().(*array.Struct)
+	scope := newScopeReader(scopeSpans.Field(0))
+	scopeSchemaURL := scopeSpans.Field(1).(*array.String)
+	spansList := scopeSpans.Field(2).(*array.List)
+	spans := newSpanReader(spansList.ListValues())
+
+	td = ptrace.NewTraces()
+	rss := td.ResourceSpans()
+	rss.EnsureCapacity(int(rec.NumRows()))
+	for i := 0; i < int(rec.NumRows()); i++ {
+		rs := rss.AppendEmpty()
+		if err = resource.read(i, rs.Resource()); err != nil {
+			return ptrace.Traces{}, err
+		}
+		rs.SetSchemaUrl(schemaURL.Value(i))
+		start, end := listRange(scopeSpansList, i)
+		rs.ScopeSpans().EnsureCapacity(end - start)
+		for j := start; j < end; j++ {
+			ss := rs.ScopeSpans().AppendEmpty()
+			if err = scope.read(j, ss.Scope()); err != nil {
+				return ptrace.Traces{}, err
+			}
+			ss.SetSchemaUrl(scopeSchemaURL.Value(j))
+			spansStart, spansEnd := listRange(spansList, j)
+			ss.Spans().EnsureCapacity(spansEnd - spansStart)
+			for k := spansStart; k < spansEnd; k++ {
+				if err = spans.read(k, ss.Spans().AppendEmpty()); err != nil {
+					return ptrace.Traces{}, err
+				}
+			}
+		}
+	}
+	return td, nil
+}
+
+type spanBuilder struct {
+	s                 *array.StructBuilder
+	traceID           *array.FixedSizeBinaryBuilder
+	spanID            *array.FixedSizeBinaryBuilder
+	traceState        *array.StringBuilder
+	parentSpanID      *array.FixedSizeBinaryBuilder
+	flags             *array.Uint32Builder
+	name              *array.StringBuilder
+	kind              *array.Int32Builder
+	start             *array.Uint64Builder
+	end               *array.Uint64Builder
+	attributes        attributesBuilder
+	droppedAttributes *array.Uint32Builder
+	eventsList        *array.ListBuilder
+	events            *array.StructBuilder
+	eventTime         *array.Uint64Builder
+	eventName         *array.StringBuilder
+	eventAttributes   attributesBuilder
+	eventDropped      *array.Uint32Builder
+	droppedEvents     *array.Uint32Builder
+	linksList         *array.ListBuilder
+	links             *array.StructBuilder
+	linkTraceID       *array.FixedSizeBinaryBuilder
+	linkSpanID        *array.FixedSizeBinaryBuilder
+	linkTraceState    *array.StringBuilder
+	linkAttributes    attributesBuilder
+	linkDropped       *array.Uint32Builder
+	linkFlags         *array.Uint32Builder
+	droppedLinks      *array.Uint32Builder
+	statusCode        *array.Int32Builder
+	statusMessage     *array.StringBuilder
+}
+
+func newSpanBuilder(b array.Builder) spanBuilder {
+	s := b.(*array.StructBuilder)
+	eventsList := s.FieldBuilder(11).(*array.ListBuilder)
+	events := eventsList.ValueBuilder().(*array.StructBuilder)
+	linksList := s.FieldBuilder(13).(*array.ListBuilder)
+	links := linksList.ValueBuilder().(*array.StructBuilder)
+	return spanBuilder{
+		s:                 s,
+		traceID:           s.FieldBuilder(0).(*array.FixedSizeBinaryBuilder),
+		spanID:            s.FieldBuilder(1).(*array.FixedSizeBinaryBuilder),
+		traceState:        s.FieldBuilder(2).(*array.StringBuilder),
+		parentSpanID:      s.FieldBuilder(3).(*array.FixedSizeBinaryBuilder),
+		flags:             s.FieldBuilder(4).(*array.Uint32Builder),
+		name:              s.FieldBuilder(5).(*array.StringBuilder),
+		kind:              s.FieldBuilder(6).(*array.Int32Builder),
+		start:             s.FieldBuilder(7).(*array.Uint64Builder),
+		end:               s.FieldBuilder(8).(*array.Uint64Builder),
+		attributes:        newAttributesBuilder(s.FieldBuilder(9)),
+		droppedAttributes: s.FieldBuilder(10).(*array.Uint32Builder),
+		eventsList:        eventsList,
+		events:            events,
+		eventTime:         events.FieldBuilder(0).(*array.Uint64Builder),
+		eventName:         events.FieldBuilder(1).(*array.StringBuilder),
+		eventAttributes:   newAttributesBuilder(events.FieldBuilder(2)),
+		eventDropped:      events.FieldBuilder(3).(*array.Uint32Builder),
+		droppedEvents:     s.FieldBuilder(12).(*array.Uint32Builder),
+		linksList:         linksList,
+		links:             links,
+		linkTraceID:       links.FieldBuilder(0).(*array.FixedSizeBinaryBuilder),
+		linkSpanID:        links.FieldBuilder(1).(*array.FixedSizeBinaryBuilder),
+		linkTraceState:    links.FieldBuilder(2).(*array.StringBuilder),
+		linkAttributes:    newAttributesBuilder(links.FieldBuilder(3)),
+		linkDropped:       links.FieldBuilder(4).(*array.Uint32Builder),
+		linkFlags:         links.FieldBuilder(5).(*array.Uint32Builder),
+		droppedLinks:      s.FieldBuilder(14).(*array.Uint32Builder),
+		statusCode:        s.FieldBuilder(15).(*array.Int32Builder),
+		statusMessage:     s.FieldBuilder(16).(*array.StringBuilder),
+	}
+}
+
+func (b spanBuilder) append(span ptrace.Span) {
+	b.s.Append(true)
+	traceID, spanID, parentSpanID := span.TraceID(), span.SpanID(), span.ParentSpanID()
+	b.traceID.Append(traceID[:])
+	b.spanID.Append(spanID[:])
+	b.traceState.Append(span.TraceState().AsRaw())
+	b.parentSpanID.Append(parentSpanID[:])
+	b.flags.Append(span.Flags())
+	b.name.Append(span.Name())
+	b.kind.Append(int32(span.Kind()))
+	b.start.Append(uint64(span.StartTimestamp()))
+	b.end.Append(uint64(span.EndTimestamp()))
+	b.attributes.append(span.Attributes())
+	b.droppedAttributes.Append(span.DroppedAttributesCount())
+
+	b.eventsList.Append(true)
+	for i := 0; i < span.Events().Len(); i++ {
+		event := span.Events().At(i)
+		b.events.Append(true)
+		b.eventTime.Append(uint64(event.Timestamp()))
+		b.eventName.Append(event.Name())
+		b.eventAttributes.append(event.Attributes())
+		b.eventDropped.Append(event.DroppedAttributesCount())
+	}
+	b.droppedEvents.Append(span.DroppedEventsCount())
+
+	b.linksList.Append(true)
+	for i := 0; i < span.Links().Len(); i++ {
+		link := span.Links().At(i)
+		linkTraceID, linkSpanID := link.TraceID(), link.SpanID()
+		b.links.Append(true)
+		b.linkTraceID.Append(linkTraceID[:])
+		b.linkSpanID.Append(linkSpanID[:])
+		b.linkTraceState.Append(link.TraceState().AsRaw())
+		b.linkAttributes.append(link.Attributes())
+		b.linkDropped.Append(link.DroppedAttributesCount())
+		b.linkFlags.Append(link.Flags())
+	}
+	b.droppedLinks.Append(span.DroppedLinksCount())
+
+	b.statusCode.Append(int32(span.Status().Code()))
+	b.statusMessage.Append(span.Status().Message())
+}
+
+type spanReader struct {
+	traceID           *array.FixedSizeBinary
+	spanID            *array.FixedSizeBinary
+	traceState        *array.String
+	parentSpanID      *array.FixedSizeBinary
+	flags             *array.Uint32
+	name              *array.String
+	kind              *array.Int32
+	start             *array.Uint64
+	end               *array.Uint64
+	attributes        attributesReader
+	droppedAttributes *array.Uint32
+	eventsList        *array.List
+	eventTime         *array.Uint64
+	eventName         *array.String
+	eventAttributes   attributesReader
+	eventDropped      *array.Uint32
+	droppedEvents     *array.Uint32
+	linksList         *array.List
+	linkTraceID       *array.FixedSizeBinary
+	linkSpanID        *array.FixedSizeBinary
+	linkTraceState    *array.String
+	linkAttributes    attributesReader
+	linkDropped       *array.Uint32
+	linkFlags         *array.Uint32
+	droppedLinks      *array.Uint32
+	statusCode        *array.Int32
+	statusMessage     *array.String
+}
+
+func newSpanReader(a arrow.Array) spanReader {
+	s := a.(*array.Struct)
+	eventsList := s.Field(11).(*array.List)
+	events := eventsList.ListValues().(*array.Struct)
+	linksList := s.Field(13).(*array.List)
+	links := linksList.ListValues().(*array.Struct)
+	return spanReader{
+		traceID:           s.Field(0).(*array.FixedSizeBinary),
+		spanID:            s.Field(1).(*array.FixedSizeBinary),
+		traceState:        s.Field(2).(*array.String),
+		parentSpanID:      s.Field(3).(*array.FixedSizeBinary),
+		flags:             s.Field(4).(*array.Uint32),
+		name:              s.Field(5).(*array.String),
+		kind:              s.Field(6).(*array.Int32),
+		start:             s.Field(7).(*array.Uint64),
+		end:               s.Field(8).(*array.Uint64),
+		attributes:        newAttributesReader(s.Field(9)),
+		droppedAttributes: s.Field(10).(*array.Uint32),
+		eventsList:        eventsList,
+		eventTime:         events.Field(0).(*array.Uint64),
+		eventName:         events.Field(1).(*array.String),
+		eventAttributes:   newAttributesReader(events.Field(2)),
+		eventDropped:      events.Field(3).(*array.Uint32),
+		droppedEvents:     s.Field(12).(*array.Uint32),
+		linksList:         linksList,
+		linkTraceID:       links.Field(0).(*array.FixedSizeBinary),
+		linkSpanID:        links.Field(1).(*array.FixedSizeBinary),
+		linkTraceState:    links.Field(2).(*array.String),
+		linkAttributes:    newAttributesReader(links.Field(3)),
+		linkDropped:       links.Field(4).(*array.Uint32),
+		linkFlags:         links.Field(5).(*array.Uint32),
+		droppedLinks:      s.Field(14).(*array.Uint32),
+		statusCode:        s.Field(15).(*array.Int32),
+		statusMessage:     s.Field(16).(*array.String),
+	}
+}
+
+func (r spanReader) read(i int, dest ptrace.Span) error {
+	dest.SetTraceID(pcommon.TraceID(r.traceID.Value(i)))
+	dest.SetSpanID(pcommon.SpanID(r.spanID.Value(i)))
+	dest.TraceState().FromRaw(r.traceState.Value(i))
+	dest.SetParentSpanID(pcommon.SpanID(r.parentSpanID.Value(i)))
+	dest.SetFlags(r.flags.Value(i))
+	dest.SetName(r.name.Value(i))
+	dest.SetKind(ptrace.SpanKind(r.kind.Value(i)))
+	dest.SetStartTimestamp(pcommon.Timestamp(r.start.Value(i)))
+	dest.SetEndTimestamp(pcommon.Timestamp(r.end.Value(i)))
+	if err := r.attributes.read(i, dest.Attributes()); err != nil {
+		return err
+	}
+	dest.SetDroppedAttributesCount(r.droppedAttributes.Value(i))
+
+	start, end := listRange(r.eventsList, i)
+	dest.Events().EnsureCapacity(end - start)
+	for j := start; j < end; j++ {
+		event := dest.Events().AppendEmpty()
+		event.SetTimestamp(pcommon.Timestamp(r.eventTime.Value(j)))
+		event.SetName(r.eventName.Value(j))
+		if err := r.eventAttributes.read(j, event.Attributes()); err != nil {
+			return err
+		}
+		event.SetDroppedAttributesCount(r.eventDropped.Value(j))
+	}
+	dest.SetDroppedEventsCount(r.droppedEvents.Value(i))
+
+	start, end = listRange(r.linksList, i)
+	dest.Links().EnsureCapacity(end - start)
+	for j := start; j < end; j++ {
+		link := dest.Links().AppendEmpty()
+		link.SetTraceID(pcommon.TraceID(r.linkTraceID.Value(j)))
+		link.SetSpanID(pcommon.SpanID(r.linkSpanID.Value(j)))
+		link.TraceState().FromRaw(r.linkTraceState.Value(j))
+		if err := r.linkAttributes.read(j, link.Attributes()); err != nil {
+			return err
+		}
+		link.SetDroppedAttributesCount(r.linkDropped.Value(j))
+		link.SetFlags(r.linkFlags.Value(j))
+	}
+	dest.SetDroppedLinksCount(r.droppedLinks.Value(i))
+
+	dest.Status().SetCode(ptrace.StatusCode(r.statusCode.Value(i)))
+	dest.Status().SetMessage(r.statusMessage.Value(i))
+	return nil
+}
@@ -0,0 +1,134 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package pdatatest compares telemetry payloads, reporting their differences with the path of each
+// difference in the OTLP/JSON representation of the payloads, e.g.
+// `resourceSpans[0].scopeSpans[0].spans[1].attributes["http.method"].stringValue`.
+package pdatatest // import "go.opentelemetry.io/collector/pdata/xpdata/pdatatest"
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// Difference is a difference between the expected and the actual payloads.
+type Difference struct {
+	// Path is the path of the difference in the OTLP/JSON representation of the payloads.
+	Path string
+	// Expected and Actual are the JSON representation of the values, or "<missing>".
+	Expected string
+	Actual   string
+}
+
+func (d Difference) String() string {
+	return fmt.Sprintf("%s: expected %s, got %s", d.Path, d.Expected, d.Actual)
+}
+
+// DiffError is returned when the payloads compared are different.
+type DiffError struct {
+	Differences []Difference
+}
+
+func (e *DiffError) Error() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%d differences:", len(e.Differences))
+	for _, d := range e.Differences {
+		sb.WriteString("\n")
+		sb.WriteString(d.String())
+	}
+	return sb.String()
+}
+
+// CompareTraces compares the expected and actual traces. It returns a *DiffError listing
+// the differences, or nil if there are none.
+func CompareTraces(expected, actual ptrace.Traces, opts ...CompareOption) error {
+	o := newCompareOptions(opts)
+	normalize := func(td ptrace.Traces) ([]byte, error) {
+		if o.ignoreResourceGrouping {
+			td = regroupTraces(td)
+		}
+		return (&ptrace.JSONMarshaler{}).MarshalTraces(td)
+	}
+	return compare(o, normalize, expected, actual)
+}
+
+// CompareMetrics compares the expected and actual metrics. It returns a *DiffError listing
+// the differences, or nil if there are none.
+func CompareMetrics(expected, actual pmetric.Metrics, opts ...CompareOption) error {
+	o := newCompareOptions(opts)
+	normalize := func(md pmetric.Metrics) ([]byte, error) {
+		if o.ignoreResourceGrouping {
+			md = regroupMetrics(md)
+		}
+		return (&pmetric.JSONMarshaler{}).MarshalMetrics(md)
+	}
+	return compare(o, normalize, expected, actual)
+}
+
+// CompareLogs compares the expected and actual logs. It returns a *DiffError listing
+// the differences, or nil if there are none.
+func CompareLogs(expected, actual plog.Logs, opts ...CompareOption) error {
+	o := newCompareOptions(opts)
+	normalize := func(ld plog.Logs) ([]byte, error) {
+		if o.ignoreResourceGrouping {
+			ld = regroupLogs(ld)
+		}
+		return (&plog.JSONMarshaler{}).MarshalLogs(ld)
+	}
+	return compare(o, normalize, expected, actual)
+}
+
+// CompareProfiles compares the expected and actual profiles. It returns a *DiffError listing
+// the differences, or nil if there are none. The tables of the profiles are compared as is:
+// IgnoreOrder and IgnoreAttributes do not apply to them.
+func CompareProfiles(expected, actual pprofile.Profiles, opts ...CompareOption) error {
+	o := newCompareOptions(opts)
+	normalize := func(pd pprofile.Profiles) ([]byte, error) {
+		if o.ignoreResourceGrouping {
+			pd = regroupProfiles(pd)
+		}
+		return (&pprofile.JSONMarshaler{}).MarshalProfiles(pd)
+	}
+	return compare(o, normalize, expected, actual)
+}
+
+func compare[T any](o *compareOptions, marshal func(T) ([]byte, error), expected, actual T) error {
+	expectedTree, err := toTree(o, marshal, expected)
+	if err != nil {
+		return fmt.Errorf("failed to read the expected payload: %w", err)
+	}
+	actualTree, err := toTree(o, marshal, actual)
+	if err != nil {
+		return fmt.Errorf("failed to read the actual payload: %w", err)
+	}
+
+	d := differ{opts: o}
+	d.diff("", "", expectedTree, actualTree)
+	if len(d.differences) == 0 {
+		return nil
+	}
+	return &DiffError{Differences: d.differences}
+}
+
+// toTree returns the OTLP/JSON representation of a payload, decoded as nested maps and slices,
+// with the attributes as attrMap, and the ignored fields removed.
+func toTree[T any](o *compareOptions, marshal func(T) ([]byte, error), payload T) (any, error) {
+	buf, err := marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(buf))
+	dec.UseNumber()
+	var tree any
+	if err = dec.Decode(&tree); err != nil {
+		return nil, err
+	}
+	return normalizeTree(o, "", tree), nil
+}
@@ -0,0 +1,188 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pdatatest
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/testdata"
+)
+
+func requireDifferences(t *testing.T, err error) []Difference {
+	var diffErr *DiffError
+	require.ErrorAs(t, err, &diffErr)
+	return diffErr.Differences
+}
+
+func TestCompareEqual(t *testing.T) {
+	require.NoError(t, CompareTraces(testdata.GenerateTraces(3), testdata.GenerateTraces(3)))
+	require.NoError(t, CompareMetrics(testdata.GenerateMetrics(3), testdata.GenerateMetrics(3)))
+	require.NoError(t, CompareLogs(testdata.GenerateLogs(3), testdata.GenerateLogs(3)))
+	require.NoError(t, CompareProfiles(testdata.GenerateProfiles(3), testdata.GenerateProfiles(3)))
+}
+
+func TestCompareTracesDifference(t *testing.T) {
+	expected := testdata.GenerateTraces(2)
+	actual := testdata.GenerateTraces(2)
+	expected.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(1).Attributes().PutStr("http.method", "GET")
+	actual.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(1).Attributes().PutStr("http.method", "POST")
+
+	err := CompareTraces(expected, actual)
+	assert.Equal(t, []Difference{{
+		Path:     `resourceSpans[0].scopeSpans[0].spans[1].attributes["http.method"].stringValue`,
+		Expected: `"GET"`,
+		Actual:   `"POST"`,
+	}}, requireDifferences(t, err))
+	assert.EqualError(t, err, "1 differences:\n"+
+		`resourceSpans[0].scopeSpans[0].spans[1].attributes["http.method"].stringValue: expected "GET", got "POST"`)
+}
+
+func TestCompareMissing(t *testing.T) {
+	expected := testdata.GenerateLogs(3)
+	actual := testdata.GenerateLogs(2)
+	actual.ResourceLogs().At(0).Resource().Attributes().PutInt("extra", 1)
+
+	diffs := requireDifferences(t, CompareLogs(expected, actual))
+	require.Len(t, diffs, 2)
+	assert.Equal(t, `resourceLogs[0].resource.attributes["extra"]`, diffs[0].Path)
+	assert.Equal(t, missing, diffs[0].Expected)
+	assert.JSONEq(t, `{"intValue":"1"}`, diffs[0].Actual)
+	assert.Equal(t, "resourceLogs[0].scopeLogs[0].logRecords[2]", diffs[1].Path)
+	assert.Equal(t, missing, diffs[1].Actual)
+}
+
+func TestCompareTruncatesValues(t *testing.T) {
+	expected := plog.NewLogs()
+	expected.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr(strings.Repeat("a", 300))
+	actual := plog.NewLogs()
+	actual.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr("b")
+
+	diffs := requireDifferences(t, CompareLogs(expected, actual))
+	require.Len(t, diffs, 1)
+	assert.Len(t, diffs[0].Expected, maxValueLength+len("..."))
+	assert.Equal(t, `"b"`, diffs[0].Actual)
+}
+
+func TestCompareKvlistIgnoresOrder(t *testing.T) {
+	expected := plog.NewLogs()
+	body := expected.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetEmptyMap()
+	body.PutStr("a", "1")
+	body.PutStr("b", "2")
+	actual := plog.NewLogs()
+	body = actual.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetEmptyMap()
+	body.PutStr("b", "2")
+	body.PutStr("a", "3")
+
+	assert.Equal(t, []Difference{{
+		Path:     `resourceLogs[0].scopeLogs[0].logRecords[0].body.kvlistValue.values["a"].stringValue`,
+		Expected: `"1"`,
+		Actual:   `"3"`,
+	}}, requireDifferences(t, CompareLogs(expected, actual)))
+}
+
+func TestCompareIgnoreOrder(t *testing.T) {
+	expected := testdata.GenerateTraces(3)
+	actual := testdata.GenerateTraces(3)
+	spans := actual.ResourceSpans().At(0).ScopeSpans().At(0).Spans()
+	spans.At(0).CopyTo(spans.AppendEmpty())
+	first := true
+	spans.RemoveIf(func(ptrace.Span) bool {
+		defer func() { first = false }()
+		return first
+	})
+
+	require.Error(t, CompareTraces(expected, actual))
+	require.NoError(t, CompareTraces(expected, actual, IgnoreOrder()))
+
+	spans.At(2).SetName("operationC")
+	assert.Equal(t, []Difference{{
+		Path:     "resourceSpans[0].scopeSpans[0].spans[2].name",
+		Expected: `"operationA"`,
+		Actual:   `"operationC"`,
+	}}, requireDifferences(t, CompareTraces(expected, actual, IgnoreOrder())))
+
+	spans.AppendEmpty().SetName("operationD")
+	diffs := requireDifferences(t, CompareTraces(expected, actual, IgnoreOrder()))
+	require.Len(t, diffs, 2)
+	assert.Equal(t, "resourceSpans[0].scopeSpans[0].spans[3]", diffs[1].Path)
+	assert.Equal(t, missing, diffs[1].Expected)
+}
+
+func TestCompareIgnoreTimestamps(t *testing.T) {
+	expected := testdata.GenerateMetrics(5)
+	actual := testdata.GenerateMetrics(5)
+	dp := actual.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Gauge().DataPoints().At(0)
+	dp.SetTimestamp(dp.Timestamp() + 1)
+	dp.SetStartTimestamp(0)
+
+	assert.Len(t, requireDifferences(t, CompareMetrics(expected, actual)), 2)
+	require.NoError(t, CompareMetrics(expected, actual, IgnoreTimestamps()))
+}
+
+func TestCompareIgnoreAttributes(t *testing.T) {
+	expected := testdata.GenerateLogs(2)
+	actual := testdata.GenerateLogs(2)
+	actual.ResourceLogs().At(0).Resource().Attributes().PutStr("host.name", "b")
+	actual.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(1).Attributes().PutStr("host.name", "b")
+	actual.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(1).Attributes().PutStr("thread.id", "1")
+
+	assert.Len(t, requireDifferences(t, CompareLogs(expected, actual)), 3)
+	assert.Len(t, requireDifferences(t, CompareLogs(expected, actual, IgnoreAttributes("host.name"))), 1)
+	require.NoError(t, CompareLogs(expected, actual, IgnoreAttributes("host.name", "thread.id")))
+}
+
+func TestCompareIgnoreResourceGrouping(t *testing.T) {
+	newLogs := func(groups ...[]string) plog.Logs {
+		ld := plog.NewLogs()
+		for _, bodies := range groups {
+			rl := ld.ResourceLogs().AppendEmpty()
+			rl.Resource().Attributes().PutStr("service.name", bodies[0])
+			sl := rl.ScopeLogs().AppendEmpty()
+			sl.Scope().SetName("scope")
+			for _, body := range bodies[1:] {
+				sl.LogRecords().AppendEmpty().Body().SetStr(body)
+			}
+		}
+		return ld
+	}
+	expected := newLogs([]string{"a", "1", "2"}, []string{"b", "3"})
+	actual := newLogs([]string{"a", "1"}, []string{"b", "3"}, []string{"a", "2"})
+
+	require.Error(t, CompareLogs(expected, actual))
+	require.NoError(t, CompareLogs(expected, actual, IgnoreResourceGrouping()))
+	assert.Equal(t, 3, actual.ResourceLogs().Len(), "the payloads compared must not be modified")
+
+	actual.ResourceLogs().At(2).ScopeLogs().At(0).Scope().SetVersion("v1")
+	require.Error(t, CompareLogs(expected, actual, IgnoreResourceGrouping()))
+}
+
+func TestRegroupTraces(t *testing.T) {
+	td := ptrace.NewTraces()
+	for _, name := range []string{"a", "b", "a"} {
+		rs := td.ResourceSpans().AppendEmpty()
+		initResource(rs.Resource())
+		rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName(name)
+	}
+
+	got := regroupTraces(td)
+	require.Equal(t, 1, got.ResourceSpans().Len())
+	require.Equal(t, 1, got.ResourceSpans().At(0).ScopeSpans().Len())
+	spans := got.ResourceSpans().At(0).ScopeSpans().At(0).Spans()
+	require.Equal(t, 3, spans.Len())
+	for i, name := range []string{"a", "b", "a"} {
+		assert.Equal(t, name, spans.At(i).Name())
+	}
+}
+
+func initResource(r pcommon.Resource) {
+	r.Attributes().PutStr("service.name", "svc")
+	r.SetDroppedAttributesCount(1)
+}
@@ -0,0 +1,253 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pdatatest // import "go.opentelemetry.io/collector/pdata/xpdata/pdatatest"
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+const (
+	missing = "<missing>"
+	// maxValueLength is the length after which the values reported in a Difference are truncated.
+	maxValueLength = 200
+)
+
+// unorderedFields are the lists whose order is ignored with IgnoreOrder.
+var unorderedFields = map[string]struct{}{
+	"resourceSpans":    {},
+	"scopeSpans":       {},
+	"spans":            {},
+	"events":           {},
+	"links":            {},
+	"resourceLogs":     {},
+	"scopeLogs":        {},
+	"logRecords":       {},
+	"resourceMetrics":  {},
+	"scopeMetrics":     {},
+	"metrics":          {},
+	"dataPoints":       {},
+	"exemplars":        {},
+	"resourceProfiles": {},
+	"scopeProfiles":    {},
+	"profiles":         {},
+}
+
+// attrMap holds the attributes of an OTLP/JSON object, or the values of a kvlistValue, by key.
+type attrMap map[string]any
+
+func isTimestamp(field string) bool {
+	return strings.HasSuffix(field, "UnixNano") || field == "timeNanos"
+}
+
+// normalizeTree turns the attributes of the OTLP/JSON objects in tree into attrMap, and removes the ignored fields.
+func normalizeTree(o *compareOptions, field string, tree any) any {
+	switch v := tree.(type) {
+	case map[string]any:
+		for k, child := range v {
+			if o.ignoreTimestamps && isTimestamp(k) {
+				delete(v, k)
+				continue
+			}
+			v[k] = normalizeTree(o, k, child)
+		}
+		if kvs, ok := v["values"].([]any); ok && field == "kvlistValue" {
+			v["values"] = toAttrMap(o, kvs, false)
+		}
+		return v
+	case []any:
+		if field == "attributes" {
+			return toAttrMap(o, v, true)
+		}
+		for i, child := range v {
+			v[i] = normalizeTree(o, field, child)
+		}
+		return v
+	}
+	return tree
+}
+
+func toAttrMap(o *compareOptions, kvs []any, ignore bool) any {
+	m := make(attrMap, len(kvs))
+	for _, kv := range kvs {
+		obj, ok := kv.(map[string]any)
+		if !ok {
+			return kvs
+		}
+		key, _ := obj["key"].(string)
+		if _, ignored := o.ignoredAttributes[key]; ignore && ignored {
+			continue
+		}
+		m[key] = normalizeTree(o, "value", obj["value"])
+	}
+	return m
+}
+
+// differ records the differences between two trees.
+type differ struct {
+	opts        *compareOptions
+	differences []Difference
+}
+
+func (d *differ) report(path string, expected, actual any) {
+	d.differences = append(d.differences, Difference{Path: path, Expected: format(expected), Actual: format(actual)})
+}
+
+// equal returns whether the trees have no differences.
+func (d *differ) equal(expected, actual any, field string) bool {
+	sub := differ{opts: d.opts}
+	sub.diff("", field, expected, actual)
+	return len(sub.differences) == 0
+}
+
+func (d *differ) diff(path, field string, expected, actual any) {
+	switch e := expected.(type) {
+	case map[string]any:
+		if a, ok := actual.(map[string]any); ok {
+			d.diffObjects(path, e, a)
+			return
+		}
+	case attrMap:
+		if a, ok := actual.(attrMap); ok {
+			d.diffAttributes(path, e, a)
+			return
+		}
+	case []any:
+		if a, ok := actual.([]any); ok {
+			d.diffLists(path, field, e, a)
+			return
+		}
+	default:
+		if expected == actual {
+			return
+		}
+	}
+	d.report(path, expected, actual)
+}
+
+func (d *differ) diffObjects(path string, expected, actual map[string]any) {
+	keys := make([]string, 0, len(expected)+len(actual))
+	for k := range expected {
+		keys = append(keys, k)
+	}
+	for k := range actual {
+		if _, ok := expected[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	slices.Sort(keys)
+	for _, k := range keys {
+		childPath := k
+		if path != "" {
+			childPath = path + "." + k
+		}
+		d.diffChild(childPath, k, expected, actual, k)
+	}
+}
+
+func (d *differ) diffAttributes(path string, expected, actual attrMap) {
+	keys := make([]string, 0, len(expected)+len(actual))
+	for k := range expected {
+		keys = append(keys, k)
+	}
+	for k := range actual {
+		if _, ok := expected[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	slices.Sort(keys)
+	for _, k := range keys {
+		d.diffChild(fmt.Sprintf("%s[%q]", path, k), "value", expected, actual, k)
+	}
+}
+
+// diffChild compares the entries with the given key of two maps, either of them may be missing.
+func (d *differ) diffChild(path, field string, expected, actual map[string]any, key string) {
+	e, inExpected := expected[key]
+	a, inActual := actual[key]
+	switch {
+	case !inExpected:
+		d.report(path, missingValue{}, a)
+	case !inActual:
+		d.report(path, e, missingValue{})
+	default:
+		d.diff(path, field, e, a)
+	}
+}
+
+func (d *differ) diffLists(path, field string, expected, actual []any) {
+	if _, ok := unorderedFields[field]; ok && d.opts.ignoreOrder {
+		d.diffUnorderedLists(path, field, expected, actual)
+		return
+	}
+	for i := 0; i < max(len(expected), len(actual)); i++ {
+		elemPath := fmt.Sprintf("%s[%d]", path, i)
+		switch {
+		case i >= len(expected):
+			d.report(elemPath, missingValue{}, actual[i])
+		case i >= len(actual):
+			d.report(elemPath, expected[i], missingValue{})
+		default:
+			d.diff(elemPath, field, expected[i], actual[i])
+		}
+	}
+}
+
+// diffUnorderedLists matches each element of expected with an equal element of actual. The elements
+// left in expected are then compared with the elements left in actual, in order. The paths use the
+// index of the elements in expected, or in actual for the elements of actual left unmatched.
+func (d *differ) diffUnorderedLists(path, field string, expected, actual []any) {
+	used := make([]bool, len(actual))
+	var unmatched []int
+	for i, e := range expected {
+		found := false
+		for j, a := range actual {
+			if !used[j] && d.equal(e, a, field) {
+				used[j], found = true, true
+				break
+			}
+		}
+		if !found {
+			unmatched = append(unmatched, i)
+		}
+	}
+
+	j := 0
+	for _, i := range unmatched {
+		for j < len(actual) && used[j] {
+			j++
+		}
+		elemPath := fmt.Sprintf("%s[%d]", path, i)
+		if j == len(actual) {
+			d.report(elemPath, expected[i], missingValue{})
+			continue
+		}
+		used[j] = true
+		d.diff(elemPath, field, expected[i], actual[j])
+	}
+	for j, a := range actual {
+		if !used[j] {
+			d.report(fmt.Sprintf("%s[%d]", path, j), missingValue{}, a)
+		}
+	}
+}
+
+// missingValue stands for a value missing from one of the payloads.
+type missingValue struct{}
+
+func format(v any) string {
+	if _, ok := v.(missingValue); ok {
+		return missing
+	}
+	buf, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	if len(buf) > maxValueLength {
+		return string(buf[:maxValueLength]) + "..."
+	}
+	return string(buf)
+}
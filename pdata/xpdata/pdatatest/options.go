@@ -0,0 +1,57 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pdatatest // import "go.opentelemetry.io/collector/pdata/xpdata/pdatatest"
+
+// CompareOption changes how payloads are compared.
+type CompareOption func(*compareOptions)
+
+type compareOptions struct {
+	ignoreOrder            bool
+	ignoreTimestamps       bool
+	ignoredAttributes      map[string]struct{}
+	ignoreResourceGrouping bool
+}
+
+func newCompareOptions(opts []CompareOption) *compareOptions {
+	o := &compareOptions{ignoredAttributes: make(map[string]struct{})}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// IgnoreOrder ignores the order of the resources, scopes, spans, span events and links, log records,
+// metrics, data points, exemplars and profiles. Elements are matched with an equal element when
+// there is one, or with the remaining elements in order otherwise.
+func IgnoreOrder() CompareOption {
+	return func(o *compareOptions) {
+		o.ignoreOrder = true
+	}
+}
+
+// IgnoreTimestamps ignores all the timestamps, e.g. the start and end time of the spans or the
+// time and observed time of the log records.
+func IgnoreTimestamps() CompareOption {
+	return func(o *compareOptions) {
+		o.ignoreTimestamps = true
+	}
+}
+
+// IgnoreAttributes ignores the attributes with the given keys, wherever they are: in resources,
+// scopes, spans, log records, data points, etc.
+func IgnoreAttributes(keys ...string) CompareOption {
+	return func(o *compareOptions) {
+		for _, k := range keys {
+			o.ignoredAttributes[k] = struct{}{}
+		}
+	}
+}
+
+// IgnoreResourceGrouping ignores how the data is grouped by resource and scope: the data of the
+// resources, and of the scopes within a resource, that are equal is merged before comparing.
+func IgnoreResourceGrouping() CompareOption {
+	return func(o *compareOptions) {
+		o.ignoreResourceGrouping = true
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pdatatest // import "go.opentelemetry.io/collector/pdata/xpdata/pdatatest"
+
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+type removeIfSlice[E any] interface {
+	RemoveIf(func(E) bool)
+}
+
+// mergeEqual merges each element of s into the first previous element equal to it, if any.
+func mergeEqual[E any](s removeIfSlice[E], hash func(E) uint64, equal func(E, E) bool, merge func(from, to E)) {
+	kept := make(map[uint64][]E)
+	s.RemoveIf(func(e E) bool {
+		h := hash(e)
+		for _, k := range kept[h] {
+			if equal(k, e) {
+				merge(e, k)
+				return true
+			}
+		}
+		kept[h] = append(kept[h], e)
+		return false
+	})
+}
+
+func equalResources(a, b pcommon.Resource) bool {
+	return a.DroppedAttributesCount() == b.DroppedAttributesCount() && a.Attributes().Equal(b.Attributes())
+}
+
+func equalScopes(a, b pcommon.InstrumentationScope) bool {
+	return a.Name() == b.Name() && a.Version() == b.Version() &&
+		a.DroppedAttributesCount() == b.DroppedAttributesCount() && a.Attributes().Equal(b.Attributes())
+}
+
+// regroupTraces returns a copy of td, where the spans of equal resources and scopes are merged.
+func regroupTraces(td ptrace.Traces) ptrace.Traces {
+	dest := ptrace.NewTraces()
+	td.CopyTo(dest)
+	mergeEqual(dest.ResourceSpans(),
+		func(rs ptrace.ResourceSpans) uint64 { return rs.Resource().Hash() },
+		func(a, b ptrace.ResourceSpans) bool {
+			return a.SchemaUrl() == b.SchemaUrl() && equalResources(a.Resource(), b.Resource())
+		},
+		func(from, to ptrace.ResourceSpans) { from.ScopeSpans().MoveAndAppendTo(to.ScopeSpans()) })
+	for _, rs := range dest.ResourceSpans().All() {
+		mergeEqual(rs.ScopeSpans(),
+			func(ss ptrace.ScopeSpans) uint64 { return ss.Scope().Hash() },
+			func(a, b ptrace.ScopeSpans) bool {
+				return a.SchemaUrl() == b.SchemaUrl() && equalScopes(a.Scope(), b.Scope())
+			},
+			func(from, to ptrace.ScopeSpans) { from.Spans().MoveAndAppendTo(to.Spans()) })
+	}
+	return dest
+}
+
+// regroupMetrics returns a copy of md, where the metrics of equal resources and scopes are merged.
+func regroupMetrics(md pmetric.Metrics) pmetric.Metrics {
+	dest := pmetric.NewMetrics()
+	md.CopyTo(dest)
+	mergeEqual(dest.ResourceMetrics(),
+		func(rm pmetric.ResourceMetrics) uint64 { return rm.Resource().Hash() },
+		func(a, b pmetric.ResourceMetrics) bool {
+			return a.SchemaUrl() == b.SchemaUrl() && equalResources(a.Resource(), b.Resource())
+		},
+		func(from, to pmetric.ResourceMetrics) { from.ScopeMetrics().MoveAndAppendTo(to.ScopeMetrics()) })
+	for _, rm := range dest.ResourceMetrics().All() {
+		mergeEqual(rm.ScopeMetrics(),
+			func(sm pmetric.ScopeMetrics) uint64 { return sm.Scope().Hash() },
+			func(a, b pmetric.ScopeMetrics) bool {
+				return a.SchemaUrl() == b.SchemaUrl() && equalScopes(a.Scope(), b.Scope())
+			},
+			func(from, to pmetric.ScopeMetrics) { from.Metrics().MoveAndAppendTo(to.Metrics()) })
+	}
+	return dest
+}
+
+// regroupLogs returns a copy of ld, where the log records of equal resources and scopes are merged.
+func regroupLogs(ld plog.Logs) plog.Logs {
+	dest := plog.NewLogs()
+	ld.CopyTo(dest)
+	mergeEqual(dest.ResourceLogs(),
+		func(rl plog.ResourceLogs) uint64 { return rl.Resource().Hash() },
+		func(a, b plog.ResourceLogs) bool {
+			return a.SchemaUrl() == b.SchemaUrl() && equalResources(a.Resource(), b.Resource())
+		},
+		func(from, to plog.ResourceLogs) { from.ScopeLogs().MoveAndAppendTo(to.ScopeLogs()) })
+	for _, rl := range dest.ResourceLogs().All() {
+		mergeEqual(rl.ScopeLogs(),
+			func(sl plog.ScopeLogs) uint64 { return sl.Scope().Hash() },
+			func(a, b plog.ScopeLogs) bool {
+				return a.SchemaUrl() == b.SchemaUrl() && equalScopes(a.Scope(), b.Scope())
+			},
+			func(from, to plog.ScopeLogs) { from.LogRecords().MoveAndAppendTo(to.LogRecords()) })
+	}
+	return dest
+}
+
+// regroupProfiles returns a copy of pd, where the profiles of equal resources and scopes are merged.
+func regroupProfiles(pd pprofile.Profiles) pprofile.Profiles {
+	dest := pprofile.NewProfiles()
+	pd.CopyTo(dest)
+	mergeEqual(dest.ResourceProfiles(),
+		func(rp pprofile.ResourceProfiles) uint64 { return rp.Resource().Hash() },
+		func(a, b pprofile.ResourceProfiles) bool {
+			return a.SchemaUrl() == b.SchemaUrl() && equalResources(a.Resource(), b.Resource())
+		},
+		func(from, to pprofile.ResourceProfiles) { from.ScopeProfiles().MoveAndAppendTo(to.ScopeProfiles()) })
+	for _, rp := range dest.ResourceProfiles().All() {
+		mergeEqual(rp.ScopeProfiles(),
+			func(sp pprofile.ScopeProfiles) uint64 { return sp.Scope().Hash() },
+			func(a, b pprofile.ScopeProfiles) bool {
+				return a.SchemaUrl() == b.SchemaUrl() && equalScopes(a.Scope(), b.Scope())
+			},
+			func(from, to pprofile.ScopeProfiles) { from.Profiles().MoveAndAppendTo(to.Profiles()) })
+	}
+	return dest
+}
@@ -0,0 +1,401 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package promtext // import "go.opentelemetry.io/collector/pdata/xpdata/promtext"
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+const (
+	typeCounter   = "counter"
+	typeGauge     = "gauge"
+	typeHistogram = "histogram"
+	typeSummary   = "summary"
+	typeInfo      = "info"
+)
+
+var _ pmetric.Marshaler = Marshaler{}
+
+// Marshaler encodes metrics in a Prometheus text-based exposition format.
+type Marshaler struct {
+	// Format is the exposition format, FormatText by default.
+	Format Format
+	// Timestamps writes the timestamps of the data points, with a millisecond precision.
+	// Scrape endpoints usually omit them, so that Prometheus uses the time of the scrape.
+	Timestamps bool
+}
+
+// family is a metric family. The samples of a family must be written together, the metrics
+// with the same name are merged in the same family.
+type family struct {
+	name    string
+	typ     string
+	help    string
+	samples []byte
+}
+
+type label struct {
+	name, value string
+}
+
+// MarshalMetrics encodes md. The metrics whose name conflicts with a metric of another type
+// are skipped.
+func (m Marshaler) MarshalMetrics(md pmetric.Metrics) ([]byte, error) {
+	e := encoder{Marshaler: m, families: map[string]*family{}}
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		resLabels := e.encodeTargetInfo(rm.Resource())
+		sms := rm.ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			sm := sms.At(j)
+			labels := resLabels
+			if name := sm.Scope().Name(); name != "" {
+				labels = append(labels[:len(labels):len(labels)], label{labelScopeName, name})
+			}
+			if version := sm.Scope().Version(); version != "" {
+				labels = append(labels[:len(labels):len(labels)], label{labelScopeVersion, version})
+			}
+			ms := sm.Metrics()
+			for k := 0; k < ms.Len(); k++ {
+				e.encodeMetric(ms.At(k), labels)
+			}
+		}
+	}
+
+	var b []byte
+	for _, f := range e.order {
+		if len(f.samples) == 0 {
+			continue
+		}
+		name := f.name
+		typ := f.typ
+		if f.typ == typeInfo && m.Format != FormatOpenMetrics {
+			typ = typeGauge
+		} else if m.Format == FormatOpenMetrics && (f.typ == typeCounter || f.typ == typeInfo) {
+			// The OpenMetrics families do not have the suffix of their samples.
+			name = strings.TrimSuffix(strings.TrimSuffix(name, suffixTotal), suffixInfo)
+		}
+		if f.help != "" {
+			b = append(b, "# HELP "...)
+			b = append(b, name...)
+			b = append(b, ' ')
+			b = appendEscaped(b, f.help, m.Format == FormatOpenMetrics)
+			b = append(b, '\n')
+		}
+		b = append(b, "# TYPE "...)
+		b = append(b, name...)
+		b = append(b, ' ')
+		b = append(b, typ...)
+		b = append(b, '\n')
+		b = append(b, f.samples...)
+	}
+	if m.Format == FormatOpenMetrics {
+		b = append(b, "# EOF\n"...)
+	}
+	return b, nil
+}
+
+type encoder struct {
+	Marshaler
+	families map[string]*family
+	order    []*family
+}
+
+// family returns the family named name, or nil if it has another type.
+func (e *encoder) family(name, typ, help string) *family {
+	f, ok := e.families[name]
+	if !ok {
+		f = &family{name: name, typ: typ, help: help}
+		e.families[name] = f
+		e.order = append(e.order, f)
+	}
+	if f.typ != typ {
+		return nil
+	}
+	return f
+}
+
+// encodeTargetInfo encodes the target_info metric of the resource, and returns the job and
+// instance labels of the resource.
+func (e *encoder) encodeTargetInfo(res pcommon.Resource) []label {
+	var labels []label
+	attrs := res.Attributes()
+	serviceName, hasName := attrs.Get("service.name")
+	if hasName {
+		job := serviceName.AsString()
+		if namespace, ok := attrs.Get("service.namespace"); ok {
+			job = namespace.AsString() + "/" + job
+		}
+		labels = append(labels, label{labelJob, job})
+	}
+	if instance, ok := attrs.Get("service.instance.id"); ok {
+		labels = append(labels, label{labelInstance, instance.AsString()})
+	}
+
+	infoAttrs := pcommon.NewMap()
+	attrs.Range(func(k string, v pcommon.Value) bool {
+		switch k {
+		case "service.name", "service.namespace", "service.instance.id":
+		default:
+			v.CopyTo(infoAttrs.PutEmpty(k))
+		}
+		return true
+	})
+	if infoAttrs.Len() > 0 {
+		if f := e.family(targetInfo, typeInfo, "Target metadata"); f != nil {
+			f.samples = e.appendSample(f.samples, targetInfo, e.labels(infoAttrs, labels), label{}, pcommon.Timestamp(0), func(b []byte) []byte {
+				return append(b, '1')
+			})
+		}
+	}
+	return labels
+}
+
+func (e *encoder) encodeMetric(m pmetric.Metric, labels []label) {
+	name := sanitizeMetricName(m.Name())
+	switch m.Type() {
+	case pmetric.MetricTypeGauge:
+		if f := e.family(name, typeGauge, m.Description()); f != nil {
+			e.encodeNumbers(f, name, m.Gauge().DataPoints(), labels)
+		}
+	case pmetric.MetricTypeSum:
+		sum := m.Sum()
+		if sum.AggregationTemporality() != pmetric.AggregationTemporalityCumulative {
+			return
+		}
+		if !sum.IsMonotonic() {
+			if f := e.family(name, typeGauge, m.Description()); f != nil {
+				e.encodeNumbers(f, name, sum.DataPoints(), labels)
+			}
+			return
+		}
+		if !strings.HasSuffix(name, suffixTotal) {
+			name += suffixTotal
+		}
+		if f := e.family(name, typeCounter, m.Description()); f != nil {
+			e.encodeNumbers(f, name, sum.DataPoints(), labels)
+		}
+	case pmetric.MetricTypeHistogram:
+		if m.Histogram().AggregationTemporality() != pmetric.AggregationTemporalityCumulative {
+			return
+		}
+		if f := e.family(name, typeHistogram, m.Description()); f != nil {
+			e.encodeHistograms(f, m.Histogram().DataPoints(), labels)
+		}
+	case pmetric.MetricTypeExponentialHistogram:
+		if m.ExponentialHistogram().AggregationTemporality() != pmetric.AggregationTemporalityCumulative {
+			return
+		}
+		if f := e.family(name, typeHistogram, m.Description()); f != nil {
+			e.encodeExponentialHistograms(f, m.ExponentialHistogram().DataPoints(), labels)
+		}
+	case pmetric.MetricTypeSummary:
+		if f := e.family(name, typeSummary, m.Description()); f != nil {
+			e.encodeSummaries(f, m.Summary().DataPoints(), labels)
+		}
+	}
+}
+
+func (e *encoder) encodeNumbers(f *family, name string, dps pmetric.NumberDataPointSlice, labels []label) {
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		if dp.Flags().NoRecordedValue() {
+			continue
+		}
+		var appendValue func([]byte) []byte
+		switch dp.ValueType() {
+		case pmetric.NumberDataPointValueTypeInt:
+			appendValue = func(b []byte) []byte { return strconv.AppendInt(b, dp.IntValue(), 10) }
+		case pmetric.NumberDataPointValueTypeDouble:
+			appendValue = func(b []byte) []byte { return appendFloat(b, e.Format, dp.DoubleValue()) }
+		default:
+			continue
+		}
+		f.samples = e.appendSample(f.samples, name, e.labels(dp.Attributes(), labels), label{}, dp.Timestamp(), appendValue)
+	}
+}
+
+func (e *encoder) encodeHistograms(f *family, dps pmetric.HistogramDataPointSlice, labels []label) {
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		if dp.Flags().NoRecordedValue() {
+			continue
+		}
+		bounds := dp.ExplicitBounds().AsRaw()
+		counts := dp.BucketCounts().AsRaw()
+		// The bucket counts are optional, and there is a bucket more than bounds, the +Inf one.
+		if len(counts) != len(bounds)+1 {
+			bounds, counts = nil, nil
+		}
+		e.appendHistogram(f, dp.Attributes(), labels, bounds, counts, dp.HasSum(), dp.Sum(), dp.Count(), dp.Timestamp())
+	}
+}
+
+func (e *encoder) encodeExponentialHistograms(f *family, dps pmetric.ExponentialHistogramDataPointSlice, labels []label) {
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		if dp.Flags().NoRecordedValue() {
+			continue
+		}
+		bounds, counts := exponentialBuckets(dp)
+		e.appendHistogram(f, dp.Attributes(), labels, bounds, counts, dp.HasSum(), dp.Sum(), dp.Count(), dp.Timestamp())
+	}
+}
+
+// exponentialBuckets returns the upper bounds and the counts of the buckets of dp, in the
+// ascending order of the bounds.
+func exponentialBuckets(dp pmetric.ExponentialHistogramDataPoint) ([]float64, []uint64) {
+	// The bucket of index i holds the values in (base^i, base^(i+1)], base = 2^(2^-scale).
+	bound := func(i int32) float64 {
+		return math.Exp2(float64(i) * math.Exp2(-float64(dp.Scale())))
+	}
+	neg := dp.Negative()
+	pos := dp.Positive()
+	n := neg.BucketCounts().Len() + pos.BucketCounts().Len() + 2
+	bounds := make([]float64, 0, n)
+	counts := make([]uint64, 0, n)
+	// The negative buckets, from the lowest values, hold the values in [-base^(i+1), -base^i).
+	for i := neg.BucketCounts().Len() - 1; i >= 0; i-- {
+		bounds = append(bounds, -bound(neg.Offset()+int32(i)))
+		counts = append(counts, neg.BucketCounts().At(i))
+	}
+	if dp.ZeroCount() > 0 || neg.BucketCounts().Len() > 0 {
+		bounds = append(bounds, dp.ZeroThreshold())
+		counts = append(counts, dp.ZeroCount())
+	}
+	for i := 0; i < pos.BucketCounts().Len(); i++ {
+		bounds = append(bounds, bound(pos.Offset()+int32(i)+1))
+		counts = append(counts, pos.BucketCounts().At(i))
+	}
+	return bounds, counts
+}
+
+// appendHistogram appends the samples of a histogram. counts[i] is the count of the bucket
+// whose upper bound is bounds[i], the values above the last bound are only counted in count.
+func (e *encoder) appendHistogram(f *family, attrs pcommon.Map, labels []label, bounds []float64, counts []uint64, hasSum bool, sum float64, count uint64, ts pcommon.Timestamp) {
+	ls := e.labels(attrs, labels)
+	var cumulative uint64
+	for i, bound := range bounds {
+		cumulative += counts[i]
+		le := label{labelBucket, string(appendFloat(nil, e.Format, bound))}
+		f.samples = e.appendSample(f.samples, f.name+suffixBucket, ls, le, ts, func(b []byte) []byte {
+			return strconv.AppendUint(b, cumulative, 10)
+		})
+	}
+	f.samples = e.appendSample(f.samples, f.name+suffixBucket, ls, label{labelBucket, "+Inf"}, ts, func(b []byte) []byte {
+		return strconv.AppendUint(b, count, 10)
+	})
+	if hasSum {
+		f.samples = e.appendSample(f.samples, f.name+suffixSum, ls, label{}, ts, func(b []byte) []byte {
+			return appendFloat(b, e.Format, sum)
+		})
+	}
+	f.samples = e.appendSample(f.samples, f.name+suffixCount, ls, label{}, ts, func(b []byte) []byte {
+		return strconv.AppendUint(b, count, 10)
+	})
+}
+
+func (e *encoder) encodeSummaries(f *family, dps pmetric.SummaryDataPointSlice, labels []label) {
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		if dp.Flags().NoRecordedValue() {
+			continue
+		}
+		ls := e.labels(dp.Attributes(), labels)
+		qvs := dp.QuantileValues()
+		for j := 0; j < qvs.Len(); j++ {
+			qv := qvs.At(j)
+			q := label{labelQuantile, string(appendFloat(nil, e.Format, qv.Quantile()))}
+			f.samples = e.appendSample(f.samples, f.name, ls, q, dp.Timestamp(), func(b []byte) []byte {
+				return appendFloat(b, e.Format, qv.Value())
+			})
+		}
+		f.samples = e.appendSample(f.samples, f.name+suffixSum, ls, label{}, dp.Timestamp(), func(b []byte) []byte {
+			return appendFloat(b, e.Format, dp.Sum())
+		})
+		f.samples = e.appendSample(f.samples, f.name+suffixCount, ls, label{}, dp.Timestamp(), func(b []byte) []byte {
+			return strconv.AppendUint(b, dp.Count(), 10)
+		})
+	}
+}
+
+// labels returns the labels of attrs and extra, sorted by name. The values of the attributes
+// whose sanitized names are the same are joined with `;`, the extra labels take precedence.
+func (e *encoder) labels(attrs pcommon.Map, extra []label) []label {
+	keys := make([]string, 0, attrs.Len())
+	attrs.Range(func(k string, _ pcommon.Value) bool {
+		keys = append(keys, k)
+		return true
+	})
+	sort.Strings(keys)
+	values := make(map[string]string, len(keys)+len(extra))
+	for _, k := range keys {
+		v, _ := attrs.Get(k)
+		name := sanitizeLabelName(k)
+		if name == "" {
+			continue
+		}
+		if prev, ok := values[name]; ok {
+			values[name] = prev + ";" + v.AsString()
+		} else {
+			values[name] = v.AsString()
+		}
+	}
+	for _, l := range extra {
+		values[l.name] = l.value
+	}
+	labels := make([]label, 0, len(values))
+	for name, value := range values {
+		labels = append(labels, label{name, value})
+	}
+	sort.Slice(labels, func(i, j int) bool { return labels[i].name < labels[j].name })
+	return labels
+}
+
+// appendSample appends a sample line. The extra label, if named, is written last, e.g. `le`.
+func (e *encoder) appendSample(b []byte, name string, labels []label, extra label, ts pcommon.Timestamp, appendValue func([]byte) []byte) []byte {
+	b = append(b, name...)
+	if len(labels) > 0 || extra.name != "" {
+		b = append(b, '{')
+		for i, l := range labels {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = appendLabel(b, l)
+		}
+		if extra.name != "" {
+			if len(labels) > 0 {
+				b = append(b, ',')
+			}
+			b = appendLabel(b, extra)
+		}
+		b = append(b, '}')
+	}
+	b = append(b, ' ')
+	b = appendValue(b)
+	if e.Timestamps && ts != 0 {
+		b = append(b, ' ')
+		ms := int64(ts) / int64(1e6)
+		if e.Format == FormatOpenMetrics {
+			b = strconv.AppendFloat(b, float64(ms)/1e3, 'f', -1, 64)
+		} else {
+			b = strconv.AppendInt(b, ms, 10)
+		}
+	}
+	return append(b, '\n')
+}
+
+func appendLabel(b []byte, l label) []byte {
+	b = append(b, l.name...)
+	b = append(b, `="`...)
+	b = appendLabelValue(b, l.value)
+	return append(b, '"')
+}
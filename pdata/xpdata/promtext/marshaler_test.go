@@ -0,0 +1,222 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package promtext
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+var testTimestamp = pcommon.NewTimestampFromTime(time.Unix(1700000000, 123000000))
+
+// generateMetrics returns metrics of all the types, with a resource and a scope.
+func generateMetrics() pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr("service.name", "checkout")
+	rm.Resource().Attributes().PutStr("service.namespace", "shop")
+	rm.Resource().Attributes().PutStr("service.instance.id", "pod-1")
+	rm.Resource().Attributes().PutStr("host.name", "node-1")
+	sm := rm.ScopeMetrics().AppendEmpty()
+	sm.Scope().SetName("meter")
+	sm.Scope().SetVersion("1.0")
+	ms := sm.Metrics()
+
+	m := ms.AppendEmpty()
+	m.SetName("http.requests")
+	m.SetDescription("Requests \"served\"\nby the server.")
+	sum := m.SetEmptySum()
+	sum.SetIsMonotonic(true)
+	sum.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	dp := sum.DataPoints().AppendEmpty()
+	dp.SetIntValue(42)
+	dp.SetTimestamp(testTimestamp)
+	dp.Attributes().PutStr("http.method", "GET")
+	dp.Attributes().PutInt("2xx", 1)
+	dp.Attributes().PutStr("path", `/a"b\c`)
+
+	m = ms.AppendEmpty()
+	m.SetName("queue.size")
+	sum = m.SetEmptySum()
+	sum.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	sum.DataPoints().AppendEmpty().SetDoubleValue(1.5)
+
+	m = ms.AppendEmpty()
+	m.SetName("temperature")
+	m.SetEmptyGauge().DataPoints().AppendEmpty().SetDoubleValue(math.Inf(-1))
+	m.Gauge().DataPoints().AppendEmpty().SetDoubleValue(20)
+
+	m = ms.AppendEmpty()
+	m.SetName("latency")
+	hist := m.SetEmptyHistogram()
+	hist.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	hdp := hist.DataPoints().AppendEmpty()
+	hdp.ExplicitBounds().FromRaw([]float64{0.1, 1})
+	hdp.BucketCounts().FromRaw([]uint64{1, 2, 3})
+	hdp.SetCount(6)
+	hdp.SetSum(10.5)
+
+	m = ms.AppendEmpty()
+	m.SetName("size")
+	ehist := m.SetEmptyExponentialHistogram()
+	ehist.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	edp := ehist.DataPoints().AppendEmpty()
+	edp.SetScale(0)
+	edp.SetZeroCount(1)
+	edp.Negative().SetOffset(0)
+	edp.Negative().BucketCounts().FromRaw([]uint64{2})
+	edp.Positive().SetOffset(1)
+	edp.Positive().BucketCounts().FromRaw([]uint64{3, 4})
+	edp.SetCount(10)
+	edp.SetSum(20)
+
+	m = ms.AppendEmpty()
+	m.SetName("rpc.duration")
+	sdp := m.SetEmptySummary().DataPoints().AppendEmpty()
+	qv := sdp.QuantileValues().AppendEmpty()
+	qv.SetQuantile(0.5)
+	qv.SetValue(2)
+	qv = sdp.QuantileValues().AppendEmpty()
+	qv.SetQuantile(0.99)
+	qv.SetValue(4.5)
+	sdp.SetCount(3)
+	sdp.SetSum(7)
+
+	// Not exposed: delta temporality, no recorded value, conflicting type.
+	m = ms.AppendEmpty()
+	m.SetName("delta")
+	sum = m.SetEmptySum()
+	sum.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	sum.DataPoints().AppendEmpty().SetIntValue(1)
+	m = ms.AppendEmpty()
+	m.SetName("stale")
+	gdp := m.SetEmptyGauge().DataPoints().AppendEmpty()
+	gdp.SetFlags(pmetric.DefaultDataPointFlags.WithNoRecordedValue(true))
+	m = ms.AppendEmpty()
+	m.SetName("temperature")
+	m.SetEmptySum().DataPoints().AppendEmpty().SetIntValue(1)
+
+	// Merged with the temperature family of the first scope.
+	m = rm.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	m.SetName("temperature")
+	m.SetEmptyGauge().DataPoints().AppendEmpty().SetIntValue(-3)
+	return md
+}
+
+func TestMarshalText(t *testing.T) {
+	buf, err := Marshaler{Timestamps: true}.MarshalMetrics(generateMetrics())
+	require.NoError(t, err)
+	assert.Equal(t, `# HELP target_info Target metadata
+# TYPE target_info gauge
+target_info{host_name="node-1",instance="pod-1",job="shop/checkout"} 1
+# HELP http_requests_total Requests "served"\nby the server.
+# TYPE http_requests_total counter
+http_requests_total{http_method="GET",instance="pod-1",job="shop/checkout",key_2xx="1",otel_scope_name="meter",otel_scope_version="1.0",path="/a\"b\\c"} 42 1700000000123
+# TYPE queue_size gauge
+queue_size{instance="pod-1",job="shop/checkout",otel_scope_name="meter",otel_scope_version="1.0"} 1.5
+# TYPE temperature gauge
+temperature{instance="pod-1",job="shop/checkout",otel_scope_name="meter",otel_scope_version="1.0"} -Inf
+temperature{instance="pod-1",job="shop/checkout",otel_scope_name="meter",otel_scope_version="1.0"} 20
+temperature{instance="pod-1",job="shop/checkout"} -3
+# TYPE latency histogram
+latency_bucket{instance="pod-1",job="shop/checkout",otel_scope_name="meter",otel_scope_version="1.0",le="0.1"} 1
+latency_bucket{instance="pod-1",job="shop/checkout",otel_scope_name="meter",otel_scope_version="1.0",le="1"} 3
+latency_bucket{instance="pod-1",job="shop/checkout",otel_scope_name="meter",otel_scope_version="1.0",le="+Inf"} 6
+latency_sum{instance="pod-1",job="shop/checkout",otel_scope_name="meter",otel_scope_version="1.0"} 10.5
+latency_count{instance="pod-1",job="shop/checkout",otel_scope_name="meter",otel_scope_version="1.0"} 6
+# TYPE size histogram
+size_bucket{instance="pod-1",job="shop/checkout",otel_scope_name="meter",otel_scope_version="1.0",le="-1"} 2
+size_bucket{instance="pod-1",job="shop/checkout",otel_scope_name="meter",otel_scope_version="1.0",le="0"} 3
+size_bucket{instance="pod-1",job="shop/checkout",otel_scope_name="meter",otel_scope_version="1.0",le="4"} 6
+size_bucket{instance="pod-1",job="shop/checkout",otel_scope_name="meter",otel_scope_version="1.0",le="8"} 10
+size_bucket{instance="pod-1",job="shop/checkout",otel_scope_name="meter",otel_scope_version="1.0",le="+Inf"} 10
+size_sum{instance="pod-1",job="shop/checkout",otel_scope_name="meter",otel_scope_version="1.0"} 20
+size_count{instance="pod-1",job="shop/checkout",otel_scope_name="meter",otel_scope_version="1.0"} 10
+# TYPE rpc_duration summary
+rpc_duration{instance="pod-1",job="shop/checkout",otel_scope_name="meter",otel_scope_version="1.0",quantile="0.5"} 2
+rpc_duration{instance="pod-1",job="shop/checkout",otel_scope_name="meter",otel_scope_version="1.0",quantile="0.99"} 4.5
+rpc_duration_sum{instance="pod-1",job="shop/checkout",otel_scope_name="meter",otel_scope_version="1.0"} 7
+rpc_duration_count{instance="pod-1",job="shop/checkout",otel_scope_name="meter",otel_scope_version="1.0"} 3
+`, string(buf))
+}
+
+func TestMarshalOpenMetrics(t *testing.T) {
+	md := pmetric.NewMetrics()
+	ms := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+	m := ms.AppendEmpty()
+	m.SetName("requests_total")
+	m.SetDescription(`Requests "served"`)
+	sum := m.SetEmptySum()
+	sum.SetIsMonotonic(true)
+	sum.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	dp := sum.DataPoints().AppendEmpty()
+	dp.SetDoubleValue(3)
+	dp.SetTimestamp(testTimestamp)
+	m = ms.AppendEmpty()
+	m.SetName("latency")
+	hist := m.SetEmptyHistogram()
+	hist.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	hdp := hist.DataPoints().AppendEmpty()
+	hdp.ExplicitBounds().FromRaw([]float64{1, 2.5})
+	hdp.BucketCounts().FromRaw([]uint64{1, 0, 1})
+	hdp.SetCount(2)
+	hdp.SetSum(4)
+	hdp.SetTimestamp(testTimestamp)
+
+	marshaler := Marshaler{Format: FormatOpenMetrics, Timestamps: true}
+	buf, err := marshaler.MarshalMetrics(md)
+	require.NoError(t, err)
+	assert.Equal(t, `# HELP requests Requests \"served\"
+# TYPE requests counter
+requests_total 3.0 1700000000.123
+# TYPE latency histogram
+latency_bucket{le="1.0"} 1 1700000000.123
+latency_bucket{le="2.5"} 1 1700000000.123
+latency_bucket{le="+Inf"} 2 1700000000.123
+latency_sum 4.0 1700000000.123
+latency_count 2 1700000000.123
+# EOF
+`, string(buf))
+	assert.Equal(t, "application/openmetrics-text; version=1.0.0; charset=utf-8", marshaler.Format.ContentType())
+	assert.Equal(t, "text/plain; version=0.0.4; charset=utf-8", Marshaler{}.Format.ContentType())
+}
+
+func TestMarshalEmpty(t *testing.T) {
+	buf, err := Marshaler{}.MarshalMetrics(pmetric.NewMetrics())
+	require.NoError(t, err)
+	assert.Empty(t, buf)
+
+	buf, err = Marshaler{Format: FormatOpenMetrics}.MarshalMetrics(pmetric.NewMetrics())
+	require.NoError(t, err)
+	assert.Equal(t, "# EOF\n", string(buf))
+}
+
+func TestSanitize(t *testing.T) {
+	assert.Equal(t, "http_server_duration", sanitizeMetricName("http.server.duration"))
+	assert.Equal(t, "_1xx:ok", sanitizeMetricName("1xx:ok"))
+	assert.Equal(t, "_", sanitizeMetricName(""))
+	assert.Equal(t, "caf__", sanitizeMetricName("café!"))
+
+	assert.Equal(t, "http_method", sanitizeLabelName("http.method"))
+	assert.Equal(t, "key_1xx", sanitizeLabelName("1xx"))
+	assert.Equal(t, "key__reserved", sanitizeLabelName("__reserved"))
+	assert.Equal(t, "_private", sanitizeLabelName("_private"))
+	assert.Equal(t, "a_b", sanitizeLabelName("a:b"))
+}
+
+func TestLabelsCollision(t *testing.T) {
+	attrs := pcommon.NewMap()
+	attrs.PutStr("a.b", "1")
+	attrs.PutStr("a_b", "2")
+	attrs.PutStr("job", "ignored")
+	e := encoder{}
+	assert.Equal(t, []label{{"a_b", "1;2"}, {"job", "svc"}}, e.labels(attrs, []label{{"job", "svc"}}))
+}
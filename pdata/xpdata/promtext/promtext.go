@@ -0,0 +1,158 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package promtext encodes metrics in the Prometheus text exposition format and in the
+// OpenMetrics text format, e.g. to debug metrics or to serve them on simple scrape endpoints.
+//
+// The metrics are converted following the Prometheus compatibility of the OpenTelemetry
+// specification:
+//   - Gauges are exposed as gauges, cumulative monotonic sums as counters, with the `_total`
+//     suffix, and cumulative non-monotonic sums as gauges.
+//   - Cumulative histograms and summaries are exposed as histograms and summaries.
+//   - Cumulative exponential histograms are exposed as classic histograms whose buckets are the
+//     buckets of the exponential histogram, as the text formats do not support native histograms.
+//   - Metrics with the delta temporality are not exposed, Prometheus only supports cumulative metrics.
+//   - The names of the metrics and of the labels are sanitized, the invalid characters are
+//     replaced with `_`.
+//   - The `job` and `instance` labels are set from the service attributes of the resource, and
+//     the other resource attributes are exposed by a `target_info` metric.
+//   - The `otel_scope_name` and `otel_scope_version` labels are set from the instrumentation scope.
+package promtext // import "go.opentelemetry.io/collector/pdata/xpdata/promtext"
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Format is a Prometheus text-based exposition format.
+type Format int
+
+const (
+	// FormatText is the Prometheus text exposition format, version 0.0.4.
+	FormatText Format = iota
+	// FormatOpenMetrics is the OpenMetrics text format, version 1.0.0.
+	FormatOpenMetrics
+)
+
+// ContentType returns the content type of the format, e.g. for the Content-Type HTTP header.
+func (f Format) ContentType() string {
+	if f == FormatOpenMetrics {
+		return "application/openmetrics-text; version=1.0.0; charset=utf-8"
+	}
+	return "text/plain; version=0.0.4; charset=utf-8"
+}
+
+const (
+	labelJob          = "job"
+	labelInstance     = "instance"
+	labelScopeName    = "otel_scope_name"
+	labelScopeVersion = "otel_scope_version"
+	labelBucket       = "le"
+	labelQuantile     = "quantile"
+
+	suffixTotal  = "_total"
+	suffixBucket = "_bucket"
+	suffixSum    = "_sum"
+	suffixCount  = "_count"
+	suffixInfo   = "_info"
+
+	targetInfo = "target_info"
+)
+
+// sanitizeMetricName replaces the characters of name that are invalid in a metric name with `_`.
+// Names starting with a digit are prefixed with `_`.
+func sanitizeMetricName(name string) string {
+	name = sanitize(name, func(_ int, r rune) bool {
+		return r == '_' || r == ':' || isLetter(r) || isDigit(r)
+	})
+	if isDigit(rune(name[0])) {
+		return "_" + name
+	}
+	return name
+}
+
+// sanitizeLabelName replaces the characters of name that are invalid in a label name with `_`.
+// Names starting with a digit are prefixed with `key_`, and the names starting with `__`,
+// reserved by Prometheus, with `key`.
+func sanitizeLabelName(name string) string {
+	if name == "" {
+		return ""
+	}
+	name = sanitize(name, func(_ int, r rune) bool {
+		return r == '_' || isLetter(r) || isDigit(r)
+	})
+	switch {
+	case isDigit(rune(name[0])):
+		return "key_" + name
+	case strings.HasPrefix(name, "__"):
+		return "key" + name
+	}
+	return name
+}
+
+func sanitize(name string, valid func(i int, r rune) bool) string {
+	if name == "" {
+		return "_"
+	}
+	var sb strings.Builder
+	for i, r := range name {
+		if valid(i, r) {
+			sb.WriteRune(r)
+		} else {
+			sb.WriteByte('_')
+		}
+	}
+	return sb.String()
+}
+
+func isLetter(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+}
+
+func isDigit(r rune) bool {
+	return r >= '0' && r <= '9'
+}
+
+// appendFloat appends the text representation of v. OpenMetrics requires the canonical
+// representation of the floats, which always has a decimal point or an exponent.
+func appendFloat(b []byte, f Format, v float64) []byte {
+	switch {
+	case math.IsInf(v, 1):
+		return append(b, "+Inf"...)
+	case math.IsInf(v, -1):
+		return append(b, "-Inf"...)
+	case math.IsNaN(v):
+		return append(b, "NaN"...)
+	}
+	start := len(b)
+	b = strconv.AppendFloat(b, v, 'g', -1, 64)
+	if f == FormatOpenMetrics && !strings.ContainsAny(string(b[start:]), ".e") {
+		b = append(b, ".0"...)
+	}
+	return b
+}
+
+// appendLabelValue appends v, escaping the backslashes, double quotes and line feeds.
+func appendLabelValue(b []byte, v string) []byte {
+	return appendEscaped(b, v, true)
+}
+
+func appendEscaped(b []byte, v string, quotes bool) []byte {
+	for i := 0; i < len(v); {
+		r, size := utf8.DecodeRuneInString(v[i:])
+		switch {
+		case r == '\\':
+			b = append(b, `\\`...)
+		case r == '\n':
+			b = append(b, `\n`...)
+		case r == '"' && quotes:
+			b = append(b, `\"`...)
+		default:
+			b = append(b, v[i:i+size]...)
+		}
+		i += size
+	}
+	return b
+}
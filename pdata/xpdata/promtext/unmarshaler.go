@@ -0,0 +1,405 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package promtext // import "go.opentelemetry.io/collector/pdata/xpdata/promtext"
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+var errInvalidLine = errors.New("invalid line")
+
+var _ pmetric.Unmarshaler = Unmarshaler{}
+
+// Unmarshaler decodes metrics from a Prometheus text-based exposition format, e.g. to test the
+// metrics encoded by Marshaler.
+//
+// The metrics are decoded in a single ResourceMetrics and ScopeMetrics, all the labels are
+// decoded as attributes of the data points. The counters are decoded as cumulative monotonic
+// sums named without the `_total` suffix, the gauges and the untyped metrics as gauges, and the
+// histograms and summaries as cumulative histograms and summaries. The values of the counters
+// and gauges are decoded as doubles. The exemplars are ignored.
+type Unmarshaler struct {
+	// Format is the exposition format, FormatText by default.
+	Format Format
+}
+
+// UnmarshalMetrics decodes the metrics in buf.
+func (u Unmarshaler) UnmarshalMetrics(buf []byte) (pmetric.Metrics, error) {
+	d := decoder{format: u.Format, md: pmetric.NewMetrics()}
+	d.ms = d.md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+	scanner := bufio.NewScanner(bytes.NewReader(buf))
+	scanner.Buffer(nil, len(buf)+1)
+	for n := 1; scanner.Scan(); n++ {
+		if err := d.decodeLine(scanner.Text()); err != nil {
+			return pmetric.Metrics{}, fmt.Errorf("line %d: %w", n, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return pmetric.Metrics{}, err
+	}
+	return d.md, nil
+}
+
+type decoder struct {
+	format Format
+	md     pmetric.Metrics
+	ms     pmetric.MetricSlice
+
+	// The current metric family.
+	name   string
+	typ    string
+	help   string
+	metric pmetric.Metric
+	// points holds the histogram and summary data points of the current family, by labels.
+	points map[string]any
+}
+
+func (d *decoder) decodeLine(line string) error {
+	if strings.TrimSpace(line) == "" {
+		return nil
+	}
+	if strings.HasPrefix(line, "#") {
+		fields := strings.SplitN(line, " ", 4)
+		if len(fields) < 2 {
+			return nil
+		}
+		switch fields[1] {
+		case "HELP":
+			if len(fields) < 3 {
+				return errInvalidLine
+			}
+			help := ""
+			if len(fields) == 4 {
+				help = unescape(fields[3])
+			}
+			d.startFamily(fields[2])
+			d.help = help
+		case "TYPE":
+			if len(fields) != 4 {
+				return errInvalidLine
+			}
+			d.startFamily(fields[2])
+			d.typ = fields[3]
+		}
+		return nil
+	}
+
+	name, labels, value, ts, err := d.parseSample(line)
+	if err != nil {
+		return err
+	}
+	return d.decodeSample(name, labels, value, ts)
+}
+
+// startFamily starts the family named name, unless it is the current one.
+func (d *decoder) startFamily(name string) {
+	if name == d.name {
+		return
+	}
+	d.name = name
+	d.typ = ""
+	d.help = ""
+	d.metric = pmetric.Metric{}
+	d.points = map[string]any{}
+}
+
+func (d *decoder) decodeSample(name string, labels []label, value float64, ts pcommon.Timestamp) error {
+	suffix, ok := d.sampleSuffix(name)
+	if !ok {
+		// A sample without metadata is untyped.
+		d.startFamily(name)
+	}
+
+	switch d.typ {
+	case typeCounter:
+		if d.metric == (pmetric.Metric{}) {
+			d.metric = d.newMetric(strings.TrimSuffix(d.name, suffixTotal))
+			d.metric.SetEmptySum().SetIsMonotonic(true)
+			d.metric.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+		}
+		dp := d.metric.Sum().DataPoints().AppendEmpty()
+		dp.SetDoubleValue(value)
+		dp.SetTimestamp(ts)
+		putLabels(dp.Attributes(), labels)
+	case typeHistogram:
+		if d.metric == (pmetric.Metric{}) {
+			d.metric = d.newMetric(d.name)
+			d.metric.SetEmptyHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+		}
+		var le string
+		labels, le = removeLabel(labels, labelBucket)
+		key := labelsKey(labels)
+		dp, ok := d.points[key].(pmetric.HistogramDataPoint)
+		if !ok {
+			dp = d.metric.Histogram().DataPoints().AppendEmpty()
+			dp.SetTimestamp(ts)
+			putLabels(dp.Attributes(), labels)
+			d.points[key] = dp
+		}
+		switch suffix {
+		case suffixBucket:
+			bound, err := parseFloat(le)
+			if err != nil {
+				return err
+			}
+			// The buckets are cumulative, the +Inf one is only counted in the count.
+			var previous float64
+			for i := 0; i < dp.BucketCounts().Len(); i++ {
+				previous += float64(dp.BucketCounts().At(i))
+			}
+			if !math.IsInf(bound, 1) {
+				dp.ExplicitBounds().Append(bound)
+			}
+			dp.BucketCounts().Append(uint64(value - previous))
+		case suffixSum:
+			dp.SetSum(value)
+		case suffixCount:
+			dp.SetCount(uint64(value))
+		default:
+			return errInvalidLine
+		}
+	case typeSummary:
+		if d.metric == (pmetric.Metric{}) {
+			d.metric = d.newMetric(d.name)
+			d.metric.SetEmptySummary()
+		}
+		var quantile string
+		labels, quantile = removeLabel(labels, labelQuantile)
+		key := labelsKey(labels)
+		dp, ok := d.points[key].(pmetric.SummaryDataPoint)
+		if !ok {
+			dp = d.metric.Summary().DataPoints().AppendEmpty()
+			dp.SetTimestamp(ts)
+			putLabels(dp.Attributes(), labels)
+			d.points[key] = dp
+		}
+		switch suffix {
+		case "":
+			q, err := parseFloat(quantile)
+			if err != nil {
+				return err
+			}
+			qv := dp.QuantileValues().AppendEmpty()
+			qv.SetQuantile(q)
+			qv.SetValue(value)
+		case suffixSum:
+			dp.SetSum(value)
+		case suffixCount:
+			dp.SetCount(uint64(value))
+		default:
+			return errInvalidLine
+		}
+	default:
+		if d.metric == (pmetric.Metric{}) {
+			d.metric = d.newMetric(name)
+			d.metric.SetEmptyGauge()
+		}
+		dp := d.metric.Gauge().DataPoints().AppendEmpty()
+		dp.SetDoubleValue(value)
+		dp.SetTimestamp(ts)
+		putLabels(dp.Attributes(), labels)
+	}
+	return nil
+}
+
+// sampleSuffix returns the suffix of the sample named name, e.g. `_bucket`, or false if the
+// sample is not in the current family.
+func (d *decoder) sampleSuffix(name string) (string, bool) {
+	if name == d.name {
+		return "", d.typ != typeInfo
+	}
+	suffix, ok := strings.CutPrefix(name, d.name)
+	if !ok {
+		return "", false
+	}
+	switch d.typ {
+	case typeCounter:
+		return suffix, suffix == suffixTotal
+	case typeInfo:
+		return suffix, suffix == suffixInfo
+	case typeHistogram:
+		return suffix, suffix == suffixBucket || suffix == suffixSum || suffix == suffixCount
+	case typeSummary:
+		return suffix, suffix == suffixSum || suffix == suffixCount
+	}
+	return "", false
+}
+
+func (d *decoder) newMetric(name string) pmetric.Metric {
+	m := d.ms.AppendEmpty()
+	m.SetName(name)
+	m.SetDescription(d.help)
+	return m
+}
+
+// parseSample parses a sample line: name{labels} value [timestamp] [# exemplar].
+func (d *decoder) parseSample(line string) (string, []label, float64, pcommon.Timestamp, error) {
+	end := strings.IndexAny(line, "{ ")
+	if end <= 0 {
+		return "", nil, 0, 0, errInvalidLine
+	}
+	name := line[:end]
+	rest := line[end:]
+	var labels []label
+	if rest[0] == '{' {
+		var err error
+		if labels, rest, err = parseLabels(rest[1:]); err != nil {
+			return "", nil, 0, 0, err
+		}
+	}
+	if i := strings.Index(rest, " # "); i >= 0 {
+		rest = rest[:i]
+	}
+	fields := strings.Fields(rest)
+	if len(fields) == 0 || len(fields) > 2 {
+		return "", nil, 0, 0, errInvalidLine
+	}
+	value, err := parseFloat(fields[0])
+	if err != nil {
+		return "", nil, 0, 0, err
+	}
+	var ts pcommon.Timestamp
+	if len(fields) == 2 {
+		if ts, err = d.parseTimestamp(fields[1]); err != nil {
+			return "", nil, 0, 0, err
+		}
+	}
+	return name, labels, value, ts, nil
+}
+
+func (d *decoder) parseTimestamp(s string) (pcommon.Timestamp, error) {
+	if d.format == FormatOpenMetrics {
+		seconds, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return 0, fmt.Errorf("%w: %w", errInvalidLine, err)
+		}
+		// Round to milliseconds, the precision of Marshaler, before scaling to nanoseconds.
+		return pcommon.Timestamp(int64(math.Round(seconds*1e3)) * 1e6), nil
+	}
+	ms, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %w", errInvalidLine, err)
+	}
+	return pcommon.Timestamp(ms * 1e6), nil
+}
+
+// parseLabels parses the labels following `{`, and returns the rest of the line after `}`.
+func parseLabels(s string) ([]label, string, error) {
+	var labels []label
+	for {
+		s = strings.TrimLeft(s, " ")
+		if strings.HasPrefix(s, "}") {
+			return labels, s[1:], nil
+		}
+		eq := strings.IndexByte(s, '=')
+		if eq <= 0 || len(s) < eq+2 || s[eq+1] != '"' {
+			return nil, "", errInvalidLine
+		}
+		name := strings.TrimSpace(s[:eq])
+		s = s[eq+2:]
+		var value strings.Builder
+		i := 0
+		for ; i < len(s) && s[i] != '"'; i++ {
+			if s[i] != '\\' {
+				value.WriteByte(s[i])
+				continue
+			}
+			i++
+			if i == len(s) {
+				return nil, "", errInvalidLine
+			}
+			switch s[i] {
+			case 'n':
+				value.WriteByte('\n')
+			default:
+				value.WriteByte(s[i])
+			}
+		}
+		if i == len(s) {
+			return nil, "", errInvalidLine
+		}
+		labels = append(labels, label{name, value.String()})
+		s = strings.TrimLeft(s[i+1:], " ")
+		if strings.HasPrefix(s, ",") {
+			s = s[1:]
+		} else if !strings.HasPrefix(s, "}") {
+			return nil, "", errInvalidLine
+		}
+	}
+}
+
+func parseFloat(s string) (float64, error) {
+	switch s {
+	case "+Inf", "Inf":
+		return math.Inf(1), nil
+	case "-Inf":
+		return math.Inf(-1), nil
+	case "NaN":
+		return math.NaN(), nil
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %w", errInvalidLine, err)
+	}
+	return v, nil
+}
+
+// unescape unescapes the backslashes, line feeds and double quotes of a HELP text.
+func unescape(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i == len(s)-1 {
+			sb.WriteByte(s[i])
+			continue
+		}
+		i++
+		if s[i] == 'n' {
+			sb.WriteByte('\n')
+		} else {
+			sb.WriteByte(s[i])
+		}
+	}
+	return sb.String()
+}
+
+func removeLabel(labels []label, name string) ([]label, string) {
+	for i, l := range labels {
+		if l.name == name {
+			return append(labels[:i:i], labels[i+1:]...), l.value
+		}
+	}
+	return labels, ""
+}
+
+// labelsKey returns a key identifying the set of labels.
+func labelsKey(labels []label) string {
+	sorted := append([]label(nil), labels...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].name < sorted[j].name })
+	var sb strings.Builder
+	for _, l := range sorted {
+		sb.WriteString(strconv.Quote(l.name))
+		sb.WriteString(strconv.Quote(l.value))
+	}
+	return sb.String()
+}
+
+func putLabels(attrs pcommon.Map, labels []label) {
+	attrs.EnsureCapacity(len(labels))
+	for _, l := range labels {
+		attrs.PutStr(l.name, l.value)
+	}
+}
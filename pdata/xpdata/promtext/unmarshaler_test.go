@@ -0,0 +1,109 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package promtext
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func TestUnmarshal(t *testing.T) {
+	for _, format := range []Format{FormatText, FormatOpenMetrics} {
+		buf, err := Marshaler{Format: format, Timestamps: true}.MarshalMetrics(generateMetrics())
+		require.NoError(t, err)
+		md, err := Unmarshaler{Format: format}.UnmarshalMetrics(buf)
+		require.NoError(t, err)
+
+		ms := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+		require.Equal(t, 7, ms.Len())
+
+		info := ms.At(0)
+		assert.Equal(t, targetInfo, info.Name())
+		assert.Equal(t, "Target metadata", info.Description())
+		assert.Equal(t, map[string]any{"host_name": "node-1", "instance": "pod-1", "job": "shop/checkout"}, info.Gauge().DataPoints().At(0).Attributes().AsRaw())
+
+		requests := ms.At(1)
+		assert.Equal(t, "http_requests", requests.Name())
+		assert.Equal(t, "Requests \"served\"\nby the server.", requests.Description())
+		assert.True(t, requests.Sum().IsMonotonic())
+		assert.Equal(t, pmetric.AggregationTemporalityCumulative, requests.Sum().AggregationTemporality())
+		dp := requests.Sum().DataPoints().At(0)
+		assert.InDelta(t, 42, dp.DoubleValue(), 0)
+		assert.Equal(t, testTimestamp, dp.Timestamp())
+		assert.Equal(t, map[string]any{
+			"http_method":        "GET",
+			"instance":           "pod-1",
+			"job":                "shop/checkout",
+			"key_2xx":            "1",
+			"otel_scope_name":    "meter",
+			"otel_scope_version": "1.0",
+			"path":               `/a"b\c`,
+		}, dp.Attributes().AsRaw())
+
+		assert.Equal(t, "queue_size", ms.At(2).Name())
+		assert.InDelta(t, 1.5, ms.At(2).Gauge().DataPoints().At(0).DoubleValue(), 0)
+
+		temperature := ms.At(3).Gauge().DataPoints()
+		require.Equal(t, 3, temperature.Len())
+		assert.True(t, math.IsInf(temperature.At(0).DoubleValue(), -1))
+		assert.InDelta(t, 20, temperature.At(1).DoubleValue(), 0)
+		assert.InDelta(t, -3, temperature.At(2).DoubleValue(), 0)
+
+		latency := ms.At(4).Histogram()
+		assert.Equal(t, pmetric.AggregationTemporalityCumulative, latency.AggregationTemporality())
+		hdp := latency.DataPoints().At(0)
+		assert.Equal(t, []float64{0.1, 1}, hdp.ExplicitBounds().AsRaw())
+		assert.Equal(t, []uint64{1, 2, 3}, hdp.BucketCounts().AsRaw())
+		assert.Equal(t, uint64(6), hdp.Count())
+		assert.InDelta(t, 10.5, hdp.Sum(), 0)
+
+		size := ms.At(5).Histogram().DataPoints().At(0)
+		assert.Equal(t, []float64{-1, 0, 4, 8}, size.ExplicitBounds().AsRaw())
+		assert.Equal(t, []uint64{2, 1, 3, 4, 0}, size.BucketCounts().AsRaw())
+		assert.Equal(t, uint64(10), size.Count())
+
+		summary := ms.At(6).Summary().DataPoints().At(0)
+		assert.Equal(t, "rpc_duration", ms.At(6).Name())
+		require.Equal(t, 2, summary.QuantileValues().Len())
+		assert.InDelta(t, 0.99, summary.QuantileValues().At(1).Quantile(), 0)
+		assert.InDelta(t, 4.5, summary.QuantileValues().At(1).Value(), 0)
+		assert.Equal(t, uint64(3), summary.Count())
+		assert.InDelta(t, 7, summary.Sum(), 0)
+	}
+}
+
+func TestUnmarshalUntyped(t *testing.T) {
+	md, err := Unmarshaler{}.UnmarshalMetrics([]byte(`
+# A comment.
+up 1
+up{job="a"} 0 1700000000123 # {trace_id="1"} 1
+`))
+	require.NoError(t, err)
+	ms := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 1, ms.Len())
+	dps := ms.At(0).Gauge().DataPoints()
+	require.Equal(t, 2, dps.Len())
+	assert.Equal(t, testTimestamp.AsTime().UnixMilli(), dps.At(1).Timestamp().AsTime().UnixMilli())
+	assert.Equal(t, map[string]any{"job": "a"}, dps.At(1).Attributes().AsRaw())
+}
+
+func TestUnmarshalInvalid(t *testing.T) {
+	for _, line := range []string{
+		"up",
+		"up{job=\"a\" 1",
+		"up{job=a} 1",
+		"up one",
+		"up 1 soon",
+		"# HELP",
+	} {
+		_, err := Unmarshaler{}.UnmarshalMetrics([]byte("ok 1\n" + line + "\n"))
+		require.ErrorIs(t, err, errInvalidLine, line)
+		assert.Contains(t, err.Error(), "line 2", line)
+	}
+}
@@ -19,7 +19,10 @@ import (
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/internal/telemetry/componentattribute"
 	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/testdata"
+	"go.opentelemetry.io/collector/pipeline"
 	"go.opentelemetry.io/collector/processor"
 	"go.opentelemetry.io/collector/processor/processorhelper/internal/metadatatest"
 	"go.opentelemetry.io/collector/processor/processortest"
@@ -145,6 +148,26 @@ func TestLogs_RecordInOut(t *testing.T) {
 		}, metricdatatest.IgnoreTimestamp())
 }
 
+func TestLogs_RecordInOutPipeline(t *testing.T) {
+	tel := componenttest.NewTelemetry()
+	lp, err := NewLogs(context.Background(), newSettings(tel), &testLogsCfg, consumertest.NewNop(), newTestLProcessor(nil))
+	require.NoError(t, err)
+
+	ctx := componentattribute.ContextWithPipeline(context.Background(), pipeline.NewIDWithName(pipeline.SignalLogs, "1"), nil)
+	assert.NoError(t, lp.Start(context.Background(), componenttest.NewNopHost()))
+	assert.NoError(t, lp.ConsumeLogs(ctx, testdata.GenerateLogs(2)))
+	assert.NoError(t, lp.Shutdown(context.Background()))
+
+	attrs := attribute.NewSet(
+		attribute.String("processor", "processorhelper"),
+		attribute.String("otel.signal", "logs"),
+		attribute.String(componentattribute.PipelineIDKey, "logs/1"))
+	metadatatest.AssertEqualProcessorIncomingItems(t, tel,
+		[]metricdata.DataPoint[int64]{{Value: 2, Attributes: attrs}}, metricdatatest.IgnoreTimestamp())
+	metadatatest.AssertEqualProcessorOutgoingItems(t, tel,
+		[]metricdata.DataPoint[int64]{{Value: 2, Attributes: attrs}}, metricdatatest.IgnoreTimestamp())
+}
+
 func TestLogs_RecordIn_ErrorOut(t *testing.T) {
 	// Regardless of input, return error
 	mockErr := func(_ context.Context, _ plog.Logs) (plog.Logs, error) {
@@ -7,8 +7,8 @@ import (
 	"context"
 
 	"go.opentelemetry.io/otel/attribute"
-	"go.opentelemetry.io/otel/metric"
 
+	"go.opentelemetry.io/collector/internal/telemetry/componentattribute"
 	"go.opentelemetry.io/collector/pipeline"
 	"go.opentelemetry.io/collector/processor"
 	"go.opentelemetry.io/collector/processor/internal"
@@ -18,7 +18,7 @@ import (
 const signalKey = "otel.signal"
 
 type obsReport struct {
-	otelAttrs        metric.MeasurementOption
+	otelAttrs        *componentattribute.PipelineMeasurementOptions
 	telemetryBuilder *metadata.TelemetryBuilder
 }
 
@@ -28,15 +28,16 @@ func newObsReport(set processor.Settings, signal pipeline.Signal) (*obsReport, e
 		return nil, err
 	}
 	return &obsReport{
-		otelAttrs: metric.WithAttributeSet(attribute.NewSet(
+		otelAttrs: componentattribute.NewPipelineMeasurementOptions(
 			attribute.String(internal.ProcessorKey, set.ID.String()),
 			attribute.String(signalKey, signal.String()),
-		)),
+		),
 		telemetryBuilder: telemetryBuilder,
 	}, nil
 }
 
 func (or *obsReport) recordInOut(ctx context.Context, incoming, outgoing int) {
-	or.telemetryBuilder.ProcessorIncomingItems.Add(ctx, int64(incoming), or.otelAttrs)
-	or.telemetryBuilder.ProcessorOutgoingItems.Add(ctx, int64(outgoing), or.otelAttrs)
+	attrs := or.otelAttrs.ForContext(ctx)
+	or.telemetryBuilder.ProcessorIncomingItems.Add(ctx, int64(incoming), attrs)
+	or.telemetryBuilder.ProcessorOutgoingItems.Add(ctx, int64(outgoing), attrs)
 }
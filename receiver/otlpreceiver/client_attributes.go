@@ -0,0 +1,95 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpreceiver // import "go.opentelemetry.io/collector/receiver/otlpreceiver"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/xconsumer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// clientAttributes copies client.Info fields from the request context into resource attributes.
+// Values read from the client always overwrite attributes of the same name set in the payload,
+// so that senders cannot spoof them.
+type clientAttributes struct {
+	cfg *ClientAttributesConfig
+}
+
+// apply writes the configured client fields from info into the given resource.
+func (ca clientAttributes) apply(info client.Info, res pcommon.Resource) {
+	attrs := res.Attributes()
+	if ca.cfg.AddrAttribute != "" && info.Addr != nil {
+		attrs.PutStr(ca.cfg.AddrAttribute, info.Addr.String())
+	}
+	for _, md := range ca.cfg.Metadata {
+		vals := info.Metadata.Get(md.Key)
+		switch len(vals) {
+		case 0:
+			continue
+		case 1:
+			attrs.PutStr(md.attributeName(), vals[0])
+		default:
+			s := attrs.PutEmptySlice(md.attributeName())
+			s.EnsureCapacity(len(vals))
+			for _, v := range vals {
+				s.AppendEmpty().SetStr(v)
+			}
+		}
+	}
+}
+
+func (ca clientAttributes) traces(next consumer.Traces) consumer.Traces {
+	tc, _ := consumer.NewTraces(func(ctx context.Context, td ptrace.Traces) error {
+		info := client.FromContext(ctx)
+		rss := td.ResourceSpans()
+		for i := 0; i < rss.Len(); i++ {
+			ca.apply(info, rss.At(i).Resource())
+		}
+		return next.ConsumeTraces(ctx, td)
+	})
+	return tc
+}
+
+func (ca clientAttributes) metrics(next consumer.Metrics) consumer.Metrics {
+	mc, _ := consumer.NewMetrics(func(ctx context.Context, md pmetric.Metrics) error {
+		info := client.FromContext(ctx)
+		rms := md.ResourceMetrics()
+		for i := 0; i < rms.Len(); i++ {
+			ca.apply(info, rms.At(i).Resource())
+		}
+		return next.ConsumeMetrics(ctx, md)
+	})
+	return mc
+}
+
+func (ca clientAttributes) logs(next consumer.Logs) consumer.Logs {
+	lc, _ := consumer.NewLogs(func(ctx context.Context, ld plog.Logs) error {
+		info := client.FromContext(ctx)
+		rls := ld.ResourceLogs()
+		for i := 0; i < rls.Len(); i++ {
+			ca.apply(info, rls.At(i).Resource())
+		}
+		return next.ConsumeLogs(ctx, ld)
+	})
+	return lc
+}
+
+func (ca clientAttributes) profiles(next xconsumer.Profiles) xconsumer.Profiles {
+	pc, _ := xconsumer.NewProfiles(func(ctx context.Context, pd pprofile.Profiles) error {
+		info := client.FromContext(ctx)
+		rps := pd.ResourceProfiles()
+		for i := 0; i < rps.Len(); i++ {
+			ca.apply(info, rps.At(i).Resource())
+		}
+		return next.ConsumeProfiles(ctx, pd)
+	})
+	return pc
+}
@@ -0,0 +1,108 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpreceiver
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/testdata"
+)
+
+func newTestClientContext() context.Context {
+	return client.NewContext(context.Background(), client.Info{
+		Addr: &net.IPAddr{IP: net.IPv4(10, 1, 2, 3)},
+		Metadata: client.NewMetadata(map[string][]string{
+			"x-tenant": {"acme"},
+			"x-groups": {"a", "b"},
+		}),
+	})
+}
+
+func newTestClientAttributes() clientAttributes {
+	return clientAttributes{cfg: &ClientAttributesConfig{
+		AddrAttribute: "client.address",
+		Metadata: []MetadataAttribute{
+			{Key: "X-Tenant", Attribute: "tenant.id"},
+			{Key: "x-groups"},
+			{Key: "x-missing"},
+		},
+	}}
+}
+
+func assertClientAttributes(t *testing.T, res pcommon.Resource) {
+	attrs := res.Attributes()
+	v, ok := attrs.Get("client.address")
+	require.True(t, ok)
+	assert.Equal(t, "10.1.2.3", v.Str())
+	v, ok = attrs.Get("tenant.id")
+	require.True(t, ok)
+	assert.Equal(t, "acme", v.Str())
+	v, ok = attrs.Get("x-groups")
+	require.True(t, ok)
+	assert.Equal(t, []any{"a", "b"}, v.Slice().AsRaw())
+	_, ok = attrs.Get("x-missing")
+	assert.False(t, ok)
+}
+
+func TestClientAttributesTraces(t *testing.T) {
+	sink := new(consumertest.TracesSink)
+	td := testdata.GenerateTraces(2)
+	td.ResourceSpans().At(0).Resource().Attributes().PutStr("tenant.id", "spoofed")
+	require.NoError(t, newTestClientAttributes().traces(sink).ConsumeTraces(newTestClientContext(), td))
+
+	require.Len(t, sink.AllTraces(), 1)
+	rss := sink.AllTraces()[0].ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		assertClientAttributes(t, rss.At(i).Resource())
+	}
+}
+
+func TestClientAttributesMetrics(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	require.NoError(t, newTestClientAttributes().metrics(sink).ConsumeMetrics(newTestClientContext(), testdata.GenerateMetrics(2)))
+
+	require.Len(t, sink.AllMetrics(), 1)
+	rms := sink.AllMetrics()[0].ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		assertClientAttributes(t, rms.At(i).Resource())
+	}
+}
+
+func TestClientAttributesLogs(t *testing.T) {
+	sink := new(consumertest.LogsSink)
+	require.NoError(t, newTestClientAttributes().logs(sink).ConsumeLogs(newTestClientContext(), testdata.GenerateLogs(2)))
+
+	require.Len(t, sink.AllLogs(), 1)
+	rls := sink.AllLogs()[0].ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		assertClientAttributes(t, rls.At(i).Resource())
+	}
+}
+
+func TestClientAttributesProfiles(t *testing.T) {
+	sink := new(consumertest.ProfilesSink)
+	require.NoError(t, newTestClientAttributes().profiles(sink).ConsumeProfiles(newTestClientContext(), testdata.GenerateProfiles(2)))
+
+	require.Len(t, sink.AllProfiles(), 1)
+	rps := sink.AllProfiles()[0].ResourceProfiles()
+	for i := 0; i < rps.Len(); i++ {
+		assertClientAttributes(t, rps.At(i).Resource())
+	}
+}
+
+func TestClientAttributesNoClientInfo(t *testing.T) {
+	sink := new(consumertest.LogsSink)
+	ld := testdata.GenerateLogs(1)
+	before := ld.ResourceLogs().At(0).Resource().Attributes().Len()
+	require.NoError(t, newTestClientAttributes().logs(sink).ConsumeLogs(context.Background(), ld))
+	assert.Equal(t, before, sink.AllLogs()[0].ResourceLogs().At(0).Resource().Attributes().Len())
+}
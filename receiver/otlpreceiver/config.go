@@ -45,10 +45,70 @@ type Protocols struct {
 	_ struct{}
 }
 
+// MetadataAttribute maps a single client.Info.Metadata key to a resource attribute.
+type MetadataAttribute struct {
+	// Key is the client metadata key to read, for example a request header name.
+	// Keys are matched case-insensitively.
+	Key string `mapstructure:"key"`
+
+	// Attribute is the resource attribute the value is written to. If omitted, Key is used.
+	Attribute string `mapstructure:"attribute,omitempty"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// ClientAttributesConfig configures which fields of client.Info are copied into
+// the resource attributes of every received payload.
+//
+// Metadata is only available when `include_metadata` is enabled on the protocol
+// server configuration.
+type ClientAttributesConfig struct {
+	// Metadata lists the client metadata keys copied into resource attributes.
+	Metadata []MetadataAttribute `mapstructure:"metadata,omitempty"`
+
+	// AddrAttribute is the resource attribute the client address (client.Info.Addr)
+	// is written to. If empty, the address is not copied.
+	AddrAttribute string `mapstructure:"addr_attribute,omitempty"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// Validate checks the client attributes configuration is valid.
+func (cfg *ClientAttributesConfig) Validate() error {
+	seen := make(map[string]struct{}, len(cfg.Metadata)+1)
+	if cfg.AddrAttribute != "" {
+		seen[cfg.AddrAttribute] = struct{}{}
+	}
+	for _, md := range cfg.Metadata {
+		if md.Key == "" {
+			return errors.New("client_attributes: metadata key must not be empty")
+		}
+		attr := md.attributeName()
+		if _, ok := seen[attr]; ok {
+			return fmt.Errorf("client_attributes: duplicate resource attribute %q", attr)
+		}
+		seen[attr] = struct{}{}
+	}
+	return nil
+}
+
+func (md MetadataAttribute) attributeName() string {
+	if md.Attribute != "" {
+		return md.Attribute
+	}
+	return md.Key
+}
+
 // Config defines configuration for OTLP receiver.
 type Config struct {
 	// Protocols is the configuration for the supported protocols, currently gRPC and HTTP (Proto and JSON).
 	Protocols `mapstructure:"protocols"`
+
+	// ClientAttributes copies client.Info fields into resource attributes of all received signals.
+	// If nil, no attributes are added.
+	ClientAttributes *ClientAttributesConfig `mapstructure:"client_attributes,omitempty"`
 }
 
 var (
@@ -238,3 +238,31 @@ func TestUnmarshalConfigEmpty(t *testing.T) {
 	require.NoError(t, confmap.New().Unmarshal(&cfg))
 	assert.EqualError(t, xconfmap.Validate(cfg), "must specify at least one protocol when using the OTLP receiver")
 }
+
+func TestUnmarshalConfigClientAttributes(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "client_attributes.yaml"))
+	require.NoError(t, err)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	require.NoError(t, cm.Unmarshal(&cfg))
+	require.NoError(t, xconfmap.Validate(cfg))
+
+	assert.Equal(t,
+		&ClientAttributesConfig{
+			AddrAttribute: "client.address",
+			Metadata: []MetadataAttribute{
+				{Key: "x-tenant", Attribute: "tenant.id"},
+				{Key: "x-team"},
+			},
+		}, cfg.(*Config).ClientAttributes)
+	assert.True(t, cfg.(*Config).GRPC.IncludeMetadata)
+}
+
+func TestUnmarshalConfigClientAttributesDuplicate(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "client_attributes_duplicate.yaml"))
+	require.NoError(t, err)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	require.NoError(t, cm.Unmarshal(&cfg))
+	assert.ErrorContains(t, xconfmap.Validate(cfg), `client_attributes: duplicate resource attribute "tenant.id"`)
+}
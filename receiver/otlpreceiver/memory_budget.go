@@ -0,0 +1,184 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpreceiver // import "go.opentelemetry.io/collector/receiver/otlpreceiver"
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/encoding"
+	"google.golang.org/grpc/encoding/proto"
+	"google.golang.org/grpc/mem"
+	"google.golang.org/grpc/stats"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"go.opentelemetry.io/collector/internal/reservation"
+	"go.opentelemetry.io/collector/receiver/receiverhelper"
+)
+
+// memoryBudgetRetryDelay is the delay suggested to the clients whose requests are refused
+// because the memory budget is exhausted.
+const memoryBudgetRetryDelay = time.Second
+
+// memoryBudgetExhaustedError returns a RESOURCE_EXHAUSTED status, sent with HTTP code 429.
+// It carries a RetryInfo since clients only retry RESOURCE_EXHAUSTED errors carrying one.
+func memoryBudgetExhaustedError() error {
+	st := status.New(codes.ResourceExhausted, receiverhelper.ErrMemoryBudgetExhausted.Error())
+	if dt, err := st.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(memoryBudgetRetryDelay)}); err == nil {
+		st = dt
+	}
+	return st.Err()
+}
+
+// budgetCodec decodes the gRPC messages with the proto codec. It reserves the size on the wire of the
+// OTLP requests from the memory budget before decoding them, the requests refused because the budget is
+// exhausted are not decoded.
+type budgetCodec struct {
+	encoding.CodecV2
+	budget *receiverhelper.MemoryBudget
+
+	// requests holds the reservations of the decoded requests, until budgetStatsHandler passes them to
+	// the RPCs they belong to.
+	requests sync.Map // OTLP request -> *requestReservation
+}
+
+// requestReservation is the reservation of an OTLP request, made before decoding it.
+type requestReservation struct {
+	// refused is true if the request was not decoded because the memory budget is exhausted.
+	refused bool
+	res     *reservation.Reservation
+}
+
+func newBudgetCodec(budget *receiverhelper.MemoryBudget) *budgetCodec {
+	return &budgetCodec{CodecV2: encoding.GetCodecV2(proto.Name), budget: budget}
+}
+
+func (c *budgetCodec) Unmarshal(data mem.BufferSlice, v any) error {
+	// Only the OTLP requests have a Size method.
+	if _, ok := v.(interface{ Size() int }); !ok {
+		return c.CodecV2.Unmarshal(data, v)
+	}
+	ctx, release, err := c.budget.Reserve(context.Background(), int64(data.Len()))
+	if err != nil {
+		c.requests.Store(v, &requestReservation{refused: true})
+		return nil
+	}
+	if err = c.CodecV2.Unmarshal(data, v); err != nil {
+		release()
+		return err
+	}
+	res := reservation.FromContext(ctx)
+	if res == nil {
+		// The host does not share a memory budget.
+		return nil
+	}
+	c.requests.Store(v, &requestReservation{res: res})
+	return nil
+}
+
+type rpcReservationKey struct{}
+
+// rpcReservation holds the reservation of the request of a unary RPC, from the request being decoded
+// to reserveMemoryInterceptor passing it to the next consumer. The reservation is released when the RPC
+// ends if the request was not passed, e.g. because it was refused by the authenticator.
+type rpcReservation struct {
+	mu  sync.Mutex
+	req *requestReservation
+}
+
+func (rr *rpcReservation) swap(req *requestReservation) *requestReservation {
+	rr.mu.Lock()
+	defer rr.mu.Unlock()
+	prev := rr.req
+	rr.req = req
+	return prev
+}
+
+// budgetStatsHandler passes the reservations made by budgetCodec to the RPCs of the requests. gRPC reports
+// the decoded requests to the stats handlers before calling the interceptors.
+type budgetStatsHandler struct {
+	codec *budgetCodec
+}
+
+func (budgetStatsHandler) TagRPC(ctx context.Context, _ *stats.RPCTagInfo) context.Context {
+	return context.WithValue(ctx, rpcReservationKey{}, &rpcReservation{})
+}
+
+func (h budgetStatsHandler) HandleRPC(ctx context.Context, s stats.RPCStats) {
+	rr, ok := ctx.Value(rpcReservationKey{}).(*rpcReservation)
+	if !ok {
+		return
+	}
+	switch s := s.(type) {
+	case *stats.InPayload:
+		if req, loaded := h.codec.requests.LoadAndDelete(s.Payload); loaded {
+			rr.swap(req.(*requestReservation))
+		}
+	case *stats.End:
+		if req := rr.swap(nil); req != nil && req.res != nil {
+			req.res.Release()
+		}
+	}
+}
+
+func (budgetStatsHandler) TagConn(ctx context.Context, _ *stats.ConnTagInfo) context.Context {
+	return ctx
+}
+
+func (budgetStatsHandler) HandleConn(context.Context, stats.ConnStats) {}
+
+// reserveMemoryInterceptor refuses the gRPC requests not decoded because the memory budget is exhausted,
+// and passes the reservation of the others to the next consumer.
+func (r *otlpReceiver) reserveMemoryInterceptor(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	rr, ok := ctx.Value(rpcReservationKey{}).(*rpcReservation)
+	if !ok {
+		return handler(ctx, req)
+	}
+	rreq := rr.swap(nil)
+	switch {
+	case rreq == nil:
+		return handler(ctx, req)
+	case rreq.refused:
+		return nil, memoryBudgetExhaustedError()
+	}
+	defer rreq.res.Release()
+	return handler(reservation.NewContext(ctx, rreq.res), req)
+}
+
+// reserveAndReadBody reserves the memory of the body of an HTTP request and reads it. The body
+// is not read if its length is known and the memory budget is exhausted. The returned context
+// carries the reservation and the returned function releases it.
+func reserveAndReadBody(resp http.ResponseWriter, req *http.Request, enc encoder, budget *receiverhelper.MemoryBudget) (context.Context, []byte, func(), bool) {
+	// The length of compressed bodies is unknown until they are decompressed.
+	if req.ContentLength >= 0 {
+		ctx, release, err := budget.Reserve(req.Context(), req.ContentLength)
+		if err != nil {
+			writeError(resp, enc, memoryBudgetExhaustedError(), http.StatusTooManyRequests)
+			return nil, nil, nil, false
+		}
+		body, ok := readAndCloseBody(resp, req, enc)
+		if !ok {
+			release()
+			return nil, nil, nil, false
+		}
+		return ctx, body, release, true
+	}
+
+	body, ok := readAndCloseBody(resp, req, enc)
+	if !ok {
+		return nil, nil, nil, false
+	}
+	ctx, release, err := budget.Reserve(req.Context(), int64(len(body)))
+	if err != nil {
+		writeError(resp, enc, memoryBudgetExhaustedError(), http.StatusTooManyRequests)
+		return nil, nil, nil, false
+	}
+	return ctx, body, release, true
+}
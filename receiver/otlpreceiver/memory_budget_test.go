@@ -0,0 +1,205 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otlpreceiver
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/mem"
+	"google.golang.org/grpc/stats"
+	"google.golang.org/grpc/status"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/internal/testutil"
+	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+	"go.opentelemetry.io/collector/pdata/testdata"
+	"go.opentelemetry.io/collector/receiver/receiverhelper"
+)
+
+// memoryBudgetHost shares a memory budget of limit bytes.
+type memoryBudgetHost struct {
+	component.Host
+	limit int64
+
+	mu       sync.Mutex
+	reserved int64
+	reserves int
+	lastSize int64
+}
+
+func (h *memoryBudgetHost) ReserveMemory(_ component.ID, size int64) (func(), bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.reserved+size > h.limit {
+		return nil, false
+	}
+	h.reserved += size
+	h.reserves++
+	h.lastSize = size
+	return func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		h.reserved -= size
+	}, true
+}
+
+func (h *memoryBudgetHost) state() (reserved int64, reserves int) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.reserved, h.reserves
+}
+
+func TestGRPCMemoryBudget(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		limit    int64
+		expected codes.Code
+	}{
+		{name: "accepted", limit: 1 << 30, expected: codes.OK},
+		{name: "refused", limit: 1, expected: codes.ResourceExhausted},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			addr := testutil.GetAvailableLocalAddress(t)
+			sink := newErrOrSinkConsumer()
+			host := &memoryBudgetHost{Host: componenttest.NewNopHost(), limit: tt.limit}
+			recv := newGRPCReceiver(t, componenttest.NewNopTelemetrySettings(), addr, sink)
+			require.NoError(t, recv.Start(context.Background(), host))
+			t.Cleanup(func() { require.NoError(t, recv.Shutdown(context.Background())) })
+
+			cc, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+			require.NoError(t, err)
+			defer func() {
+				assert.NoError(t, cc.Close())
+			}()
+
+			td := testdata.GenerateTraces(10)
+			err = exportTraces(cc, td)
+			st := status.Convert(err)
+			assert.Equal(t, tt.expected, st.Code())
+			reserved, reserves := host.state()
+			assert.Zero(t, reserved)
+			if tt.expected == codes.OK {
+				assert.Equal(t, 1, reserves)
+				// The size of the request on the wire is reserved.
+				wire, merr := ptraceotlp.NewExportRequestFromTraces(td).MarshalProto()
+				require.NoError(t, merr)
+				assert.Equal(t, int64(len(wire)), host.lastSize)
+				assert.Len(t, sink.AllTraces(), 1)
+				return
+			}
+			assert.Contains(t, st.Message(), receiverhelper.ErrMemoryBudgetExhausted.Error())
+			assert.Len(t, st.Details(), 1)
+			assert.Empty(t, sink.AllTraces())
+		})
+	}
+}
+
+// sizedMessage is a message with a Size method, like the OTLP requests.
+type sizedMessage struct {
+	data []byte
+}
+
+func (m *sizedMessage) Reset()                   { m.data = nil }
+func (m *sizedMessage) String() string           { return string(m.data) }
+func (*sizedMessage) ProtoMessage()              {}
+func (m *sizedMessage) Size() int                { return len(m.data) }
+func (m *sizedMessage) Marshal() ([]byte, error) { return m.data, nil }
+func (m *sizedMessage) Unmarshal(b []byte) error {
+	m.data = append([]byte{}, b...)
+	return nil
+}
+
+func TestBudgetCodec(t *testing.T) {
+	host := &memoryBudgetHost{Host: componenttest.NewNopHost(), limit: 1}
+	codec := newBudgetCodec(receiverhelper.NewMemoryBudget(component.MustNewID("otlp"), host))
+	handler := budgetStatsHandler{codec: codec}
+	wire := mem.BufferSlice{mem.SliceBuffer("request")}
+	next := func(context.Context, any) (any, error) { return nil, nil }
+
+	// The request is not decoded when the budget is exhausted.
+	req := &sizedMessage{}
+	ctx := handler.TagRPC(context.Background(), &stats.RPCTagInfo{})
+	require.NoError(t, codec.Unmarshal(wire, req))
+	assert.Empty(t, req.data)
+	handler.HandleRPC(ctx, &stats.InPayload{Payload: req})
+	_, err := (&otlpReceiver{}).reserveMemoryInterceptor(ctx, req, nil, next)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	// The reservation is released once the request was passed to the next consumer.
+	host.limit = 1 << 30
+	req = &sizedMessage{}
+	ctx = handler.TagRPC(context.Background(), &stats.RPCTagInfo{})
+	require.NoError(t, codec.Unmarshal(wire, req))
+	assert.Equal(t, "request", string(req.data))
+	handler.HandleRPC(ctx, &stats.InPayload{Payload: req})
+	_, err = (&otlpReceiver{}).reserveMemoryInterceptor(ctx, req, nil, func(ctx context.Context, _ any) (any, error) {
+		reserved, _ := host.state()
+		assert.Equal(t, int64(len("request")), reserved)
+		return nil, nil
+	})
+	require.NoError(t, err)
+	handler.HandleRPC(ctx, &stats.End{})
+	reserved, _ := host.state()
+	assert.Zero(t, reserved)
+
+	// Or when the RPC ends, e.g. if the authenticator refused the request.
+	req = &sizedMessage{}
+	ctx = handler.TagRPC(context.Background(), &stats.RPCTagInfo{})
+	require.NoError(t, codec.Unmarshal(wire, req))
+	handler.HandleRPC(ctx, &stats.InPayload{Payload: req})
+	reserved, _ = host.state()
+	assert.Equal(t, int64(len("request")), reserved)
+	handler.HandleRPC(ctx, &stats.End{})
+	reserved, _ = host.state()
+	assert.Zero(t, reserved)
+}
+
+func TestHTTPMemoryBudget(t *testing.T) {
+	tracesReq := generateTracesRequest(t)
+	for _, tt := range []struct {
+		name     string
+		encoding string
+		limit    int64
+		expected int
+	}{
+		{name: "accepted", limit: int64(len(tracesReq.protoBytes)), expected: http.StatusOK},
+		{name: "refused", limit: int64(len(tracesReq.protoBytes)) - 1, expected: http.StatusTooManyRequests},
+		{name: "accepted_gzip", encoding: "gzip", limit: int64(len(tracesReq.protoBytes)), expected: http.StatusOK},
+		{name: "refused_gzip", encoding: "gzip", limit: int64(len(tracesReq.protoBytes)) - 1, expected: http.StatusTooManyRequests},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			addr := testutil.GetAvailableLocalAddress(t)
+			sink := newErrOrSinkConsumer()
+			host := &memoryBudgetHost{Host: componenttest.NewNopHost(), limit: tt.limit}
+			recv := newHTTPReceiver(t, componenttest.NewNopTelemetrySettings(), addr, sink)
+			require.NoError(t, recv.Start(context.Background(), host))
+			t.Cleanup(func() { require.NoError(t, recv.Shutdown(context.Background())) })
+
+			req := createHTTPRequest(t, "http://"+addr+defaultTracesURLPath, tt.encoding, pbContentType, tracesReq.protoBytes)
+			resp, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			require.NoError(t, resp.Body.Close())
+			assert.Equal(t, tt.expected, resp.StatusCode)
+
+			reserved, reserves := host.state()
+			assert.Zero(t, reserved)
+			if tt.expected == http.StatusOK {
+				assert.Equal(t, 1, reserves)
+				assert.Len(t, sink.AllTraces(), 1)
+				return
+			}
+			assert.Equal(t, "1", resp.Header.Get("Retry-After"))
+			assert.Empty(t, sink.AllTraces())
+		})
+	}
+}
@@ -15,6 +15,7 @@ import (
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/config/configgrpc"
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/xconsumer"
@@ -47,6 +48,8 @@ type otlpReceiver struct {
 	obsrepGRPC *receiverhelper.ObsReport
 	obsrepHTTP *receiverhelper.ObsReport
 
+	memoryBudget *receiverhelper.MemoryBudget
+
 	settings *receiver.Settings
 }
 
@@ -92,8 +95,13 @@ func (r *otlpReceiver) startGRPCServer(host component.Host) error {
 		return nil
 	}
 
+	// The requests are reserved from the memory budget before being decoded.
+	codec := newBudgetCodec(r.memoryBudget)
 	var err error
-	if r.serverGRPC, err = r.cfg.GRPC.ToServer(context.Background(), host, r.settings.TelemetrySettings); err != nil {
+	if r.serverGRPC, err = r.cfg.GRPC.ToServer(context.Background(), host, r.settings.TelemetrySettings,
+		configgrpc.WithGrpcServerOption(grpc.ForceServerCodecV2(codec)),
+		configgrpc.WithGrpcServerOption(grpc.StatsHandler(budgetStatsHandler{codec: codec})),
+		configgrpc.WithGrpcServerOption(grpc.ChainUnaryInterceptor(r.reserveMemoryInterceptor))); err != nil {
 		return err
 	}
 
@@ -140,28 +148,28 @@ func (r *otlpReceiver) startHTTPServer(ctx context.Context, host component.Host)
 	if r.nextTraces != nil {
 		httpTracesReceiver := trace.New(r.nextTraces, r.obsrepHTTP)
 		httpMux.HandleFunc(r.cfg.HTTP.TracesURLPath, func(resp http.ResponseWriter, req *http.Request) {
-			handleTraces(resp, req, httpTracesReceiver)
+			handleTraces(resp, req, httpTracesReceiver, r.memoryBudget)
 		})
 	}
 
 	if r.nextMetrics != nil {
 		httpMetricsReceiver := metrics.New(r.nextMetrics, r.obsrepHTTP)
 		httpMux.HandleFunc(r.cfg.HTTP.MetricsURLPath, func(resp http.ResponseWriter, req *http.Request) {
-			handleMetrics(resp, req, httpMetricsReceiver)
+			handleMetrics(resp, req, httpMetricsReceiver, r.memoryBudget)
 		})
 	}
 
 	if r.nextLogs != nil {
 		httpLogsReceiver := logs.New(r.nextLogs, r.obsrepHTTP)
 		httpMux.HandleFunc(r.cfg.HTTP.LogsURLPath, func(resp http.ResponseWriter, req *http.Request) {
-			handleLogs(resp, req, httpLogsReceiver)
+			handleLogs(resp, req, httpLogsReceiver, r.memoryBudget)
 		})
 	}
 
 	if r.nextProfiles != nil {
 		httpProfilesReceiver := profiles.New(r.nextProfiles)
 		httpMux.HandleFunc(defaultProfilesURLPath, func(resp http.ResponseWriter, req *http.Request) {
-			handleProfiles(resp, req, httpProfilesReceiver)
+			handleProfiles(resp, req, httpProfilesReceiver, r.memoryBudget)
 		})
 	}
 
@@ -190,6 +198,7 @@ func (r *otlpReceiver) startHTTPServer(ctx context.Context, host component.Host)
 // Start runs the trace receiver on the gRPC server. Currently
 // it also enables the metrics receiver too.
 func (r *otlpReceiver) Start(ctx context.Context, host component.Host) error {
+	r.memoryBudget = receiverhelper.NewMemoryBudget(r.settings.ID, host)
 	if err := r.startGRPCServer(host); err != nil {
 		return err
 	}
@@ -220,17 +229,29 @@ func (r *otlpReceiver) Shutdown(ctx context.Context) error {
 }
 
 func (r *otlpReceiver) registerTraceConsumer(tc consumer.Traces) {
+	if r.cfg.ClientAttributes != nil {
+		tc = clientAttributes{cfg: r.cfg.ClientAttributes}.traces(tc)
+	}
 	r.nextTraces = tc
 }
 
 func (r *otlpReceiver) registerMetricsConsumer(mc consumer.Metrics) {
+	if r.cfg.ClientAttributes != nil {
+		mc = clientAttributes{cfg: r.cfg.ClientAttributes}.metrics(mc)
+	}
 	r.nextMetrics = mc
 }
 
 func (r *otlpReceiver) registerLogsConsumer(lc consumer.Logs) {
+	if r.cfg.ClientAttributes != nil {
+		lc = clientAttributes{cfg: r.cfg.ClientAttributes}.logs(lc)
+	}
 	r.nextLogs = lc
 }
 
 func (r *otlpReceiver) registerProfilesConsumer(tc xconsumer.Profiles) {
+	if r.cfg.ClientAttributes != nil {
+		tc = clientAttributes{cfg: r.cfg.ClientAttributes}.profiles(tc)
+	}
 	r.nextProfiles = tc
 }
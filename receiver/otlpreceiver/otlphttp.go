@@ -19,6 +19,7 @@ import (
 	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/metrics"
 	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/profiles"
 	"go.opentelemetry.io/collector/receiver/otlpreceiver/internal/trace"
+	"go.opentelemetry.io/collector/receiver/receiverhelper"
 )
 
 // Pre-computed status with code=Internal to be used in case of a marshaling error.
@@ -26,16 +27,17 @@ var fallbackMsg = []byte(`{"code": 13, "message": "failed to marshal error messa
 
 const fallbackContentType = "application/json"
 
-func handleTraces(resp http.ResponseWriter, req *http.Request, tracesReceiver *trace.Receiver) {
+func handleTraces(resp http.ResponseWriter, req *http.Request, tracesReceiver *trace.Receiver, budget *receiverhelper.MemoryBudget) {
 	enc, ok := readContentType(resp, req)
 	if !ok {
 		return
 	}
 
-	body, ok := readAndCloseBody(resp, req, enc)
+	ctx, body, release, ok := reserveAndReadBody(resp, req, enc, budget)
 	if !ok {
 		return
 	}
+	defer release()
 
 	otlpReq, err := enc.unmarshalTracesRequest(body)
 	if err != nil {
@@ -43,7 +45,7 @@ func handleTraces(resp http.ResponseWriter, req *http.Request, tracesReceiver *t
 		return
 	}
 
-	otlpResp, err := tracesReceiver.Export(req.Context(), otlpReq)
+	otlpResp, err := tracesReceiver.Export(ctx, otlpReq)
 	if err != nil {
 		writeError(resp, enc, err, http.StatusInternalServerError)
 		return
@@ -57,16 +59,17 @@ func handleTraces(resp http.ResponseWriter, req *http.Request, tracesReceiver *t
 	writeResponse(resp, enc.contentType(), http.StatusOK, msg)
 }
 
-func handleMetrics(resp http.ResponseWriter, req *http.Request, metricsReceiver *metrics.Receiver) {
+func handleMetrics(resp http.ResponseWriter, req *http.Request, metricsReceiver *metrics.Receiver, budget *receiverhelper.MemoryBudget) {
 	enc, ok := readContentType(resp, req)
 	if !ok {
 		return
 	}
 
-	body, ok := readAndCloseBody(resp, req, enc)
+	ctx, body, release, ok := reserveAndReadBody(resp, req, enc, budget)
 	if !ok {
 		return
 	}
+	defer release()
 
 	otlpReq, err := enc.unmarshalMetricsRequest(body)
 	if err != nil {
@@ -74,7 +77,7 @@ func handleMetrics(resp http.ResponseWriter, req *http.Request, metricsReceiver
 		return
 	}
 
-	otlpResp, err := metricsReceiver.Export(req.Context(), otlpReq)
+	otlpResp, err := metricsReceiver.Export(ctx, otlpReq)
 	if err != nil {
 		writeError(resp, enc, err, http.StatusInternalServerError)
 		return
@@ -88,16 +91,17 @@ func handleMetrics(resp http.ResponseWriter, req *http.Request, metricsReceiver
 	writeResponse(resp, enc.contentType(), http.StatusOK, msg)
 }
 
-func handleLogs(resp http.ResponseWriter, req *http.Request, logsReceiver *logs.Receiver) {
+func handleLogs(resp http.ResponseWriter, req *http.Request, logsReceiver *logs.Receiver, budget *receiverhelper.MemoryBudget) {
 	enc, ok := readContentType(resp, req)
 	if !ok {
 		return
 	}
 
-	body, ok := readAndCloseBody(resp, req, enc)
+	ctx, body, release, ok := reserveAndReadBody(resp, req, enc, budget)
 	if !ok {
 		return
 	}
+	defer release()
 
 	otlpReq, err := enc.unmarshalLogsRequest(body)
 	if err != nil {
@@ -105,7 +109,7 @@ func handleLogs(resp http.ResponseWriter, req *http.Request, logsReceiver *logs.
 		return
 	}
 
-	otlpResp, err := logsReceiver.Export(req.Context(), otlpReq)
+	otlpResp, err := logsReceiver.Export(ctx, otlpReq)
 	if err != nil {
 		writeError(resp, enc, err, http.StatusInternalServerError)
 		return
@@ -119,16 +123,17 @@ func handleLogs(resp http.ResponseWriter, req *http.Request, logsReceiver *logs.
 	writeResponse(resp, enc.contentType(), http.StatusOK, msg)
 }
 
-func handleProfiles(resp http.ResponseWriter, req *http.Request, profilesReceiver *profiles.Receiver) {
+func handleProfiles(resp http.ResponseWriter, req *http.Request, profilesReceiver *profiles.Receiver, budget *receiverhelper.MemoryBudget) {
 	enc, ok := readContentType(resp, req)
 	if !ok {
 		return
 	}
 
-	body, ok := readAndCloseBody(resp, req, enc)
+	ctx, body, release, ok := reserveAndReadBody(resp, req, enc, budget)
 	if !ok {
 		return
 	}
+	defer release()
 
 	otlpReq, err := enc.unmarshalProfilesRequest(body)
 	if err != nil {
@@ -136,7 +141,7 @@ func handleProfiles(resp http.ResponseWriter, req *http.Request, profilesReceive
 		return
 	}
 
-	otlpResp, err := profilesReceiver.Export(req.Context(), otlpReq)
+	otlpResp, err := profilesReceiver.Export(ctx, otlpReq)
 	if err != nil {
 		writeError(resp, enc, err, http.StatusInternalServerError)
 		return
@@ -23,15 +23,17 @@ func Tracer(settings component.TelemetrySettings) trace.Tracer {
 // TelemetryBuilder provides an interface for components to report telemetry
 // as defined in metadata and user config.
 type TelemetryBuilder struct {
-	meter                        metric.Meter
-	mu                           sync.Mutex
-	registrations                []metric.Registration
-	ReceiverAcceptedLogRecords   metric.Int64Counter
-	ReceiverAcceptedMetricPoints metric.Int64Counter
-	ReceiverAcceptedSpans        metric.Int64Counter
-	ReceiverRefusedLogRecords    metric.Int64Counter
-	ReceiverRefusedMetricPoints  metric.Int64Counter
-	ReceiverRefusedSpans         metric.Int64Counter
+	meter                         metric.Meter
+	mu                            sync.Mutex
+	registrations                 []metric.Registration
+	ReceiverAcceptedLogRecords    metric.Int64Counter
+	ReceiverAcceptedMetricPoints  metric.Int64Counter
+	ReceiverAcceptedSpans         metric.Int64Counter
+	ReceiverPipelineAcceptedItems metric.Int64Counter
+	ReceiverPipelineRefusedItems  metric.Int64Counter
+	ReceiverRefusedLogRecords     metric.Int64Counter
+	ReceiverRefusedMetricPoints   metric.Int64Counter
+	ReceiverRefusedSpans          metric.Int64Counter
 }
 
 // TelemetryBuilderOption applies changes to default builder.
@@ -81,6 +83,18 @@ func NewTelemetryBuilder(settings component.TelemetrySettings, options ...Teleme
 		metric.WithUnit("{spans}"),
 	)
 	errs = errors.Join(errs, err)
+	builder.ReceiverPipelineAcceptedItems, err = builder.meter.Int64Counter(
+		"otelcol_receiver_pipeline_accepted_items",
+		metric.WithDescription("Number of items successfully pushed into each pipeline the receiver passes the data to, with the `otelcol.pipeline.id` attribute. [alpha]"),
+		metric.WithUnit("{items}"),
+	)
+	errs = errors.Join(errs, err)
+	builder.ReceiverPipelineRefusedItems, err = builder.meter.Int64Counter(
+		"otelcol_receiver_pipeline_refused_items",
+		metric.WithDescription("Number of items that could not be pushed into each pipeline the receiver passes the data to, with the `otelcol.pipeline.id` attribute. [alpha]"),
+		metric.WithUnit("{items}"),
+	)
+	errs = errors.Join(errs, err)
 	builder.ReceiverRefusedLogRecords, err = builder.meter.Int64Counter(
 		"otelcol_receiver_refused_log_records",
 		metric.WithDescription("Number of log records that could not be pushed into the pipeline. [alpha]"),
@@ -0,0 +1,61 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package receiverhelper // import "go.opentelemetry.io/collector/receiver/receiverhelper"
+
+import (
+	"context"
+	"errors"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/internal/reservation"
+)
+
+// ErrMemoryBudgetExhausted is returned by MemoryBudget.Reserve when the memory budget shared by
+// the receivers is exhausted. Receivers should reject the request with a retryable error,
+// e.g. RESOURCE_EXHAUSTED for gRPC or 429 for HTTP.
+var ErrMemoryBudgetExhausted = errors.New("memory budget exhausted, retry later")
+
+// memoryBudgetHost is implemented by the hosts sharing a memory budget between the receivers,
+// see hostcapabilities.MemoryBudget.
+type memoryBudgetHost interface {
+	ReserveMemory(id component.ID, size int64) (release func(), ok bool)
+}
+
+// MemoryBudget reserves the memory of the requests received by a receiver from the budget
+// shared by the receivers of the Collector, see service::memory_budget.
+//
+// Experimental: *NOTE* this type is subject to change or removal in the future.
+type MemoryBudget struct {
+	id   component.ID
+	host memoryBudgetHost
+}
+
+// NewMemoryBudget creates a MemoryBudget for the receiver id. If the host does not share
+// a memory budget, reservations always succeed.
+func NewMemoryBudget(id component.ID, host component.Host) *MemoryBudget {
+	mb := &MemoryBudget{id: id}
+	if h, ok := host.(memoryBudgetHost); ok {
+		mb.host = h
+	}
+	return mb
+}
+
+// Reserve reserves size bytes for a request, before decoding it if its size is known.
+// It returns the context to pass to the next consumer and a function releasing the
+// reservation, to call once the next consumer returns. The exporters queuing the request
+// keep the memory reserved until it is exported.
+//
+// Reserve returns ErrMemoryBudgetExhausted if the budget is exhausted. A nil MemoryBudget never
+// refuses requests.
+func (mb *MemoryBudget) Reserve(ctx context.Context, size int64) (context.Context, func(), error) {
+	if mb == nil || mb.host == nil {
+		return ctx, func() {}, nil
+	}
+	release, ok := mb.host.ReserveMemory(mb.id, size)
+	if !ok {
+		return ctx, nil, ErrMemoryBudgetExhausted
+	}
+	r := reservation.New(release)
+	return reservation.NewContext(ctx, r), r.Release, nil
+}
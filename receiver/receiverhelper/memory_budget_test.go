@@ -0,0 +1,61 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package receiverhelper
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/internal/reservation"
+)
+
+type budgetHost struct {
+	component.Host
+	limit, used int64
+	reservedBy  []component.ID
+}
+
+func (h *budgetHost) ReserveMemory(id component.ID, size int64) (func(), bool) {
+	if h.used+size > h.limit {
+		return nil, false
+	}
+	h.used += size
+	h.reservedBy = append(h.reservedBy, id)
+	return func() { h.used -= size }, true
+}
+
+func TestMemoryBudget(t *testing.T) {
+	host := &budgetHost{Host: componenttest.NewNopHost(), limit: 100}
+	mb := NewMemoryBudget(receiverID, host)
+
+	ctx, release, err := mb.Reserve(context.Background(), 60)
+	require.NoError(t, err)
+	assert.Equal(t, int64(60), host.used)
+	assert.Equal(t, []component.ID{receiverID}, host.reservedBy)
+
+	_, _, err = mb.Reserve(context.Background(), 60)
+	require.ErrorIs(t, err, ErrMemoryBudgetExhausted)
+
+	// A component holding the request keeps the memory reserved after the receiver releases it.
+	r := reservation.FromContext(ctx)
+	require.NotNil(t, r)
+	r.Hold()
+	release()
+	assert.Equal(t, int64(60), host.used)
+	r.Release()
+	assert.Equal(t, int64(0), host.used)
+}
+
+func TestMemoryBudgetNotShared(t *testing.T) {
+	mb := NewMemoryBudget(receiverID, componenttest.NewNopHost())
+	ctx, release, err := mb.Reserve(context.Background(), 1<<40)
+	require.NoError(t, err)
+	assert.Nil(t, reservation.FromContext(ctx))
+	release()
+}
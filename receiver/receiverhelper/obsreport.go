@@ -14,6 +14,7 @@ import (
 	"go.opentelemetry.io/otel/trace"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/internal/telemetry/componentattribute"
 	"go.opentelemetry.io/collector/pipeline"
 	"go.opentelemetry.io/collector/receiver"
 	"go.opentelemetry.io/collector/receiver/receiverhelper/internal"
@@ -28,6 +29,7 @@ type ObsReport struct {
 	tracer         trace.Tracer
 
 	otelAttrs        metric.MeasurementOption
+	pipelineAttrs    *componentattribute.PipelineMeasurementOptions
 	telemetryBuilder *metadata.TelemetryBuilder
 }
 
@@ -67,6 +69,10 @@ func newReceiver(cfg ObsReportSettings) (*ObsReport, error) {
 			attribute.String(internal.ReceiverKey, cfg.ReceiverID.String()),
 			attribute.String(internal.TransportKey, cfg.Transport),
 		)),
+		pipelineAttrs: componentattribute.NewPipelineMeasurementOptions(
+			attribute.String(internal.ReceiverKey, cfg.ReceiverID.String()),
+			attribute.String(internal.TransportKey, cfg.Transport),
+		),
 		telemetryBuilder: telemetryBuilder,
 	}, nil
 }
@@ -147,7 +153,8 @@ func (rec *ObsReport) startOp(receiverCtx context.Context, operationSuffix strin
 	if rec.transport != "" {
 		span.SetAttributes(attribute.String(internal.TransportKey, rec.transport))
 	}
-	return ctx
+	// Collect the outcome of every pipeline the data is passed to, to report the items per pipeline.
+	return componentattribute.ContextWithPipelineOutcomes(ctx)
 }
 
 // endOp records the observability signals at the end of an operation.
@@ -168,6 +175,14 @@ func (rec *ObsReport) endOp(
 	span := trace.SpanFromContext(receiverCtx)
 
 	rec.recordMetrics(receiverCtx, signal, numAccepted, numRefused)
+	// Every pipeline the data is passed to also reports the items it accepted or refused.
+	for _, o := range componentattribute.PipelineOutcomesFromContext(receiverCtx) {
+		if o.Err != nil {
+			rec.telemetryBuilder.ReceiverPipelineRefusedItems.Add(receiverCtx, int64(numReceivedItems), rec.pipelineAttrs.ForPipeline(o.ID))
+		} else {
+			rec.telemetryBuilder.ReceiverPipelineAcceptedItems.Add(receiverCtx, int64(numReceivedItems), rec.pipelineAttrs.ForPipeline(o.ID))
+		}
+	}
 
 	// end span according to errors
 	if span.IsRecording() {
@@ -17,6 +17,8 @@ import (
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/internal/telemetry/componentattribute"
+	"go.opentelemetry.io/collector/pipeline"
 	"go.opentelemetry.io/collector/receiver"
 	"go.opentelemetry.io/collector/receiver/receiverhelper/internal"
 	"go.opentelemetry.io/collector/receiver/receiverhelper/internal/metadatatest"
@@ -287,6 +289,58 @@ func TestReceiveWithLongLivedCtx(t *testing.T) {
 	}
 }
 
+func TestReceivePipelines(t *testing.T) {
+	testTelemetry(t, func(t *testing.T, tt *componenttest.Telemetry) {
+		rec, err := newReceiver(ObsReportSettings{
+			ReceiverID:             receiverID,
+			Transport:              transport,
+			ReceiverCreateSettings: receiver.Settings{ID: receiverID, TelemetrySettings: tt.NewTelemetrySettings(), BuildInfo: component.NewDefaultBuildInfo()},
+		})
+		require.NoError(t, err)
+
+		// The pipelines record the outcome of the data passed to them.
+		ctx := rec.StartLogsOp(context.Background())
+		componentattribute.RecordPipelineOutcome(ctx, pipeline.NewIDWithName(pipeline.SignalLogs, "1"), nil)
+		componentattribute.RecordPipelineOutcome(ctx, pipeline.NewIDWithName(pipeline.SignalLogs, "2"), errFake)
+		rec.EndLogsOp(ctx, format, 7, errFake)
+
+		// The existing series are not split by pipeline.
+		metadatatest.AssertEqualReceiverAcceptedLogRecords(t, tt,
+			[]metricdata.DataPoint[int64]{
+				{
+					Attributes: attribute.NewSet(
+						attribute.String(internal.ReceiverKey, receiverID.String()),
+						attribute.String(internal.TransportKey, transport)),
+					Value: 0,
+				},
+			}, metricdatatest.IgnoreTimestamp(), metricdatatest.IgnoreExemplars())
+		metadatatest.AssertEqualReceiverRefusedLogRecords(t, tt,
+			[]metricdata.DataPoint[int64]{
+				{
+					Attributes: attribute.NewSet(
+						attribute.String(internal.ReceiverKey, receiverID.String()),
+						attribute.String(internal.TransportKey, transport)),
+					Value: 7,
+				},
+			}, metricdatatest.IgnoreTimestamp(), metricdatatest.IgnoreExemplars())
+
+		attrs := func(pipelineID string) attribute.Set {
+			return attribute.NewSet(
+				attribute.String(internal.ReceiverKey, receiverID.String()),
+				attribute.String(internal.TransportKey, transport),
+				attribute.String(componentattribute.PipelineIDKey, pipelineID))
+		}
+		metadatatest.AssertEqualReceiverPipelineAcceptedItems(t, tt,
+			[]metricdata.DataPoint[int64]{
+				{Attributes: attrs("logs/1"), Value: 7},
+			}, metricdatatest.IgnoreTimestamp(), metricdatatest.IgnoreExemplars())
+		metadatatest.AssertEqualReceiverPipelineRefusedItems(t, tt,
+			[]metricdata.DataPoint[int64]{
+				{Attributes: attrs("logs/2"), Value: 7},
+			}, metricdatatest.IgnoreTimestamp(), metricdatatest.IgnoreExemplars())
+	})
+}
+
 func TestCheckReceiverTracesViews(t *testing.T) {
 	tt := componenttest.NewTelemetry()
 	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
@@ -0,0 +1,17 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package telemetryreceiver // import "go.opentelemetry.io/collector/receiver/telemetryreceiver"
+
+import (
+	"go.opentelemetry.io/collector/component"
+)
+
+// Config defines configuration for the telemetry receiver. How the Collector's own
+// telemetry is collected is configured in service::telemetry.
+type Config struct {
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+var _ component.Config = (*Config)(nil)
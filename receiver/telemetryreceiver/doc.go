@@ -0,0 +1,8 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate mdatagen metadata.yaml
+
+// Package telemetryreceiver receives the Collector's own logs, metrics and traces,
+// see service::telemetry::loopback.
+package telemetryreceiver // import "go.opentelemetry.io/collector/receiver/telemetryreceiver"
@@ -0,0 +1,46 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package telemetryreceiver // import "go.opentelemetry.io/collector/receiver/telemetryreceiver"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/receiver/telemetryreceiver/internal/metadata"
+	"go.opentelemetry.io/collector/service/hostcapabilities"
+)
+
+// NewFactory returns a receiver.Factory that constructs telemetry receivers.
+func NewFactory() receiver.Factory {
+	return receiver.NewFactory(
+		metadata.Type,
+		createDefaultConfig,
+		receiver.WithTraces(createTraces, metadata.TracesStability),
+		receiver.WithMetrics(createMetrics, metadata.MetricsStability),
+		receiver.WithLogs(createLogs, metadata.LogsStability))
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{}
+}
+
+func createTraces(_ context.Context, set receiver.Settings, _ component.Config, next consumer.Traces) (receiver.Traces, error) {
+	return newReceiver(func(host hostcapabilities.SelfTelemetry) (func(), error) {
+		return host.RegisterSelfTelemetryTraces(set.ID, next)
+	}), nil
+}
+
+func createMetrics(_ context.Context, set receiver.Settings, _ component.Config, next consumer.Metrics) (receiver.Metrics, error) {
+	return newReceiver(func(host hostcapabilities.SelfTelemetry) (func(), error) {
+		return host.RegisterSelfTelemetryMetrics(set.ID, next)
+	}), nil
+}
+
+func createLogs(_ context.Context, set receiver.Settings, _ component.Config, next consumer.Logs) (receiver.Logs, error) {
+	return newReceiver(func(host hostcapabilities.SelfTelemetry) (func(), error) {
+		return host.RegisterSelfTelemetryLogs(set.ID, next)
+	}), nil
+}
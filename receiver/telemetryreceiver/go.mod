@@ -0,0 +1,126 @@
+module go.opentelemetry.io/collector/receiver/telemetryreceiver
+
+go 1.23.0
+
+replace (
+	go.opentelemetry.io/collector => ../..
+	go.opentelemetry.io/collector/client => ../../client
+	go.opentelemetry.io/collector/component => ../../component
+	go.opentelemetry.io/collector/component/componentstatus => ../../component/componentstatus
+	go.opentelemetry.io/collector/component/componenttest => ../../component/componenttest
+	go.opentelemetry.io/collector/config/configauth => ../../config/configauth
+	go.opentelemetry.io/collector/config/configcompression => ../../config/configcompression
+	go.opentelemetry.io/collector/config/configheaders => ../../config/configheaders
+	go.opentelemetry.io/collector/config/confighttp => ../../config/confighttp
+	go.opentelemetry.io/collector/config/configopaque => ../../config/configopaque
+	go.opentelemetry.io/collector/config/configretry => ../../config/configretry
+	go.opentelemetry.io/collector/config/configtelemetry => ../../config/configtelemetry
+	go.opentelemetry.io/collector/config/configtls => ../../config/configtls
+	go.opentelemetry.io/collector/confmap => ../../confmap
+	go.opentelemetry.io/collector/confmap/xconfmap => ../../confmap/xconfmap
+	go.opentelemetry.io/collector/connector => ../../connector
+	go.opentelemetry.io/collector/connector/connectortest => ../../connector/connectortest
+	go.opentelemetry.io/collector/connector/xconnector => ../../connector/xconnector
+	go.opentelemetry.io/collector/consumer => ../../consumer
+	go.opentelemetry.io/collector/consumer/consumererror => ../../consumer/consumererror
+	go.opentelemetry.io/collector/consumer/consumertest => ../../consumer/consumertest
+	go.opentelemetry.io/collector/consumer/xconsumer => ../../consumer/xconsumer
+	go.opentelemetry.io/collector/exporter => ../../exporter
+	go.opentelemetry.io/collector/exporter/exportertest => ../../exporter/exportertest
+	go.opentelemetry.io/collector/exporter/xexporter => ../../exporter/xexporter
+	go.opentelemetry.io/collector/extension => ../../extension
+	go.opentelemetry.io/collector/extension/extensionauth => ../../extension/extensionauth
+	go.opentelemetry.io/collector/extension/extensionauth/extensionauthtest => ../../extension/extensionauth/extensionauthtest
+	go.opentelemetry.io/collector/extension/extensioncapabilities => ../../extension/extensioncapabilities
+	go.opentelemetry.io/collector/extension/extensiontest => ../../extension/extensiontest
+	go.opentelemetry.io/collector/extension/xextension => ../../extension/xextension
+	go.opentelemetry.io/collector/extension/zpagesextension => ../../extension/zpagesextension
+	go.opentelemetry.io/collector/featuregate => ../../featuregate
+	go.opentelemetry.io/collector/internal/fanoutconsumer => ../../internal/fanoutconsumer
+	go.opentelemetry.io/collector/internal/telemetry => ../../internal/telemetry
+	go.opentelemetry.io/collector/pdata => ../../pdata
+	go.opentelemetry.io/collector/pdata/pprofile => ../../pdata/pprofile
+	go.opentelemetry.io/collector/pdata/testdata => ../../pdata/testdata
+	go.opentelemetry.io/collector/pipeline => ../../pipeline
+	go.opentelemetry.io/collector/pipeline/xpipeline => ../../pipeline/xpipeline
+	go.opentelemetry.io/collector/processor => ../../processor
+	go.opentelemetry.io/collector/processor/processortest => ../../processor/processortest
+	go.opentelemetry.io/collector/processor/xprocessor => ../../processor/xprocessor
+	go.opentelemetry.io/collector/receiver => ../../receiver
+	go.opentelemetry.io/collector/receiver/receivertest => ../../receiver/receivertest
+	go.opentelemetry.io/collector/receiver/xreceiver => ../../receiver/xreceiver
+	go.opentelemetry.io/collector/semconv => ../../semconv
+	go.opentelemetry.io/collector/service => ../../service
+	go.opentelemetry.io/collector/service/hostcapabilities => ../../service/hostcapabilities
+)
+
+replace go.opentelemetry.io/collector/otelcol => ../../otelcol
+
+replace go.opentelemetry.io/collector/confmap/provider/fileprovider => ../../confmap/provider/fileprovider
+
+replace go.opentelemetry.io/collector/confmap/provider/yamlprovider => ../../confmap/provider/yamlprovider
+
+replace go.opentelemetry.io/collector/extension/extensionmiddleware/extensionmiddlewaretest => ../../extension/extensionmiddleware/extensionmiddlewaretest
+
+replace go.opentelemetry.io/collector/config/configmiddleware => ../../config/configmiddleware
+
+replace go.opentelemetry.io/collector/extension/extensionmiddleware => ../../extension/extensionmiddleware
+
+require (
+	github.com/stretchr/testify v1.10.0
+	go.opentelemetry.io/collector/component v1.30.0
+	go.opentelemetry.io/collector/component/componenttest v0.124.0
+	go.opentelemetry.io/collector/confmap v1.30.0
+	go.opentelemetry.io/collector/consumer v1.30.0
+	go.opentelemetry.io/collector/consumer/consumertest v0.124.0
+	go.opentelemetry.io/collector/pdata v1.30.0
+	go.opentelemetry.io/collector/receiver v1.30.0
+	go.opentelemetry.io/collector/receiver/receivertest v0.124.0
+	go.opentelemetry.io/collector/service/hostcapabilities v0.124.0
+	go.uber.org/goleak v1.3.0
+	go.uber.org/zap v1.27.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/go-logr/logr v1.4.2 // indirect
+	github.com/go-logr/stdr v1.2.2 // indirect
+	github.com/go-viper/mapstructure/v2 v2.2.1 // indirect
+	github.com/gogo/protobuf v1.3.2 // indirect
+	github.com/google/uuid v1.6.0 // indirect
+	github.com/hashicorp/go-version v1.7.0 // indirect
+	github.com/json-iterator/go v1.1.12 // indirect
+	github.com/knadh/koanf/maps v0.1.2 // indirect
+	github.com/knadh/koanf/providers/confmap v1.0.0 // indirect
+	github.com/knadh/koanf/v2 v2.2.0 // indirect
+	github.com/mitchellh/copystructure v1.2.0 // indirect
+	github.com/mitchellh/reflectwalk v1.0.2 // indirect
+	github.com/modern-go/concurrent v0.0.0-20180306012644-bacd9c7ef1dd // indirect
+	github.com/modern-go/reflect2 v1.0.2 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	go.opentelemetry.io/auto/sdk v1.1.0 // indirect
+	go.opentelemetry.io/collector/consumer/consumererror v0.124.0 // indirect
+	go.opentelemetry.io/collector/consumer/xconsumer v0.124.0 // indirect
+	go.opentelemetry.io/collector/featuregate v1.30.0 // indirect
+	go.opentelemetry.io/collector/internal/telemetry v0.124.0 // indirect
+	go.opentelemetry.io/collector/pdata/pprofile v0.124.0 // indirect
+	go.opentelemetry.io/collector/pipeline v0.124.0 // indirect
+	go.opentelemetry.io/collector/receiver/xreceiver v0.124.0 // indirect
+	go.opentelemetry.io/collector/service v0.124.0 // indirect
+	go.opentelemetry.io/contrib/bridges/otelzap v0.10.0 // indirect
+	go.opentelemetry.io/otel v1.35.0 // indirect
+	go.opentelemetry.io/otel/log v0.11.0 // indirect
+	go.opentelemetry.io/otel/metric v1.35.0 // indirect
+	go.opentelemetry.io/otel/sdk v1.35.0 // indirect
+	go.opentelemetry.io/otel/sdk/metric v1.35.0 // indirect
+	go.opentelemetry.io/otel/trace v1.35.0 // indirect
+	go.uber.org/multierr v1.11.0 // indirect
+	golang.org/x/net v0.39.0 // indirect
+	golang.org/x/sys v0.32.0 // indirect
+	golang.org/x/text v0.24.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20250218202821-56aae31c358a // indirect
+	google.golang.org/grpc v1.71.1 // indirect
+	google.golang.org/protobuf v1.36.6 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+	sigs.k8s.io/yaml v1.4.0 // indirect
+)
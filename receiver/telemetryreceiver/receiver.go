@@ -0,0 +1,47 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package telemetryreceiver // import "go.opentelemetry.io/collector/receiver/telemetryreceiver"
+
+import (
+	"context"
+	"errors"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/service/hostcapabilities"
+)
+
+var errNoSelfTelemetry = errors.New("the host does not send the Collector's own telemetry to receivers")
+
+// telemetryReceiver registers its consumer to the host for one signal.
+type telemetryReceiver struct {
+	register   func(hostcapabilities.SelfTelemetry) (func(), error)
+	unregister func()
+}
+
+func newReceiver(register func(hostcapabilities.SelfTelemetry) (func(), error)) *telemetryReceiver {
+	return &telemetryReceiver{
+		register: register,
+	}
+}
+
+func (r *telemetryReceiver) Start(_ context.Context, host component.Host) error {
+	st, ok := host.(hostcapabilities.SelfTelemetry)
+	if !ok {
+		return errNoSelfTelemetry
+	}
+	unregister, err := r.register(st)
+	if err != nil {
+		return err
+	}
+	r.unregister = unregister
+	return nil
+}
+
+func (r *telemetryReceiver) Shutdown(context.Context) error {
+	if r.unregister != nil {
+		r.unregister()
+		r.unregister = nil
+	}
+	return nil
+}
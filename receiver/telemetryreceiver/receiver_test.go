@@ -0,0 +1,93 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package telemetryreceiver
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+	"go.opentelemetry.io/collector/receiver/telemetryreceiver/internal/metadata"
+)
+
+type selfTelemetryHost struct {
+	component.Host
+	err          error
+	registered   map[string]component.ID
+	unregistered int
+}
+
+func (h *selfTelemetryHost) register(signal string, id component.ID) (func(), error) {
+	if h.err != nil {
+		return nil, h.err
+	}
+	h.registered[signal] = id
+	return func() { h.unregistered++ }, nil
+}
+
+func (h *selfTelemetryHost) RegisterSelfTelemetryLogs(id component.ID, _ consumer.Logs) (func(), error) {
+	return h.register("logs", id)
+}
+
+func (h *selfTelemetryHost) RegisterSelfTelemetryMetrics(id component.ID, _ consumer.Metrics) (func(), error) {
+	return h.register("metrics", id)
+}
+
+func (h *selfTelemetryHost) RegisterSelfTelemetryTraces(id component.ID, _ consumer.Traces) (func(), error) {
+	return h.register("traces", id)
+}
+
+func createAll(t *testing.T) map[string]component.Component {
+	factory := NewFactory()
+	set := receivertest.NewNopSettings(metadata.Type)
+	cfg := factory.CreateDefaultConfig()
+
+	logs, err := factory.CreateLogs(context.Background(), set, cfg, consumertest.NewNop())
+	require.NoError(t, err)
+	metrics, err := factory.CreateMetrics(context.Background(), set, cfg, consumertest.NewNop())
+	require.NoError(t, err)
+	traces, err := factory.CreateTraces(context.Background(), set, cfg, consumertest.NewNop())
+	require.NoError(t, err)
+	return map[string]component.Component{"logs": logs, "metrics": metrics, "traces": traces}
+}
+
+func TestReceiver(t *testing.T) {
+	host := &selfTelemetryHost{Host: componenttest.NewNopHost(), registered: map[string]component.ID{}}
+	for signal, rcvr := range createAll(t) {
+		require.NoError(t, rcvr.Start(context.Background(), host), signal)
+		assert.Equal(t, metadata.Type, host.registered[signal].Type())
+		require.NoError(t, rcvr.Shutdown(context.Background()))
+		require.NoError(t, rcvr.Shutdown(context.Background()))
+	}
+	assert.Equal(t, 3, host.unregistered)
+}
+
+func TestReceiverRegisterError(t *testing.T) {
+	host := &selfTelemetryHost{Host: componenttest.NewNopHost(), err: errors.New("disabled")}
+	for signal, rcvr := range createAll(t) {
+		require.EqualError(t, rcvr.Start(context.Background(), host), "disabled", signal)
+		require.NoError(t, rcvr.Shutdown(context.Background()))
+	}
+}
+
+func TestReceiverUnsupportedHost(t *testing.T) {
+	for signal, rcvr := range createAll(t) {
+		require.ErrorIs(t, rcvr.Start(context.Background(), componenttest.NewNopHost()), errNoSelfTelemetry, signal)
+		require.NoError(t, rcvr.Shutdown(context.Background()))
+	}
+}
+
+func TestShutdownWithoutStart(t *testing.T) {
+	for _, rcvr := range createAll(t) {
+		require.NoError(t, rcvr.Shutdown(context.Background()))
+	}
+}
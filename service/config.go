@@ -4,6 +4,10 @@
 package service // import "go.opentelemetry.io/collector/service"
 
 import (
+	"errors"
+	"fmt"
+	"time"
+
 	"go.opentelemetry.io/collector/service/extensions"
 	"go.opentelemetry.io/collector/service/pipelines"
 	"go.opentelemetry.io/collector/service/telemetry"
@@ -19,4 +23,73 @@ type Config struct {
 
 	// Pipelines are the set of data pipelines configured for the service.
 	Pipelines pipelines.Config `mapstructure:"pipelines"`
+
+	// Startup is the configuration of how the service starts its components.
+	Startup StartupConfig `mapstructure:"startup,omitempty"`
+
+	// Shutdown is the configuration of how the service stops its components.
+	Shutdown ShutdownConfig `mapstructure:"shutdown,omitempty"`
+
+	// MemoryBudget is the configuration of the memory shared by the receivers.
+	MemoryBudget MemoryBudgetConfig `mapstructure:"memory_budget,omitempty"`
+}
+
+const mibBytes = 1024 * 1024
+
+// MemoryBudgetConfig defines the memory shared by the receivers for the requests in flight.
+type MemoryBudgetConfig struct {
+	// LimitMiB is the maximum memory, in MiB, reserved by the receivers for the requests being
+	// processed or queued in memory by the exporters. Receivers refuse requests beyond it.
+	// Zero disables the budget.
+	LimitMiB uint32 `mapstructure:"limit_mib"`
+}
+
+// StartupConfig defines how the service starts its components.
+type StartupConfig struct {
+	// ExtensionsReadyTimeout is how long to wait for an extension to report it is ready before
+	// failing to start the extensions and pipeline components referencing it. Zero disables waiting.
+	ExtensionsReadyTimeout time.Duration `mapstructure:"extensions_ready_timeout"`
+}
+
+// Validate checks whether the startup configuration is valid.
+func (cfg *StartupConfig) Validate() error {
+	if cfg.ExtensionsReadyTimeout < 0 {
+		return errors.New("startup::extensions_ready_timeout must not be negative")
+	}
+	return nil
+}
+
+// ShutdownConfig defines how long the service takes to stop its components. A zero timeout means no limit.
+type ShutdownConfig struct {
+	// Timeout limits the time to stop the pipelines and the extensions.
+	Timeout time.Duration `mapstructure:"timeout"`
+	// ReceiversTimeout limits the time to stop the receivers.
+	ReceiversTimeout time.Duration `mapstructure:"receivers_timeout"`
+	// ProcessorsTimeout limits the time to stop the processors and connectors.
+	ProcessorsTimeout time.Duration `mapstructure:"processors_timeout"`
+	// ExportersTimeout limits the time to stop the exporters. Exporters drain their sending queue
+	// until then, and drop the data left in a memory queue.
+	ExportersTimeout time.Duration `mapstructure:"exporters_timeout"`
+	// ExtensionsTimeout limits the time to stop the extensions.
+	ExtensionsTimeout time.Duration `mapstructure:"extensions_timeout"`
+}
+
+// Validate checks whether the shutdown configuration is valid.
+func (cfg *ShutdownConfig) Validate() error {
+	var errs error
+	for _, timeout := range []struct {
+		name  string
+		value time.Duration
+	}{
+		{"timeout", cfg.Timeout},
+		{"receivers_timeout", cfg.ReceiversTimeout},
+		{"processors_timeout", cfg.ProcessorsTimeout},
+		{"exporters_timeout", cfg.ExportersTimeout},
+		{"extensions_timeout", cfg.ExtensionsTimeout},
+	} {
+		if timeout.value < 0 {
+			errs = errors.Join(errs, fmt.Errorf("shutdown::%s must not be negative", timeout.name))
+		}
+	}
+	return errs
 }
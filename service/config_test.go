@@ -76,6 +76,24 @@ func TestConfigValidate(t *testing.T) {
 			},
 			expected: errors.New("collector telemetry metrics reader should exist when metric level is not none"),
 		},
+		{
+			name: "invalid-startup-extensions-ready-timeout",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Startup.ExtensionsReadyTimeout = -time.Second
+				return cfg
+			},
+			expected: errors.New("startup::extensions_ready_timeout must not be negative"),
+		},
+		{
+			name: "invalid-shutdown-timeout",
+			cfgFn: func() *Config {
+				cfg := generateConfig()
+				cfg.Shutdown.ExportersTimeout = -time.Second
+				return cfg
+			},
+			expected: errors.New("shutdown::exporters_timeout must not be negative"),
+		},
 	}
 
 	for _, tt := range testCases {
@@ -135,6 +153,14 @@ func TestConfmapMarshalConfig(t *testing.T) {
 					},
 				},
 			},
+			"loopback": map[string]any{
+				"enabled":          false,
+				"metrics_interval": time.Minute,
+			},
+			"runtime": map[string]any{
+				"max_procs":                0,
+				"max_procs_check_interval": time.Minute,
+			},
 		},
 	}, conf.ToStringMap())
 }
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"net/http"
 	"sort"
+	"time"
 
 	"go.uber.org/multierr"
 	"go.uber.org/zap"
@@ -34,30 +35,55 @@ type Extensions struct {
 	instanceIDs  map[component.ID]*componentstatus.InstanceID
 	extensionIDs []component.ID // start order (and reverse stop order)
 	reporter     status.Reporter
+	readyTimeout time.Duration
+	readiness    map[component.ID]*readiness
 }
 
-// Start starts all extensions.
+// instanceHost is implemented by hosts that provide each component with its own
+// component.Host, through which the component can report its status.
+type instanceHost interface {
+	ForInstance(instanceID *componentstatus.InstanceID) component.Host
+}
+
+// Start starts all extensions. An extension is started once the extensions it depends on are ready.
 func (bes *Extensions) Start(ctx context.Context, host component.Host) error {
 	bes.telemetry.Logger.Info("Starting extensions...")
 	for _, extID := range bes.extensionIDs {
 		extLogger := componentattribute.ZapLoggerWithAttributes(bes.telemetry.Logger,
 			*attribute.Extension(extID).Set())
-		extLogger.Info("Extension is starting...")
 		instanceID := bes.instanceIDs[extID]
 		ext := bes.extMap[extID]
+		if dep, ok := ext.(extensioncapabilities.Dependent); ok {
+			if err := bes.WaitReady(ctx, dep.Dependencies()...); err != nil {
+				extLogger.WithOptions(zap.AddStacktrace(zap.DPanicLevel)).Error("Failed to start extension", zap.Error(err))
+				return fmt.Errorf("failed to start extension %q: %w", extID, err)
+			}
+		}
+		extLogger.Info("Extension is starting...")
 		bes.reporter.ReportStatus(
 			instanceID,
 			componentstatus.NewEvent(componentstatus.StatusStarting),
 		)
-		if err := ext.Start(ctx, host); err != nil {
+		extHost := host
+		if ih, ok := host.(instanceHost); ok {
+			extHost = ih.ForInstance(instanceID)
+		}
+		if err := ext.Start(ctx, extHost); err != nil {
 			bes.reporter.ReportStatus(
 				instanceID,
 				componentstatus.NewPermanentErrorEvent(err),
 			)
 			// We log with zap.AddStacktrace(zap.DPanicLevel) to avoid adding the stack trace to the error log
 			extLogger.WithOptions(zap.AddStacktrace(zap.DPanicLevel)).Error("Failed to start extension", zap.Error(err))
+			bes.readiness[extID].set(err)
 			return err
 		}
+		if reportsReadiness(ext) {
+			// The extension reports StatusOK itself once it is ready.
+			extLogger.Info("Extension started, waiting for it to report it is ready.")
+			continue
+		}
+		bes.readiness[extID].set(nil)
 		bes.reporter.ReportOKIfStarting(instanceID)
 		extLogger.Info("Extension started.")
 	}
@@ -129,6 +155,14 @@ func (bes *Extensions) NotifyConfig(ctx context.Context, conf *confmap.Conf) err
 }
 
 func (bes *Extensions) NotifyComponentStatusChange(source *componentstatus.InstanceID, event *componentstatus.Event) {
+	if source.Kind() == component.KindExtension && bes.instanceIDs[source.ComponentID()] == source {
+		switch event.Status() {
+		case componentstatus.StatusOK:
+			bes.readiness[source.ComponentID()].set(nil)
+		case componentstatus.StatusPermanentError, componentstatus.StatusFatalError:
+			bes.readiness[source.ComponentID()].set(event.Err())
+		}
+	}
 	for _, extID := range bes.extensionIDs {
 		ext := bes.extMap[extID]
 		if sw, ok := ext.(componentstatus.Watcher); ok {
@@ -196,6 +230,14 @@ func WithReporter(reporter status.Reporter) Option {
 	})
 }
 
+// WithReadyTimeout sets how long to wait for an extension to be ready before failing to start
+// the components depending on it. A zero timeout disables waiting.
+func WithReadyTimeout(timeout time.Duration) Option {
+	return optionFunc(func(e *Extensions) {
+		e.readyTimeout = timeout
+	})
+}
+
 // New creates a new Extensions from Config.
 func New(ctx context.Context, set Settings, cfg Config, options ...Option) (*Extensions, error) {
 	exts := &Extensions{
@@ -204,6 +246,7 @@ func New(ctx context.Context, set Settings, cfg Config, options ...Option) (*Ext
 		instanceIDs:  make(map[component.ID]*componentstatus.InstanceID),
 		extensionIDs: make([]component.ID, 0, len(cfg)),
 		reporter:     &nopReporter{},
+		readiness:    make(map[component.ID]*readiness),
 	}
 
 	for _, opt := range options {
@@ -230,6 +273,7 @@ func New(ctx context.Context, set Settings, cfg Config, options ...Option) (*Ext
 
 		exts.extMap[extID] = ext
 		exts.instanceIDs[extID] = instanceID
+		exts.readiness[extID] = newReadiness()
 	}
 	order, err := computeOrder(exts)
 	if err != nil {
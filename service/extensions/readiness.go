@@ -0,0 +1,79 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package extensions // import "go.opentelemetry.io/collector/service/extensions"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension"
+	"go.opentelemetry.io/collector/extension/extensioncapabilities"
+)
+
+// readiness tracks whether an extension is ready to be used by the components depending on it.
+type readiness struct {
+	done chan struct{}
+	once sync.Once
+	err  error
+}
+
+func newReadiness() *readiness {
+	return &readiness{done: make(chan struct{})}
+}
+
+// set marks the extension as ready, or as failed to become ready if err is not nil.
+// Only the first call has an effect.
+func (r *readiness) set(err error) {
+	r.once.Do(func() {
+		r.err = err
+		close(r.done)
+	})
+}
+
+func reportsReadiness(ext extension.Extension) bool {
+	rr, ok := ext.(extensioncapabilities.ReadinessReporter)
+	return ok && rr.ReportsReadiness()
+}
+
+// WaitReady blocks until the given extensions are ready, one of them failed to become ready,
+// the extensions ready timeout expires or ctx is done.
+// IDs that do not belong to a configured extension are ignored. WaitReady returns immediately
+// when the extensions ready timeout is zero.
+func (bes *Extensions) WaitReady(ctx context.Context, ids ...component.ID) error {
+	if bes.readyTimeout == 0 {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, bes.readyTimeout)
+	defer cancel()
+	for _, id := range ids {
+		r, ok := bes.readiness[id]
+		if !ok {
+			continue
+		}
+		select {
+		case <-r.done:
+		default:
+			bes.telemetry.Logger.Info("Waiting for extension to be ready...",
+				zap.String("id", id.String()),
+				zap.Duration("timeout", bes.readyTimeout))
+			select {
+			case <-r.done:
+			case <-ctx.Done():
+				if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+					return fmt.Errorf("extension %q is not ready after %s", id, bes.readyTimeout)
+				}
+				return ctx.Err()
+			}
+		}
+		if r.err != nil {
+			return fmt.Errorf("extension %q failed to become ready: %w", id, r.err)
+		}
+	}
+	return nil
+}
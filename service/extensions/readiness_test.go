@@ -0,0 +1,256 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package extensions
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/extension"
+	"go.opentelemetry.io/collector/extension/extensioncapabilities"
+	"go.opentelemetry.io/collector/service/internal/builders"
+	"go.opentelemetry.io/collector/service/internal/status"
+)
+
+var readinessType = component.MustNewType("readiness")
+
+type readinessExtensionConfig struct {
+	reportsReadiness bool
+	dependencies     []component.ID
+}
+
+// readinessExtension reports it is ready, or failed to become ready, once an error or nil is sent to ready.
+type readinessExtension struct {
+	cfg     readinessExtensionConfig
+	ready   chan error
+	started atomic.Bool
+	done    chan struct{}
+}
+
+var (
+	_ extensioncapabilities.ReadinessReporter = (*readinessExtension)(nil)
+	_ extensioncapabilities.Dependent         = (*readinessExtension)(nil)
+)
+
+func (ext *readinessExtension) Start(_ context.Context, host component.Host) error {
+	ext.started.Store(true)
+	go func() {
+		defer close(ext.done)
+		select {
+		case err := <-ext.ready:
+			if err != nil {
+				componentstatus.ReportStatus(host, componentstatus.NewPermanentErrorEvent(err))
+				return
+			}
+			componentstatus.ReportStatus(host, componentstatus.NewEvent(componentstatus.StatusOK))
+		case <-time.After(time.Second):
+		}
+	}()
+	return nil
+}
+
+func (ext *readinessExtension) Shutdown(context.Context) error {
+	if ext.started.Load() {
+		<-ext.done
+	}
+	return nil
+}
+
+func (ext *readinessExtension) ReportsReadiness() bool {
+	return ext.cfg.reportsReadiness
+}
+
+func (ext *readinessExtension) Dependencies() []component.ID {
+	return ext.cfg.dependencies
+}
+
+// readinessHost gives each extension a host able to report its status.
+type readinessHost struct {
+	component.Host
+	reporter status.Reporter
+}
+
+func (h *readinessHost) ForInstance(instanceID *componentstatus.InstanceID) component.Host {
+	return &readinessInstanceHost{Host: h.Host, reporter: h.reporter, instanceID: instanceID}
+}
+
+type readinessInstanceHost struct {
+	component.Host
+	reporter   status.Reporter
+	instanceID *componentstatus.InstanceID
+}
+
+func (h *readinessInstanceHost) Report(ev *componentstatus.Event) {
+	h.reporter.ReportStatus(h.instanceID, ev)
+}
+
+// newReadinessExtensions creates "readiness/slow", which reports its readiness, and "readiness/dependent",
+// which depends on it.
+func newReadinessExtensions(t *testing.T, readyTimeout time.Duration) (*Extensions, map[component.ID]*readinessExtension, component.Host) {
+	slowID := component.NewIDWithName(readinessType, "slow")
+	dependentID := component.NewIDWithName(readinessType, "dependent")
+	created := make(map[component.ID]*readinessExtension)
+	factory := extension.NewFactory(
+		readinessType,
+		func() component.Config { return readinessExtensionConfig{} },
+		func(_ context.Context, set extension.Settings, cfg component.Config) (extension.Extension, error) {
+			ext := &readinessExtension{
+				cfg:   cfg.(readinessExtensionConfig),
+				ready: make(chan error, 1),
+				done:  make(chan struct{}),
+			}
+			created[set.ID] = ext
+			return ext, nil
+		},
+		component.StabilityLevelDevelopment,
+	)
+
+	var exts *Extensions
+	rep := status.NewReporter(func(id *componentstatus.InstanceID, ev *componentstatus.Event) {
+		exts.NotifyComponentStatusChange(id, ev)
+	}, func(error) {
+		// Invalid transitions, e.g. stopping an extension that was not started, are benign.
+	})
+	exts, err := New(context.Background(), Settings{
+		Telemetry: componenttest.NewNopTelemetrySettings(),
+		BuildInfo: component.NewDefaultBuildInfo(),
+		Extensions: builders.NewExtension(
+			map[component.ID]component.Config{
+				slowID:      readinessExtensionConfig{reportsReadiness: true},
+				dependentID: readinessExtensionConfig{dependencies: []component.ID{slowID}},
+			},
+			map[component.Type]extension.Factory{readinessType: factory}),
+	}, Config{dependentID, slowID}, WithReporter(rep), WithReadyTimeout(readyTimeout))
+	require.NoError(t, err)
+	return exts, created, &readinessHost{Host: componenttest.NewNopHost(), reporter: rep}
+}
+
+func TestStartWaitsForDependencies(t *testing.T) {
+	exts, created, host := newReadinessExtensions(t, time.Minute)
+	slowID := component.NewIDWithName(readinessType, "slow")
+	dependentID := component.NewIDWithName(readinessType, "dependent")
+
+	startErr := make(chan error, 1)
+	go func() {
+		startErr <- exts.Start(context.Background(), host)
+	}()
+
+	assert.Eventually(t, created[slowID].started.Load, time.Second, time.Millisecond)
+	assert.Never(t, created[dependentID].started.Load, 50*time.Millisecond, time.Millisecond)
+
+	created[slowID].ready <- nil
+	require.NoError(t, <-startErr)
+	assert.True(t, created[dependentID].started.Load())
+	require.NoError(t, exts.WaitReady(context.Background(), slowID, dependentID))
+
+	created[dependentID].ready <- nil
+	require.NoError(t, exts.Shutdown(context.Background()))
+}
+
+func TestStartDependencyNotReady(t *testing.T) {
+	exts, created, host := newReadinessExtensions(t, 20*time.Millisecond)
+	slowID := component.NewIDWithName(readinessType, "slow")
+	dependentID := component.NewIDWithName(readinessType, "dependent")
+
+	err := exts.Start(context.Background(), host)
+	require.ErrorContains(t, err, `extension "readiness/slow" is not ready after 20ms`)
+	assert.False(t, created[dependentID].started.Load())
+
+	created[slowID].ready <- nil
+	require.NoError(t, exts.Shutdown(context.Background()))
+}
+
+func TestStartDependencyFailed(t *testing.T) {
+	exts, created, host := newReadinessExtensions(t, time.Minute)
+	slowID := component.NewIDWithName(readinessType, "slow")
+	dependentID := component.NewIDWithName(readinessType, "dependent")
+
+	created[slowID].ready <- assert.AnError
+	err := exts.Start(context.Background(), host)
+	require.ErrorIs(t, err, assert.AnError)
+	require.ErrorContains(t, err, `extension "readiness/slow" failed to become ready`)
+	assert.False(t, created[dependentID].started.Load())
+
+	require.ErrorIs(t, exts.WaitReady(context.Background(), slowID), assert.AnError)
+	require.NoError(t, exts.Shutdown(context.Background()))
+}
+
+func TestStartNoReadyTimeout(t *testing.T) {
+	exts, created, host := newReadinessExtensions(t, 0)
+	slowID := component.NewIDWithName(readinessType, "slow")
+	dependentID := component.NewIDWithName(readinessType, "dependent")
+
+	require.NoError(t, exts.Start(context.Background(), host))
+	assert.True(t, created[dependentID].started.Load())
+	require.NoError(t, exts.WaitReady(context.Background(), slowID))
+
+	created[slowID].ready <- nil
+	created[dependentID].ready <- nil
+	require.NoError(t, exts.Shutdown(context.Background()))
+}
+
+func TestWaitReadyContextCanceled(t *testing.T) {
+	exts, created, host := newReadinessExtensions(t, time.Minute)
+	slowID := component.NewIDWithName(readinessType, "slow")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.ErrorIs(t, exts.Start(ctx, host), context.Canceled)
+	require.ErrorIs(t, exts.WaitReady(ctx, slowID), context.Canceled)
+	// IDs of unknown extensions are ignored.
+	require.NoError(t, exts.WaitReady(ctx, component.MustNewID("unknown")))
+
+	created[slowID].ready <- nil
+	require.NoError(t, exts.Shutdown(context.Background()))
+}
+
+type referencingConfig struct {
+	Auth       *authConfig
+	Storage    *component.ID
+	Middleware []component.ID
+	Named      map[string]component.ID
+	Any        any
+	Payload    []byte
+
+	hidden component.ID
+}
+
+type authConfig struct {
+	AuthenticatorID component.ID
+}
+
+func TestReferenced(t *testing.T) {
+	authID := component.MustNewIDWithName("auth", "a")
+	storageID := component.MustNewID("storage")
+	middlewareID := component.MustNewID("middleware")
+	namedID := component.MustNewIDWithName("middleware", "named")
+	anyID := component.MustNewIDWithName("auth", "any")
+	hiddenID := component.MustNewIDWithName("auth", "hidden")
+
+	exts := &Extensions{extMap: make(map[component.ID]extension.Extension)}
+	for _, id := range []component.ID{authID, storageID, middlewareID, namedID, anyID, hiddenID} {
+		exts.extMap[id] = &statusTestExtension{}
+	}
+
+	cfg := &referencingConfig{
+		Auth:       &authConfig{AuthenticatorID: authID},
+		Storage:    &storageID,
+		Middleware: []component.ID{middlewareID, component.MustNewID("notanextension"), authID},
+		Named:      map[string]component.ID{"named": namedID},
+		Any:        &authConfig{AuthenticatorID: anyID},
+		Payload:    []byte("payload"),
+		hidden:     hiddenID,
+	}
+	assert.ElementsMatch(t, []component.ID{authID, storageID, middlewareID, namedID, anyID}, exts.Referenced(cfg))
+	assert.Empty(t, exts.Referenced(nil))
+	assert.Empty(t, exts.Referenced(&struct{}{}))
+}
@@ -0,0 +1,81 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package extensions // import "go.opentelemetry.io/collector/service/extensions"
+
+import (
+	"reflect"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+var componentIDType = reflect.TypeOf(component.ID{})
+
+// Referenced returns the IDs of the configured extensions referenced by cfg, e.g. the authenticator
+// of a client or server configuration, in the order they are found.
+func (bes *Extensions) Referenced(cfg component.Config) []component.ID {
+	if cfg == nil {
+		return nil
+	}
+	var ids []component.ID
+	seen := make(map[component.ID]bool)
+	visitIDs(reflect.ValueOf(cfg), make(map[uintptr]bool), func(id component.ID) {
+		if _, ok := bes.extMap[id]; ok && !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	})
+	return ids
+}
+
+// visitIDs calls visit for every component.ID reachable from v through exported struct fields,
+// pointers, interfaces, slices, arrays and map values.
+func visitIDs(v reflect.Value, visited map[uintptr]bool, visit func(component.ID)) {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() || visited[v.Pointer()] {
+			return
+		}
+		visited[v.Pointer()] = true
+		visitIDs(v.Elem(), visited, visit)
+	case reflect.Interface:
+		if !v.IsNil() {
+			visitIDs(v.Elem(), visited, visit)
+		}
+	case reflect.Struct:
+		if v.Type() == componentIDType {
+			visit(v.Interface().(component.ID))
+			return
+		}
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() {
+				visitIDs(v.Field(i), visited, visit)
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		if !mayHoldIDs(v.Type().Elem()) {
+			return
+		}
+		for i := 0; i < v.Len(); i++ {
+			visitIDs(v.Index(i), visited, visit)
+		}
+	case reflect.Map:
+		if !mayHoldIDs(v.Type().Elem()) {
+			return
+		}
+		iter := v.MapRange()
+		for iter.Next() {
+			visitIDs(iter.Value(), visited, visit)
+		}
+	}
+}
+
+// mayHoldIDs returns whether values of type t can hold a component.ID, so that
+// collections of scalars, e.g. a []byte, are not walked.
+func mayHoldIDs(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Pointer, reflect.Interface, reflect.Struct, reflect.Slice, reflect.Array, reflect.Map:
+		return true
+	}
+	return false
+}
@@ -31,6 +31,7 @@ require (
 	go.opentelemetry.io/collector/extension/zpagesextension v0.124.0
 	go.opentelemetry.io/collector/featuregate v1.30.0
 	go.opentelemetry.io/collector/internal/fanoutconsumer v0.124.0
+	go.opentelemetry.io/collector/internal/memorylimiter v0.124.0
 	go.opentelemetry.io/collector/internal/telemetry v0.124.0
 	go.opentelemetry.io/collector/otelcol v0.124.0
 	go.opentelemetry.io/collector/pdata v1.30.0
@@ -103,6 +104,7 @@ require (
 	go.opentelemetry.io/collector/client v1.30.0 // indirect
 	go.opentelemetry.io/collector/config/configauth v0.124.0 // indirect
 	go.opentelemetry.io/collector/config/configcompression v1.30.0 // indirect
+	go.opentelemetry.io/collector/config/configheaders v0.0.0-00010101000000-000000000000 // indirect
 	go.opentelemetry.io/collector/config/configmiddleware v0.0.0-00010101000000-000000000000 // indirect
 	go.opentelemetry.io/collector/config/configopaque v1.30.0 // indirect
 	go.opentelemetry.io/collector/config/configtls v1.30.0 // indirect
@@ -191,6 +193,8 @@ replace go.opentelemetry.io/collector/extension/extensioncapabilities => ../exte
 
 replace go.opentelemetry.io/collector/config/configopaque => ../config/configopaque
 
+replace go.opentelemetry.io/collector/config/configheaders => ../config/configheaders
+
 replace go.opentelemetry.io/collector/config/confighttp => ../config/confighttp
 
 replace go.opentelemetry.io/collector/config/configauth => ../config/configauth
@@ -242,3 +246,7 @@ replace go.opentelemetry.io/collector/extension/extensionmiddleware => ../extens
 replace go.opentelemetry.io/collector/config/configmiddleware => ../config/configmiddleware
 
 replace go.opentelemetry.io/collector/extension/extensionmiddleware/extensionmiddlewaretest => ../extension/extensionmiddleware/extensionmiddlewaretest
+
+replace go.opentelemetry.io/collector/internal/reservation => ../internal/reservation
+
+replace go.opentelemetry.io/collector/internal/memorylimiter => ../internal/memorylimiter
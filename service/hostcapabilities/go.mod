@@ -4,6 +4,7 @@ go 1.23.0
 
 require (
 	go.opentelemetry.io/collector/component v1.30.0
+	go.opentelemetry.io/collector/consumer v1.30.0
 	go.opentelemetry.io/collector/pipeline v0.124.0
 	go.opentelemetry.io/collector/service v0.124.0
 )
@@ -14,6 +15,9 @@ require (
 	github.com/gogo/protobuf v1.3.2 // indirect
 	github.com/google/uuid v1.6.0 // indirect
 	github.com/hashicorp/go-version v1.7.0 // indirect
+	github.com/json-iterator/go v1.1.12 // indirect
+	github.com/modern-go/concurrent v0.0.0-20180306012644-bacd9c7ef1dd // indirect
+	github.com/modern-go/reflect2 v1.0.2 // indirect
 	go.opentelemetry.io/auto/sdk v1.1.0 // indirect
 	go.opentelemetry.io/collector/featuregate v1.30.0 // indirect
 	go.opentelemetry.io/collector/internal/telemetry v0.124.0 // indirect
@@ -42,6 +46,7 @@ replace (
 	go.opentelemetry.io/collector/component/componenttest => ../../component/componenttest
 	go.opentelemetry.io/collector/config/configauth => ../../config/configauth
 	go.opentelemetry.io/collector/config/configcompression => ../../config/configcompression
+	go.opentelemetry.io/collector/config/configheaders => ../../config/configheaders
 	go.opentelemetry.io/collector/config/confighttp => ../../config/confighttp
 	go.opentelemetry.io/collector/config/configopaque => ../../config/configopaque
 	go.opentelemetry.io/collector/config/configretry => ../../config/configretry
@@ -7,6 +7,7 @@ package hostcapabilities // import "go.opentelemetry.io/collector/service/hostca
 
 import (
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/pipeline"
 	"go.opentelemetry.io/collector/service/internal/moduleinfo"
 )
@@ -34,3 +35,37 @@ type ComponentFactory interface {
 	// component type
 	GetFactory(kind component.Kind, componentType component.Type) component.Factory
 }
+
+// SelfTelemetry is an interface that may be implemented by the host to send the
+// Collector's own telemetry to a receiver, see service::telemetry::loopback.
+//
+// To avoid loops, the logs and spans emitted by the receiver and by the components
+// downstream of it are not sent to it.
+// Experimental: *NOTE* this interface is subject to change or removal in the future.
+type SelfTelemetry interface {
+	// RegisterSelfTelemetryLogs sends the Collector's own logs to next, the consumer of
+	// the receiver id. The returned function stops sending them.
+	RegisterSelfTelemetryLogs(id component.ID, next consumer.Logs) (func(), error)
+
+	// RegisterSelfTelemetryMetrics sends the Collector's own metrics to next, the consumer of
+	// the receiver id. The returned function stops sending them.
+	RegisterSelfTelemetryMetrics(id component.ID, next consumer.Metrics) (func(), error)
+
+	// RegisterSelfTelemetryTraces sends the Collector's own spans to next, the consumer of
+	// the receiver id. The returned function stops sending them.
+	RegisterSelfTelemetryTraces(id component.ID, next consumer.Traces) (func(), error)
+}
+
+// MemoryBudget is an interface that may be implemented by the host to share a budget of
+// memory between the receivers, see service::memory_budget.
+//
+// Receivers reserve the size of each request, before decoding it when possible, and reject
+// the request when the budget is exhausted. Use receiverhelper.MemoryBudget rather than
+// calling this interface directly, so that the exporters queuing the request keep the
+// memory reserved until it is exported.
+// Experimental: *NOTE* this interface is subject to change or removal in the future.
+type MemoryBudget interface {
+	// ReserveMemory reserves size bytes for a request received by the receiver id. It returns
+	// false if the budget is exhausted, otherwise a function releasing the memory, to call once.
+	ReserveMemory(id component.ID, size int64) (release func(), ok bool)
+}
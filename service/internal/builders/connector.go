@@ -376,6 +376,11 @@ func (b *ConnectorBuilder) IsConfigured(componentID component.ID) bool {
 	return ok
 }
 
+// Config returns the configuration of the connector with the given ID, or nil if it is not configured.
+func (b *ConnectorBuilder) Config(id component.ID) component.Config {
+	return b.cfgs[id]
+}
+
 func (b *ConnectorBuilder) Factory(componentType component.Type) component.Factory {
 	return b.factories[componentType]
 }
@@ -94,6 +94,11 @@ func (b *ExporterBuilder) CreateProfiles(ctx context.Context, set exporter.Setti
 	return f.CreateProfiles(ctx, set, cfg)
 }
 
+// Config returns the configuration of the exporter with the given ID, or nil if it is not configured.
+func (b *ExporterBuilder) Config(id component.ID) component.Config {
+	return b.cfgs[id]
+}
+
 func (b *ExporterBuilder) Factory(componentType component.Type) component.Factory {
 	return b.factories[componentType]
 }
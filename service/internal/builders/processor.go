@@ -108,6 +108,11 @@ func (b *ProcessorBuilder) CreateProfiles(ctx context.Context, set processor.Set
 	return f.CreateProfiles(ctx, set, cfg, next)
 }
 
+// Config returns the configuration of the processor with the given ID, or nil if it is not configured.
+func (b *ProcessorBuilder) Config(id component.ID) component.Config {
+	return b.cfgs[id]
+}
+
 func (b *ProcessorBuilder) Factory(componentType component.Type) component.Factory {
 	return b.factories[componentType]
 }
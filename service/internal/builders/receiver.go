@@ -110,6 +110,11 @@ func (b *ReceiverBuilder) CreateProfiles(ctx context.Context, set receiver.Setti
 	return f.CreateProfiles(ctx, set, cfg, next)
 }
 
+// Config returns the configuration of the receiver with the given ID, or nil if it is not configured.
+func (b *ReceiverBuilder) Config(id component.ID) component.Config {
+	return b.cfgs[id]
+}
+
 func (b *ReceiverBuilder) Factory(componentType component.Type) component.Factory {
 	return b.factories[componentType]
 }
@@ -36,6 +36,8 @@ import (
 	"go.opentelemetry.io/collector/service/hostcapabilities"
 	"go.opentelemetry.io/collector/service/internal/builders"
 	"go.opentelemetry.io/collector/service/internal/capabilityconsumer"
+	"go.opentelemetry.io/collector/service/internal/metadata"
+	"go.opentelemetry.io/collector/service/internal/selftelemetry"
 	"go.opentelemetry.io/collector/service/internal/status"
 	"go.opentelemetry.io/collector/service/pipelines"
 )
@@ -54,6 +56,9 @@ type Settings struct {
 	PipelineConfigs pipelines.Config
 
 	ReportStatus status.ServiceStatusFunc
+
+	// ShutdownTimeouts limits how long ShutdownAll takes to stop each kind of component.
+	ShutdownTimeouts ShutdownTimeouts
 }
 
 type Graph struct {
@@ -66,17 +71,28 @@ type Graph struct {
 	// Keep track of status source per node
 	instanceIDs map[int64]*componentstatus.InstanceID
 
+	// Keep track of the data tap points per node, and the consumer wrapping each tapped node.
+	// The taps are only created by EnableTaps.
+	taps   map[int64]*tapPoint
+	tapped map[int64]baseConsumer
+
 	telemetry component.TelemetrySettings
+
+	shutdownTimeouts ShutdownTimeouts
+	drained          drainReport
 }
 
 // Build builds a full pipeline graph.
 // Build also validates the configuration of the pipelines and does the actual initialization of each Component in the Graph.
 func Build(ctx context.Context, set Settings) (*Graph, error) {
 	pipelines := &Graph{
-		componentGraph: simple.NewDirectedGraph(),
-		pipelines:      make(map[pipeline.ID]*pipelineNodes, len(set.PipelineConfigs)),
-		instanceIDs:    make(map[int64]*componentstatus.InstanceID),
-		telemetry:      set.Telemetry,
+		componentGraph:   simple.NewDirectedGraph(),
+		pipelines:        make(map[pipeline.ID]*pipelineNodes, len(set.PipelineConfigs)),
+		instanceIDs:      make(map[int64]*componentstatus.InstanceID),
+		taps:             make(map[int64]*tapPoint),
+		tapped:           make(map[int64]baseConsumer),
+		telemetry:        set.Telemetry,
+		shutdownTimeouts: set.ShutdownTimeouts,
 	}
 	for pipelineID := range set.PipelineConfigs {
 		pipelines.pipelines[pipelineID] = &pipelineNodes{
@@ -294,6 +310,10 @@ func (g *Graph) buildComponents(ctx context.Context, set Settings) error {
 	if err != nil {
 		return cycleErr(err, topo.DirectedCyclesIn(g.componentGraph))
 	}
+	tb, err := metadata.NewTelemetryBuilder(set.Telemetry)
+	if err != nil {
+		return err
+	}
 
 	for i := len(nodes) - 1; i >= 0; i-- {
 		node := nodes[i]
@@ -303,7 +323,8 @@ func (g *Graph) buildComponents(ctx context.Context, set Settings) error {
 			err = n.buildComponent(ctx, set.Telemetry, set.BuildInfo, set.ReceiverBuilder, g.nextConsumers(n.ID()))
 		case *processorNode:
 			// nextConsumers is guaranteed to be length 1.  Either it is the next processor or it is the fanout node for the exporters.
-			err = n.buildComponent(ctx, set.Telemetry, set.BuildInfo, set.ProcessorBuilder, g.nextConsumers(n.ID())[0])
+			// The pipeline ID is passed again along with the data, in case the processor did not keep its context.
+			err = n.buildComponent(ctx, set.Telemetry, set.BuildInfo, set.ProcessorBuilder, newPipelineConsumer(n.pipelineID, g.nextConsumers(n.ID())[0]))
 		case *exporterNode:
 			err = n.buildComponent(ctx, set.Telemetry, set.BuildInfo, set.ExporterBuilder)
 		case *connectorNode:
@@ -317,23 +338,31 @@ func (g *Graph) buildComponents(ctx context.Context, set Settings) error {
 				capability.MutatesData = capability.MutatesData || proc.(*processorNode).getConsumer().Capabilities().MutatesData
 			}
 			next := g.nextConsumers(n.ID())[0]
+			// Receivers hold the capabilitiesNode itself, so the tap and the pipeline context are placed inside of it.
+			var cc baseConsumer
+			switch n.pipelineID.Signal() {
+			case pipeline.SignalTraces:
+				cc = newPipelineEntryConsumer(n.pipelineID, tb.PipelineLatency, capabilityconsumer.NewTraces(next.(consumer.Traces), capability))
+			case pipeline.SignalMetrics:
+				cc = newPipelineEntryConsumer(n.pipelineID, tb.PipelineLatency, capabilityconsumer.NewMetrics(next.(consumer.Metrics), capability))
+			case pipeline.SignalLogs:
+				cc = newPipelineEntryConsumer(n.pipelineID, tb.PipelineLatency, capabilityconsumer.NewLogs(next.(consumer.Logs), capability))
+			case xpipeline.SignalProfiles:
+				cc = newPipelineEntryConsumer(n.pipelineID, tb.PipelineLatency, capabilityconsumer.NewProfiles(next.(xconsumer.Profiles), capability))
+			}
+			p := &tapPoint{}
+			g.taps[n.ID()] = p
+			cc = newTapConsumer(n.pipelineID.Signal(), p, cc)
+			n.baseConsumer = cc
 			switch n.pipelineID.Signal() {
 			case pipeline.SignalTraces:
-				cc := capabilityconsumer.NewTraces(next.(consumer.Traces), capability)
-				n.baseConsumer = cc
-				n.ConsumeTracesFunc = cc.ConsumeTraces
+				n.ConsumeTracesFunc = cc.(consumer.Traces).ConsumeTraces
 			case pipeline.SignalMetrics:
-				cc := capabilityconsumer.NewMetrics(next.(consumer.Metrics), capability)
-				n.baseConsumer = cc
-				n.ConsumeMetricsFunc = cc.ConsumeMetrics
+				n.ConsumeMetricsFunc = cc.(consumer.Metrics).ConsumeMetrics
 			case pipeline.SignalLogs:
-				cc := capabilityconsumer.NewLogs(next.(consumer.Logs), capability)
-				n.baseConsumer = cc
-				n.ConsumeLogsFunc = cc.ConsumeLogs
+				n.ConsumeLogsFunc = cc.(consumer.Logs).ConsumeLogs
 			case xpipeline.SignalProfiles:
-				cc := capabilityconsumer.NewProfiles(next.(xconsumer.Profiles), capability)
-				n.baseConsumer = cc
-				n.ConsumeProfilesFunc = cc.ConsumeProfiles
+				n.ConsumeProfilesFunc = cc.(xconsumer.Profiles).ConsumeProfiles
 			}
 		case *fanOutNode:
 			nexts := g.nextConsumers(n.ID())
@@ -376,11 +405,47 @@ func (g *Graph) nextConsumers(nodeID int64) []baseConsumer {
 	nextNodes := g.componentGraph.From(nodeID)
 	nexts := make([]baseConsumer, 0, nextNodes.Len())
 	for nextNodes.Next() {
-		nexts = append(nexts, nextNodes.Node().(consumerNode).getConsumer())
+		nexts = append(nexts, g.tapConsumer(nextNodes.Node().(consumerNode)))
 	}
 	return nexts
 }
 
+// tapConsumer returns the consumer of the node wrapped with a tap point.
+// All the upstream nodes share the same tap point. The capabilitiesNode is returned as is, since connectors
+// depend on its type, it is tapped when built.
+func (g *Graph) tapConsumer(n consumerNode) baseConsumer {
+	var signal pipeline.Signal
+	switch cn := n.(type) {
+	case *processorNode:
+		signal = cn.pipelineID.Signal()
+	case *fanOutNode:
+		signal = cn.pipelineID.Signal()
+	case *exporterNode:
+		signal = cn.pipelineType
+	case *connectorNode:
+		signal = cn.exprPipelineType
+	default:
+		return n.getConsumer()
+	}
+
+	id := n.(graph.Node).ID()
+	if c, ok := g.tapped[id]; ok {
+		return c
+	}
+	p := &tapPoint{}
+	g.taps[id] = p
+	c := newTapConsumer(signal, p, n.getConsumer())
+	g.tapped[id] = c
+	return c
+}
+
+// EnableTaps creates the data taps served by the tapz zPage, it is called when the zPages are registered.
+func (g *Graph) EnableTaps() {
+	for _, p := range g.taps {
+		p.enable()
+	}
+}
+
 // A node-based representation of a pipeline configuration.
 type pipelineNodes struct {
 	// Use map to assist with deduplication of connector instances.
@@ -428,7 +493,15 @@ func (g *Graph) StartAll(ctx context.Context, host *Host) error {
 			componentstatus.NewEvent(componentstatus.StatusStarting),
 		)
 
-		if compErr := comp.Start(ctx, &HostWrapper{Host: host, InstanceID: instanceID}); compErr != nil {
+		if waitErr := g.waitExtensionsReady(ctx, host, node); waitErr != nil {
+			host.Reporter.ReportStatus(
+				instanceID,
+				componentstatus.NewPermanentErrorEvent(waitErr),
+			)
+			return fmt.Errorf("failed to start %q %s: %w", instanceID.ComponentID().String(), strings.ToLower(instanceID.Kind().String()), waitErr)
+		}
+
+		if compErr := comp.Start(ctx, host.ForInstance(instanceID)); compErr != nil {
 			host.Reporter.ReportStatus(
 				instanceID,
 				componentstatus.NewPermanentErrorEvent(compErr),
@@ -448,46 +521,85 @@ func (g *Graph) StartAll(ctx context.Context, host *Host) error {
 	return nil
 }
 
+// waitExtensionsReady waits until the extensions referenced by the configuration of the component are ready.
+func (g *Graph) waitExtensionsReady(ctx context.Context, host *Host, node graph.Node) error {
+	if host.ServiceExtensions == nil {
+		return nil
+	}
+	var cfg component.Config
+	switch n := node.(type) {
+	case *receiverNode:
+		if host.Receivers != nil {
+			cfg = host.Receivers.Config(n.componentID)
+		}
+	case *processorNode:
+		if host.Processors != nil {
+			cfg = host.Processors.Config(n.componentID)
+		}
+	case *exporterNode:
+		if host.Exporters != nil {
+			cfg = host.Exporters.Config(n.componentID)
+		}
+	case *connectorNode:
+		if host.Connectors != nil {
+			cfg = host.Connectors.Config(n.componentID)
+		}
+	}
+	return host.ServiceExtensions.WaitReady(ctx, host.ServiceExtensions.Referenced(cfg)...)
+}
+
 func (g *Graph) ShutdownAll(ctx context.Context, reporter status.Reporter) error {
 	nodes, err := topo.Sort(g.componentGraph)
 	if err != nil {
 		return err
 	}
 
+	// End the live data streams, so that they do not keep the zpages server busy.
+	for _, p := range g.taps {
+		if t := p.tap.Load(); t != nil {
+			t.close()
+		}
+	}
+
 	// Stop in topological order so that upstream components
 	// are stopped before downstream components.  This ensures
 	// that each component has a chance to drain to its consumer
-	// before the consumer is stopped.
+	// before the consumer is stopped. The receivers are stopped first,
+	// then the processors and connectors, then the exporters, each
+	// phase bounded by its timeout.
 	var errs error
-	for i := 0; i < len(nodes); i++ {
-		node := nodes[i]
-		comp, ok := node.(component.Component)
+	for _, phase := range []int{shutdownReceivers, shutdownProcessors, shutdownExporters} {
+		phaseCtx, cancel := g.shutdownTimeouts.context(ctx, phase)
+		for _, node := range nodes {
+			comp, ok := node.(component.Component)
+			if !ok || shutdownPhase(node) != phase {
+				// Skip capabilities/fanout nodes, and the nodes of other phases
+				continue
+			}
 
-		if !ok {
-			// Skip capabilities/fanout nodes
-			continue
-		}
+			instanceID := g.instanceIDs[node.ID()]
+			reporter.ReportStatus(
+				instanceID,
+				componentstatus.NewEvent(componentstatus.StatusStopping),
+			)
 
-		instanceID := g.instanceIDs[node.ID()]
-		reporter.ReportStatus(
-			instanceID,
-			componentstatus.NewEvent(componentstatus.StatusStopping),
-		)
+			if compErr := comp.Shutdown(phaseCtx); compErr != nil {
+				errs = multierr.Append(errs, compErr)
+				reporter.ReportStatus(
+					instanceID,
+					componentstatus.NewPermanentErrorEvent(compErr),
+				)
+				continue
+			}
 
-		if compErr := comp.Shutdown(ctx); compErr != nil {
-			errs = multierr.Append(errs, compErr)
 			reporter.ReportStatus(
 				instanceID,
-				componentstatus.NewPermanentErrorEvent(compErr),
+				componentstatus.NewEvent(componentstatus.StatusStopped),
 			)
-			continue
 		}
-
-		reporter.ReportStatus(
-			instanceID,
-			componentstatus.NewEvent(componentstatus.StatusStopped),
-		)
+		cancel()
 	}
+	g.reportDrain(ctx, nodes)
 	return errs
 }
 
@@ -509,6 +621,41 @@ func (g *Graph) GetExporters() map[pipeline.Signal]map[component.ID]component.Co
 	return exportersMap
 }
 
+// DownstreamComponents returns the keys, see [selftelemetry.ComponentKey], of the receiver and
+// of every component the data it receives for the signal goes through, including the pipelines
+// fed by connectors.
+func (g *Graph) DownstreamComponents(recvID component.ID, signal pipeline.Signal) []string {
+	start := g.componentGraph.Node(newReceiverNode(signal, recvID).ID())
+	if start == nil {
+		return nil
+	}
+	var keys []string
+	seen := make(map[int64]bool)
+	stack := []graph.Node{start}
+	for len(stack) > 0 {
+		node := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if seen[node.ID()] {
+			continue
+		}
+		seen[node.ID()] = true
+		switch n := node.(type) {
+		case *receiverNode:
+			keys = append(keys, selftelemetry.ComponentKey(component.KindReceiver, n.componentID))
+		case *processorNode:
+			keys = append(keys, selftelemetry.ComponentKey(component.KindProcessor, n.componentID))
+		case *exporterNode:
+			keys = append(keys, selftelemetry.ComponentKey(component.KindExporter, n.componentID))
+		case *connectorNode:
+			keys = append(keys, selftelemetry.ComponentKey(component.KindConnector, n.componentID))
+		}
+		for it := g.componentGraph.From(node.ID()); it.Next(); {
+			stack = append(stack, it.Node())
+		}
+	}
+	return keys
+}
+
 func cycleErr(err error, cycles [][]graph.Node) error {
 	var topoErr topo.Unorderable
 	if !errors.As(err, &topoErr) || len(cycles) == 0 || len(cycles[0]) == 0 {
@@ -19,6 +19,10 @@ import (
 	"go.opentelemetry.io/collector/connector/connectortest"
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/pdata/testdata"
 	"go.opentelemetry.io/collector/pipeline"
 	"go.opentelemetry.io/collector/pipeline/xpipeline"
@@ -960,7 +964,10 @@ func TestConnectorPipelinesGraph(t *testing.T) {
 					if tracesExporter.Traces[i].IsReadOnly() {
 						assert.Equal(t, expectedReadOnly, tracesExporter.Traces[i])
 					} else {
-						assert.Equal(t, expectedMutable, tracesExporter.Traces[i])
+						// The data may share its resources with other pipelines, compare a copy of it.
+						actual := ptrace.NewTraces()
+						tracesExporter.Traces[i].CopyTo(actual)
+						assert.Equal(t, expectedMutable, actual)
 					}
 				}
 			}
@@ -974,7 +981,10 @@ func TestConnectorPipelinesGraph(t *testing.T) {
 					if metricsExporter.Metrics[i].IsReadOnly() {
 						assert.Equal(t, expectedReadOnly, metricsExporter.Metrics[i])
 					} else {
-						assert.Equal(t, expectedMutable, metricsExporter.Metrics[i])
+						// The data may share its resources with other pipelines, compare a copy of it.
+						actual := pmetric.NewMetrics()
+						metricsExporter.Metrics[i].CopyTo(actual)
+						assert.Equal(t, expectedMutable, actual)
 					}
 				}
 			}
@@ -988,7 +998,10 @@ func TestConnectorPipelinesGraph(t *testing.T) {
 					if logsExporter.Logs[i].IsReadOnly() {
 						assert.Equal(t, expectedReadOnly, logsExporter.Logs[i])
 					} else {
-						assert.Equal(t, expectedMutable, logsExporter.Logs[i])
+						// The data may share its resources with other pipelines, compare a copy of it.
+						actual := plog.NewLogs()
+						logsExporter.Logs[i].CopyTo(actual)
+						assert.Equal(t, expectedMutable, actual)
 					}
 				}
 			}
@@ -1002,7 +1015,10 @@ func TestConnectorPipelinesGraph(t *testing.T) {
 					if profilesExporter.Profiles[i].IsReadOnly() {
 						assert.Equal(t, expectedReadOnly, profilesExporter.Profiles[i])
 					} else {
-						assert.Equal(t, expectedMutable, profilesExporter.Profiles[i])
+						// The data may share its resources with other pipelines, compare a copy of it.
+						actual := pprofile.NewProfiles()
+						profilesExporter.Profiles[i].CopyTo(actual)
+						assert.Equal(t, expectedMutable, actual)
 					}
 				}
 			}
@@ -1174,6 +1190,77 @@ func TestInstances(t *testing.T) {
 	}
 }
 
+func TestDownstreamComponents(t *testing.T) {
+	set := Settings{
+		Telemetry: componenttest.NewNopTelemetrySettings(),
+		BuildInfo: component.NewDefaultBuildInfo(),
+		ReceiverBuilder: builders.NewReceiver(
+			map[component.ID]component.Config{
+				component.MustNewIDWithName("examplereceiver", "1"): testcomponents.ExampleReceiverFactory.CreateDefaultConfig(),
+				component.MustNewIDWithName("examplereceiver", "2"): testcomponents.ExampleReceiverFactory.CreateDefaultConfig(),
+			},
+			map[component.Type]receiver.Factory{
+				testcomponents.ExampleReceiverFactory.Type(): testcomponents.ExampleReceiverFactory,
+			},
+		),
+		ProcessorBuilder: builders.NewProcessor(
+			map[component.ID]component.Config{
+				component.MustNewID("exampleprocessor"): testcomponents.ExampleProcessorFactory.CreateDefaultConfig(),
+			},
+			map[component.Type]processor.Factory{
+				testcomponents.ExampleProcessorFactory.Type(): testcomponents.ExampleProcessorFactory,
+			},
+		),
+		ExporterBuilder: builders.NewExporter(
+			map[component.ID]component.Config{
+				component.MustNewIDWithName("exampleexporter", "1"): testcomponents.ExampleExporterFactory.CreateDefaultConfig(),
+				component.MustNewIDWithName("exampleexporter", "2"): testcomponents.ExampleExporterFactory.CreateDefaultConfig(),
+			},
+			map[component.Type]exporter.Factory{
+				testcomponents.ExampleExporterFactory.Type(): testcomponents.ExampleExporterFactory,
+			},
+		),
+		ConnectorBuilder: builders.NewConnector(
+			map[component.ID]component.Config{
+				component.MustNewID("exampleconnector"): testcomponents.ExampleConnectorFactory.CreateDefaultConfig(),
+			},
+			map[component.Type]connector.Factory{
+				testcomponents.ExampleConnectorFactory.Type(): testcomponents.ExampleConnectorFactory,
+			},
+		),
+		PipelineConfigs: pipelines.Config{
+			pipeline.NewIDWithName(pipeline.SignalLogs, "in"): {
+				Receivers:  []component.ID{component.MustNewIDWithName("examplereceiver", "1")},
+				Processors: []component.ID{component.MustNewID("exampleprocessor")},
+				Exporters:  []component.ID{component.MustNewID("exampleconnector")},
+			},
+			pipeline.NewIDWithName(pipeline.SignalLogs, "out"): {
+				Receivers: []component.ID{component.MustNewID("exampleconnector")},
+				Exporters: []component.ID{component.MustNewIDWithName("exampleexporter", "1")},
+			},
+			pipeline.NewIDWithName(pipeline.SignalLogs, "other"): {
+				Receivers: []component.ID{component.MustNewIDWithName("examplereceiver", "2")},
+				Exporters: []component.ID{component.MustNewIDWithName("exampleexporter", "2")},
+			},
+		},
+	}
+
+	pg, err := Build(context.Background(), set)
+	require.NoError(t, err)
+
+	assert.ElementsMatch(t, []string{
+		"receiver examplereceiver/1",
+		"processor exampleprocessor",
+		"connector exampleconnector",
+		"exporter exampleexporter/1",
+	}, pg.DownstreamComponents(component.MustNewIDWithName("examplereceiver", "1"), pipeline.SignalLogs))
+	assert.ElementsMatch(t, []string{
+		"receiver examplereceiver/2",
+		"exporter exampleexporter/2",
+	}, pg.DownstreamComponents(component.MustNewIDWithName("examplereceiver", "2"), pipeline.SignalLogs))
+	assert.Empty(t, pg.DownstreamComponents(component.MustNewIDWithName("examplereceiver", "1"), pipeline.SignalTraces))
+}
+
 func TestConnectorRouter(t *testing.T) {
 	rcvrID := component.MustNewID("examplereceiver")
 	routeTracesID := component.MustNewIDWithName("examplerouter", "traces")
@@ -4,6 +4,7 @@
 package graph // import "go.opentelemetry.io/collector/service/internal/graph"
 
 import (
+	"errors"
 	"net/http"
 	"path"
 	"runtime"
@@ -11,12 +12,16 @@ import (
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/featuregate"
 	"go.opentelemetry.io/collector/pipeline"
 	"go.opentelemetry.io/collector/service/extensions"
 	"go.opentelemetry.io/collector/service/hostcapabilities"
 	"go.opentelemetry.io/collector/service/internal/builders"
+	"go.opentelemetry.io/collector/service/internal/loglevel"
+	"go.opentelemetry.io/collector/service/internal/memorybudget"
 	"go.opentelemetry.io/collector/service/internal/moduleinfo"
+	"go.opentelemetry.io/collector/service/internal/selftelemetry"
 	"go.opentelemetry.io/collector/service/internal/status"
 	"go.opentelemetry.io/collector/service/internal/zpages"
 )
@@ -26,8 +31,12 @@ var (
 	_ hostcapabilities.ModuleInfo       = (*Host)(nil)
 	_ hostcapabilities.ExposeExporters  = (*Host)(nil)
 	_ hostcapabilities.ComponentFactory = (*Host)(nil)
+	_ hostcapabilities.SelfTelemetry    = (*Host)(nil)
+	_ hostcapabilities.MemoryBudget     = (*Host)(nil)
 )
 
+var errSelfTelemetryDisabled = errors.New("the Collector's own telemetry is not sent to the pipelines, enable service::telemetry::loopback")
+
 type Host struct {
 	AsyncErrorChannel chan error
 	Receivers         *builders.ReceiverBuilder
@@ -43,6 +52,16 @@ type Host struct {
 	ServiceExtensions *extensions.Extensions
 
 	Reporter status.Reporter
+
+	// LogLevels allows changing the log levels at runtime, it can be nil.
+	LogLevels *loglevel.Controller
+
+	// SelfTelemetry sends the Collector's own telemetry to the pipelines, it is nil unless
+	// service::telemetry::loopback is enabled.
+	SelfTelemetry *selftelemetry.Hub
+
+	// MemoryBudget is shared by the receivers, it is nil unless service::memory_budget::limit_mib is set.
+	MemoryBudget *memorybudget.Budget
 }
 
 func (host *Host) GetFactory(kind component.Kind, componentType component.Type) component.Factory {
@@ -65,6 +84,12 @@ func (host *Host) GetExtensions() map[component.ID]component.Component {
 	return host.ServiceExtensions.GetExtensions()
 }
 
+// ForInstance returns the component.Host given to the component with the given instance ID,
+// through which it can report its status.
+func (host *Host) ForInstance(instanceID *componentstatus.InstanceID) component.Host {
+	return &HostWrapper{Host: host, InstanceID: instanceID}
+}
+
 func (host *Host) GetModuleInfos() moduleinfo.ModuleInfos {
 	return host.ModuleInfos
 }
@@ -79,6 +104,34 @@ func (host *Host) GetExporters() map[pipeline.Signal]map[component.ID]component.
 	return host.Pipelines.GetExporters()
 }
 
+func (host *Host) RegisterSelfTelemetryLogs(id component.ID, next consumer.Logs) (func(), error) {
+	if host.SelfTelemetry == nil {
+		return nil, errSelfTelemetryDisabled
+	}
+	return host.SelfTelemetry.RegisterLogs(next, host.Pipelines.DownstreamComponents(id, pipeline.SignalLogs)), nil
+}
+
+func (host *Host) RegisterSelfTelemetryMetrics(_ component.ID, next consumer.Metrics) (func(), error) {
+	if host.SelfTelemetry == nil {
+		return nil, errSelfTelemetryDisabled
+	}
+	return host.SelfTelemetry.RegisterMetrics(next), nil
+}
+
+func (host *Host) RegisterSelfTelemetryTraces(id component.ID, next consumer.Traces) (func(), error) {
+	if host.SelfTelemetry == nil {
+		return nil, errSelfTelemetryDisabled
+	}
+	return host.SelfTelemetry.RegisterTraces(next, host.Pipelines.DownstreamComponents(id, pipeline.SignalTraces)), nil
+}
+
+func (host *Host) ReserveMemory(id component.ID, size int64) (func(), bool) {
+	if host.MemoryBudget == nil {
+		return func() {}, true
+	}
+	return host.MemoryBudget.Reserve(id, size)
+}
+
 func (host *Host) NotifyComponentStatusChange(source *componentstatus.InstanceID, event *componentstatus.Event) {
 	host.ServiceExtensions.NotifyComponentStatusChange(source, event)
 	if event.Status() == componentstatus.StatusFatalError {
@@ -92,6 +145,8 @@ const (
 	zPipelinePath  = "pipelinez"
 	zExtensionPath = "extensionz"
 	zFeaturePath   = "featurez"
+	zTapPath       = "tapz"
+	zLogLevelPath  = "loglevelz"
 )
 
 // InfoVar is a singleton instance of the Info struct.
@@ -112,6 +167,11 @@ func (host *Host) RegisterZPages(mux *http.ServeMux, pathPrefix string) {
 	mux.HandleFunc(path.Join(pathPrefix, zPipelinePath), host.Pipelines.HandleZPages)
 	mux.HandleFunc(path.Join(pathPrefix, zExtensionPath), host.ServiceExtensions.HandleZPages)
 	mux.HandleFunc(path.Join(pathPrefix, zFeaturePath), handleFeaturezRequest)
+	host.Pipelines.EnableTaps()
+	mux.HandleFunc(path.Join(pathPrefix, zTapPath), host.Pipelines.HandleTapZPages)
+	if host.LogLevels != nil {
+		mux.HandleFunc(path.Join(pathPrefix, zLogLevelPath), host.LogLevels.HandleZPages)
+	}
 }
 
 func (host *Host) zPagesRequest(w http.ResponseWriter, _ *http.Request) {
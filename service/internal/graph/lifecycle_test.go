@@ -7,6 +7,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -19,11 +20,13 @@ import (
 	"go.opentelemetry.io/collector/connector/connectortest"
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/extension"
 	"go.opentelemetry.io/collector/pipeline"
 	"go.opentelemetry.io/collector/processor"
 	"go.opentelemetry.io/collector/processor/processortest"
 	"go.opentelemetry.io/collector/receiver"
 	"go.opentelemetry.io/collector/receiver/receivertest"
+	"go.opentelemetry.io/collector/service/extensions"
 	"go.opentelemetry.io/collector/service/internal/builders"
 	"go.opentelemetry.io/collector/service/internal/status"
 	"go.opentelemetry.io/collector/service/internal/status/statustest"
@@ -438,3 +441,102 @@ func TestStatusReportedOnStartupShutdown(t *testing.T) {
 		})
 	}
 }
+
+type readinessExtension struct {
+	ready bool
+}
+
+func (ext *readinessExtension) Start(_ context.Context, host component.Host) error {
+	if ext.ready {
+		componentstatus.ReportStatus(host, componentstatus.NewEvent(componentstatus.StatusOK))
+	}
+	return nil
+}
+
+func (ext *readinessExtension) Shutdown(context.Context) error {
+	return nil
+}
+
+func (ext *readinessExtension) ReportsReadiness() bool {
+	return true
+}
+
+func TestGraphStartWaitsForReferencedExtensions(t *testing.T) {
+	extID := component.MustNewID("auth")
+	recvID := component.MustNewID("nop")
+	exprID := component.MustNewID("nop")
+	for _, tt := range []struct {
+		name     string
+		ready    bool
+		expected string
+	}{
+		{
+			name:  "ready",
+			ready: true,
+		},
+		{
+			name:     "not_ready",
+			expected: `failed to start "nop" exporter: extension "auth" is not ready after 10ms`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			extFactory := extension.NewFactory(extID.Type(), func() component.Config { return &struct{}{} },
+				func(context.Context, extension.Settings, component.Config) (extension.Extension, error) {
+					return &readinessExtension{ready: tt.ready}, nil
+				}, component.StabilityLevelDevelopment)
+			// The exporter configuration references the extension, as an authenticator would.
+			exprCfg := &struct{ Auth *component.ID }{Auth: &extID}
+			set := Settings{
+				Telemetry: componenttest.NewNopTelemetrySettings(),
+				BuildInfo: component.NewDefaultBuildInfo(),
+				ReceiverBuilder: builders.NewReceiver(
+					map[component.ID]component.Config{recvID: receivertest.NewNopFactory().CreateDefaultConfig()},
+					map[component.Type]receiver.Factory{recvID.Type(): receivertest.NewNopFactory()},
+				),
+				ProcessorBuilder: builders.NewProcessor(map[component.ID]component.Config{}, map[component.Type]processor.Factory{}),
+				ExporterBuilder: builders.NewExporter(
+					map[component.ID]component.Config{exprID: exprCfg},
+					map[component.Type]exporter.Factory{exprID.Type(): exportertest.NewNopFactory()},
+				),
+				ConnectorBuilder: builders.NewConnector(map[component.ID]component.Config{}, map[component.Type]connector.Factory{}),
+				PipelineConfigs: pipelines.Config{
+					pipeline.NewID(pipeline.SignalLogs): {
+						Receivers: []component.ID{recvID},
+						Exporters: []component.ID{exprID},
+					},
+				},
+			}
+			pg, err := Build(context.Background(), set)
+			require.NoError(t, err)
+
+			host := &Host{
+				Receivers:  set.ReceiverBuilder,
+				Processors: set.ProcessorBuilder,
+				Exporters:  set.ExporterBuilder,
+				Connectors: set.ConnectorBuilder,
+			}
+			host.Reporter = status.NewReporter(func(id *componentstatus.InstanceID, ev *componentstatus.Event) {
+				host.ServiceExtensions.NotifyComponentStatusChange(id, ev)
+			}, func(error) {})
+			host.ServiceExtensions, err = extensions.New(context.Background(), extensions.Settings{
+				Telemetry: componenttest.NewNopTelemetrySettings(),
+				BuildInfo: component.NewDefaultBuildInfo(),
+				Extensions: builders.NewExtension(
+					map[component.ID]component.Config{extID: extFactory.CreateDefaultConfig()},
+					map[component.Type]extension.Factory{extID.Type(): extFactory},
+				),
+			}, extensions.Config{extID}, extensions.WithReporter(host.Reporter), extensions.WithReadyTimeout(10*time.Millisecond))
+			require.NoError(t, err)
+			require.NoError(t, host.ServiceExtensions.Start(context.Background(), host))
+
+			err = pg.StartAll(context.Background(), host)
+			if tt.expected != "" {
+				require.EqualError(t, err, tt.expected)
+			} else {
+				require.NoError(t, err)
+			}
+			require.NoError(t, pg.ShutdownAll(context.Background(), host.Reporter))
+			require.NoError(t, host.ServiceExtensions.Shutdown(context.Background()))
+		})
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph // import "go.opentelemetry.io/collector/service/internal/graph"
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+	"gonum.org/v1/gonum/graph"
+
+	"go.opentelemetry.io/collector/exporter/xexporter"
+	"go.opentelemetry.io/collector/internal/telemetry"
+	"go.opentelemetry.io/collector/internal/telemetry/componentattribute"
+	"go.opentelemetry.io/collector/service/internal/metadata"
+)
+
+// ShutdownTimeouts limits how long each phase of ShutdownAll takes. A zero timeout means
+// no limit other than the deadline of the context passed to ShutdownAll.
+type ShutdownTimeouts struct {
+	// Receivers limits the time to stop the receivers.
+	Receivers time.Duration
+	// Processors limits the time to stop the processors and connectors.
+	Processors time.Duration
+	// Exporters limits the time to stop the exporters, including draining their sending queues.
+	Exporters time.Duration
+}
+
+// The phases of ShutdownAll, in order.
+const (
+	shutdownReceivers = iota
+	shutdownProcessors
+	shutdownExporters
+)
+
+func shutdownPhase(node graph.Node) int {
+	switch node.(type) {
+	case *receiverNode:
+		return shutdownReceivers
+	case *exporterNode:
+		return shutdownExporters
+	}
+	return shutdownProcessors
+}
+
+func (st ShutdownTimeouts) context(ctx context.Context, phase int) (context.Context, context.CancelFunc) {
+	timeout := [...]time.Duration{
+		shutdownReceivers:  st.Receivers,
+		shutdownProcessors: st.Processors,
+		shutdownExporters:  st.Exporters,
+	}[phase]
+	if timeout == 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// drainReport is the data left by the exporters once they are shut down.
+type drainReport struct {
+	queuedRequests int64
+	droppedItems   int64
+}
+
+// reportDrain logs and records the data left by each exporter once it is shut down.
+func (g *Graph) reportDrain(ctx context.Context, nodes []graph.Node) {
+	for _, node := range nodes {
+		n, ok := node.(*exporterNode)
+		if !ok {
+			continue
+		}
+		dr, ok := n.Component.(xexporter.DrainReporter)
+		if !ok {
+			continue
+		}
+		queued, dropped := dr.DrainReport()
+		if queued == 0 && dropped == 0 {
+			continue
+		}
+		g.drained.queuedRequests += queued
+		g.drained.droppedItems += dropped
+
+		logger := componentattribute.ZapLoggerWithAttributes(g.telemetry.Logger, *n.Attributes.Set())
+		logger.Warn("Exporter shut down with data left in its sending queue.",
+			zap.Int64("queued_requests", queued),
+			zap.Int64("dropped_items", dropped))
+		tb, err := metadata.NewTelemetryBuilder(telemetry.WithAttributeSet(g.telemetry, *n.Attributes.Set()))
+		if err != nil {
+			logger.Error("Failed to record the data left in the sending queue.", zap.Error(err))
+			continue
+		}
+		tb.ExporterShutdownQueuedRequests.Add(ctx, queued)
+		tb.ExporterShutdownDroppedItems.Add(ctx, dropped)
+		tb.Shutdown()
+	}
+}
+
+// DrainReport returns the number of requests left in the persistent sending queues of the exporters,
+// to be sent after restart, and the number of items the exporters dropped because the shutdown deadline
+// was reached before their sending queues were drained. It is only meaningful after ShutdownAll.
+func (g *Graph) DrainReport() (queuedRequests, droppedItems int64) {
+	return g.drained.queuedRequests, g.drained.droppedItems
+}
@@ -0,0 +1,117 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+	"gonum.org/v1/gonum/graph/simple"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/exporter/xexporter"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/service/internal/metadatatest"
+	"go.opentelemetry.io/collector/service/internal/status/statustest"
+)
+
+// shutdownRecorder records the order in which components are shut down, and whether they were given a deadline.
+type shutdownRecorder struct {
+	mu          sync.Mutex
+	order       []component.ID
+	hasDeadline map[component.ID]bool
+}
+
+func (r *shutdownRecorder) record(ctx context.Context, id component.ID) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.order = append(r.order, id)
+	_, r.hasDeadline[id] = ctx.Deadline()
+}
+
+type recordingComponent struct {
+	id       component.ID
+	recorder *shutdownRecorder
+	queued   int64
+	dropped  int64
+}
+
+func (c *recordingComponent) Start(context.Context, component.Host) error {
+	return nil
+}
+
+func (c *recordingComponent) Shutdown(ctx context.Context) error {
+	c.recorder.record(ctx, c.id)
+	return nil
+}
+
+type drainingComponent struct {
+	recordingComponent
+}
+
+var _ xexporter.DrainReporter = (*drainingComponent)(nil)
+
+func (c *drainingComponent) DrainReport() (int64, int64) {
+	return c.queued, c.dropped
+}
+
+func TestGraphShutdownPhases(t *testing.T) {
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tel.Shutdown(context.Background())) })
+	recorder := &shutdownRecorder{hasDeadline: make(map[component.ID]bool)}
+
+	pg := &Graph{componentGraph: simple.NewDirectedGraph()}
+	pg.telemetry = tel.NewTelemetrySettings()
+	pg.instanceIDs = make(map[int64]*componentstatus.InstanceID)
+	pg.shutdownTimeouts = ShutdownTimeouts{Exporters: time.Minute}
+
+	// Two pipelines, sharing no component: r1 -> p1 -> e1 and r2 -> e2.
+	r1, r2 := newReceiverNode(pipeline.SignalLogs, component.MustNewIDWithName("r", "1")), newReceiverNode(pipeline.SignalLogs, component.MustNewIDWithName("r", "2"))
+	p1 := newProcessorNode(pipeline.NewID(pipeline.SignalLogs), component.MustNewIDWithName("p", "1"))
+	e1, e2 := newExporterNode(pipeline.SignalLogs, component.MustNewIDWithName("e", "1")), newExporterNode(pipeline.SignalLogs, component.MustNewIDWithName("e", "2"))
+	r1.Component = &recordingComponent{id: r1.componentID, recorder: recorder}
+	r2.Component = &recordingComponent{id: r2.componentID, recorder: recorder}
+	p1.Component = &recordingComponent{id: p1.componentID, recorder: recorder}
+	e1.Component = &drainingComponent{recordingComponent{id: e1.componentID, recorder: recorder, queued: 2, dropped: 5}}
+	e2.Component = &recordingComponent{id: e2.componentID, recorder: recorder}
+	for _, edge := range []simple.Edge{{F: r1, T: p1}, {F: p1, T: e1}, {F: r2, T: e2}} {
+		pg.componentGraph.SetEdge(edge)
+	}
+	for _, n := range []int64{r1.ID(), r2.ID(), p1.ID(), e1.ID(), e2.ID()} {
+		pg.instanceIDs[n] = &componentstatus.InstanceID{}
+	}
+
+	require.NoError(t, pg.ShutdownAll(context.Background(), statustest.NewNopStatusReporter()))
+
+	// The receivers are stopped first, then the processors, then the exporters.
+	require.Len(t, recorder.order, 5)
+	assert.ElementsMatch(t, []component.ID{r1.componentID, r2.componentID}, recorder.order[:2])
+	assert.Equal(t, p1.componentID, recorder.order[2])
+	assert.ElementsMatch(t, []component.ID{e1.componentID, e2.componentID}, recorder.order[3:])
+	assert.Equal(t, map[component.ID]bool{
+		r1.componentID: false,
+		r2.componentID: false,
+		p1.componentID: false,
+		e1.componentID: true,
+		e2.componentID: true,
+	}, recorder.hasDeadline)
+
+	queuedRequests, droppedItems := pg.DrainReport()
+	assert.Equal(t, int64(2), queuedRequests)
+	assert.Equal(t, int64(5), droppedItems)
+	metadatatest.AssertEqualExporterShutdownQueuedRequests(t, tel,
+		[]metricdata.DataPoint[int64]{{Value: 2}},
+		metricdatatest.IgnoreTimestamp())
+	metadatatest.AssertEqualExporterShutdownDroppedItems(t, tel,
+		[]metricdata.DataPoint[int64]{{Value: 5}},
+		metricdatatest.IgnoreTimestamp())
+}
@@ -0,0 +1,198 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph // import "go.opentelemetry.io/collector/service/internal/graph"
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/xconsumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/pipeline/xpipeline"
+)
+
+// tapBufferSize is the number of batches buffered for every subscriber.
+// Batches are dropped when a subscriber does not keep up.
+const tapBufferSize = 16
+
+// tap allows to observe the data flowing into a consumer without reconfiguring the pipeline.
+// When nobody is subscribed the only cost is a single atomic load per call.
+type tap struct {
+	active atomic.Bool
+
+	mu          sync.Mutex
+	subscribers map[*tapSubscriber]struct{}
+	closed      bool
+}
+
+// tapPoint is where a tap can be placed in front of a consumer. The tap is only created once
+// the tapz zPage is registered, until then the only cost is a single atomic load per call.
+type tapPoint struct {
+	tap atomic.Pointer[tap]
+}
+
+// enable creates the tap, if not created yet.
+func (p *tapPoint) enable() {
+	p.tap.CompareAndSwap(nil, &tap{})
+}
+
+// active returns the tap if it has subscribers, or nil.
+func (p *tapPoint) active() *tap {
+	if t := p.tap.Load(); t != nil && t.active.Load() {
+		return t
+	}
+	return nil
+}
+
+// tapSubscriber receives the OTLP JSON encoded batches published by a tap.
+type tapSubscriber struct {
+	// sampleRate keeps one every sampleRate batches.
+	sampleRate uint64
+	seen       uint64
+	ch         chan []byte
+	// done is closed when the tap is closed, because the pipelines are shutting down.
+	done chan struct{}
+}
+
+func newTapSubscriber(sampleRate uint64) *tapSubscriber {
+	if sampleRate == 0 {
+		sampleRate = 1
+	}
+	return &tapSubscriber{
+		sampleRate: sampleRate,
+		ch:         make(chan []byte, tapBufferSize),
+		done:       make(chan struct{}),
+	}
+}
+
+func (t *tap) attach(sub *tapSubscriber) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.closed {
+		close(sub.done)
+		return
+	}
+	if t.subscribers == nil {
+		t.subscribers = make(map[*tapSubscriber]struct{})
+	}
+	t.subscribers[sub] = struct{}{}
+	t.active.Store(true)
+}
+
+func (t *tap) detach(sub *tapSubscriber) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.subscribers, sub)
+	t.active.Store(len(t.subscribers) > 0)
+}
+
+// close detaches all subscribers and prevents new ones from attaching.
+func (t *tap) close() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for sub := range t.subscribers {
+		close(sub.done)
+	}
+	t.subscribers = nil
+	t.closed = true
+	t.active.Store(false)
+}
+
+// publish sends the batch to every subscriber that samples it. The batch is marshaled
+// at most once and only if at least one subscriber needs it, outside of the lock so
+// that the subscribers attaching or detaching are not blocked by it.
+func (t *tap) publish(marshal func() ([]byte, error)) {
+	var subs []*tapSubscriber
+	t.mu.Lock()
+	for sub := range t.subscribers {
+		sub.seen++
+		if (sub.seen-1)%sub.sampleRate == 0 {
+			subs = append(subs, sub)
+		}
+	}
+	t.mu.Unlock()
+	if len(subs) == 0 {
+		return
+	}
+
+	data, err := marshal()
+	if err != nil {
+		return
+	}
+	for _, sub := range subs {
+		select {
+		case sub.ch <- data:
+		default:
+		}
+	}
+}
+
+// newTapConsumer wraps next so that the data it receives is published to the tap of the point.
+func newTapConsumer(signal pipeline.Signal, p *tapPoint, next baseConsumer) baseConsumer {
+	switch signal {
+	case pipeline.SignalTraces:
+		return &tapTraces{Traces: next.(consumer.Traces), point: p}
+	case pipeline.SignalMetrics:
+		return &tapMetrics{Metrics: next.(consumer.Metrics), point: p}
+	case pipeline.SignalLogs:
+		return &tapLogs{Logs: next.(consumer.Logs), point: p}
+	case xpipeline.SignalProfiles:
+		return &tapProfiles{Profiles: next.(xconsumer.Profiles), point: p}
+	}
+	return next
+}
+
+type tapTraces struct {
+	consumer.Traces
+	point *tapPoint
+}
+
+func (tt *tapTraces) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	if t := tt.point.active(); t != nil {
+		t.publish(func() ([]byte, error) { return (&ptrace.JSONMarshaler{}).MarshalTraces(td) })
+	}
+	return tt.Traces.ConsumeTraces(ctx, td)
+}
+
+type tapMetrics struct {
+	consumer.Metrics
+	point *tapPoint
+}
+
+func (tm *tapMetrics) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	if t := tm.point.active(); t != nil {
+		t.publish(func() ([]byte, error) { return (&pmetric.JSONMarshaler{}).MarshalMetrics(md) })
+	}
+	return tm.Metrics.ConsumeMetrics(ctx, md)
+}
+
+type tapLogs struct {
+	consumer.Logs
+	point *tapPoint
+}
+
+func (tl *tapLogs) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	if t := tl.point.active(); t != nil {
+		t.publish(func() ([]byte, error) { return (&plog.JSONMarshaler{}).MarshalLogs(ld) })
+	}
+	return tl.Logs.ConsumeLogs(ctx, ld)
+}
+
+type tapProfiles struct {
+	xconsumer.Profiles
+	point *tapPoint
+}
+
+func (tp *tapProfiles) ConsumeProfiles(ctx context.Context, pd pprofile.Profiles) error {
+	if t := tp.point.active(); t != nil {
+		t.publish(func() ([]byte, error) { return (&pprofile.JSONMarshaler{}).MarshalProfiles(pd) })
+	}
+	return tp.Profiles.ConsumeProfiles(ctx, pd)
+}
@@ -0,0 +1,215 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/testdata"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/service/internal/builders"
+	"go.opentelemetry.io/collector/service/internal/status"
+	"go.opentelemetry.io/collector/service/internal/testcomponents"
+	"go.opentelemetry.io/collector/service/pipelines"
+)
+
+func TestTapPublish(t *testing.T) {
+	tp := &tap{}
+	assert.False(t, tp.active.Load())
+
+	all := newTapSubscriber(0)
+	sampled := newTapSubscriber(2)
+	tp.attach(all)
+	tp.attach(sampled)
+	assert.True(t, tp.active.Load())
+
+	marshaled := 0
+	for i := 0; i < tapBufferSize+4; i++ {
+		tp.publish(func() ([]byte, error) {
+			marshaled++
+			return []byte("data"), nil
+		})
+	}
+	// Marshaled once per published batch, regardless the number of subscribers.
+	assert.Equal(t, tapBufferSize+4, marshaled)
+	// Slow subscribers drop the batches that do not fit in their buffer.
+	assert.Len(t, all.ch, tapBufferSize)
+	assert.Len(t, sampled.ch, (tapBufferSize+4)/2)
+
+	tp.detach(all)
+	assert.True(t, tp.active.Load())
+	tp.close()
+	assert.False(t, tp.active.Load())
+	<-sampled.done
+
+	late := newTapSubscriber(1)
+	tp.attach(late)
+	<-late.done
+	assert.False(t, tp.active.Load())
+}
+
+func newTapTestGraph(t *testing.T) *Graph {
+	rcvrID := component.MustNewID("examplereceiver")
+	procID := component.MustNewID("exampleprocessor")
+	expID := component.MustNewID("exampleexporter")
+	set := Settings{
+		Telemetry: componenttest.NewNopTelemetrySettings(),
+		BuildInfo: component.NewDefaultBuildInfo(),
+		ReceiverBuilder: builders.NewReceiver(
+			map[component.ID]component.Config{rcvrID: testcomponents.ExampleReceiverFactory.CreateDefaultConfig()},
+			map[component.Type]receiver.Factory{testcomponents.ExampleReceiverFactory.Type(): testcomponents.ExampleReceiverFactory},
+		),
+		ProcessorBuilder: builders.NewProcessor(
+			map[component.ID]component.Config{procID: testcomponents.ExampleProcessorFactory.CreateDefaultConfig()},
+			map[component.Type]processor.Factory{testcomponents.ExampleProcessorFactory.Type(): testcomponents.ExampleProcessorFactory},
+		),
+		ExporterBuilder: builders.NewExporter(
+			map[component.ID]component.Config{expID: testcomponents.ExampleExporterFactory.CreateDefaultConfig()},
+			map[component.Type]exporter.Factory{testcomponents.ExampleExporterFactory.Type(): testcomponents.ExampleExporterFactory},
+		),
+		ConnectorBuilder: builders.NewConnector(map[component.ID]component.Config{}, map[component.Type]connector.Factory{}),
+		PipelineConfigs: pipelines.Config{
+			pipeline.NewID(pipeline.SignalTraces): {
+				Receivers:  []component.ID{rcvrID},
+				Processors: []component.ID{procID},
+				Exporters:  []component.ID{expID},
+			},
+		},
+	}
+	g, err := Build(context.Background(), set)
+	require.NoError(t, err)
+	return g
+}
+
+func TestFindTap(t *testing.T) {
+	g := newTapTestGraph(t)
+	g.EnableTaps()
+	p := g.pipelines[pipeline.NewID(pipeline.SignalTraces)]
+
+	tests := []struct {
+		kind    string
+		name    string
+		nodeID  int64
+		wantErr string
+	}{
+		{kind: "", nodeID: p.capabilitiesNode.ID()},
+		{kind: "receiver", nodeID: p.capabilitiesNode.ID()},
+		{kind: "processor", name: "exampleprocessor", nodeID: p.processors[0].ID()},
+		{kind: "exporter", nodeID: p.fanOutNode.ID()},
+		{kind: "exporter", name: "exampleexporter", nodeID: func() int64 {
+			for id := range p.exporters {
+				return id
+			}
+			return 0
+		}()},
+		{kind: "processor", name: "missing", wantErr: `no such tap point: processor "missing" not found in pipeline "traces"`},
+		{kind: "connector", name: "exampleexporter", wantErr: `no such tap point: connector "exampleexporter" not found in pipeline "traces"`},
+		{kind: "extension", wantErr: `no such tap point: unknown kind "extension"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.kind+"/"+tt.name, func(t *testing.T) {
+			tp, err := g.findTap("traces", tt.kind, tt.name)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Same(t, g.taps[tt.nodeID].tap.Load(), tp)
+		})
+	}
+
+	_, err := g.findTap("logs", "", "")
+	require.ErrorIs(t, err, errTapNotFound)
+	_, err = g.findTap("", "", "")
+	require.Error(t, err)
+}
+
+func TestTapsEnabledByZPages(t *testing.T) {
+	g := newTapTestGraph(t)
+	p := g.pipelines[pipeline.NewID(pipeline.SignalTraces)]
+	_, isTap := p.capabilitiesNode.baseConsumer.(*tapTraces)
+	assert.True(t, isTap)
+
+	// The taps are only created once the zPages are registered.
+	_, err := g.findTap("traces", "", "")
+	require.EqualError(t, err, "no such tap point: the taps are not enabled")
+	for _, point := range g.taps {
+		assert.Nil(t, point.tap.Load())
+	}
+
+	host := &Host{Pipelines: g}
+	host.RegisterZPages(http.NewServeMux(), "/debug")
+	for _, point := range g.taps {
+		assert.NotNil(t, point.tap.Load())
+	}
+	_, err = g.findTap("traces", "", "")
+	require.NoError(t, err)
+}
+
+func TestHandleTapZPages(t *testing.T) {
+	g := newTapTestGraph(t)
+	g.EnableTaps()
+	host := &Host{
+		Reporter: status.NewReporter(func(*componentstatus.InstanceID, *componentstatus.Event) {}, func(error) {}),
+	}
+	require.NoError(t, g.StartAll(context.Background(), host))
+
+	srv := httptest.NewServer(http.HandlerFunc(g.HandleTapZPages))
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "?" + zPipelineName + "=unknown")
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	resp, err = http.Get(srv.URL + "?" + zPipelineName + "=traces&" + zLimit + "=abc")
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp, err = http.Get(srv.URL + "?" + zPipelineName + "=traces&" + zComponentKind + "=processor&" + zComponentName + "=exampleprocessor&" + zLimit + "=2")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, "application/x-ndjson", resp.Header.Get("Content-Type"))
+
+	tp, err := g.findTap("traces", "processor", "exampleprocessor")
+	require.NoError(t, err)
+	assert.Eventually(t, tp.active.Load, time.Second, time.Millisecond)
+
+	rcvr := g.getReceivers()[pipeline.SignalTraces][component.MustNewID("examplereceiver")].(*testcomponents.ExampleReceiver)
+	for i := 0; i < 3; i++ {
+		require.NoError(t, rcvr.ConsumeTraces(context.Background(), testdata.GenerateTraces(1)))
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	lines := 0
+	for scanner.Scan() {
+		td, err := (&ptrace.JSONUnmarshaler{}).UnmarshalTraces(scanner.Bytes())
+		require.NoError(t, err)
+		assert.Equal(t, 1, td.SpanCount())
+		lines++
+	}
+	require.NoError(t, scanner.Err())
+	// The stream ends once the limit is reached and the tap detaches.
+	assert.Equal(t, 2, lines)
+	assert.Eventually(t, func() bool { return !tp.active.Load() }, time.Second, time.Millisecond)
+
+	require.NoError(t, g.ShutdownAll(context.Background(), host.Reporter))
+}
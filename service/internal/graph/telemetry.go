@@ -0,0 +1,140 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph // import "go.opentelemetry.io/collector/service/internal/graph"
+
+import (
+	"context"
+	"time"
+
+	otelattr "go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/xconsumer"
+	"go.opentelemetry.io/collector/internal/telemetry/componentattribute"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/pipeline/xpipeline"
+)
+
+// pipelineContext passes the ID of the pipeline along with the data, so that the receiverhelper, processorhelper
+// and exporterhelper metrics are reported per pipeline. Receivers, exporters and connectors can be shared by
+// several pipelines, so the ID cannot be part of their telemetry settings.
+type pipelineContext struct {
+	id pipeline.ID
+	// entry is true for the consumer receiving the data from the receivers and connectors.
+	entry bool
+
+	latency     metric.Float64Histogram
+	withSuccess metric.RecordOption
+	withFailure metric.RecordOption
+}
+
+func (pc *pipelineContext) enter(ctx context.Context) context.Context {
+	if !pc.entry {
+		if id, ok := componentattribute.PipelineFromContext(ctx); ok && id == pc.id {
+			return ctx
+		}
+		return componentattribute.ContextWithPipeline(ctx, pc.id, nil)
+	}
+	// The latency is recorded when an exporter completes its export, which can happen after the data was
+	// queued. Pipelines fed by a connector also report it for the upstream pipeline, from its receivers.
+	start := time.Now()
+	upstreamCtx := ctx
+	return componentattribute.ContextWithPipeline(ctx, pc.id, func(ctx context.Context, err error) {
+		opt := pc.withSuccess
+		if err != nil {
+			opt = pc.withFailure
+		}
+		pc.latency.Record(ctx, time.Since(start).Seconds(), opt)
+		componentattribute.PipelineExported(upstreamCtx, err)
+	})
+}
+
+func (pc *pipelineContext) exit(ctx context.Context, err error) {
+	if pc.entry {
+		// Let the receiver report its telemetry for this pipeline.
+		componentattribute.RecordPipelineOutcome(ctx, pc.id, err)
+	}
+}
+
+// newPipelineEntryConsumer wraps next so that the ID of the pipeline is passed along with the data,
+// and the time the data takes to be exported is recorded.
+func newPipelineEntryConsumer(pipelineID pipeline.ID, latency metric.Float64Histogram, next baseConsumer) baseConsumer {
+	pipelineAttr := otelattr.String(componentattribute.PipelineIDKey, pipelineID.String())
+	return newPipelineContextConsumer(&pipelineContext{
+		id:          pipelineID,
+		entry:       true,
+		latency:     latency,
+		withSuccess: metric.WithAttributeSet(otelattr.NewSet(pipelineAttr, otelattr.String("outcome", "success"))),
+		withFailure: metric.WithAttributeSet(otelattr.NewSet(pipelineAttr, otelattr.String("outcome", "failure"))),
+	}, next)
+}
+
+// newPipelineConsumer wraps next so that the ID of the pipeline is passed along with the data,
+// if the previous component did not keep it.
+func newPipelineConsumer(pipelineID pipeline.ID, next baseConsumer) baseConsumer {
+	return newPipelineContextConsumer(&pipelineContext{id: pipelineID}, next)
+}
+
+func newPipelineContextConsumer(pc *pipelineContext, next baseConsumer) baseConsumer {
+	switch pc.id.Signal() {
+	case pipeline.SignalTraces:
+		return &pipelineTraces{Traces: next.(consumer.Traces), pipelineContext: pc}
+	case pipeline.SignalMetrics:
+		return &pipelineMetrics{Metrics: next.(consumer.Metrics), pipelineContext: pc}
+	case pipeline.SignalLogs:
+		return &pipelineLogs{Logs: next.(consumer.Logs), pipelineContext: pc}
+	case xpipeline.SignalProfiles:
+		return &pipelineProfiles{Profiles: next.(xconsumer.Profiles), pipelineContext: pc}
+	}
+	return next
+}
+
+type pipelineTraces struct {
+	consumer.Traces
+	*pipelineContext
+}
+
+func (pt *pipelineTraces) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	err := pt.Traces.ConsumeTraces(pt.enter(ctx), td)
+	pt.exit(ctx, err)
+	return err
+}
+
+type pipelineMetrics struct {
+	consumer.Metrics
+	*pipelineContext
+}
+
+func (pm *pipelineMetrics) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	err := pm.Metrics.ConsumeMetrics(pm.enter(ctx), md)
+	pm.exit(ctx, err)
+	return err
+}
+
+type pipelineLogs struct {
+	consumer.Logs
+	*pipelineContext
+}
+
+func (pl *pipelineLogs) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	err := pl.Logs.ConsumeLogs(pl.enter(ctx), ld)
+	pl.exit(ctx, err)
+	return err
+}
+
+type pipelineProfiles struct {
+	xconsumer.Profiles
+	*pipelineContext
+}
+
+func (pp *pipelineProfiles) ConsumeProfiles(ctx context.Context, pd pprofile.Profiles) error {
+	err := pp.Profiles.ConsumeProfiles(pp.enter(ctx), pd)
+	pp.exit(ctx, err)
+	return err
+}
@@ -0,0 +1,107 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package graph
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	otelattr "go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/internal/telemetry/componentattribute"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/testdata"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/service/internal/metadata"
+)
+
+// latencyCounts returns the number of latency measurements, by pipeline ID and outcome.
+func latencyCounts(t *testing.T, tel *componenttest.Telemetry) map[[2]string]uint64 {
+	got := make(map[[2]string]uint64)
+	latency, err := tel.GetMetric("otelcol_pipeline_latency")
+	if err != nil {
+		return got
+	}
+	for _, dp := range latency.Data.(metricdata.Histogram[float64]).DataPoints {
+		pipelineID, _ := dp.Attributes.Value(componentattribute.PipelineIDKey)
+		outcome, _ := dp.Attributes.Value(otelattr.Key("outcome"))
+		got[[2]string{pipelineID.AsString(), outcome.AsString()}] += dp.Count
+	}
+	return got
+}
+
+// ctxLogs returns a consumer keeping the context passed along with the last data.
+func ctxLogs(t *testing.T, got *context.Context) consumer.Logs {
+	next, err := consumer.NewLogs(func(ctx context.Context, _ plog.Logs) error {
+		*got = ctx
+		return nil
+	})
+	require.NoError(t, err)
+	return next
+}
+
+func TestPipelineEntryConsumer(t *testing.T) {
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tel.Shutdown(context.Background())) })
+	tb, err := metadata.NewTelemetryBuilder(tel.NewTelemetrySettings())
+	require.NoError(t, err)
+
+	logs1 := pipeline.NewIDWithName(pipeline.SignalLogs, "1")
+	logs3 := pipeline.NewIDWithName(pipeline.SignalLogs, "3")
+	var got1, got3 context.Context
+	entry1 := newPipelineEntryConsumer(logs1, tb.PipelineLatency, ctxLogs(t, &got1)).(consumer.Logs)
+	entry3 := newPipelineEntryConsumer(logs3, tb.PipelineLatency, ctxLogs(t, &got3)).(consumer.Logs)
+
+	receiverCtx := componentattribute.ContextWithPipelineOutcomes(context.Background())
+	require.NoError(t, entry1.ConsumeLogs(receiverCtx, testdata.GenerateLogs(2)))
+	id, ok := componentattribute.PipelineFromContext(got1)
+	require.True(t, ok)
+	assert.Equal(t, logs1, id)
+	assert.Equal(t, []componentattribute.PipelineOutcome{{ID: logs1}}, componentattribute.PipelineOutcomesFromContext(receiverCtx))
+
+	// The latency is only recorded once the data is exported.
+	assert.Empty(t, latencyCounts(t, tel))
+	componentattribute.PipelineExported(got1, nil)
+	assert.Equal(t, map[[2]string]uint64{{"logs/1", "success"}: 1}, latencyCounts(t, tel))
+
+	// A connector passes the data from logs/1 to logs/3, exporting it from logs/3 is reported for both.
+	require.NoError(t, entry3.ConsumeLogs(got1, testdata.GenerateLogs(2)))
+	id, ok = componentattribute.PipelineFromContext(got3)
+	require.True(t, ok)
+	assert.Equal(t, logs3, id)
+	componentattribute.PipelineExported(got3, errors.New("failed"))
+	assert.Equal(t, map[[2]string]uint64{
+		{"logs/1", "success"}: 1,
+		{"logs/1", "failure"}: 1,
+		{"logs/3", "failure"}: 1,
+	}, latencyCounts(t, tel))
+	// Only the pipelines the receiver passed the data to are reported for the receiver.
+	assert.Equal(t, []componentattribute.PipelineOutcome{{ID: logs1}}, componentattribute.PipelineOutcomesFromContext(receiverCtx))
+}
+
+func TestPipelineConsumer(t *testing.T) {
+	logs1 := pipeline.NewIDWithName(pipeline.SignalLogs, "1")
+	var got context.Context
+	pc := newPipelineConsumer(logs1, ctxLogs(t, &got)).(consumer.Logs)
+
+	// A processor did not keep the context.
+	require.NoError(t, pc.ConsumeLogs(context.Background(), testdata.GenerateLogs(1)))
+	id, ok := componentattribute.PipelineFromContext(got)
+	require.True(t, ok)
+	assert.Equal(t, logs1, id)
+
+	// The context of the pipeline is kept as is.
+	exported := 0
+	ctx := componentattribute.ContextWithPipeline(context.Background(), logs1, func(context.Context, error) { exported++ })
+	require.NoError(t, pc.ConsumeLogs(ctx, testdata.GenerateLogs(1)))
+	assert.Equal(t, ctx, got)
+	componentattribute.PipelineExported(got, nil)
+	assert.Equal(t, 1, exported)
+}
@@ -4,9 +4,14 @@
 package graph // import "go.opentelemetry.io/collector/service/internal/graph"
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
 	"sort"
+	"strconv"
 
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pipeline"
 	"go.opentelemetry.io/collector/service/internal/zpages"
 )
 
@@ -16,8 +21,15 @@ const (
 	zPipelineName  = "pipelinenamez"
 	zComponentName = "componentnamez"
 	zComponentKind = "componentkindz"
+	zSampleRate    = "samplez"
+	zLimit         = "limitz"
+
+	// defaultTapLimit is the number of batches after which a tap ends, if not configured.
+	defaultTapLimit = 100
 )
 
+var errTapNotFound = errors.New("no such tap point")
+
 func (g *Graph) HandleZPages(w http.ResponseWriter, r *http.Request) {
 	qValues := r.URL.Query()
 	pipelineName := qValues.Get(zPipelineName)
@@ -79,3 +91,125 @@ func (g *Graph) HandleZPages(w http.ResponseWriter, r *http.Request) {
 	}
 	zpages.WriteHTMLPageFooter(w)
 }
+
+// HandleTapZPages streams the data flowing into the selected pipeline or component as
+// OTLP JSON lines, until the client disconnects or the limit is reached.
+func (g *Graph) HandleTapZPages(w http.ResponseWriter, r *http.Request) {
+	qValues := r.URL.Query()
+	sampleRate, err := parseTapParam(qValues.Get(zSampleRate), 1)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("invalid %s: %v", zSampleRate, err), http.StatusBadRequest)
+		return
+	}
+	limit, err := parseTapParam(qValues.Get(zLimit), defaultTapLimit)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("invalid %s: %v", zLimit, err), http.StatusBadRequest)
+		return
+	}
+	t, err := g.findTap(qValues.Get(zPipelineName), qValues.Get(zComponentKind), qValues.Get(zComponentName))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	sub := newTapSubscriber(sampleRate)
+	t.attach(sub)
+	defer t.detach(sub)
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	rc := http.NewResponseController(w)
+	_ = rc.Flush()
+
+	for sent := uint64(0); limit == 0 || sent < limit; sent++ {
+		select {
+		case data := <-sub.ch:
+			if _, err = w.Write(data); err != nil {
+				return
+			}
+			if _, err = w.Write([]byte{'\n'}); err != nil {
+				return
+			}
+			if err = rc.Flush(); err != nil {
+				return
+			}
+		case <-sub.done:
+			return
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
+func parseTapParam(value string, defaultValue uint64) (uint64, error) {
+	if value == "" {
+		return defaultValue, nil
+	}
+	return strconv.ParseUint(value, 10, 64)
+}
+
+// findTap returns the tap for the given pipeline and component:
+//   - "receiver" kind, or no kind, taps the data the receivers send to the pipeline;
+//   - "processor" kind taps the data the named processor receives in the pipeline;
+//   - "exporter" or "connector" kind taps the data the named exporter or connector receives,
+//     from every pipeline of the same signal. Without a name, taps the data the pipeline
+//     sends to its exporters.
+func (g *Graph) findTap(pipelineName, kind, componentName string) (*tap, error) {
+	var pipelineID pipeline.ID
+	if err := pipelineID.UnmarshalText([]byte(pipelineName)); err != nil {
+		return nil, fmt.Errorf("invalid pipeline %q: %w", pipelineName, err)
+	}
+	p, ok := g.pipelines[pipelineID]
+	if !ok {
+		return nil, fmt.Errorf("%w: pipeline %q not found", errTapNotFound, pipelineName)
+	}
+
+	var nodeID int64
+	found := false
+	switch kind {
+	case "", "receiver":
+		nodeID, found = p.capabilitiesNode.ID(), true
+	case "processor":
+		for _, n := range p.processors {
+			if n.(*processorNode).componentID.String() == componentName {
+				nodeID, found = n.ID(), true
+			}
+		}
+	case "exporter", "connector":
+		if componentName == "" {
+			nodeID, found = p.fanOutNode.ID(), true
+			break
+		}
+		for _, n := range p.exporters {
+			var id component.ID
+			switch en := n.(type) {
+			case *exporterNode:
+				if kind != "exporter" {
+					continue
+				}
+				id = en.componentID
+			case *connectorNode:
+				if kind != "connector" {
+					continue
+				}
+				id = en.componentID
+			}
+			if id.String() == componentName {
+				nodeID, found = n.ID(), true
+			}
+		}
+	default:
+		return nil, fmt.Errorf("%w: unknown kind %q", errTapNotFound, kind)
+	}
+
+	point, ok := g.taps[nodeID]
+	if !found || !ok {
+		return nil, fmt.Errorf("%w: %s %q not found in pipeline %q", errTapNotFound, kind, componentName, pipelineName)
+	}
+	t := point.tap.Load()
+	if t == nil {
+		return nil, fmt.Errorf("%w: the taps are not enabled", errTapNotFound)
+	}
+	return t, nil
+}
@@ -0,0 +1,252 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package loglevel allows changing the level of the Collector's own logs at runtime.
+package loglevel // import "go.opentelemetry.io/collector/service/internal/loglevel"
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap/zapcore"
+
+	"go.opentelemetry.io/collector/internal/telemetry/componentattribute"
+)
+
+// MaxTTL is the longest time a level change can stay in effect.
+const MaxTTL = 24 * time.Hour
+
+var errInvalidTTL = fmt.Errorf("ttl must be positive and at most %v", MaxTTL)
+
+// Controller holds the current log levels and sampling. The configured level can be
+// overridden, for the whole Collector or for single components, and the configured
+// sampling for the whole Collector. Every override is reverted automatically once its
+// TTL expires.
+type Controller struct {
+	base         zapcore.Level
+	baseSampling Sampling
+	now          func() time.Time
+
+	mu               sync.Mutex
+	global           *override
+	components       map[string]*override
+	samplingOverride *samplingOverride
+
+	// levels is rebuilt on every change, so that checking a level does not need the lock.
+	levels atomic.Pointer[levels]
+	// sampling is replaced on every change, the cores rebuild their sampler when it changes.
+	sampling atomic.Pointer[Sampling]
+}
+
+type override struct {
+	level   zapcore.Level
+	expires time.Time
+	timer   *time.Timer
+}
+
+type levels struct {
+	global     zapcore.Level
+	components map[string]zapcore.Level
+}
+
+// NewController returns a Controller using the configured level and sampling until they are overridden.
+func NewController(base zapcore.Level, sampling Sampling) *Controller {
+	c := &Controller{
+		base:         base,
+		baseSampling: sampling,
+		now:          time.Now,
+		components:   make(map[string]*override),
+	}
+	c.levels.Store(&levels{global: base})
+	c.sampling.Store(&sampling)
+	return c
+}
+
+// Enabled reports whether logs at the given level are enabled for the component.
+// An empty component ID checks the Collector wide level.
+func (c *Controller) Enabled(componentID string, lvl zapcore.Level) bool {
+	l := c.levels.Load()
+	if componentLevel, ok := l.components[componentID]; ok && componentID != "" {
+		return lvl >= componentLevel
+	}
+	return lvl >= l.global
+}
+
+// Set overrides the level of the component, or the Collector wide level if componentID
+// is empty, for the given TTL. Setting a new level replaces the previous override.
+func (c *Controller) Set(componentID string, lvl zapcore.Level, ttl time.Duration) error {
+	if ttl <= 0 || ttl > MaxTTL {
+		return errInvalidTTL
+	}
+	if lvl < zapcore.DebugLevel || lvl > zapcore.FatalLevel {
+		return fmt.Errorf("invalid level %q", lvl)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.stopLocked(componentID)
+	o := &override{level: lvl, expires: c.now().Add(ttl)}
+	o.timer = time.AfterFunc(ttl, func() { c.expire(componentID, o) })
+	if componentID == "" {
+		c.global = o
+	} else {
+		c.components[componentID] = o
+	}
+	c.updateLocked()
+	return nil
+}
+
+// Reset removes the override of the component, or of the Collector wide level if componentID is empty.
+func (c *Controller) Reset(componentID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.stopLocked(componentID)
+	c.updateLocked()
+}
+
+// Shutdown removes all the overrides.
+func (c *Controller) Shutdown() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.stopLocked("")
+	for id := range c.components {
+		c.stopLocked(id)
+	}
+	c.updateLocked()
+	c.stopSamplingLocked()
+}
+
+// expire removes the override if it was not replaced in the meantime.
+func (c *Controller) expire(componentID string, o *override) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if componentID == "" && c.global != o {
+		return
+	}
+	if componentID != "" && c.components[componentID] != o {
+		return
+	}
+	c.stopLocked(componentID)
+	c.updateLocked()
+}
+
+func (c *Controller) stopLocked(componentID string) {
+	if componentID == "" {
+		if c.global != nil {
+			c.global.timer.Stop()
+			c.global = nil
+		}
+		return
+	}
+	if o, ok := c.components[componentID]; ok {
+		o.timer.Stop()
+		delete(c.components, componentID)
+	}
+}
+
+func (c *Controller) updateLocked() {
+	l := &levels{global: c.base}
+	if c.global != nil {
+		l.global = c.global.level
+	}
+	if len(c.components) > 0 {
+		l.components = make(map[string]zapcore.Level, len(c.components))
+		for id, o := range c.components {
+			l.components[id] = o.level
+		}
+	}
+	c.levels.Store(l)
+}
+
+// Level is the JSON representation of a level override.
+type Level struct {
+	Level   string    `json:"level"`
+	Expires time.Time `json:"expires"`
+}
+
+// Status is the JSON representation of the current levels.
+type Status struct {
+	// Configured is the level set in service::telemetry::logs::level.
+	Configured string `json:"configured"`
+	// Level is the Collector wide level currently in effect.
+	Level string `json:"level"`
+	// Override is the Collector wide override, if any.
+	Override *Level `json:"override,omitempty"`
+	// Components are the per component overrides, keyed by component ID.
+	Components map[string]Level `json:"components"`
+	// Sampling is the sampling currently in effect.
+	Sampling SamplingStatus `json:"sampling"`
+}
+
+// SamplingStatus is the JSON representation of the current sampling.
+type SamplingStatus struct {
+	// Configured is the sampling set in service::telemetry::logs::sampling.
+	Configured Sampling `json:"configured"`
+	// Current is the sampling currently in effect.
+	Current Sampling `json:"current"`
+	// Expires is when the override of the sampling expires, if any.
+	Expires *time.Time `json:"expires,omitempty"`
+}
+
+// Status returns the current levels and overrides.
+func (c *Controller) Status() Status {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	s := Status{
+		Configured: c.base.String(),
+		Level:      c.base.String(),
+		Components: make(map[string]Level, len(c.components)),
+		Sampling:   SamplingStatus{Configured: c.baseSampling, Current: c.baseSampling},
+	}
+	if c.samplingOverride != nil {
+		s.Sampling.Current = c.samplingOverride.sampling
+		s.Sampling.Expires = &c.samplingOverride.expires
+	}
+	if c.global != nil {
+		s.Level = c.global.level.String()
+		s.Override = &Level{Level: c.global.level.String(), Expires: c.global.expires}
+	}
+	for id, o := range c.components {
+		s.Components[id] = Level{Level: o.level.String(), Expires: o.expires}
+	}
+	return s
+}
+
+// WrapCore returns a core filtering and sampling the logs of core according to the current levels and sampling.
+// The component ID is taken from the component attributes set on the core, see [componentattribute].
+// The logs of each component are sampled separately.
+//
+// The level of core itself must be low enough to let through every level that can be set at runtime.
+func (c *Controller) WrapCore(core zapcore.Core) zapcore.Core {
+	return componentattribute.NewWrapperCoreWithAttributeSet(core, attribute.NewSet(), func(inner zapcore.Core, attrs attribute.Set) zapcore.Core {
+		var componentID string
+		if v, ok := attrs.Value(componentattribute.ComponentIDKey); ok {
+			componentID = v.AsString()
+		}
+		return &levelCore{Core: newSamplingCore(inner, c), controller: c, componentID: componentID}
+	})
+}
+
+type levelCore struct {
+	zapcore.Core
+	controller  *Controller
+	componentID string
+}
+
+func (lc *levelCore) Enabled(lvl zapcore.Level) bool {
+	return lc.controller.Enabled(lc.componentID, lvl)
+}
+
+func (lc *levelCore) With(fields []zapcore.Field) zapcore.Core {
+	return &levelCore{Core: lc.Core.With(fields), controller: lc.controller, componentID: lc.componentID}
+}
+
+func (lc *levelCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !lc.Enabled(ent.Level) {
+		return ce
+	}
+	return lc.Core.Check(ent, ce)
+}
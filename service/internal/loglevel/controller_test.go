@@ -0,0 +1,216 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package loglevel
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"go.opentelemetry.io/collector/internal/telemetry/componentattribute"
+)
+
+func TestControllerSetReset(t *testing.T) {
+	c := NewController(zapcore.InfoLevel, Sampling{})
+	t.Cleanup(c.Shutdown)
+
+	assert.False(t, c.Enabled("", zapcore.DebugLevel))
+	assert.False(t, c.Enabled("otlp", zapcore.DebugLevel))
+
+	require.NoError(t, c.Set("otlp", zapcore.DebugLevel, time.Minute))
+	assert.False(t, c.Enabled("", zapcore.DebugLevel))
+	assert.True(t, c.Enabled("otlp", zapcore.DebugLevel))
+	assert.False(t, c.Enabled("batch", zapcore.DebugLevel))
+
+	require.NoError(t, c.Set("", zapcore.ErrorLevel, time.Minute))
+	assert.False(t, c.Enabled("batch", zapcore.WarnLevel))
+	assert.True(t, c.Enabled("otlp", zapcore.DebugLevel))
+
+	status := c.Status()
+	assert.Equal(t, "info", status.Configured)
+	assert.Equal(t, "error", status.Level)
+	require.NotNil(t, status.Override)
+	require.Contains(t, status.Components, "otlp")
+	assert.Equal(t, "debug", status.Components["otlp"].Level)
+
+	c.Reset("otlp")
+	assert.False(t, c.Enabled("otlp", zapcore.DebugLevel))
+	c.Reset("")
+	assert.True(t, c.Enabled("batch", zapcore.WarnLevel))
+	assert.Equal(t, Status{Configured: "info", Level: "info", Components: map[string]Level{}}, c.Status())
+}
+
+func TestControllerInvalid(t *testing.T) {
+	c := NewController(zapcore.InfoLevel, Sampling{})
+	t.Cleanup(c.Shutdown)
+
+	require.ErrorIs(t, c.Set("", zapcore.DebugLevel, 0), errInvalidTTL)
+	require.ErrorIs(t, c.Set("", zapcore.DebugLevel, MaxTTL+time.Second), errInvalidTTL)
+	require.Error(t, c.Set("", zapcore.InvalidLevel, time.Minute))
+}
+
+func TestControllerExpire(t *testing.T) {
+	c := NewController(zapcore.InfoLevel, Sampling{})
+	t.Cleanup(c.Shutdown)
+
+	require.NoError(t, c.Set("", zapcore.DebugLevel, 10*time.Millisecond))
+	require.NoError(t, c.Set("otlp", zapcore.DebugLevel, 10*time.Millisecond))
+	assert.True(t, c.Enabled("", zapcore.DebugLevel))
+	assert.Eventually(t, func() bool {
+		return !c.Enabled("", zapcore.DebugLevel) && !c.Enabled("otlp", zapcore.DebugLevel)
+	}, time.Second, time.Millisecond)
+}
+
+func TestControllerExpireReplaced(t *testing.T) {
+	c := NewController(zapcore.InfoLevel, Sampling{})
+	t.Cleanup(c.Shutdown)
+
+	require.NoError(t, c.Set("", zapcore.DebugLevel, time.Minute))
+	o := c.global
+	require.NoError(t, c.Set("", zapcore.WarnLevel, time.Minute))
+	// A stale timer must not remove the new override.
+	c.expire("", o)
+	assert.Equal(t, "warn", c.Status().Level)
+}
+
+func TestWrapCore(t *testing.T) {
+	c := NewController(zapcore.InfoLevel, Sampling{})
+	t.Cleanup(c.Shutdown)
+
+	core, observed := observer.New(zapcore.DebugLevel)
+	logger := zap.New(c.WrapCore(componentattribute.NewConsoleCoreWithAttributes(core, attribute.NewSet())))
+	otlp := componentattribute.ZapLoggerWithAttributes(logger, attribute.NewSet(attribute.String(componentattribute.ComponentIDKey, "otlp")))
+	batch := componentattribute.ZapLoggerWithAttributes(logger, attribute.NewSet(attribute.String(componentattribute.ComponentIDKey, "batch")))
+
+	require.NoError(t, c.Set("otlp", zapcore.DebugLevel, time.Minute))
+	logger.Debug("service")
+	otlp.Debug("otlp")
+	otlp.With(zap.String("key", "value")).Debug("otlp with")
+	batch.Debug("batch")
+	batch.Info("batch info")
+
+	var messages []string
+	for _, entry := range observed.All() {
+		messages = append(messages, entry.Message)
+	}
+	assert.Equal(t, []string{"otlp", "otlp with", "batch info"}, messages)
+}
+
+func TestControllerSampling(t *testing.T) {
+	configured := Sampling{Enabled: true, Tick: time.Minute, Initial: 2, Thereafter: 0}
+	c := NewController(zapcore.InfoLevel, configured)
+	t.Cleanup(c.Shutdown)
+
+	core, observed := observer.New(zapcore.DebugLevel)
+	logger := zap.New(c.WrapCore(componentattribute.NewConsoleCoreWithAttributes(core, attribute.NewSet())))
+	otlp := componentattribute.ZapLoggerWithAttributes(logger, attribute.NewSet(attribute.String(componentattribute.ComponentIDKey, "otlp"))).With(zap.String("key", "value"))
+	logN := func(l *zap.Logger, n int) {
+		for i := 0; i < n; i++ {
+			l.Info("message")
+		}
+	}
+
+	// The configured sampling applies until it is overridden, separately for each component.
+	logN(logger, 5)
+	logN(otlp, 5)
+	assert.Len(t, observed.TakeAll(), 4)
+
+	require.NoError(t, c.SetSampling(Sampling{}, time.Minute))
+	logN(logger, 5)
+	logN(otlp, 5)
+	assert.Len(t, observed.TakeAll(), 10)
+	status := c.Status().Sampling
+	assert.Equal(t, configured, status.Configured)
+	assert.Equal(t, Sampling{}, status.Current)
+	assert.NotNil(t, status.Expires)
+
+	require.NoError(t, c.SetSampling(Sampling{Enabled: true, Tick: time.Minute, Initial: 1}, 10*time.Millisecond))
+	logN(otlp, 5)
+	assert.Len(t, observed.TakeAll(), 1)
+	// The configured sampling is restored with new counts once the override expires.
+	assert.Eventually(t, func() bool { return c.Status().Sampling.Expires == nil }, time.Second, time.Millisecond)
+	logN(otlp, 5)
+	assert.Len(t, observed.TakeAll(), 2)
+
+	require.ErrorIs(t, c.SetSampling(Sampling{}, 0), errInvalidTTL)
+	require.Error(t, c.SetSampling(Sampling{Enabled: true}, time.Minute))
+	require.Error(t, c.SetSampling(Sampling{Enabled: true, Tick: time.Second, Initial: -1}, time.Minute))
+}
+
+func TestHandleZPages(t *testing.T) {
+	c := NewController(zapcore.InfoLevel, Sampling{})
+	t.Cleanup(c.Shutdown)
+
+	post := func(values url.Values) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/debug/loglevelz", strings.NewReader(values.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rr := httptest.NewRecorder()
+		c.HandleZPages(rr, req)
+		return rr
+	}
+
+	rr := post(url.Values{"level": {"debug"}, "component": {"otlp"}, "ttl": {"1m"}})
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"otlp":{"level":"debug"`)
+	assert.True(t, c.Enabled("otlp", zapcore.DebugLevel))
+
+	rr = post(url.Values{"level": {"debug"}})
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"level":"debug"`)
+	require.NotNil(t, c.Status().Override)
+	assert.WithinDuration(t, time.Now().Add(DefaultTTL), c.Status().Override.Expires, time.Minute)
+
+	for _, values := range []url.Values{
+		{},
+		{"level": {"loud"}},
+		{"level": {"debug"}, "ttl": {"forever"}},
+		{"level": {"debug"}, "ttl": {"48h"}},
+		{"sampling": {"maybe"}},
+		{"sampling": {"true"}, "component": {"otlp"}},
+		{"sampling": {"true"}, "sampling_tick": {"0s"}},
+		{"sampling": {"true"}, "sampling_initial": {"ten"}},
+		{"level": {"debug"}, "sampling": {"true"}, "sampling_thereafter": {"-1"}},
+	} {
+		assert.Equal(t, http.StatusBadRequest, post(values).Code, values)
+	}
+
+	rr = post(url.Values{"sampling": {"true"}, "sampling_initial": {"5"}})
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, Sampling{Enabled: true, Tick: 10 * time.Second, Initial: 5, Thereafter: 100}, c.Status().Sampling.Current)
+	rr = post(url.Values{"sampling": {"false"}, "level": {"warn"}})
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, Sampling{}, c.Status().Sampling.Current)
+	assert.Equal(t, "warn", c.Status().Level)
+
+	rr = httptest.NewRecorder()
+	c.HandleZPages(rr, httptest.NewRequest(http.MethodDelete, "/debug/loglevelz?component=otlp", http.NoBody))
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.NotContains(t, c.Status().Components, "otlp")
+	assert.NotNil(t, c.Status().Sampling.Expires)
+
+	rr = httptest.NewRecorder()
+	c.HandleZPages(rr, httptest.NewRequest(http.MethodDelete, "/debug/loglevelz", http.NoBody))
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.Nil(t, c.Status().Override)
+	assert.Nil(t, c.Status().Sampling.Expires)
+
+	rr = httptest.NewRecorder()
+	c.HandleZPages(rr, httptest.NewRequest(http.MethodGet, "/debug/loglevelz", http.NoBody))
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+
+	rr = httptest.NewRecorder()
+	c.HandleZPages(rr, httptest.NewRequest(http.MethodPut, "/debug/loglevelz", http.NoBody))
+	assert.Equal(t, http.StatusMethodNotAllowed, rr.Code)
+}
@@ -0,0 +1,164 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package loglevel // import "go.opentelemetry.io/collector/service/internal/loglevel"
+
+import (
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// Sampling is a sampling strategy for the logs, see service::telemetry::logs::sampling.
+type Sampling struct {
+	Enabled bool `json:"enabled"`
+	// Tick is the interval after which the counts are reset.
+	Tick time.Duration `json:"tick"`
+	// Initial is the number of identical messages logged every Tick.
+	Initial int `json:"initial"`
+	// Thereafter is the rate at which the identical messages are logged after Initial, 0 drops them.
+	Thereafter int `json:"thereafter"`
+}
+
+func (s Sampling) validate() error {
+	if !s.Enabled {
+		return nil
+	}
+	if s.Tick <= 0 {
+		return errors.New("sampling tick must be positive")
+	}
+	if s.Initial < 0 || s.Thereafter < 0 {
+		return errors.New("sampling initial and thereafter must not be negative")
+	}
+	return nil
+}
+
+// SetSampling overrides the Collector wide sampling for the given TTL.
+// Setting a new sampling replaces the previous override.
+func (c *Controller) SetSampling(s Sampling, ttl time.Duration) error {
+	if ttl <= 0 || ttl > MaxTTL {
+		return errInvalidTTL
+	}
+	if err := s.validate(); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.stopSamplingLocked()
+	o := &samplingOverride{sampling: s, expires: c.now().Add(ttl)}
+	o.timer = time.AfterFunc(ttl, func() { c.expireSampling(o) })
+	c.samplingOverride = o
+	c.sampling.Store(&o.sampling)
+	return nil
+}
+
+// ResetSampling removes the override of the sampling.
+func (c *Controller) ResetSampling() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.stopSamplingLocked()
+}
+
+// expireSampling removes the override if it was not replaced in the meantime.
+func (c *Controller) expireSampling(o *samplingOverride) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.samplingOverride == o {
+		c.stopSamplingLocked()
+	}
+}
+
+func (c *Controller) stopSamplingLocked() {
+	if c.samplingOverride == nil {
+		return
+	}
+	c.samplingOverride.timer.Stop()
+	c.samplingOverride = nil
+	base := c.baseSampling
+	c.sampling.Store(&base)
+}
+
+type samplingOverride struct {
+	sampling Sampling
+	expires  time.Time
+	timer    *time.Timer
+}
+
+// samplingRoot holds the sampler of a core for the current sampling. The sampler is
+// replaced when the sampling changes, the cores derived from it share its counts.
+type samplingRoot struct {
+	core       zapcore.Core
+	controller *Controller
+	current    atomic.Pointer[sampler]
+}
+
+type sampler struct {
+	sampling *Sampling
+	core     zapcore.Core
+}
+
+func (r *samplingRoot) sampler() *sampler {
+	s := r.controller.sampling.Load()
+	if cur := r.current.Load(); cur != nil && cur.sampling == s {
+		return cur
+	}
+	next := &sampler{sampling: s, core: r.core}
+	if s.Enabled {
+		next.core = zapcore.NewSamplerWithOptions(r.core, s.Tick, s.Initial, s.Thereafter)
+	}
+	r.current.Store(next)
+	return next
+}
+
+// samplingCore samples the logs according to the current sampling of the controller.
+type samplingCore struct {
+	root   *samplingRoot
+	fields []zapcore.Field
+	cached atomic.Pointer[sampledCore]
+}
+
+type sampledCore struct {
+	sampler *sampler
+	core    zapcore.Core
+}
+
+func newSamplingCore(core zapcore.Core, c *Controller) *samplingCore {
+	return &samplingCore{root: &samplingRoot{core: core, controller: c}}
+}
+
+// current returns the sampler of the root with the fields of the core.
+func (sc *samplingCore) current() zapcore.Core {
+	s := sc.root.sampler()
+	if cached := sc.cached.Load(); cached != nil && cached.sampler == s {
+		return cached.core
+	}
+	core := s.core
+	if len(sc.fields) > 0 {
+		core = core.With(sc.fields)
+	}
+	sc.cached.Store(&sampledCore{sampler: s, core: core})
+	return core
+}
+
+func (sc *samplingCore) Enabled(lvl zapcore.Level) bool {
+	return sc.root.core.Enabled(lvl)
+}
+
+func (sc *samplingCore) With(fields []zapcore.Field) zapcore.Core {
+	return &samplingCore{root: sc.root, fields: append(sc.fields[:len(sc.fields):len(sc.fields)], fields...)}
+}
+
+func (sc *samplingCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	return sc.current().Check(ent, ce)
+}
+
+func (sc *samplingCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	return sc.current().Write(ent, fields)
+}
+
+func (sc *samplingCore) Sync() error {
+	return sc.root.core.Sync()
+}
@@ -0,0 +1,141 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package loglevel // import "go.opentelemetry.io/collector/service/internal/loglevel"
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	zLevel       = "level"
+	zComponentID = "component"
+	zTTL         = "ttl"
+
+	zSampling           = "sampling"
+	zSamplingTick       = "sampling_tick"
+	zSamplingInitial    = "sampling_initial"
+	zSamplingThereafter = "sampling_thereafter"
+
+	// DefaultTTL is the TTL of a level change when none is given.
+	DefaultTTL = 10 * time.Minute
+)
+
+// HandleZPages shows and changes the log levels and sampling:
+//   - GET returns the current levels and sampling as JSON;
+//   - POST sets the "level" parameter for the "component" parameter, or for the whole
+//     Collector without component, and the Collector wide sampling if the "sampling"
+//     parameter is set, during the "ttl" parameter or DefaultTTL;
+//   - DELETE removes the override of the "component" parameter, or the Collector wide
+//     level and sampling overrides.
+func (c *Controller) HandleZPages(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPost:
+		if err := c.change(r); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	case http.MethodDelete:
+		componentID := r.FormValue(zComponentID)
+		c.Reset(componentID)
+		if componentID == "" {
+			c.ResetSampling()
+		}
+	default:
+		w.Header().Set("Allow", "GET, POST, DELETE")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(c.Status())
+}
+
+// change applies the level and sampling changes of the request, the request is
+// validated entirely before anything is changed.
+func (c *Controller) change(r *http.Request) error {
+	levelText, samplingText := r.FormValue(zLevel), r.FormValue(zSampling)
+	if levelText == "" && samplingText == "" {
+		return fmt.Errorf("missing %s or %s", zLevel, zSampling)
+	}
+	ttl := DefaultTTL
+	if text := r.FormValue(zTTL); text != "" {
+		var err error
+		if ttl, err = time.ParseDuration(text); err != nil {
+			return fmt.Errorf("invalid %s: %w", zTTL, err)
+		}
+	}
+	if ttl <= 0 || ttl > MaxTTL {
+		return errInvalidTTL
+	}
+
+	componentID := r.FormValue(zComponentID)
+	lvl := zapcore.InvalidLevel
+	if levelText != "" {
+		var err error
+		if lvl, err = zapcore.ParseLevel(levelText); err != nil {
+			return fmt.Errorf("invalid %s: %w", zLevel, err)
+		}
+	}
+	var sampling Sampling
+	if samplingText != "" {
+		if componentID != "" {
+			return errors.New("the sampling can only be changed for the whole Collector")
+		}
+		var err error
+		if sampling, err = c.parseSampling(r, samplingText); err != nil {
+			return err
+		}
+	}
+
+	if levelText != "" {
+		if err := c.Set(componentID, lvl, ttl); err != nil {
+			return err
+		}
+	}
+	if samplingText != "" {
+		return c.SetSampling(sampling, ttl)
+	}
+	return nil
+}
+
+// defaultSampling is used for the parameters not set when enabling the sampling, unless it is configured.
+var defaultSampling = Sampling{Enabled: true, Tick: 10 * time.Second, Initial: 10, Thereafter: 100}
+
+func (c *Controller) parseSampling(r *http.Request, text string) (Sampling, error) {
+	enabled, err := strconv.ParseBool(text)
+	if err != nil {
+		return Sampling{}, fmt.Errorf("invalid %s: %w", zSampling, err)
+	}
+	if !enabled {
+		return Sampling{}, nil
+	}
+	s := defaultSampling
+	if c.baseSampling.Enabled {
+		s = c.baseSampling
+	}
+	if text = r.FormValue(zSamplingTick); text != "" {
+		if s.Tick, err = time.ParseDuration(text); err != nil {
+			return Sampling{}, fmt.Errorf("invalid %s: %w", zSamplingTick, err)
+		}
+	}
+	if text = r.FormValue(zSamplingInitial); text != "" {
+		if s.Initial, err = strconv.Atoi(text); err != nil {
+			return Sampling{}, fmt.Errorf("invalid %s: %w", zSamplingInitial, err)
+		}
+	}
+	if text = r.FormValue(zSamplingThereafter); text != "" {
+		if s.Thereafter, err = strconv.Atoi(text); err != nil {
+			return Sampling{}, fmt.Errorf("invalid %s: %w", zSamplingThereafter, err)
+		}
+	}
+	return s, s.validate()
+}
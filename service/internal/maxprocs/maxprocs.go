@@ -0,0 +1,138 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package maxprocs sets GOMAXPROCS from the CPU quota of the cgroup of the Collector.
+package maxprocs // import "go.opentelemetry.io/collector/service/internal/maxprocs"
+
+import (
+	"math"
+	"os"
+	"runtime"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/internal/memorylimiter/iruntime"
+)
+
+const envGOMAXPROCS = "GOMAXPROCS"
+
+// Settings configures a Tuner.
+type Settings struct {
+	Logger *zap.Logger
+	// MaxProcs overrides GOMAXPROCS when positive.
+	MaxProcs int
+	// CheckInterval is how often the CPU quota is checked again. Zero checks it only at startup.
+	CheckInterval time.Duration
+}
+
+// Tuner sets GOMAXPROCS, and restores it when shut down.
+type Tuner struct {
+	logger        *zap.Logger
+	maxProcs      int
+	checkInterval time.Duration
+	// previous is the value of GOMAXPROCS before Start, restored by Shutdown.
+	previous int
+
+	// The functions to read the CPU quota and the environment, and to set GOMAXPROCS,
+	// are set as a reference to help with testing.
+	cpuQuotaFn   func() (float64, bool, error)
+	lookupEnvFn  func(string) (string, bool)
+	numCPUFn     func() int
+	gomaxprocsFn func(int) int
+
+	stop chan struct{}
+	done sync.WaitGroup
+}
+
+// New creates a Tuner.
+func New(set Settings) *Tuner {
+	return newTuner(set, iruntime.CPUQuota, os.LookupEnv, runtime.NumCPU, runtime.GOMAXPROCS)
+}
+
+func newTuner(set Settings, cpuQuotaFn func() (float64, bool, error), lookupEnvFn func(string) (string, bool), numCPUFn func() int, gomaxprocsFn func(int) int) *Tuner {
+	return &Tuner{
+		logger:        set.Logger,
+		checkInterval: set.CheckInterval,
+		maxProcs:      set.MaxProcs,
+		cpuQuotaFn:    cpuQuotaFn,
+		lookupEnvFn:   lookupEnvFn,
+		numCPUFn:      numCPUFn,
+		gomaxprocsFn:  gomaxprocsFn,
+		stop:          make(chan struct{}),
+	}
+}
+
+// Start sets GOMAXPROCS, to the configured value if any. Otherwise, unless the GOMAXPROCS
+// environment variable is set, it sets it from the CPU quota and checks the quota again
+// every check interval.
+func (t *Tuner) Start() {
+	t.previous = t.gomaxprocsFn(0)
+	if t.maxProcs > 0 {
+		t.gomaxprocsFn(t.maxProcs)
+		t.logger.Info("Setting GOMAXPROCS from service::telemetry::runtime::max_procs.",
+			zap.Int("gomaxprocs", t.maxProcs),
+			zap.Int("previous", t.previous))
+		return
+	}
+	if value, ok := t.lookupEnvFn(envGOMAXPROCS); ok {
+		t.logger.Info("Keeping GOMAXPROCS set through the environment.", zap.String("gomaxprocs", value))
+		return
+	}
+
+	t.update()
+	if t.checkInterval <= 0 {
+		return
+	}
+	t.done.Add(1)
+	go func() {
+		defer t.done.Done()
+		ticker := time.NewTicker(t.checkInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				t.update()
+			case <-t.stop:
+				return
+			}
+		}
+	}()
+}
+
+// update sets GOMAXPROCS from the CPU quota. Without a quota, GOMAXPROCS is kept as it was before Start.
+func (t *Tuner) update() {
+	quota, defined, err := t.cpuQuotaFn()
+	if err != nil {
+		t.logger.Warn("Failed to read the CPU quota, keeping GOMAXPROCS.", zap.Error(err))
+		return
+	}
+	procs := t.previous
+	if defined {
+		// Round down, a fraction of a CPU is not enough for another thread to run Go code.
+		procs = min(t.numCPUFn(), max(1, int(math.Floor(quota))))
+	}
+
+	previous := t.gomaxprocsFn(procs)
+	if previous == procs {
+		return
+	}
+	if !defined {
+		t.logger.Info("Restoring GOMAXPROCS, the CPU quota was removed.",
+			zap.Int("gomaxprocs", procs),
+			zap.Int("previous", previous))
+		return
+	}
+	t.logger.Info("Setting GOMAXPROCS from the CPU quota.",
+		zap.Int("gomaxprocs", procs),
+		zap.Int("previous", previous),
+		zap.Float64("cpu_quota", quota))
+}
+
+// Shutdown stops checking the CPU quota, and restores GOMAXPROCS.
+func (t *Tuner) Shutdown() {
+	close(t.stop)
+	t.done.Wait()
+	t.gomaxprocsFn(t.previous)
+}
@@ -0,0 +1,125 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package maxprocs
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+// fakeRuntime records GOMAXPROCS, and returns the configured CPU quota and environment.
+type fakeRuntime struct {
+	mu         sync.Mutex
+	gomaxprocs int
+	quota      float64
+	defined    bool
+	err        error
+	env        map[string]string
+}
+
+func (r *fakeRuntime) tuner(set Settings) *Tuner {
+	set.Logger = zap.NewNop()
+	return newTuner(set, r.cpuQuota, r.lookupEnv, func() int { return 8 }, r.setGOMAXPROCS)
+}
+
+func (r *fakeRuntime) cpuQuota() (float64, bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.quota, r.defined, r.err
+}
+
+func (r *fakeRuntime) lookupEnv(key string) (string, bool) {
+	value, ok := r.env[key]
+	return value, ok
+}
+
+func (r *fakeRuntime) setGOMAXPROCS(n int) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	previous := r.gomaxprocs
+	if n > 0 {
+		r.gomaxprocs = n
+	}
+	return previous
+}
+
+func (r *fakeRuntime) setQuota(quota float64, defined bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.quota, r.defined = quota, defined
+}
+
+func (r *fakeRuntime) get() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.gomaxprocs
+}
+
+func TestTunerCPUQuota(t *testing.T) {
+	rt := &fakeRuntime{gomaxprocs: 6, quota: 2.5, defined: true}
+	tuner := rt.tuner(Settings{CheckInterval: time.Millisecond})
+
+	tuner.Start()
+	assert.Equal(t, 2, rt.get())
+
+	// The quota is checked again periodically.
+	rt.setQuota(0.5, true)
+	assert.Eventually(t, func() bool { return rt.get() == 1 }, time.Second, time.Millisecond)
+	// Without a quota, GOMAXPROCS is set back to its value before Start, not to the number of CPUs.
+	rt.setQuota(-1, false)
+	assert.Eventually(t, func() bool { return rt.get() == 6 }, time.Second, time.Millisecond)
+
+	rt.setQuota(4, true)
+	assert.Eventually(t, func() bool { return rt.get() == 4 }, time.Second, time.Millisecond)
+	tuner.Shutdown()
+	assert.Equal(t, 6, rt.get())
+}
+
+func TestTunerNoCPUQuota(t *testing.T) {
+	rt := &fakeRuntime{gomaxprocs: 3, quota: -1, defined: false}
+	tuner := rt.tuner(Settings{CheckInterval: time.Millisecond})
+	tuner.Start()
+	assert.Never(t, func() bool { return rt.get() != 3 }, 20*time.Millisecond, time.Millisecond)
+	tuner.Shutdown()
+	assert.Equal(t, 3, rt.get())
+}
+
+func TestTunerQuotaAboveNumCPU(t *testing.T) {
+	rt := &fakeRuntime{gomaxprocs: 8, quota: 16, defined: true}
+	tuner := rt.tuner(Settings{})
+	tuner.Start()
+	assert.Equal(t, 8, rt.get())
+	tuner.Shutdown()
+}
+
+func TestTunerQuotaError(t *testing.T) {
+	rt := &fakeRuntime{gomaxprocs: 8, err: errors.New("invalid cgroup")}
+	tuner := rt.tuner(Settings{})
+	tuner.Start()
+	assert.Equal(t, 8, rt.get())
+	tuner.Shutdown()
+}
+
+func TestTunerOverride(t *testing.T) {
+	rt := &fakeRuntime{gomaxprocs: 8, quota: 2, defined: true, env: map[string]string{envGOMAXPROCS: "6"}}
+	tuner := rt.tuner(Settings{MaxProcs: 3, CheckInterval: time.Millisecond})
+	tuner.Start()
+	assert.Equal(t, 3, rt.get())
+	tuner.Shutdown()
+	assert.Equal(t, 8, rt.get())
+}
+
+func TestTunerEnvironment(t *testing.T) {
+	rt := &fakeRuntime{gomaxprocs: 6, quota: 2, defined: true, env: map[string]string{envGOMAXPROCS: "6"}}
+	tuner := rt.tuner(Settings{CheckInterval: time.Millisecond})
+	tuner.Start()
+	assert.Never(t, func() bool { return rt.get() != 6 }, 20*time.Millisecond, time.Millisecond)
+	tuner.Shutdown()
+	assert.Equal(t, 6, rt.get())
+}
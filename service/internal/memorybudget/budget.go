@@ -0,0 +1,66 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package memorybudget implements the memory budget shared by the receivers, see service::memory_budget.
+package memorybudget // import "go.opentelemetry.io/collector/service/internal/memorybudget"
+
+import (
+	"context"
+	"sync/atomic"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/service/internal/metadata"
+)
+
+// Budget accounts for the memory reserved by the receivers for the requests in flight.
+type Budget struct {
+	limit    int64
+	reserved atomic.Int64
+	tb       *metadata.TelemetryBuilder
+}
+
+// New creates a Budget of limit bytes.
+func New(set component.TelemetrySettings, limit int64) (*Budget, error) {
+	tb, err := metadata.NewTelemetryBuilder(set)
+	if err != nil {
+		return nil, err
+	}
+	return &Budget{limit: limit, tb: tb}, nil
+}
+
+// Reserve reserves size bytes for a request received by the receiver id. It returns false if
+// the budget is exhausted, otherwise a function releasing the memory.
+//
+// A request larger than the whole budget is accepted when nothing else is reserved, so that
+// it is not refused forever.
+func (b *Budget) Reserve(id component.ID, size int64) (func(), bool) {
+	for {
+		reserved := b.reserved.Load()
+		if reserved > 0 && reserved+size > b.limit {
+			b.tb.MemoryBudgetRefusedRequests.Add(context.Background(), 1,
+				metric.WithAttributes(attribute.String("receiver", id.String())))
+			return nil, false
+		}
+		if b.reserved.CompareAndSwap(reserved, reserved+size) {
+			break
+		}
+	}
+	b.tb.MemoryBudgetReserved.Add(context.Background(), size)
+	return func() {
+		b.reserved.Add(-size)
+		b.tb.MemoryBudgetReserved.Add(context.Background(), -size)
+	}, true
+}
+
+// Reserved returns the memory currently reserved.
+func (b *Budget) Reserved() int64 {
+	return b.reserved.Load()
+}
+
+// Shutdown unregisters the telemetry of the budget.
+func (b *Budget) Shutdown() {
+	b.tb.Shutdown()
+}
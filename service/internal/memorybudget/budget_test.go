@@ -0,0 +1,56 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package memorybudget
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/service/internal/metadatatest"
+)
+
+func TestBudget(t *testing.T) {
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tel.Shutdown(context.Background())) })
+	id := component.MustNewID("otlp")
+
+	b, err := New(tel.NewTelemetrySettings(), 100)
+	require.NoError(t, err)
+	defer b.Shutdown()
+
+	release1, ok := b.Reserve(id, 60)
+	require.True(t, ok)
+	release2, ok := b.Reserve(id, 40)
+	require.True(t, ok)
+	assert.Equal(t, int64(100), b.Reserved())
+
+	_, ok = b.Reserve(id, 1)
+	require.False(t, ok)
+
+	release1()
+	release2()
+	assert.Zero(t, b.Reserved())
+
+	// A request larger than the budget is accepted when nothing else is reserved.
+	release3, ok := b.Reserve(id, 150)
+	require.True(t, ok)
+	_, ok = b.Reserve(id, 1)
+	require.False(t, ok)
+	release3()
+
+	metadatatest.AssertEqualMemoryBudgetReserved(t, tel,
+		[]metricdata.DataPoint[int64]{{Value: 0}},
+		metricdatatest.IgnoreTimestamp())
+	metadatatest.AssertEqualMemoryBudgetRefusedRequests(t, tel,
+		[]metricdata.DataPoint[int64]{{Value: 2, Attributes: attribute.NewSet(attribute.String("receiver", "otlp"))}},
+		metricdatatest.IgnoreTimestamp())
+}
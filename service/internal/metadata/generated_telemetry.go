@@ -28,6 +28,11 @@ type TelemetryBuilder struct {
 	meter                             metric.Meter
 	mu                                sync.Mutex
 	registrations                     []metric.Registration
+	ExporterShutdownDroppedItems      metric.Int64Counter
+	ExporterShutdownQueuedRequests    metric.Int64Counter
+	MemoryBudgetRefusedRequests       metric.Int64Counter
+	MemoryBudgetReserved              metric.Int64UpDownCounter
+	PipelineLatency                   metric.Float64Histogram
 	ProcessCPUSeconds                 metric.Float64ObservableCounter
 	ProcessMemoryRss                  metric.Int64ObservableGauge
 	ProcessRuntimeHeapAllocBytes      metric.Int64ObservableGauge
@@ -175,6 +180,37 @@ func NewTelemetryBuilder(settings component.TelemetrySettings, options ...Teleme
 	}
 	builder.meter = Meter(settings)
 	var err, errs error
+	builder.ExporterShutdownDroppedItems, err = builder.meter.Int64Counter(
+		"otelcol_exporter_shutdown_dropped_items",
+		metric.WithDescription("Number of items the exporter dropped from its sending queue because the shutdown deadline was reached before the queue was drained. [development]"),
+		metric.WithUnit("{items}"),
+	)
+	errs = errors.Join(errs, err)
+	builder.ExporterShutdownQueuedRequests, err = builder.meter.Int64Counter(
+		"otelcol_exporter_shutdown_queued_requests",
+		metric.WithDescription("Number of requests left in the persistent sending queue of the exporter when it was shut down, sent after restart. [development]"),
+		metric.WithUnit("{requests}"),
+	)
+	errs = errors.Join(errs, err)
+	builder.MemoryBudgetRefusedRequests, err = builder.meter.Int64Counter(
+		"otelcol_memory_budget_refused_requests",
+		metric.WithDescription("Number of requests the receivers refused because the memory budget was exhausted, see service::memory_budget. [development]"),
+		metric.WithUnit("{requests}"),
+	)
+	errs = errors.Join(errs, err)
+	builder.MemoryBudgetReserved, err = builder.meter.Int64UpDownCounter(
+		"otelcol_memory_budget_reserved",
+		metric.WithDescription("Memory reserved by the receivers for the requests being processed or queued by the exporters, see service::memory_budget. [development]"),
+		metric.WithUnit("By"),
+	)
+	errs = errors.Join(errs, err)
+	builder.PipelineLatency, err = builder.meter.Float64Histogram(
+		"otelcol_pipeline_latency",
+		metric.WithDescription("Duration from the pipeline receiving data to an exporter completing its export. Only reported when service::telemetry::metrics::level is detailed. [development]"),
+		metric.WithUnit("s"),
+		metric.WithExplicitBucketBoundaries([]float64{0.001, 0.0025, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30}...),
+	)
+	errs = errors.Join(errs, err)
 	builder.ProcessCPUSeconds, err = builder.meter.Float64ObservableCounter(
 		"otelcol_process_cpu_seconds",
 		metric.WithDescription("Total CPU user and system time in seconds [alpha]"),
@@ -0,0 +1,70 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package selftelemetry // import "go.opentelemetry.io/collector/service/internal/selftelemetry"
+
+import (
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// putAttributes copies OpenTelemetry API attributes to a pdata map.
+func putAttributes(dest pcommon.Map, attrs []attribute.KeyValue) {
+	dest.EnsureCapacity(dest.Len() + len(attrs))
+	for _, kv := range attrs {
+		putAttribute(dest.PutEmpty(string(kv.Key)), kv.Value)
+	}
+}
+
+func putAttribute(dest pcommon.Value, v attribute.Value) {
+	switch v.Type() {
+	case attribute.BOOL:
+		dest.SetBool(v.AsBool())
+	case attribute.INT64:
+		dest.SetInt(v.AsInt64())
+	case attribute.FLOAT64:
+		dest.SetDouble(v.AsFloat64())
+	case attribute.BOOLSLICE:
+		s := dest.SetEmptySlice()
+		for _, b := range v.AsBoolSlice() {
+			s.AppendEmpty().SetBool(b)
+		}
+	case attribute.INT64SLICE:
+		s := dest.SetEmptySlice()
+		for _, i := range v.AsInt64Slice() {
+			s.AppendEmpty().SetInt(i)
+		}
+	case attribute.FLOAT64SLICE:
+		s := dest.SetEmptySlice()
+		for _, f := range v.AsFloat64Slice() {
+			s.AppendEmpty().SetDouble(f)
+		}
+	case attribute.STRINGSLICE:
+		s := dest.SetEmptySlice()
+		for _, str := range v.AsStringSlice() {
+			s.AppendEmpty().SetStr(str)
+		}
+	default:
+		dest.SetStr(v.Emit())
+	}
+}
+
+// putField copies a field, as encoded by a zapcore.MapObjectEncoder, to a pdata map.
+func putField(dest pcommon.Map, key string, value any) {
+	switch v := value.(type) {
+	case time.Duration:
+		dest.PutStr(key, v.String())
+	case time.Time:
+		dest.PutStr(key, v.Format(time.RFC3339Nano))
+	case fmt.Stringer:
+		dest.PutStr(key, v.String())
+	default:
+		if err := dest.PutEmpty(key).FromRaw(value); err != nil {
+			dest.PutStr(key, fmt.Sprint(value))
+		}
+	}
+}
@@ -0,0 +1,295 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package selftelemetry routes the Collector's own logs, metrics and traces into its pipelines.
+package selftelemetry // import "go.opentelemetry.io/collector/service/internal/selftelemetry"
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	config "go.opentelemetry.io/contrib/otelconf/v0.3.0"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/internal/telemetry/componentattribute"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+const (
+	// flushInterval is how often the buffered logs and spans are sent to the pipelines.
+	flushInterval = time.Second
+	// maxBuffered is the maximum number of log records, or spans, buffered between two flushes.
+	// Additional ones are dropped, so that the Collector's own telemetry never blocks it.
+	maxBuffered = 8192
+)
+
+// Settings configures a Hub.
+type Settings struct {
+	// Resource is set on all the telemetry sent to the pipelines.
+	Resource pcommon.Resource
+	// MetricsInterval is how often the metrics are collected and sent to the pipelines.
+	MetricsInterval time.Duration
+	// Views are applied to the metrics sent to the pipelines, as they are to the configured readers.
+	Views []config.View
+}
+
+// Hub buffers the Collector's own telemetry and sends it to the registered consumers.
+//
+// To avoid loops, the telemetry emitted by the components downstream of a consumer is not
+// sent back to it: the logs of an exporter failing to export logs would otherwise produce
+// more logs to export.
+type Hub struct {
+	resource        pcommon.Resource
+	flushInterval   time.Duration
+	metricsInterval time.Duration
+	reader          *sdkmetric.ManualReader
+	meterProvider   *sdkmetric.MeterProvider
+
+	// logsActive and tracesActive avoid any work while nothing is registered for the signal.
+	logsActive   atomic.Bool
+	tracesActive atomic.Bool
+	// excludedLogs and excludedTraces are the components whose telemetry must not be sent back.
+	excludedLogs   atomic.Pointer[map[string]struct{}]
+	excludedTraces atomic.Pointer[map[string]struct{}]
+
+	// mu guards the buffers.
+	mu     sync.Mutex
+	logs   *logsBuffer
+	traces *tracesBuffer
+
+	// sinksMu guards the sinks, it is held while sending data so that
+	// a consumer is not called anymore once it is unregistered.
+	sinksMu sync.Mutex
+	sinks   map[*sink]struct{}
+	stop    chan struct{}
+	done    chan struct{}
+}
+
+type sink struct {
+	logs     consumer.Logs
+	metrics  consumer.Metrics
+	traces   consumer.Traces
+	excluded []string
+}
+
+// NewHub creates a Hub. Nothing is buffered until a consumer is registered.
+func NewHub(set Settings) (*Hub, error) {
+	views, err := sdkViews(set.Views)
+	if err != nil {
+		return nil, err
+	}
+	reader := sdkmetric.NewManualReader()
+	h := &Hub{
+		resource:        set.Resource,
+		flushInterval:   flushInterval,
+		metricsInterval: set.MetricsInterval,
+		reader:          reader,
+		meterProvider:   sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader), sdkmetric.WithView(views...)),
+		logs:            newLogsBuffer(),
+		traces:          newTracesBuffer(),
+		sinks:           make(map[*sink]struct{}),
+	}
+	h.excludedLogs.Store(&map[string]struct{}{})
+	h.excludedTraces.Store(&map[string]struct{}{})
+	return h, nil
+}
+
+// ComponentKey identifies a component in the exclusion lists of the Hub.
+func ComponentKey(kind component.Kind, id component.ID) string {
+	return strings.ToLower(kind.String()) + " " + id.String()
+}
+
+// componentKeyFromAttributes returns the key of the component which emitted telemetry with the attributes.
+func componentKeyFromAttributes(attrs attribute.Set) string {
+	kind, _ := attrs.Value(componentattribute.ComponentKindKey)
+	id, _ := attrs.Value(componentattribute.ComponentIDKey)
+	return kind.AsString() + " " + id.AsString()
+}
+
+// RegisterLogs sends the Collector's own logs to next, except those of the excluded components.
+// The returned function stops sending them.
+func (h *Hub) RegisterLogs(next consumer.Logs, excluded []string) func() {
+	return h.register(&sink{logs: next, excluded: excluded})
+}
+
+// RegisterMetrics sends the Collector's own metrics to next. The returned function stops sending them.
+func (h *Hub) RegisterMetrics(next consumer.Metrics) func() {
+	return h.register(&sink{metrics: next})
+}
+
+// RegisterTraces sends the Collector's own spans to next, except those of the excluded components.
+// The returned function stops sending them.
+func (h *Hub) RegisterTraces(next consumer.Traces, excluded []string) func() {
+	return h.register(&sink{traces: next, excluded: excluded})
+}
+
+func (h *Hub) register(s *sink) func() {
+	h.sinksMu.Lock()
+	defer h.sinksMu.Unlock()
+	h.sinks[s] = struct{}{}
+	h.updateLocked()
+	if h.stop == nil {
+		h.stop = make(chan struct{})
+		h.done = make(chan struct{})
+		go h.run(h.stop, h.done)
+	}
+	var once sync.Once
+	return func() { once.Do(func() { h.unregister(s) }) }
+}
+
+func (h *Hub) unregister(s *sink) {
+	h.sinksMu.Lock()
+	delete(h.sinks, s)
+	h.updateLocked()
+	var stop, done chan struct{}
+	if len(h.sinks) == 0 {
+		stop, done = h.stop, h.done
+		h.stop, h.done = nil, nil
+	}
+	h.sinksMu.Unlock()
+
+	if stop != nil {
+		close(stop)
+		<-done
+	}
+}
+
+// updateLocked refreshes the state used when telemetry is emitted after the sinks changed.
+func (h *Hub) updateLocked() {
+	excludedLogs := make(map[string]struct{})
+	excludedTraces := make(map[string]struct{})
+	var logsActive, tracesActive bool
+	for s := range h.sinks {
+		switch {
+		case s.logs != nil:
+			logsActive = true
+			for _, key := range s.excluded {
+				excludedLogs[key] = struct{}{}
+			}
+		case s.traces != nil:
+			tracesActive = true
+			for _, key := range s.excluded {
+				excludedTraces[key] = struct{}{}
+			}
+		}
+	}
+	h.excludedLogs.Store(&excludedLogs)
+	h.excludedTraces.Store(&excludedTraces)
+	h.logsActive.Store(logsActive)
+	h.tracesActive.Store(tracesActive)
+}
+
+func (h *Hub) run(stop, done chan struct{}) {
+	defer close(done)
+	flushTicker := time.NewTicker(h.flushInterval)
+	defer flushTicker.Stop()
+	metricsTicker := time.NewTicker(h.metricsInterval)
+	defer metricsTicker.Stop()
+	for {
+		select {
+		case <-flushTicker.C:
+			h.flush()
+		case <-metricsTicker.C:
+			h.collectMetrics()
+		case <-stop:
+			return
+		}
+	}
+}
+
+// flush sends the buffered logs and spans to the registered consumers.
+func (h *Hub) flush() {
+	h.mu.Lock()
+	ld := h.logs.take(h.resource)
+	td := h.traces.take(h.resource)
+	h.mu.Unlock()
+
+	h.sinksMu.Lock()
+	defer h.sinksMu.Unlock()
+	if ld.LogRecordCount() > 0 {
+		var sinks []*sink
+		for s := range h.sinks {
+			if s.logs != nil {
+				sinks = append(sinks, s)
+			}
+		}
+		for i, s := range sinks {
+			data := ld
+			if i < len(sinks)-1 {
+				data = plog.NewLogs()
+				ld.CopyTo(data)
+			}
+			// Errors are ignored, logging them could produce more data to send.
+			_ = s.logs.ConsumeLogs(context.Background(), data)
+		}
+	}
+	if td.SpanCount() > 0 {
+		var sinks []*sink
+		for s := range h.sinks {
+			if s.traces != nil {
+				sinks = append(sinks, s)
+			}
+		}
+		for i, s := range sinks {
+			data := td
+			if i < len(sinks)-1 {
+				data = ptrace.NewTraces()
+				td.CopyTo(data)
+			}
+			_ = s.traces.ConsumeTraces(context.Background(), data)
+		}
+	}
+}
+
+// collectMetrics sends the current value of the metrics to the registered consumers.
+func (h *Hub) collectMetrics() {
+	h.sinksMu.Lock()
+	defer h.sinksMu.Unlock()
+	var sinks []*sink
+	for s := range h.sinks {
+		if s.metrics != nil {
+			sinks = append(sinks, s)
+		}
+	}
+	if len(sinks) == 0 {
+		return
+	}
+
+	md, err := h.readMetrics(context.Background())
+	if err != nil || md.DataPointCount() == 0 {
+		return
+	}
+	for i, s := range sinks {
+		data := md
+		if i < len(sinks)-1 {
+			data = pmetric.NewMetrics()
+			md.CopyTo(data)
+		}
+		_ = s.metrics.ConsumeMetrics(context.Background(), data)
+	}
+}
+
+// Shutdown stops sending data to all the consumers. The meter provider is shut down
+// along with the other telemetry providers.
+func (h *Hub) Shutdown() {
+	h.sinksMu.Lock()
+	clear(h.sinks)
+	h.updateLocked()
+	stop, done := h.stop, h.done
+	h.stop, h.done = nil, nil
+	h.sinksMu.Unlock()
+
+	if stop != nil {
+		close(stop)
+		<-done
+	}
+}
@@ -0,0 +1,204 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package selftelemetry
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	config "go.opentelemetry.io/contrib/otelconf/v0.3.0"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/internal/telemetry/componentattribute"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+var exporterAttrs = attribute.NewSet(
+	attribute.String(componentattribute.ComponentKindKey, "exporter"),
+	attribute.String(componentattribute.ComponentIDKey, "otlp"),
+)
+
+func newTestHub(t *testing.T) *Hub {
+	res := pcommon.NewResource()
+	res.Attributes().PutStr("service.name", "otelcol")
+	h, err := NewHub(Settings{Resource: res, MetricsInterval: 10 * time.Millisecond})
+	require.NoError(t, err)
+	h.flushInterval = 10 * time.Millisecond
+	t.Cleanup(h.Shutdown)
+	return h
+}
+
+func TestComponentKey(t *testing.T) {
+	id := component.MustNewID("otlp")
+	assert.Equal(t, "exporter otlp", ComponentKey(component.KindExporter, id))
+	assert.Equal(t, ComponentKey(component.KindExporter, id), componentKeyFromAttributes(exporterAttrs))
+}
+
+func TestNewHubInvalidView(t *testing.T) {
+	invalid := config.ViewSelectorInstrumentType("invalid")
+	_, err := NewHub(Settings{
+		MetricsInterval: time.Minute,
+		Views:           []config.View{{Selector: &config.ViewSelector{InstrumentType: &invalid}}},
+	})
+	require.Error(t, err)
+}
+
+func TestHubLogs(t *testing.T) {
+	h := newTestHub(t)
+	core, observed := observer.New(zapcore.InfoLevel)
+	logger := zap.New(h.WrapLogsCore(core, zapcore.InfoLevel))
+
+	// Nothing is buffered while no consumer is registered.
+	logger.Info("before")
+	assert.Equal(t, 0, h.logs.count)
+
+	sink := new(consumertest.LogsSink)
+	unregister := h.RegisterLogs(sink, nil)
+	logger.With(zap.String("a", "b")).Info("message", zap.Int64("c", 1))
+	logger.Debug("filtered")
+	componentattribute.ZapLoggerWithAttributes(logger, exporterAttrs).Warn("component")
+
+	require.Eventually(t, func() bool {
+		return sink.LogRecordCount() == 2
+	}, time.Second, 5*time.Millisecond)
+	unregister()
+	unregister()
+
+	ld := sink.AllLogs()[0]
+	rl := ld.ResourceLogs().At(0)
+	name, _ := rl.Resource().Attributes().Get("service.name")
+	assert.Equal(t, "otelcol", name.Str())
+	require.Equal(t, 2, rl.ScopeLogs().Len())
+
+	lr := rl.ScopeLogs().At(0).LogRecords().At(0)
+	assert.Equal(t, scopeName, rl.ScopeLogs().At(0).Scope().Name())
+	assert.Equal(t, "message", lr.Body().Str())
+	assert.Equal(t, plog.SeverityNumberInfo, lr.SeverityNumber())
+	assert.Equal(t, map[string]any{"a": "b", "c": int64(1)}, lr.Attributes().AsRaw())
+
+	sl := rl.ScopeLogs().At(1)
+	assert.Equal(t, map[string]any{
+		componentattribute.ComponentKindKey: "exporter",
+		componentattribute.ComponentIDKey:   "otlp",
+	}, sl.Scope().Attributes().AsRaw())
+	assert.Equal(t, plog.SeverityNumberWarn, sl.LogRecords().At(0).SeverityNumber())
+
+	// The console output is not affected.
+	assert.Equal(t, 3, observed.Len())
+}
+
+func TestHubLogsExcluded(t *testing.T) {
+	h := newTestHub(t)
+	core, _ := observer.New(zapcore.InfoLevel)
+	logger := zap.New(h.WrapLogsCore(core, zapcore.InfoLevel))
+
+	sink := new(consumertest.LogsSink)
+	defer h.RegisterLogs(sink, []string{ComponentKey(component.KindExporter, component.MustNewID("otlp"))})()
+	componentattribute.ZapLoggerWithAttributes(logger, exporterAttrs).Error("excluded")
+	logger.Info("included")
+
+	require.Eventually(t, func() bool {
+		return sink.LogRecordCount() == 1
+	}, time.Second, 5*time.Millisecond)
+	assert.Equal(t, "included", sink.AllLogs()[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Body().Str())
+}
+
+func TestHubLogsFanout(t *testing.T) {
+	h := newTestHub(t)
+	core, _ := observer.New(zapcore.InfoLevel)
+	logger := zap.New(h.WrapLogsCore(core, zapcore.InfoLevel))
+
+	sink1 := new(consumertest.LogsSink)
+	sink2 := new(consumertest.LogsSink)
+	defer h.RegisterLogs(sink1, nil)()
+	defer h.RegisterLogs(sink2, nil)()
+	logger.Info("message")
+
+	require.Eventually(t, func() bool {
+		return sink1.LogRecordCount() == 1 && sink2.LogRecordCount() == 1
+	}, time.Second, 5*time.Millisecond)
+}
+
+func TestHubLogsMaxBuffered(t *testing.T) {
+	lb := newLogsBuffer()
+	for i := 0; i < maxBuffered; i++ {
+		_, ok := lb.appendEmpty(attribute.NewSet())
+		require.True(t, ok)
+	}
+	_, ok := lb.appendEmpty(attribute.NewSet())
+	assert.False(t, ok)
+	assert.Equal(t, maxBuffered, lb.take(pcommon.NewResource()).LogRecordCount())
+	assert.Equal(t, 0, lb.count)
+}
+
+func TestHubTraces(t *testing.T) {
+	h := newTestHub(t)
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(h.SpanProcessor()))
+	defer func() { require.NoError(t, tp.Shutdown(context.Background())) }()
+
+	sink := new(consumertest.TracesSink)
+	defer h.RegisterTraces(sink, []string{ComponentKey(component.KindExporter, component.MustNewID("otlp"))})()
+
+	ctx, parent := tp.Tracer("test").Start(context.Background(), "parent")
+	_, child := tp.Tracer("test").Start(ctx, "child")
+	child.AddEvent("event")
+	child.End()
+	parent.End()
+	_, excluded := componentattribute.TracerProviderWithAttributes(tp, exporterAttrs).Tracer("test").Start(context.Background(), "excluded")
+	excluded.End()
+
+	require.Eventually(t, func() bool {
+		return sink.SpanCount() == 2
+	}, time.Second, 5*time.Millisecond)
+
+	spans := sink.AllTraces()[0].ResourceSpans().At(0).ScopeSpans().At(0).Spans()
+	assert.Equal(t, "child", spans.At(0).Name())
+	assert.Equal(t, "parent", spans.At(1).Name())
+	assert.Equal(t, spans.At(1).SpanID(), spans.At(0).ParentSpanID())
+	assert.Equal(t, "event", spans.At(0).Events().At(0).Name())
+}
+
+func TestHubMetrics(t *testing.T) {
+	h := newTestHub(t)
+	counter, err := h.MeterProvider().Meter("test").Int64Counter("counter")
+	require.NoError(t, err)
+	counter.Add(context.Background(), 3)
+
+	sink := new(consumertest.MetricsSink)
+	defer h.RegisterMetrics(sink)()
+
+	require.Eventually(t, func() bool {
+		return len(sink.AllMetrics()) > 0
+	}, time.Second, 5*time.Millisecond)
+
+	m := sink.AllMetrics()[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0)
+	assert.Equal(t, "counter", m.Name())
+	assert.Equal(t, pmetric.MetricTypeSum, m.Type())
+	assert.Equal(t, int64(3), m.Sum().DataPoints().At(0).IntValue())
+}
+
+func TestHubShutdown(t *testing.T) {
+	h := newTestHub(t)
+	core, _ := observer.New(zapcore.InfoLevel)
+	logger := zap.New(h.WrapLogsCore(core, zapcore.InfoLevel))
+
+	sink := new(consumertest.LogsSink)
+	unregister := h.RegisterLogs(sink, nil)
+	h.Shutdown()
+	logger.Info("message")
+	assert.Equal(t, 0, h.logs.count)
+	// Unregistering after shutting down is a no-op.
+	unregister()
+}
@@ -0,0 +1,153 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package selftelemetry // import "go.opentelemetry.io/collector/service/internal/selftelemetry"
+
+import (
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap/zapcore"
+
+	"go.opentelemetry.io/collector/internal/telemetry/componentattribute"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+// scopeName is the instrumentation scope of the logs sent to the pipelines.
+// Component attributes are set as scope attributes, as for the logs copied to
+// service::telemetry::logs::processors.
+const scopeName = "go.opentelemetry.io/collector/service/telemetry"
+
+// logsBuffer groups the log records by component, in one ScopeLogs each.
+type logsBuffer struct {
+	ld     plog.Logs
+	scopes map[attribute.Distinct]plog.ScopeLogs
+	count  int
+}
+
+func newLogsBuffer() *logsBuffer {
+	return &logsBuffer{
+		ld:     plog.NewLogs(),
+		scopes: make(map[attribute.Distinct]plog.ScopeLogs),
+	}
+}
+
+// take returns the buffered logs, with the given resource, and resets the buffer.
+func (lb *logsBuffer) take(res pcommon.Resource) plog.Logs {
+	ld := lb.ld
+	if lb.count > 0 {
+		res.CopyTo(ld.ResourceLogs().At(0).Resource())
+	}
+	lb.ld = plog.NewLogs()
+	clear(lb.scopes)
+	lb.count = 0
+	return ld
+}
+
+func (lb *logsBuffer) appendEmpty(attrs attribute.Set) (plog.LogRecord, bool) {
+	if lb.count >= maxBuffered {
+		return plog.LogRecord{}, false
+	}
+	sl, ok := lb.scopes[attrs.Equivalent()]
+	if !ok {
+		if lb.ld.ResourceLogs().Len() == 0 {
+			lb.ld.ResourceLogs().AppendEmpty()
+		}
+		sl = lb.ld.ResourceLogs().At(0).ScopeLogs().AppendEmpty()
+		sl.Scope().SetName(scopeName)
+		putAttributes(sl.Scope().Attributes(), attrs.ToSlice())
+		lb.scopes[attrs.Equivalent()] = sl
+	}
+	lb.count++
+	return sl.LogRecords().AppendEmpty(), true
+}
+
+// WrapLogsCore returns a core copying the logs written to core to the registered logs consumers.
+// Logs below the level are not copied.
+func (h *Hub) WrapLogsCore(core zapcore.Core, level zapcore.LevelEnabler) zapcore.Core {
+	return componentattribute.NewWrapperCoreWithAttributeSet(core, attribute.NewSet(), func(inner zapcore.Core, attrs attribute.Set) zapcore.Core {
+		return zapcore.NewTee(inner, &logsCore{
+			hub:   h,
+			level: level,
+			attrs: attrs,
+			key:   componentKeyFromAttributes(attrs),
+		})
+	})
+}
+
+type logsCore struct {
+	hub    *Hub
+	level  zapcore.LevelEnabler
+	attrs  attribute.Set
+	key    string
+	fields []zapcore.Field
+}
+
+func (lc *logsCore) Enabled(lvl zapcore.Level) bool {
+	return lc.hub.logsActive.Load() && lc.level.Enabled(lvl)
+}
+
+func (lc *logsCore) With(fields []zapcore.Field) zapcore.Core {
+	clone := *lc
+	clone.fields = append(lc.fields[:len(lc.fields):len(lc.fields)], fields...)
+	return &clone
+}
+
+func (lc *logsCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if lc.Enabled(ent.Level) {
+		return ce.AddCore(ent, lc)
+	}
+	return ce
+}
+
+func (lc *logsCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	if _, excluded := (*lc.hub.excludedLogs.Load())[lc.key]; excluded {
+		return nil
+	}
+
+	enc := zapcore.NewMapObjectEncoder()
+	for _, f := range lc.fields {
+		f.AddTo(enc)
+	}
+	for _, f := range fields {
+		f.AddTo(enc)
+	}
+
+	lc.hub.mu.Lock()
+	defer lc.hub.mu.Unlock()
+	lr, ok := lc.hub.logs.appendEmpty(lc.attrs)
+	if !ok {
+		return nil
+	}
+	lr.SetTimestamp(pcommon.NewTimestampFromTime(ent.Time))
+	lr.SetObservedTimestamp(pcommon.NewTimestampFromTime(ent.Time))
+	lr.SetSeverityNumber(severityNumber(ent.Level))
+	lr.SetSeverityText(ent.Level.CapitalString())
+	lr.Body().SetStr(ent.Message)
+	for k, v := range enc.Fields {
+		putField(lr.Attributes(), k, v)
+	}
+	if ent.Stack != "" {
+		lr.Attributes().PutStr("exception.stacktrace", ent.Stack)
+	}
+	return nil
+}
+
+func (lc *logsCore) Sync() error {
+	return nil
+}
+
+func severityNumber(lvl zapcore.Level) plog.SeverityNumber {
+	switch lvl {
+	case zapcore.DebugLevel:
+		return plog.SeverityNumberDebug
+	case zapcore.InfoLevel:
+		return plog.SeverityNumberInfo
+	case zapcore.WarnLevel:
+		return plog.SeverityNumberWarn
+	case zapcore.ErrorLevel:
+		return plog.SeverityNumberError
+	case zapcore.DPanicLevel, zapcore.PanicLevel, zapcore.FatalLevel:
+		return plog.SeverityNumberFatal
+	}
+	return plog.SeverityNumberUnspecified
+}
@@ -0,0 +1,345 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package selftelemetry // import "go.opentelemetry.io/collector/service/internal/selftelemetry"
+
+import (
+	"context"
+	"errors"
+
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/embedded"
+)
+
+// NewTeeMeterProvider returns a meter provider recording every measurement in all the providers.
+// It is used to feed the Hub meter provider along with the one of the configured readers.
+func NewTeeMeterProvider(providers ...metric.MeterProvider) metric.MeterProvider {
+	return &teeMeterProvider{providers: providers}
+}
+
+type teeMeterProvider struct {
+	embedded.MeterProvider
+	providers []metric.MeterProvider
+}
+
+func (tmp *teeMeterProvider) Meter(name string, opts ...metric.MeterOption) metric.Meter {
+	tm := &teeMeter{meters: make([]metric.Meter, len(tmp.providers))}
+	for i, p := range tmp.providers {
+		tm.meters[i] = p.Meter(name, opts...)
+	}
+	return tm
+}
+
+// Shutdown shuts down the providers that support it.
+func (tmp *teeMeterProvider) Shutdown(ctx context.Context) error {
+	var errs error
+	for _, p := range tmp.providers {
+		if s, ok := p.(interface{ Shutdown(context.Context) error }); ok {
+			errs = errors.Join(errs, s.Shutdown(ctx))
+		}
+	}
+	return errs
+}
+
+type teeMeter struct {
+	embedded.Meter
+	meters []metric.Meter
+}
+
+// newInstruments creates the instrument with every meter. The instruments are in the same order as the meters.
+func newInstruments[T any](meters []metric.Meter, create func(metric.Meter) (T, error)) ([]T, error) {
+	insts := make([]T, len(meters))
+	var errs error
+	for i, m := range meters {
+		var err error
+		insts[i], err = create(m)
+		errs = errors.Join(errs, err)
+	}
+	return insts, errs
+}
+
+func (tm *teeMeter) Int64Counter(name string, options ...metric.Int64CounterOption) (metric.Int64Counter, error) {
+	insts, err := newInstruments(tm.meters, func(m metric.Meter) (metric.Int64Counter, error) {
+		return m.Int64Counter(name, options...)
+	})
+	return &teeInt64Counter{insts: insts}, err
+}
+
+func (tm *teeMeter) Int64UpDownCounter(name string, options ...metric.Int64UpDownCounterOption) (metric.Int64UpDownCounter, error) {
+	insts, err := newInstruments(tm.meters, func(m metric.Meter) (metric.Int64UpDownCounter, error) {
+		return m.Int64UpDownCounter(name, options...)
+	})
+	return &teeInt64UpDownCounter{insts: insts}, err
+}
+
+func (tm *teeMeter) Int64Histogram(name string, options ...metric.Int64HistogramOption) (metric.Int64Histogram, error) {
+	insts, err := newInstruments(tm.meters, func(m metric.Meter) (metric.Int64Histogram, error) {
+		return m.Int64Histogram(name, options...)
+	})
+	return &teeInt64Histogram{insts: insts}, err
+}
+
+func (tm *teeMeter) Int64Gauge(name string, options ...metric.Int64GaugeOption) (metric.Int64Gauge, error) {
+	insts, err := newInstruments(tm.meters, func(m metric.Meter) (metric.Int64Gauge, error) {
+		return m.Int64Gauge(name, options...)
+	})
+	return &teeInt64Gauge{insts: insts}, err
+}
+
+func (tm *teeMeter) Float64Counter(name string, options ...metric.Float64CounterOption) (metric.Float64Counter, error) {
+	insts, err := newInstruments(tm.meters, func(m metric.Meter) (metric.Float64Counter, error) {
+		return m.Float64Counter(name, options...)
+	})
+	return &teeFloat64Counter{insts: insts}, err
+}
+
+func (tm *teeMeter) Float64UpDownCounter(name string, options ...metric.Float64UpDownCounterOption) (metric.Float64UpDownCounter, error) {
+	insts, err := newInstruments(tm.meters, func(m metric.Meter) (metric.Float64UpDownCounter, error) {
+		return m.Float64UpDownCounter(name, options...)
+	})
+	return &teeFloat64UpDownCounter{insts: insts}, err
+}
+
+func (tm *teeMeter) Float64Histogram(name string, options ...metric.Float64HistogramOption) (metric.Float64Histogram, error) {
+	insts, err := newInstruments(tm.meters, func(m metric.Meter) (metric.Float64Histogram, error) {
+		return m.Float64Histogram(name, options...)
+	})
+	return &teeFloat64Histogram{insts: insts}, err
+}
+
+func (tm *teeMeter) Float64Gauge(name string, options ...metric.Float64GaugeOption) (metric.Float64Gauge, error) {
+	insts, err := newInstruments(tm.meters, func(m metric.Meter) (metric.Float64Gauge, error) {
+		return m.Float64Gauge(name, options...)
+	})
+	return &teeFloat64Gauge{insts: insts}, err
+}
+
+func (tm *teeMeter) Int64ObservableCounter(name string, options ...metric.Int64ObservableCounterOption) (metric.Int64ObservableCounter, error) {
+	insts, err := newInstruments(tm.meters, func(m metric.Meter) (metric.Int64ObservableCounter, error) {
+		return m.Int64ObservableCounter(name, options...)
+	})
+	return &teeInt64ObservableCounter{teeObservable: teeObservable[metric.Int64ObservableCounter]{insts: insts}}, err
+}
+
+func (tm *teeMeter) Int64ObservableUpDownCounter(name string, options ...metric.Int64ObservableUpDownCounterOption) (metric.Int64ObservableUpDownCounter, error) {
+	insts, err := newInstruments(tm.meters, func(m metric.Meter) (metric.Int64ObservableUpDownCounter, error) {
+		return m.Int64ObservableUpDownCounter(name, options...)
+	})
+	return &teeInt64ObservableUpDownCounter{teeObservable: teeObservable[metric.Int64ObservableUpDownCounter]{insts: insts}}, err
+}
+
+func (tm *teeMeter) Int64ObservableGauge(name string, options ...metric.Int64ObservableGaugeOption) (metric.Int64ObservableGauge, error) {
+	insts, err := newInstruments(tm.meters, func(m metric.Meter) (metric.Int64ObservableGauge, error) {
+		return m.Int64ObservableGauge(name, options...)
+	})
+	return &teeInt64ObservableGauge{teeObservable: teeObservable[metric.Int64ObservableGauge]{insts: insts}}, err
+}
+
+func (tm *teeMeter) Float64ObservableCounter(name string, options ...metric.Float64ObservableCounterOption) (metric.Float64ObservableCounter, error) {
+	insts, err := newInstruments(tm.meters, func(m metric.Meter) (metric.Float64ObservableCounter, error) {
+		return m.Float64ObservableCounter(name, options...)
+	})
+	return &teeFloat64ObservableCounter{teeObservable: teeObservable[metric.Float64ObservableCounter]{insts: insts}}, err
+}
+
+func (tm *teeMeter) Float64ObservableUpDownCounter(name string, options ...metric.Float64ObservableUpDownCounterOption) (metric.Float64ObservableUpDownCounter, error) {
+	insts, err := newInstruments(tm.meters, func(m metric.Meter) (metric.Float64ObservableUpDownCounter, error) {
+		return m.Float64ObservableUpDownCounter(name, options...)
+	})
+	return &teeFloat64ObservableUpDownCounter{teeObservable: teeObservable[metric.Float64ObservableUpDownCounter]{insts: insts}}, err
+}
+
+func (tm *teeMeter) Float64ObservableGauge(name string, options ...metric.Float64ObservableGaugeOption) (metric.Float64ObservableGauge, error) {
+	insts, err := newInstruments(tm.meters, func(m metric.Meter) (metric.Float64ObservableGauge, error) {
+		return m.Float64ObservableGauge(name, options...)
+	})
+	return &teeFloat64ObservableGauge{teeObservable: teeObservable[metric.Float64ObservableGauge]{insts: insts}}, err
+}
+
+// RegisterCallback registers the callback with every meter. The callback observes the
+// instruments of the meter calling it, through a teeObserver.
+func (tm *teeMeter) RegisterCallback(f metric.Callback, instruments ...metric.Observable) (metric.Registration, error) {
+	reg := &teeRegistration{}
+	var errs error
+	for i, m := range tm.meters {
+		insts := make([]metric.Observable, len(instruments))
+		for j, inst := range instruments {
+			insts[j] = underlying(inst, i)
+		}
+		r, err := m.RegisterCallback(func(ctx context.Context, o metric.Observer) error {
+			return f(ctx, &teeObserver{Observer: o, index: i})
+		}, insts...)
+		errs = errors.Join(errs, err)
+		if r != nil {
+			reg.regs = append(reg.regs, r)
+		}
+	}
+	return reg, errs
+}
+
+type teeRegistration struct {
+	embedded.Registration
+	regs []metric.Registration
+}
+
+func (tr *teeRegistration) Unregister() error {
+	var errs error
+	for _, r := range tr.regs {
+		errs = errors.Join(errs, r.Unregister())
+	}
+	return errs
+}
+
+type teeObserver struct {
+	metric.Observer
+	index int
+}
+
+func (to *teeObserver) ObserveFloat64(obsrv metric.Float64Observable, value float64, opts ...metric.ObserveOption) {
+	if inst, ok := underlying(obsrv, to.index).(metric.Float64Observable); ok {
+		to.Observer.ObserveFloat64(inst, value, opts...)
+	}
+}
+
+func (to *teeObserver) ObserveInt64(obsrv metric.Int64Observable, value int64, opts ...metric.ObserveOption) {
+	if inst, ok := underlying(obsrv, to.index).(metric.Int64Observable); ok {
+		to.Observer.ObserveInt64(inst, value, opts...)
+	}
+}
+
+// underlying returns the instrument of the i-th meter behind a tee observable instrument.
+func underlying(inst metric.Observable, i int) metric.Observable {
+	if to, ok := inst.(interface{ instrument(int) metric.Observable }); ok {
+		return to.instrument(i)
+	}
+	return inst
+}
+
+type teeObservable[T metric.Observable] struct {
+	insts []T
+}
+
+func (to teeObservable[T]) instrument(i int) metric.Observable {
+	return to.insts[i]
+}
+
+// The observable instruments embed their interface to implement its unexported methods,
+// which are never called.
+
+type teeInt64ObservableCounter struct {
+	metric.Int64ObservableCounter
+	teeObservable[metric.Int64ObservableCounter]
+}
+
+type teeInt64ObservableUpDownCounter struct {
+	metric.Int64ObservableUpDownCounter
+	teeObservable[metric.Int64ObservableUpDownCounter]
+}
+
+type teeInt64ObservableGauge struct {
+	metric.Int64ObservableGauge
+	teeObservable[metric.Int64ObservableGauge]
+}
+
+type teeFloat64ObservableCounter struct {
+	metric.Float64ObservableCounter
+	teeObservable[metric.Float64ObservableCounter]
+}
+
+type teeFloat64ObservableUpDownCounter struct {
+	metric.Float64ObservableUpDownCounter
+	teeObservable[metric.Float64ObservableUpDownCounter]
+}
+
+type teeFloat64ObservableGauge struct {
+	metric.Float64ObservableGauge
+	teeObservable[metric.Float64ObservableGauge]
+}
+
+type teeInt64Counter struct {
+	embedded.Int64Counter
+	insts []metric.Int64Counter
+}
+
+func (t *teeInt64Counter) Add(ctx context.Context, incr int64, options ...metric.AddOption) {
+	for _, inst := range t.insts {
+		inst.Add(ctx, incr, options...)
+	}
+}
+
+type teeInt64UpDownCounter struct {
+	embedded.Int64UpDownCounter
+	insts []metric.Int64UpDownCounter
+}
+
+func (t *teeInt64UpDownCounter) Add(ctx context.Context, incr int64, options ...metric.AddOption) {
+	for _, inst := range t.insts {
+		inst.Add(ctx, incr, options...)
+	}
+}
+
+type teeInt64Histogram struct {
+	embedded.Int64Histogram
+	insts []metric.Int64Histogram
+}
+
+func (t *teeInt64Histogram) Record(ctx context.Context, incr int64, options ...metric.RecordOption) {
+	for _, inst := range t.insts {
+		inst.Record(ctx, incr, options...)
+	}
+}
+
+type teeInt64Gauge struct {
+	embedded.Int64Gauge
+	insts []metric.Int64Gauge
+}
+
+func (t *teeInt64Gauge) Record(ctx context.Context, value int64, options ...metric.RecordOption) {
+	for _, inst := range t.insts {
+		inst.Record(ctx, value, options...)
+	}
+}
+
+type teeFloat64Counter struct {
+	embedded.Float64Counter
+	insts []metric.Float64Counter
+}
+
+func (t *teeFloat64Counter) Add(ctx context.Context, incr float64, options ...metric.AddOption) {
+	for _, inst := range t.insts {
+		inst.Add(ctx, incr, options...)
+	}
+}
+
+type teeFloat64UpDownCounter struct {
+	embedded.Float64UpDownCounter
+	insts []metric.Float64UpDownCounter
+}
+
+func (t *teeFloat64UpDownCounter) Add(ctx context.Context, incr float64, options ...metric.AddOption) {
+	for _, inst := range t.insts {
+		inst.Add(ctx, incr, options...)
+	}
+}
+
+type teeFloat64Histogram struct {
+	embedded.Float64Histogram
+	insts []metric.Float64Histogram
+}
+
+func (t *teeFloat64Histogram) Record(ctx context.Context, incr float64, options ...metric.RecordOption) {
+	for _, inst := range t.insts {
+		inst.Record(ctx, incr, options...)
+	}
+}
+
+type teeFloat64Gauge struct {
+	embedded.Float64Gauge
+	insts []metric.Float64Gauge
+}
+
+func (t *teeFloat64Gauge) Record(ctx context.Context, value float64, options ...metric.RecordOption) {
+	for _, inst := range t.insts {
+		inst.Record(ctx, value, options...)
+	}
+}
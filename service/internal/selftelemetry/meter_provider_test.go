@@ -0,0 +1,74 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package selftelemetry
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func collect(t *testing.T, reader sdkmetric.Reader) map[string]metricdata.Aggregation {
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	got := make(map[string]metricdata.Aggregation)
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			got[m.Name] = m.Data
+		}
+	}
+	return got
+}
+
+func TestTeeMeterProvider(t *testing.T) {
+	reader1 := sdkmetric.NewManualReader()
+	reader2 := sdkmetric.NewManualReader()
+	mp := NewTeeMeterProvider(
+		sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader1)),
+		sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader2)),
+	)
+	meter := mp.Meter("test")
+
+	counter, err := meter.Int64Counter("counter")
+	require.NoError(t, err)
+	counter.Add(context.Background(), 2)
+
+	histogram, err := meter.Float64Histogram("histogram")
+	require.NoError(t, err)
+	histogram.Record(context.Background(), 1.5)
+
+	gauge, err := meter.Int64ObservableGauge("gauge")
+	require.NoError(t, err)
+	reg, err := meter.RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		o.ObserveInt64(gauge, 7)
+		return nil
+	}, gauge)
+	require.NoError(t, err)
+
+	_, err = meter.Float64ObservableCounter("observable", metric.WithFloat64Callback(func(_ context.Context, o metric.Float64Observer) error {
+		o.Observe(1)
+		return nil
+	}))
+	require.NoError(t, err)
+
+	for _, reader := range []sdkmetric.Reader{reader1, reader2} {
+		got := collect(t, reader)
+		assert.Equal(t, int64(2), got["counter"].(metricdata.Sum[int64]).DataPoints[0].Value)
+		assert.Equal(t, uint64(1), got["histogram"].(metricdata.Histogram[float64]).DataPoints[0].Count)
+		assert.Equal(t, int64(7), got["gauge"].(metricdata.Gauge[int64]).DataPoints[0].Value)
+		assert.InDelta(t, 1.0, got["observable"].(metricdata.Sum[float64]).DataPoints[0].Value, 0)
+	}
+
+	require.NoError(t, reg.Unregister())
+	for _, reader := range []sdkmetric.Reader{reader1, reader2} {
+		assert.NotContains(t, collect(t, reader), "gauge")
+	}
+
+	require.NoError(t, mp.(interface{ Shutdown(context.Context) error }).Shutdown(context.Background()))
+}
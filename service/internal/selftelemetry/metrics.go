@@ -0,0 +1,286 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package selftelemetry // import "go.opentelemetry.io/collector/service/internal/selftelemetry"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	config "go.opentelemetry.io/contrib/otelconf/v0.3.0"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// MeterProvider returns the meter provider whose metrics are sent to the registered metrics consumers.
+func (h *Hub) MeterProvider() metric.MeterProvider {
+	return h.meterProvider
+}
+
+// readMetrics collects the current value of the metrics.
+func (h *Hub) readMetrics(ctx context.Context) (pmetric.Metrics, error) {
+	var rm metricdata.ResourceMetrics
+	if err := h.reader.Collect(ctx, &rm); err != nil {
+		return pmetric.Metrics{}, err
+	}
+
+	md := pmetric.NewMetrics()
+	rms := md.ResourceMetrics().AppendEmpty()
+	h.resource.CopyTo(rms.Resource())
+	for _, sm := range rm.ScopeMetrics {
+		sms := rms.ScopeMetrics().AppendEmpty()
+		sms.SetSchemaUrl(sm.Scope.SchemaURL)
+		sms.Scope().SetName(sm.Scope.Name)
+		sms.Scope().SetVersion(sm.Scope.Version)
+		putAttributes(sms.Scope().Attributes(), sm.Scope.Attributes.ToSlice())
+		for _, m := range sm.Metrics {
+			dest := sms.Metrics().AppendEmpty()
+			dest.SetName(m.Name)
+			dest.SetDescription(m.Description)
+			dest.SetUnit(m.Unit)
+			copyAggregation(dest, m.Data)
+		}
+	}
+	return md, nil
+}
+
+func copyAggregation(dest pmetric.Metric, data metricdata.Aggregation) {
+	switch a := data.(type) {
+	case metricdata.Gauge[int64]:
+		copyNumberDataPoints(dest.SetEmptyGauge().DataPoints(), a.DataPoints, pmetric.NumberDataPoint.SetIntValue)
+	case metricdata.Gauge[float64]:
+		copyNumberDataPoints(dest.SetEmptyGauge().DataPoints(), a.DataPoints, pmetric.NumberDataPoint.SetDoubleValue)
+	case metricdata.Sum[int64]:
+		sum := dest.SetEmptySum()
+		sum.SetIsMonotonic(a.IsMonotonic)
+		sum.SetAggregationTemporality(temporality(a.Temporality))
+		copyNumberDataPoints(sum.DataPoints(), a.DataPoints, pmetric.NumberDataPoint.SetIntValue)
+	case metricdata.Sum[float64]:
+		sum := dest.SetEmptySum()
+		sum.SetIsMonotonic(a.IsMonotonic)
+		sum.SetAggregationTemporality(temporality(a.Temporality))
+		copyNumberDataPoints(sum.DataPoints(), a.DataPoints, pmetric.NumberDataPoint.SetDoubleValue)
+	case metricdata.Histogram[int64]:
+		copyHistogram(dest.SetEmptyHistogram(), a)
+	case metricdata.Histogram[float64]:
+		copyHistogram(dest.SetEmptyHistogram(), a)
+	case metricdata.ExponentialHistogram[int64]:
+		copyExponentialHistogram(dest.SetEmptyExponentialHistogram(), a)
+	case metricdata.ExponentialHistogram[float64]:
+		copyExponentialHistogram(dest.SetEmptyExponentialHistogram(), a)
+	case metricdata.Summary:
+		dps := dest.SetEmptySummary().DataPoints()
+		for _, dp := range a.DataPoints {
+			dest := dps.AppendEmpty()
+			putAttributes(dest.Attributes(), dp.Attributes.ToSlice())
+			dest.SetStartTimestamp(pcommon.NewTimestampFromTime(dp.StartTime))
+			dest.SetTimestamp(pcommon.NewTimestampFromTime(dp.Time))
+			dest.SetCount(dp.Count)
+			dest.SetSum(dp.Sum)
+			for _, qv := range dp.QuantileValues {
+				q := dest.QuantileValues().AppendEmpty()
+				q.SetQuantile(qv.Quantile)
+				q.SetValue(qv.Value)
+			}
+		}
+	}
+}
+
+func copyNumberDataPoints[N int64 | float64, V int64 | float64](dest pmetric.NumberDataPointSlice, dps []metricdata.DataPoint[N], set func(pmetric.NumberDataPoint, V)) {
+	dest.EnsureCapacity(len(dps))
+	for _, dp := range dps {
+		ndp := dest.AppendEmpty()
+		putAttributes(ndp.Attributes(), dp.Attributes.ToSlice())
+		ndp.SetStartTimestamp(pcommon.NewTimestampFromTime(dp.StartTime))
+		ndp.SetTimestamp(pcommon.NewTimestampFromTime(dp.Time))
+		set(ndp, V(dp.Value))
+	}
+}
+
+func copyHistogram[N int64 | float64](dest pmetric.Histogram, h metricdata.Histogram[N]) {
+	dest.SetAggregationTemporality(temporality(h.Temporality))
+	dps := dest.DataPoints()
+	dps.EnsureCapacity(len(h.DataPoints))
+	for _, dp := range h.DataPoints {
+		hdp := dps.AppendEmpty()
+		putAttributes(hdp.Attributes(), dp.Attributes.ToSlice())
+		hdp.SetStartTimestamp(pcommon.NewTimestampFromTime(dp.StartTime))
+		hdp.SetTimestamp(pcommon.NewTimestampFromTime(dp.Time))
+		hdp.SetCount(dp.Count)
+		hdp.SetSum(float64(dp.Sum))
+		if v, ok := dp.Min.Value(); ok {
+			hdp.SetMin(float64(v))
+		}
+		if v, ok := dp.Max.Value(); ok {
+			hdp.SetMax(float64(v))
+		}
+		hdp.ExplicitBounds().FromRaw(dp.Bounds)
+		hdp.BucketCounts().FromRaw(dp.BucketCounts)
+	}
+}
+
+func copyExponentialHistogram[N int64 | float64](dest pmetric.ExponentialHistogram, h metricdata.ExponentialHistogram[N]) {
+	dest.SetAggregationTemporality(temporality(h.Temporality))
+	dps := dest.DataPoints()
+	dps.EnsureCapacity(len(h.DataPoints))
+	for _, dp := range h.DataPoints {
+		edp := dps.AppendEmpty()
+		putAttributes(edp.Attributes(), dp.Attributes.ToSlice())
+		edp.SetStartTimestamp(pcommon.NewTimestampFromTime(dp.StartTime))
+		edp.SetTimestamp(pcommon.NewTimestampFromTime(dp.Time))
+		edp.SetCount(dp.Count)
+		edp.SetSum(float64(dp.Sum))
+		if v, ok := dp.Min.Value(); ok {
+			edp.SetMin(float64(v))
+		}
+		if v, ok := dp.Max.Value(); ok {
+			edp.SetMax(float64(v))
+		}
+		edp.SetScale(dp.Scale)
+		edp.SetZeroCount(dp.ZeroCount)
+		edp.SetZeroThreshold(dp.ZeroThreshold)
+		edp.Positive().SetOffset(dp.PositiveBucket.Offset)
+		edp.Positive().BucketCounts().FromRaw(dp.PositiveBucket.Counts)
+		edp.Negative().SetOffset(dp.NegativeBucket.Offset)
+		edp.Negative().BucketCounts().FromRaw(dp.NegativeBucket.Counts)
+	}
+}
+
+func temporality(t metricdata.Temporality) pmetric.AggregationTemporality {
+	switch t {
+	case metricdata.CumulativeTemporality:
+		return pmetric.AggregationTemporalityCumulative
+	case metricdata.DeltaTemporality:
+		return pmetric.AggregationTemporalityDelta
+	}
+	return pmetric.AggregationTemporalityUnspecified
+}
+
+// sdkViews converts the configured views, as otelconf does for the configured readers.
+func sdkViews(views []config.View) ([]sdkmetric.View, error) {
+	res := make([]sdkmetric.View, 0, len(views))
+	for _, v := range views {
+		if v.Selector == nil {
+			return nil, errors.New("view: no selector provided")
+		}
+		kind, err := instrumentKind(v.Selector.InstrumentType)
+		if err != nil {
+			return nil, err
+		}
+		inst := sdkmetric.Instrument{
+			Name: strOrEmpty(v.Selector.InstrumentName),
+			Unit: strOrEmpty(v.Selector.Unit),
+			Kind: kind,
+			Scope: instrumentation.Scope{
+				Name:      strOrEmpty(v.Selector.MeterName),
+				Version:   strOrEmpty(v.Selector.MeterVersion),
+				SchemaURL: strOrEmpty(v.Selector.MeterSchemaUrl),
+			},
+		}
+		var stream sdkmetric.Stream
+		if v.Stream != nil {
+			stream = sdkmetric.Stream{
+				Name:        strOrEmpty(v.Stream.Name),
+				Description: strOrEmpty(v.Stream.Description),
+				Aggregation: aggregation(v.Stream.Aggregation),
+			}
+			if v.Stream.AttributeKeys != nil {
+				stream.AttributeFilter = attributeFilter(v.Stream.AttributeKeys)
+			}
+		}
+		res = append(res, sdkmetric.NewView(inst, stream))
+	}
+	return res, nil
+}
+
+func instrumentKind(t *config.ViewSelectorInstrumentType) (sdkmetric.InstrumentKind, error) {
+	if t == nil {
+		return 0, nil
+	}
+	switch *t {
+	case config.ViewSelectorInstrumentTypeCounter:
+		return sdkmetric.InstrumentKindCounter, nil
+	case config.ViewSelectorInstrumentTypeUpDownCounter:
+		return sdkmetric.InstrumentKindUpDownCounter, nil
+	case config.ViewSelectorInstrumentTypeHistogram:
+		return sdkmetric.InstrumentKindHistogram, nil
+	case config.ViewSelectorInstrumentTypeObservableCounter:
+		return sdkmetric.InstrumentKindObservableCounter, nil
+	case config.ViewSelectorInstrumentTypeObservableUpDownCounter:
+		return sdkmetric.InstrumentKindObservableUpDownCounter, nil
+	case config.ViewSelectorInstrumentTypeObservableGauge:
+		return sdkmetric.InstrumentKindObservableGauge, nil
+	}
+	return 0, fmt.Errorf("view: unknown instrument type %q", *t)
+}
+
+func aggregation(aggr *config.ViewStreamAggregation) sdkmetric.Aggregation {
+	switch {
+	case aggr == nil:
+		return nil
+	case aggr.Base2ExponentialBucketHistogram != nil:
+		return sdkmetric.AggregationBase2ExponentialHistogram{
+			MaxSize:  int32OrZero(aggr.Base2ExponentialBucketHistogram.MaxSize),
+			MaxScale: int32OrZero(aggr.Base2ExponentialBucketHistogram.MaxScale),
+			NoMinMax: !boolOrFalse(aggr.Base2ExponentialBucketHistogram.RecordMinMax),
+		}
+	case aggr.Drop != nil:
+		return sdkmetric.AggregationDrop{}
+	case aggr.ExplicitBucketHistogram != nil:
+		return sdkmetric.AggregationExplicitBucketHistogram{
+			Boundaries: aggr.ExplicitBucketHistogram.Boundaries,
+			NoMinMax:   !boolOrFalse(aggr.ExplicitBucketHistogram.RecordMinMax),
+		}
+	case aggr.LastValue != nil:
+		return sdkmetric.AggregationLastValue{}
+	case aggr.Sum != nil:
+		return sdkmetric.AggregationSum{}
+	}
+	return nil
+}
+
+func attributeFilter(ie *config.IncludeExclude) attribute.Filter {
+	included := make(map[attribute.Key]struct{}, len(ie.Included))
+	for _, k := range ie.Included {
+		included[attribute.Key(k)] = struct{}{}
+	}
+	excluded := make(map[attribute.Key]struct{}, len(ie.Excluded))
+	for _, k := range ie.Excluded {
+		excluded[attribute.Key(k)] = struct{}{}
+	}
+	return func(kv attribute.KeyValue) bool {
+		if _, ok := excluded[kv.Key]; ok {
+			return false
+		}
+		if len(included) == 0 {
+			return true
+		}
+		_, ok := included[kv.Key]
+		return ok
+	}
+}
+
+func strOrEmpty(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func int32OrZero(i *int) int32 {
+	if i == nil {
+		return 0
+	}
+	return int32(*i) //nolint:gosec // configured scales and sizes are small
+}
+
+func boolOrFalse(b *bool) bool {
+	return b != nil && *b
+}
@@ -0,0 +1,155 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package selftelemetry // import "go.opentelemetry.io/collector/service/internal/selftelemetry"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// tracesBuffer groups the spans by instrumentation scope, in one ScopeSpans each.
+type tracesBuffer struct {
+	td     ptrace.Traces
+	scopes map[instrumentation.Scope]ptrace.ScopeSpans
+	count  int
+}
+
+func newTracesBuffer() *tracesBuffer {
+	return &tracesBuffer{
+		td:     ptrace.NewTraces(),
+		scopes: make(map[instrumentation.Scope]ptrace.ScopeSpans),
+	}
+}
+
+// take returns the buffered spans, with the given resource, and resets the buffer.
+func (tb *tracesBuffer) take(res pcommon.Resource) ptrace.Traces {
+	td := tb.td
+	if tb.count > 0 {
+		res.CopyTo(td.ResourceSpans().At(0).Resource())
+	}
+	tb.td = ptrace.NewTraces()
+	clear(tb.scopes)
+	tb.count = 0
+	return td
+}
+
+func (tb *tracesBuffer) appendEmpty(scope instrumentation.Scope) (ptrace.Span, bool) {
+	if tb.count >= maxBuffered {
+		return ptrace.Span{}, false
+	}
+	ss, ok := tb.scopes[scope]
+	if !ok {
+		if tb.td.ResourceSpans().Len() == 0 {
+			tb.td.ResourceSpans().AppendEmpty()
+		}
+		ss = tb.td.ResourceSpans().At(0).ScopeSpans().AppendEmpty()
+		ss.SetSchemaUrl(scope.SchemaURL)
+		ss.Scope().SetName(scope.Name)
+		ss.Scope().SetVersion(scope.Version)
+		putAttributes(ss.Scope().Attributes(), scope.Attributes.ToSlice())
+		tb.scopes[scope] = ss
+	}
+	tb.count++
+	return ss.Spans().AppendEmpty(), true
+}
+
+// SpanProcessor returns a span processor copying the ended spans to the registered traces consumers.
+func (h *Hub) SpanProcessor() sdktrace.SpanProcessor {
+	return &spanProcessor{hub: h}
+}
+
+type spanProcessor struct {
+	hub *Hub
+}
+
+func (sp *spanProcessor) OnStart(context.Context, sdktrace.ReadWriteSpan) {}
+
+func (sp *spanProcessor) OnEnd(s sdktrace.ReadOnlySpan) {
+	if !sp.hub.tracesActive.Load() {
+		return
+	}
+	scope := s.InstrumentationScope()
+	if _, excluded := (*sp.hub.excludedTraces.Load())[componentKeyFromAttributes(scope.Attributes)]; excluded {
+		return
+	}
+
+	sp.hub.mu.Lock()
+	defer sp.hub.mu.Unlock()
+	span, ok := sp.hub.traces.appendEmpty(scope)
+	if !ok {
+		return
+	}
+	sc := s.SpanContext()
+	span.SetTraceID(pcommon.TraceID(sc.TraceID()))
+	span.SetSpanID(pcommon.SpanID(sc.SpanID()))
+	span.TraceState().FromRaw(sc.TraceState().String())
+	span.SetFlags(uint32(sc.TraceFlags()))
+	if parent := s.Parent(); parent.IsValid() {
+		span.SetParentSpanID(pcommon.SpanID(parent.SpanID()))
+	}
+	span.SetName(s.Name())
+	span.SetKind(spanKind(s.SpanKind()))
+	span.SetStartTimestamp(pcommon.NewTimestampFromTime(s.StartTime()))
+	span.SetEndTimestamp(pcommon.NewTimestampFromTime(s.EndTime()))
+	putAttributes(span.Attributes(), s.Attributes())
+	span.SetDroppedAttributesCount(uint32(s.DroppedAttributes())) //nolint:gosec // counts are small
+
+	for _, e := range s.Events() {
+		event := span.Events().AppendEmpty()
+		event.SetName(e.Name)
+		event.SetTimestamp(pcommon.NewTimestampFromTime(e.Time))
+		putAttributes(event.Attributes(), e.Attributes)
+		event.SetDroppedAttributesCount(uint32(e.DroppedAttributeCount)) //nolint:gosec // counts are small
+	}
+	span.SetDroppedEventsCount(uint32(s.DroppedEvents())) //nolint:gosec // counts are small
+
+	for _, l := range s.Links() {
+		link := span.Links().AppendEmpty()
+		link.SetTraceID(pcommon.TraceID(l.SpanContext.TraceID()))
+		link.SetSpanID(pcommon.SpanID(l.SpanContext.SpanID()))
+		link.TraceState().FromRaw(l.SpanContext.TraceState().String())
+		putAttributes(link.Attributes(), l.Attributes)
+		link.SetDroppedAttributesCount(uint32(l.DroppedAttributeCount)) //nolint:gosec // counts are small
+	}
+	span.SetDroppedLinksCount(uint32(s.DroppedLinks())) //nolint:gosec // counts are small
+
+	switch s.Status().Code {
+	case codes.Ok:
+		span.Status().SetCode(ptrace.StatusCodeOk)
+	case codes.Error:
+		span.Status().SetCode(ptrace.StatusCodeError)
+		span.Status().SetMessage(s.Status().Description)
+	}
+}
+
+func (sp *spanProcessor) Shutdown(context.Context) error {
+	return nil
+}
+
+func (sp *spanProcessor) ForceFlush(context.Context) error {
+	return nil
+}
+
+func spanKind(kind trace.SpanKind) ptrace.SpanKind {
+	switch kind {
+	case trace.SpanKindInternal:
+		return ptrace.SpanKindInternal
+	case trace.SpanKindServer:
+		return ptrace.SpanKindServer
+	case trace.SpanKindClient:
+		return ptrace.SpanKindClient
+	case trace.SpanKindProducer:
+		return ptrace.SpanKindProducer
+	case trace.SpanKindConsumer:
+		return ptrace.SpanKindConsumer
+	}
+	return ptrace.SpanKindUnspecified
+}
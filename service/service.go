@@ -10,6 +10,7 @@ import (
 	"errors"
 	"fmt"
 	"runtime"
+	"time"
 
 	config "go.opentelemetry.io/contrib/otelconf/v0.3.0"
 	"go.opentelemetry.io/otel/log"
@@ -35,9 +36,13 @@ import (
 	"go.opentelemetry.io/collector/service/extensions"
 	"go.opentelemetry.io/collector/service/internal/builders"
 	"go.opentelemetry.io/collector/service/internal/graph"
+	"go.opentelemetry.io/collector/service/internal/loglevel"
+	"go.opentelemetry.io/collector/service/internal/maxprocs"
+	"go.opentelemetry.io/collector/service/internal/memorybudget"
 	"go.opentelemetry.io/collector/service/internal/moduleinfo"
 	"go.opentelemetry.io/collector/service/internal/proctelemetry"
 	"go.opentelemetry.io/collector/service/internal/resource"
+	"go.opentelemetry.io/collector/service/internal/selftelemetry"
 	"go.opentelemetry.io/collector/service/internal/status"
 	"go.opentelemetry.io/collector/service/telemetry"
 )
@@ -113,12 +118,15 @@ type Service struct {
 	host              *graph.Host
 	collectorConf     *confmap.Conf
 	loggerProvider    log.LoggerProvider
+	shutdownCfg       ShutdownConfig
+	maxProcs          *maxprocs.Tuner
 }
 
 // New creates a new Service, its telemetry, and Components.
 func New(ctx context.Context, set Settings, cfg Config) (*Service, error) {
 	srv := &Service{
-		buildInfo: set.BuildInfo,
+		buildInfo:   set.BuildInfo,
+		shutdownCfg: cfg.Shutdown,
 		host: &graph.Host{
 			Receivers:  builders.NewReceiver(set.ReceiversConfigs, set.ReceiversFactories),
 			Processors: builders.NewProcessor(set.ProcessorsConfigs, set.ProcessorsFactories),
@@ -129,6 +137,7 @@ func New(ctx context.Context, set Settings, cfg Config) (*Service, error) {
 			ModuleInfos:       set.ModuleInfos,
 			BuildInfo:         set.BuildInfo,
 			AsyncErrorChannel: set.AsyncErrorChannel,
+			LogLevels:         loglevel.NewController(cfg.Telemetry.Logs.Level, logsSampling(cfg.Telemetry.Logs.Sampling)),
 		},
 		collectorConf: set.CollectorConf,
 	}
@@ -175,12 +184,26 @@ func New(ctx context.Context, set Settings, cfg Config) (*Service, error) {
 		return nil, fmt.Errorf("failed to create SDK: %w", err)
 	}
 
+	if cfg.Telemetry.Loopback.Enabled {
+		srv.host.SelfTelemetry, err = selftelemetry.NewHub(selftelemetry.Settings{
+			Resource:        pcommonRes,
+			MetricsInterval: cfg.Telemetry.Loopback.MetricsInterval,
+			Views:           mpConfig.Views,
+		})
+		if err != nil {
+			err = multierr.Append(err, sdk.Shutdown(ctx))
+			return nil, fmt.Errorf("failed to create self telemetry loopback: %w", err)
+		}
+	}
+
 	telFactory := telemetry.NewFactory()
 	telset := telemetry.Settings{
 		AsyncErrorChannel: set.AsyncErrorChannel,
 		BuildInfo:         set.BuildInfo,
 		ZapOptions:        set.LoggingOptions,
 		SDK:               &sdk,
+		LogLevels:         srv.host.LogLevels,
+		SelfTelemetry:     srv.host.SelfTelemetry,
 	}
 
 	logger, lp, err := telFactory.CreateLogger(ctx, telset, &cfg.Telemetry)
@@ -210,6 +233,12 @@ func New(ctx context.Context, set Settings, cfg Config) (*Service, error) {
 		// Construct telemetry attributes from build info and config's resource attributes.
 		Resource: pcommonRes,
 	}
+	srv.maxProcs = maxprocs.New(maxprocs.Settings{
+		Logger:        logger,
+		MaxProcs:      cfg.Telemetry.Runtime.MaxProcs,
+		CheckInterval: cfg.Telemetry.Runtime.MaxProcsCheckInterval,
+	})
+
 	srv.host.Reporter = status.NewReporter(srv.host.NotifyComponentStatusChange, func(err error) {
 		if errors.Is(err, status.ErrStatusNotReady) {
 			logger.Warn("Invalid transition", zap.Error(err))
@@ -217,13 +246,21 @@ func New(ctx context.Context, set Settings, cfg Config) (*Service, error) {
 		// ignore other errors as they represent invalid state transitions and are considered benign.
 	})
 
+	if cfg.MemoryBudget.LimitMiB > 0 {
+		srv.host.MemoryBudget, err = memorybudget.New(srv.telemetrySettings, int64(cfg.MemoryBudget.LimitMiB)*mibBytes)
+		if err != nil {
+			err = multierr.Append(err, srv.shutdownTelemetry(ctx))
+			return nil, fmt.Errorf("failed to create memory budget: %w", err)
+		}
+	}
+
 	if err = srv.initGraph(ctx, cfg); err != nil {
 		err = multierr.Append(err, srv.shutdownTelemetry(ctx))
 		return nil, err
 	}
 
 	// process the configuration and initialize the pipeline
-	if err = srv.initExtensions(ctx, cfg.Extensions); err != nil {
+	if err = srv.initExtensions(ctx, cfg.Extensions, cfg.Startup); err != nil {
 		err = multierr.Append(err, srv.shutdownTelemetry(ctx))
 		return nil, err
 	}
@@ -258,9 +295,9 @@ func logsAboutMeterProvider(logger *zap.Logger, cfg telemetry.MetricsConfig, mp
 
 // Start starts the extensions and pipelines. If Start fails Shutdown should be called to ensure a clean state.
 // Start does the following steps in order:
-// 1. Start all extensions.
+// 1. Start all extensions, each once the extensions it depends on are ready.
 // 2. Notify extensions about Collector configuration
-// 3. Start all pipelines.
+// 3. Start all pipelines, each component once the extensions referenced by its configuration are ready.
 // 4. Notify extensions that the pipeline is ready.
 func (srv *Service) Start(ctx context.Context) error {
 	srv.telemetrySettings.Logger.Info("Starting "+srv.buildInfo.Command+"...",
@@ -268,6 +305,9 @@ func (srv *Service) Start(ctx context.Context) error {
 		zap.Int("NumCPU", runtime.NumCPU()),
 	)
 
+	// GOMAXPROCS is restored when the telemetry is shut down.
+	srv.maxProcs.Start()
+
 	if err := srv.host.ServiceExtensions.Start(ctx, srv.host); err != nil {
 		return fmt.Errorf("failed to start extensions: %w", err)
 	}
@@ -297,6 +337,17 @@ func (srv *Service) shutdownTelemetry(ctx context.Context) error {
 		Shutdown(context.Context) error
 	}
 
+	srv.host.LogLevels.Shutdown()
+	if srv.maxProcs != nil {
+		srv.maxProcs.Shutdown()
+	}
+	if srv.host.SelfTelemetry != nil {
+		srv.host.SelfTelemetry.Shutdown()
+	}
+	if srv.host.MemoryBudget != nil {
+		srv.host.MemoryBudget.Shutdown()
+	}
+
 	var err error
 	if prov, ok := srv.telemetrySettings.MeterProvider.(shutdownable); ok {
 		if shutdownErr := prov.Shutdown(ctx); shutdownErr != nil {
@@ -323,6 +374,7 @@ func (srv *Service) shutdownTelemetry(ctx context.Context) error {
 // 2. Shutdown all pipelines.
 // 3. Shutdown all extensions.
 // 4. Shutdown telemetry.
+// Steps 2 and 3 are bounded by service::shutdown::timeout, and each by its own timeout.
 func (srv *Service) Shutdown(ctx context.Context) error {
 	// Accumulate errors and proceed with shutting down remaining components.
 	var errs error
@@ -330,34 +382,54 @@ func (srv *Service) Shutdown(ctx context.Context) error {
 	// Begin shutdown sequence.
 	srv.telemetrySettings.Logger.Info("Starting shutdown...")
 
+	// The telemetry is shut down with ctx rather than componentsCtx, so that the report
+	// of the data left by the exporters is flushed even when the timeout is reached.
+	componentsCtx, cancel := withOptionalTimeout(ctx, srv.shutdownCfg.Timeout)
+	defer cancel()
+
 	if err := srv.host.ServiceExtensions.NotifyPipelineNotReady(); err != nil {
 		errs = multierr.Append(errs, fmt.Errorf("failed to notify that pipeline is not ready: %w", err))
 	}
 
-	if err := srv.host.Pipelines.ShutdownAll(ctx, srv.host.Reporter); err != nil {
+	if err := srv.host.Pipelines.ShutdownAll(componentsCtx, srv.host.Reporter); err != nil {
 		errs = multierr.Append(errs, fmt.Errorf("failed to shutdown pipelines: %w", err))
 	}
 
-	if err := srv.host.ServiceExtensions.Shutdown(ctx); err != nil {
+	extensionsCtx, cancelExtensions := withOptionalTimeout(componentsCtx, srv.shutdownCfg.ExtensionsTimeout)
+	defer cancelExtensions()
+	if err := srv.host.ServiceExtensions.Shutdown(extensionsCtx); err != nil {
 		errs = multierr.Append(errs, fmt.Errorf("failed to shutdown extensions: %w", err))
 	}
 
-	srv.telemetrySettings.Logger.Info("Shutdown complete.")
+	queuedRequests, droppedItems := srv.host.Pipelines.DrainReport()
+	srv.telemetrySettings.Logger.Info("Shutdown complete.",
+		zap.Int64("queued_requests", queuedRequests),
+		zap.Int64("dropped_items", droppedItems))
 
 	errs = multierr.Append(errs, srv.shutdownTelemetry(ctx))
 
 	return errs
 }
 
+// withOptionalTimeout returns ctx with the timeout, or ctx unchanged if the timeout is zero.
+func withOptionalTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout == 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
 // Creates extensions.
-func (srv *Service) initExtensions(ctx context.Context, cfg extensions.Config) error {
+func (srv *Service) initExtensions(ctx context.Context, cfg extensions.Config, startupCfg StartupConfig) error {
 	var err error
 	extensionsSettings := extensions.Settings{
 		Telemetry:  srv.telemetrySettings,
 		BuildInfo:  srv.buildInfo,
 		Extensions: srv.host.Extensions,
 	}
-	if srv.host.ServiceExtensions, err = extensions.New(ctx, extensionsSettings, cfg, extensions.WithReporter(srv.host.Reporter)); err != nil {
+	if srv.host.ServiceExtensions, err = extensions.New(ctx, extensionsSettings, cfg,
+		extensions.WithReporter(srv.host.Reporter),
+		extensions.WithReadyTimeout(startupCfg.ExtensionsReadyTimeout)); err != nil {
 		return fmt.Errorf("failed to build extensions: %w", err)
 	}
 	return nil
@@ -375,12 +447,25 @@ func (srv *Service) initGraph(ctx context.Context, cfg Config) error {
 		ConnectorBuilder: srv.host.Connectors,
 		PipelineConfigs:  cfg.Pipelines,
 		ReportStatus:     srv.host.Reporter.ReportStatus,
+		ShutdownTimeouts: graph.ShutdownTimeouts{
+			Receivers:  cfg.Shutdown.ReceiversTimeout,
+			Processors: cfg.Shutdown.ProcessorsTimeout,
+			Exporters:  cfg.Shutdown.ExportersTimeout,
+		},
 	}); err != nil {
 		return fmt.Errorf("failed to build pipelines: %w", err)
 	}
 	return nil
 }
 
+// logsSampling returns the configured sampling of the logs, which can be changed at runtime.
+func logsSampling(sc *telemetry.LogsSamplingConfig) loglevel.Sampling {
+	if sc == nil {
+		return loglevel.Sampling{}
+	}
+	return loglevel.Sampling{Enabled: sc.Enabled, Tick: sc.Tick, Initial: sc.Initial, Thereafter: sc.Thereafter}
+}
+
 // Logger returns the logger created for this service.
 // This is a temporary API that may be removed soon after investigating how the collector should record different events.
 func (srv *Service) Logger() *zap.Logger {
@@ -519,6 +604,14 @@ func configureViews(level configtelemetry.Level) []config.View {
 		)
 	}
 
+	// Pipeline latency histograms
+	if level < configtelemetry.LevelDetailed {
+		views = append(views, dropViewOption(&config.ViewSelector{
+			MeterName:      ptr("go.opentelemetry.io/collector/service"),
+			InstrumentName: ptr("otelcol_pipeline_latency"),
+		}))
+	}
+
 	// Batch processor metrics
 	scope := ptr("go.opentelemetry.io/collector/processor/batchprocessor")
 	if level < configtelemetry.LevelNormal {
@@ -619,6 +619,7 @@ func assertZPages(t *testing.T, zpagesAddr string) {
 		"/debug/pipelinez",
 		"/debug/servicez",
 		"/debug/extensionz",
+		"/debug/loglevelz",
 	}
 
 	testZPagePathFn := func(t *testing.T, path string) {
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"net"
 	"strconv"
+	"time"
 
 	config "go.opentelemetry.io/contrib/otelconf/v0.3.0"
 
@@ -37,6 +38,37 @@ type Config struct {
 	// if they are not specified here. In order to suppress such attributes the
 	// attribute must be specified in this map with null YAML value (nil string pointer).
 	Resource map[string]*string `mapstructure:"resource,omitempty"`
+
+	// Loopback configures sending the Collector's own telemetry into its pipelines,
+	// through the `telemetry` receiver.
+	// Experimental: *NOTE* this structure is subject to change or removal in the future.
+	Loopback LoopbackConfig `mapstructure:"loopback,omitempty"`
+
+	// Runtime configures the Go runtime of the Collector.
+	// Experimental: *NOTE* this structure is subject to change or removal in the future.
+	Runtime RuntimeConfig `mapstructure:"runtime,omitempty"`
+}
+
+// RuntimeConfig configures the Go runtime of the Collector.
+// Experimental: *NOTE* this structure is subject to change or removal in the future.
+type RuntimeConfig struct {
+	// MaxProcs overrides GOMAXPROCS. By default, unless the GOMAXPROCS environment variable is set,
+	// GOMAXPROCS is set from the CPU quota of the cgroup of the Collector, if any.
+	MaxProcs int `mapstructure:"max_procs"`
+
+	// MaxProcsCheckInterval is how often the CPU quota is checked again to update GOMAXPROCS.
+	// Zero only checks it at startup.
+	MaxProcsCheckInterval time.Duration `mapstructure:"max_procs_check_interval"`
+}
+
+// LoopbackConfig configures sending the Collector's own telemetry into its pipelines.
+// Experimental: *NOTE* this structure is subject to change or removal in the future.
+type LoopbackConfig struct {
+	// Enabled allows the `telemetry` receiver to receive the Collector's own telemetry.
+	Enabled bool `mapstructure:"enabled"`
+
+	// MetricsInterval is how often the Collector's own metrics are sent to the pipelines.
+	MetricsInterval time.Duration `mapstructure:"metrics_interval"`
 }
 
 // LogsConfig defines the configurable settings for service telemetry logs.
@@ -106,11 +138,24 @@ func (c *Config) Unmarshal(conf *confmap.Conf) error {
 
 // Validate checks whether the current configuration is valid
 func (c *Config) Validate() error {
-	// Check when service telemetry metric level is not none, the metrics readers should not be empty
-	if c.Metrics.Level != configtelemetry.LevelNone && len(c.Metrics.Readers) == 0 {
+	// Check when service telemetry metric level is not none, the metrics readers should not be empty,
+	// unless the metrics are sent to the pipelines.
+	if c.Metrics.Level != configtelemetry.LevelNone && len(c.Metrics.Readers) == 0 && !c.Loopback.Enabled {
 		return errors.New("collector telemetry metrics reader should exist when metric level is not none")
 	}
 
+	if c.Loopback.Enabled && c.Loopback.MetricsInterval <= 0 {
+		return errors.New("service::telemetry::loopback::metrics_interval must be positive")
+	}
+
+	if c.Runtime.MaxProcs < 0 {
+		return errors.New("service::telemetry::runtime::max_procs must not be negative")
+	}
+
+	if c.Runtime.MaxProcsCheckInterval < 0 {
+		return errors.New("service::telemetry::runtime::max_procs_check_interval must not be negative")
+	}
+
 	if c.Metrics.Views != nil && c.Metrics.Level != configtelemetry.LevelDetailed {
 		return errors.New("service::telemetry::metrics::views can only be set when service::telemetry::metrics::level is detailed")
 	}
@@ -6,6 +6,7 @@ package telemetry
 import (
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -140,6 +141,55 @@ func TestConfigValidate(t *testing.T) {
 			},
 			success: false,
 		},
+		{
+			name: "loopback without metric readers",
+			cfg: &Config{
+				Metrics: MetricsConfig{
+					Level: configtelemetry.LevelBasic,
+				},
+				Loopback: LoopbackConfig{
+					Enabled:         true,
+					MetricsInterval: time.Minute,
+				},
+			},
+			success: true,
+		},
+		{
+			name: "invalid loopback metrics interval",
+			cfg: &Config{
+				Metrics: MetricsConfig{
+					Level: configtelemetry.LevelNone,
+				},
+				Loopback: LoopbackConfig{
+					Enabled: true,
+				},
+			},
+			success: false,
+		},
+		{
+			name: "invalid runtime max procs",
+			cfg: &Config{
+				Metrics: MetricsConfig{
+					Level: configtelemetry.LevelNone,
+				},
+				Runtime: RuntimeConfig{
+					MaxProcs: -1,
+				},
+			},
+			success: false,
+		},
+		{
+			name: "invalid runtime max procs check interval",
+			cfg: &Config{
+				Metrics: MetricsConfig{
+					Level: configtelemetry.LevelNone,
+				},
+				Runtime: RuntimeConfig{
+					MaxProcsCheckInterval: -time.Second,
+				},
+			},
+			success: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -17,6 +17,8 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configtelemetry"
 	"go.opentelemetry.io/collector/featuregate"
+	"go.opentelemetry.io/collector/service/internal/loglevel"
+	"go.opentelemetry.io/collector/service/internal/selftelemetry"
 )
 
 var useLocalHostAsDefaultMetricsAddressFeatureGate = featuregate.GlobalRegistry().MustRegister(
@@ -32,6 +34,13 @@ type Settings struct {
 	AsyncErrorChannel chan error
 	ZapOptions        []zap.Option
 	SDK               *config.SDK
+
+	// LogLevels, if not nil, controls the level and the sampling of the logger at runtime,
+	// starting from the configured ones.
+	LogLevels *loglevel.Controller
+
+	// SelfTelemetry, if not nil, receives the Collector's own telemetry to send it to the pipelines.
+	SelfTelemetry *selftelemetry.Hub
 }
 
 // Factory is factory interface for telemetry.
@@ -115,6 +124,12 @@ func createDefaultConfig() component.Config {
 				},
 			},
 		},
+		Loopback: LoopbackConfig{
+			MetricsInterval: time.Minute,
+		},
+		Runtime: RuntimeConfig{
+			MaxProcsCheckInterval: time.Minute,
+		},
 	}
 }
 
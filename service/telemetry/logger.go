@@ -17,8 +17,14 @@ func newLogger(set Settings, cfg Config) (*zap.Logger, log.LoggerProvider, error
 	// Copied from NewProductionConfig.
 	ec := zap.NewProductionEncoderConfig()
 	ec.EncodeTime = zapcore.ISO8601TimeEncoder
+	// When the level can change at runtime, the outermost core filters the logs
+	// and the inner cores must let everything through.
+	level := cfg.Logs.Level
+	if set.LogLevels != nil {
+		level = zapcore.DebugLevel
+	}
 	zapCfg := &zap.Config{
-		Level:             zap.NewAtomicLevelAt(cfg.Logs.Level),
+		Level:             zap.NewAtomicLevelAt(level),
 		Development:       cfg.Logs.Development,
 		Encoding:          cfg.Logs.Encoding,
 		EncoderConfig:     ec,
@@ -51,12 +57,19 @@ func newLogger(set Settings, cfg Config) (*zap.Logger, log.LoggerProvider, error
 				core,
 				lp,
 				"go.opentelemetry.io/collector/service/telemetry",
-				cfg.Logs.Level,
+				level,
 				attribute.NewSet(),
 			)
 		}
 
-		if cfg.Logs.Sampling != nil && cfg.Logs.Sampling.Enabled {
+		if set.SelfTelemetry != nil {
+			core = set.SelfTelemetry.WrapLogsCore(core, level)
+		}
+
+		// The controller samples the logs itself, since the sampling can change at runtime as well.
+		if set.LogLevels != nil {
+			core = set.LogLevels.WrapCore(core)
+		} else if cfg.Logs.Sampling != nil && cfg.Logs.Sampling.Enabled {
 			core = componentattribute.NewWrapperCoreWithAttributes(core, func(c zapcore.Core) zapcore.Core {
 				return newSampledCore(c, cfg.Logs.Sampling)
 			})
@@ -13,6 +13,8 @@ import (
 	"github.com/stretchr/testify/require"
 	config "go.opentelemetry.io/contrib/otelconf/v0.3.0"
 	"go.uber.org/zap/zapcore"
+
+	"go.opentelemetry.io/collector/service/internal/loglevel"
 )
 
 func TestNewLogger(t *testing.T) {
@@ -114,3 +116,24 @@ func TestNewLogger(t *testing.T) {
 		testCoreType(t, tt.wantCoreType)
 	}
 }
+
+func TestNewLoggerWithLogLevels(t *testing.T) {
+	levels := loglevel.NewController(zapcore.InfoLevel, loglevel.Sampling{})
+	t.Cleanup(levels.Shutdown)
+
+	l, _, err := newLogger(Settings{LogLevels: levels}, Config{
+		Logs: LogsConfig{
+			Level:    zapcore.InfoLevel,
+			Encoding: "console",
+		},
+	})
+	require.NoError(t, err)
+	require.False(t, l.Core().Enabled(zapcore.DebugLevel))
+	require.True(t, l.Core().Enabled(zapcore.InfoLevel))
+
+	require.NoError(t, levels.Set("", zapcore.DebugLevel, time.Minute))
+	require.True(t, l.Core().Enabled(zapcore.DebugLevel))
+
+	levels.Reset("")
+	require.False(t, l.Core().Enabled(zapcore.DebugLevel))
+}
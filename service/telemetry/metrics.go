@@ -10,16 +10,27 @@ import (
 	"go.opentelemetry.io/otel/metric/noop"
 
 	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/service/internal/selftelemetry"
 )
 
 // newMeterProvider creates a new MeterProvider from Config.
 func newMeterProvider(set Settings, cfg Config) (metric.MeterProvider, error) {
-	if cfg.Metrics.Level == configtelemetry.LevelNone || len(cfg.Metrics.Readers) == 0 {
+	if cfg.Metrics.Level == configtelemetry.LevelNone {
 		return noop.NewMeterProvider(), nil
 	}
 
-	if set.SDK != nil {
-		return set.SDK.MeterProvider(), nil
+	if len(cfg.Metrics.Readers) == 0 {
+		if set.SelfTelemetry != nil {
+			return set.SelfTelemetry.MeterProvider(), nil
+		}
+		return noop.NewMeterProvider(), nil
+	}
+
+	if set.SDK == nil {
+		return nil, errors.New("no sdk set")
+	}
+	if set.SelfTelemetry != nil {
+		return selftelemetry.NewTeeMeterProvider(set.SDK.MeterProvider(), set.SelfTelemetry.MeterProvider()), nil
 	}
-	return nil, errors.New("no sdk set")
+	return set.SDK.MeterProvider(), nil
 }
@@ -10,6 +10,7 @@ import (
 	"go.opentelemetry.io/contrib/propagators/b3"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"go.opentelemetry.io/otel/trace"
 	"go.opentelemetry.io/otel/trace/embedded"
 	"go.opentelemetry.io/otel/trace/noop"
@@ -63,7 +64,11 @@ func newTracerProvider(set Settings, cfg Config) (trace.TracerProvider, error) {
 	}
 
 	if set.SDK != nil {
-		return set.SDK.TracerProvider(), nil
+		tp := set.SDK.TracerProvider()
+		if sdkTP, ok := tp.(*sdktrace.TracerProvider); ok && set.SelfTelemetry != nil {
+			sdkTP.RegisterSpanProcessor(set.SelfTelemetry.SpanProcessor())
+		}
+		return tp, nil
 	}
 	return nil, errors.New("no sdk set")
 }